@@ -583,6 +583,13 @@ func TestReadCommittedLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestReadCommittedLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestReadCommittedLogic_delete(
 	t *testing.T,
 ) {
@@ -674,6 +681,13 @@ func TestReadCommittedLogic_distsql_srfs(
 	runLogicTest(t, "distsql_srfs")
 }
 
+func TestReadCommittedLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestReadCommittedLogic_drop_database(
 	t *testing.T,
 ) {
@@ -807,6 +821,13 @@ func TestReadCommittedLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestReadCommittedLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestReadCommittedLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -975,6 +996,13 @@ func TestReadCommittedLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestReadCommittedLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestReadCommittedLogic_hash_join(
 	t *testing.T,
 ) {
@@ -1150,6 +1178,13 @@ func TestReadCommittedLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestReadCommittedLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestReadCommittedLogic_kv_builtin_functions(
 	t *testing.T,
 ) {
@@ -1206,6 +1241,13 @@ func TestReadCommittedLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestReadCommittedLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestReadCommittedLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1500,6 +1542,20 @@ func TestReadCommittedLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestReadCommittedLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
+func TestReadCommittedLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestReadCommittedLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1605,6 +1661,13 @@ func TestReadCommittedLogic_routine_schema_change(
 	runLogicTest(t, "routine_schema_change")
 }
 
+func TestReadCommittedLogic_row_level_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "row_level_security")
+}
+
 func TestReadCommittedLogic_row_level_ttl(
 	t *testing.T,
 ) {
@@ -2221,6 +2284,13 @@ func TestReadCommittedLogic_udf(
 	runLogicTest(t, "udf")
 }
 
+func TestReadCommittedLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate")
+}
+
 func TestReadCommittedLogic_udf_calling_udf(
 	t *testing.T,
 ) {
@@ -2641,6 +2711,13 @@ func TestReadCommittedLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestReadCommittedLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
 func TestReadCommittedLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
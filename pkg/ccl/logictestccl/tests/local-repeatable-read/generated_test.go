@@ -576,6 +576,13 @@ func TestRepeatableReadLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestRepeatableReadLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestRepeatableReadLogic_delete(
 	t *testing.T,
 ) {
@@ -667,6 +674,13 @@ func TestRepeatableReadLogic_distsql_srfs(
 	runLogicTest(t, "distsql_srfs")
 }
 
+func TestRepeatableReadLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestRepeatableReadLogic_drop_database(
 	t *testing.T,
 ) {
@@ -800,6 +814,13 @@ func TestRepeatableReadLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestRepeatableReadLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestRepeatableReadLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -968,6 +989,13 @@ func TestRepeatableReadLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestRepeatableReadLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestRepeatableReadLogic_hash_join(
 	t *testing.T,
 ) {
@@ -1143,6 +1171,13 @@ func TestRepeatableReadLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestRepeatableReadLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestRepeatableReadLogic_kv_builtin_functions(
 	t *testing.T,
 ) {
@@ -1199,6 +1234,13 @@ func TestRepeatableReadLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestRepeatableReadLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestRepeatableReadLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1493,6 +1535,20 @@ func TestRepeatableReadLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestRepeatableReadLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
+func TestRepeatableReadLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestRepeatableReadLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1598,6 +1654,13 @@ func TestRepeatableReadLogic_routine_schema_change(
 	runLogicTest(t, "routine_schema_change")
 }
 
+func TestRepeatableReadLogic_row_level_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "row_level_security")
+}
+
 func TestRepeatableReadLogic_row_level_ttl(
 	t *testing.T,
 ) {
@@ -2214,6 +2277,13 @@ func TestRepeatableReadLogic_udf(
 	runLogicTest(t, "udf")
 }
 
+func TestRepeatableReadLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate")
+}
+
 func TestRepeatableReadLogic_udf_calling_udf(
 	t *testing.T,
 ) {
@@ -2634,6 +2704,13 @@ func TestRepeatableReadLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestRepeatableReadLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
 func TestRepeatableReadLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
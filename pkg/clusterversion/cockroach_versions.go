@@ -233,6 +233,19 @@ const (
 	// TTL to mirror the behaviour on the system tenant.
 	V24_3_TenantExcludeDataFromBackup
 
+	// V24_3_ReplicationSlotsAndPublications is the migration to add the
+	// replication_slots and publications tables used by logical replication
+	// connections.
+	V24_3_ReplicationSlotsAndPublications
+
+	// V24_3_AdvisoryLocks is the migration to add the advisory_locks table
+	// storing the advisory locks held by SQL sessions.
+	V24_3_AdvisoryLocks
+
+	// V24_3_AggregateScans is the earliest version which supports the
+	// AggregateScan request, which computes partial aggregates in the KV layer.
+	V24_3_AggregateScans
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -286,6 +299,10 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_3_TableMetadata:               {Major: 24, Minor: 2, Internal: 8},
 	V24_3_TenantExcludeDataFromBackup: {Major: 24, Minor: 2, Internal: 10},
 
+	V24_3_ReplicationSlotsAndPublications: {Major: 24, Minor: 2, Internal: 12},
+	V24_3_AdvisoryLocks:                   {Major: 24, Minor: 2, Internal: 14},
+	V24_3_AggregateScans:                  {Major: 24, Minor: 2, Internal: 16},
+
 	// *************************************************
 	// Step (2): Add new versions above this comment.
 	// Do not add new versions to a patch release.
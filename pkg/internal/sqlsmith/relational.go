@@ -1739,7 +1739,7 @@ func (s *Smither) isOrderable(typ *types.T) bool {
 		return typ.Family() != types.Box2DFamily
 	}
 	switch typ.Family() {
-	case types.TSQueryFamily, types.TSVectorFamily:
+	case types.TSQueryFamily, types.TSVectorFamily, types.JsonpathFamily:
 		// We can't order by these types - see #92165.
 		return false
 	default:
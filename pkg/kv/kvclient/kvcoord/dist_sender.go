@@ -1071,7 +1071,8 @@ func (ds *DistSender) initAndVerifyBatch(ctx context.Context, ba *kvpb.BatchRequ
 			switch inner.(type) {
 			case *kvpb.ScanRequest, *kvpb.ResolveIntentRangeRequest,
 				*kvpb.DeleteRangeRequest, *kvpb.RevertRangeRequest,
-				*kvpb.ExportRequest, *kvpb.QueryLocksRequest, *kvpb.IsSpanEmptyRequest:
+				*kvpb.ExportRequest, *kvpb.QueryLocksRequest, *kvpb.IsSpanEmptyRequest,
+				*kvpb.AggregateScanRequest:
 				// Accepted forward range requests.
 				foundForward = true
 
@@ -535,6 +535,20 @@ func (sr *ReverseScanResponse) combine(ctx context.Context, c combinable, ba *Ba
 
 var _ combinable = &ReverseScanResponse{}
 
+// combine implements the combinable interface.
+func (r *AggregateScanResponse) combine(_ context.Context, c combinable, _ *BatchRequest) error {
+	otherR := c.(*AggregateScanResponse)
+	if r != nil {
+		r.Results = append(r.Results, otherR.Results...)
+		if err := r.ResponseHeader.combine(otherR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ combinable = &AggregateScanResponse{}
+
 // combine implements the combinable interface.
 func (dr *DeleteRangeResponse) combine(_ context.Context, c combinable, _ *BatchRequest) error {
 	otherDR := c.(*DeleteRangeResponse)
@@ -1007,6 +1021,9 @@ func (*BarrierRequest) Method() Method { return Barrier }
 // Method implements the Request interface.
 func (*IsSpanEmptyRequest) Method() Method { return IsSpanEmpty }
 
+// Method implements the Request interface.
+func (*AggregateScanRequest) Method() Method { return AggregateScan }
+
 // ShallowCopy implements the Request interface.
 func (gr *GetRequest) ShallowCopy() Request {
 	shallowCopy := *gr
@@ -1301,6 +1318,12 @@ func (r *IsSpanEmptyRequest) ShallowCopy() Request {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Request interface.
+func (r *AggregateScanRequest) ShallowCopy() Request {
+	shallowCopy := *r
+	return &shallowCopy
+}
+
 // ShallowCopy implements the Response interface.
 func (gr *GetResponse) ShallowCopy() Response {
 	shallowCopy := *gr
@@ -1593,6 +1616,12 @@ func (r *IsSpanEmptyResponse) ShallowCopy() Response {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Response interface.
+func (r *AggregateScanResponse) ShallowCopy() Response {
+	shallowCopy := *r
+	return &shallowCopy
+}
+
 // NewLockingGet returns a Request initialized to get the value at key. A lock
 // corresponding to the supplied lock strength and durability is acquired on the
 // key, if it exists.
@@ -2131,6 +2160,9 @@ func (r *BarrierRequest) flags() flag {
 	return flags
 }
 func (*IsSpanEmptyRequest) flags() flag { return isRead | isRange }
+func (*AggregateScanRequest) flags() flag {
+	return isRead | isRange | isTxn | updatesTSCache | needsRefresh
+}
 
 // IsParallelCommit returns whether the EndTxn request is attempting to perform
 // a parallel commit. See txn_interceptor_committer.go for a discussion about
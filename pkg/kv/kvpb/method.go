@@ -176,6 +176,9 @@ const (
 	// IsSpanEmpty is a non-transaction read request used to determine whether
 	// a span contains any keys whatsoever (garbage or otherwise).
 	IsSpanEmpty
+	// AggregateScan computes partial aggregates (such as counts and sums) over
+	// the rows of a SQL index in a key span, without returning the rows.
+	AggregateScan
 	// MaxMethod is the maximum method.
 	MaxMethod Method = iota - 1
 	// NumMethods represents the total number of API methods.
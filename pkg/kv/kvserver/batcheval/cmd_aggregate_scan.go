@@ -0,0 +1,87 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package batcheval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	RegisterReadOnlyCommand(kvpb.AggregateScan, DefaultDeclareIsolatedKeys, AggregateScan)
+}
+
+// AggregateScan scans the SQL rows of an index in the key range specified by
+// start key through end key in ascending order and computes partial aggregates
+// over them. The scan behaves like a Scan with the COL_BATCH_RESPONSE format:
+// it reads at the timestamp of the batch (observing the uncertainty interval of
+// the transaction) and stops once the key or byte limits of the batch are
+// reached, in which case the resume span is set and the aggregates only include
+// the rows that were scanned.
+func AggregateScan(
+	ctx context.Context, reader storage.Reader, cArgs CommandArgs, resp kvpb.Response,
+) (result.Result, error) {
+	args := cArgs.Args.(*kvpb.AggregateScanRequest)
+	h := cArgs.Header
+	reply := resp.(*kvpb.AggregateScanResponse)
+
+	if h.ReadConsistency == kvpb.READ_UNCOMMITTED {
+		return result.Result{}, errors.AssertionFailedf(
+			"AggregateScan does not support the READ_UNCOMMITTED consistency level",
+		)
+	}
+	aggregator, err := storage.GetColBatchAggregator(&args.IndexFetchSpec, args.Aggregates)
+	if err != nil {
+		return result.Result{}, err
+	}
+
+	opts := storage.MVCCScanOptions{
+		Inconsistent:            h.ReadConsistency != kvpb.CONSISTENT,
+		Txn:                     h.Txn,
+		ScanStats:               cArgs.ScanStats,
+		Uncertainty:             cArgs.Uncertainty,
+		MaxKeys:                 h.MaxSpanRequestKeys,
+		MaxLockConflicts:        storage.MaxConflictsPerLockConflictError.Get(&cArgs.EvalCtx.ClusterSettings().SV),
+		TargetLockConflictBytes: storage.TargetBytesPerLockConflictError.Get(&cArgs.EvalCtx.ClusterSettings().SV),
+		TargetBytes:             h.TargetBytes,
+		AllowEmpty:              h.AllowEmpty,
+		WholeRowsOfSize:         h.WholeRowsOfSize,
+		MemoryAccount:           cArgs.EvalCtx.GetResponseMemoryAccount(),
+		DontInterleaveIntents:   cArgs.DontInterleaveIntents,
+		ReadCategory:            ScanReadCategory(cArgs.EvalCtx.AdmissionHeader()),
+	}
+	scanRes, err := storage.MVCCScanToColsAndAggregate(
+		ctx, reader, &args.IndexFetchSpec, aggregator, args.Key, args.EndKey,
+		h.Timestamp, opts, cArgs.EvalCtx.ClusterSettings(),
+	)
+	if err != nil {
+		return result.Result{}, err
+	}
+	aggregates, err := aggregator.Result()
+	if err != nil {
+		return result.Result{}, err
+	}
+	reply.Results = [][]byte{aggregates}
+	reply.NumKeys = scanRes.NumKeys
+	reply.NumBytes = scanRes.NumBytes
+	if scanRes.ResumeSpan != nil {
+		reply.ResumeSpan = scanRes.ResumeSpan
+		reply.ResumeReason = scanRes.ResumeReason
+		reply.ResumeNextBytes = scanRes.ResumeNextBytes
+	}
+	var res result.Result
+	res.Local.EncounteredIntents = scanRes.Intents
+	return res, nil
+}
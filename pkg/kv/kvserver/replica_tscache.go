@@ -291,6 +291,18 @@ func (r *Replica) updateTimestampCache(
 				end = resume.Key
 			}
 			addToTSCache(start, end, ts, txnID)
+		case *kvpb.AggregateScanRequest:
+			if !beforeEval && resp.(*kvpb.AggregateScanResponse).ResumeSpan != nil {
+				resume := resp.(*kvpb.AggregateScanResponse).ResumeSpan
+				if start.Equal(resume.Key) {
+					// The request did not evaluate. Ignore it.
+					continue
+				}
+				// Like for forward scans, the resume span starts right after
+				// the last key read.
+				end = resume.Key
+			}
+			addToTSCache(start, end, ts, txnID)
 		case *kvpb.ReverseScanRequest:
 			if !beforeEval && resp.(*kvpb.ReverseScanResponse).ResumeSpan != nil {
 				resume := resp.(*kvpb.ReverseScanResponse).ResumeSpan
@@ -189,6 +189,7 @@ var (
 var reqMethodToCap = map[kvpb.Method]tenantcapabilities.ID{
 	// The following requests are authorized for all workloads.
 	kvpb.AddSSTable:         noCapCheckNeeded,
+	kvpb.AggregateScan:      noCapCheckNeeded,
 	kvpb.Barrier:            noCapCheckNeeded,
 	kvpb.ClearRange:         noCapCheckNeeded,
 	kvpb.ConditionalPut:     noCapCheckNeeded,
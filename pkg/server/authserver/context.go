@@ -86,6 +86,18 @@ func ForwardSQLIdentityThroughRPCCalls(ctx context.Context) context.Context {
 	return ctx
 }
 
+// IsInternalRPC returns true if the RPC being served was not made on behalf
+// of a user logged into the DB Console or the HTTP API, i.e. it was issued by
+// another node of the cluster or by a client with a root or node certificate.
+func IsInternalRPC(ctx context.Context) bool {
+	md, ok := grpcutil.FastFromIncomingContext(ctx)
+	if !ok {
+		return true
+	}
+	_, ok = md[webSessionUserKeyStr]
+	return !ok
+}
+
 // ForwardHTTPAuthInfoToRPCCalls converts an HTTP API (v1 or v2) context, to one that
 // can issue outgoing RPC requests under the same logged-in user.
 func ForwardHTTPAuthInfoToRPCCalls(ctx context.Context, r *http.Request) context.Context {
@@ -3572,6 +3572,59 @@ func (s *statusServer) CancelQueryByKey(
 	return client.CancelQueryByKey(ctx, req)
 }
 
+// NotifyListeners delivers the notifications sent with NOTIFY to the sessions
+// listening on their channels. Unless req.Local is set, the notifications are
+// forwarded to every other node in the cluster; the sessions on the local node
+// are notified by the sender directly. The nodes to which the notifications
+// could not be forwarded are returned in the response.
+//
+// It is only meant to be called by the SQL layer of the nodes of the cluster,
+// so it rejects the requests made on behalf of a user.
+func (s *statusServer) NotifyListeners(
+	ctx context.Context, req *serverpb.NotifyListenersRequest,
+) (*serverpb.NotifyListenersResponse, error) {
+	ctx = s.AnnotateCtx(ctx)
+	if !authserver.IsInternalRPC(ctx) {
+		return nil, status.Error(
+			codes.PermissionDenied, "notifications can only be sent by the nodes of the cluster")
+	}
+	response := &serverpb.NotifyListenersResponse{}
+	if req.Local {
+		s.sqlServer.pgServer.SQLServer.DeliverNotifications(ctx, req)
+		return response, nil
+	}
+
+	localID := roachpb.NodeID(s.serverIterator.getID())
+	notifyListeners := func(ctx context.Context, status serverpb.StatusClient, nodeID roachpb.NodeID) (interface{}, error) {
+		if nodeID == localID {
+			return nil, nil
+		}
+		return status.NotifyListeners(ctx, &serverpb.NotifyListenersRequest{
+			Notifications: req.Notifications,
+			Local:         true,
+			SenderNodeID:  localID,
+			SenderEpoch:   req.SenderEpoch,
+			Seq:           req.Seq,
+		})
+	}
+
+	if err := iterateNodes(ctx, s.serverIterator, s.stopper, "notify listeners",
+		noTimeout,
+		s.dialNode,
+		notifyListeners,
+		func(nodeID roachpb.NodeID, resp interface{}) {
+			// Nothing to do here.
+		},
+		func(nodeID roachpb.NodeID, nodeFnError error) {
+			log.Warningf(ctx, "%v", nodeFnError)
+			response.FailedNodeIDs = append(response.FailedNodeIDs, nodeID)
+		},
+	); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // ListContentionEvents returns a list of contention events on all nodes in the
 // cluster.
 func (s *statusServer) ListContentionEvents(
@@ -0,0 +1,556 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// advisoryLockRetryOptions controls how often a session waiting for an
+// advisory lock checks whether it has been released.
+var advisoryLockRetryOptions = retry.Options{
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     500 * time.Millisecond,
+	Multiplier:     2,
+}
+
+// advisoryLockReleaseRetryOptions controls how many times releasing advisory
+// locks is attempted before giving up.
+var advisoryLockReleaseRetryOptions = retry.Options{
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+	MaxRetries:     5,
+}
+
+// advisoryLockReapInterval is how often every SQL instance removes the rows
+// of the advisory locks of its sessions which no longer exist.
+var advisoryLockReapInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.advisory_locks.reap_interval",
+	"how often the advisory locks left behind by closed sessions are removed",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// advisoryLockManager implements eval.AdvisoryLocks for a session.
+//
+// The advisory locks held by the sessions of the cluster are stored in
+// system.advisory_locks, with a row per lock and session holding it. A session
+// acquires a lock by writing its row in a transaction that checks that no
+// other session holds the lock in a conflicting mode, and releases it by
+// deleting its row, so no transaction stays open while a lock is held.
+//
+// Every row records the sqlliveness session of the SQL instance of the
+// session holding the lock. The locks are released when the session closes,
+// and if its SQL instance dies without releasing them, they are released when
+// its sqlliveness session expires: from then on, the rows are ignored and
+// deleted by the sessions acquiring the locks. If a session closes without
+// managing to delete its rows, they are deleted by the sessions of its SQL
+// instance acquiring the locks, and periodically by the instance (see
+// reapOrphanedAdvisoryLocks).
+//
+// A session waiting for a lock polls the table until the lock is available,
+// lock_timeout expires, or the query is canceled (which includes
+// statement_timeout expiring). There are two limitations
+// compared to Postgres:
+//   - Deadlocks between sessions waiting for advisory locks are not detected.
+//     The waiting sessions don't wait in the KV lock table, so its deadlock
+//     detection does not apply. A cycle is broken by lock_timeout or by
+//     canceling one of the queries.
+//   - The waiting sessions are not queued, so a session waiting for a lock in
+//     exclusive mode can be starved by sessions acquiring it in shared mode.
+type advisoryLockManager struct {
+	db       isql.DB
+	settings *cluster.Settings
+	// liveness is nil in some tests, in which case advisory locks are not
+	// supported.
+	liveness sqlliveness.Provider
+	// registry contains the sessions of this SQL instance. A lock held by a
+	// session of this instance which is not in the registry is not held
+	// anymore.
+	registry *SessionRegistry
+	// holderID identifies the session in system.advisory_locks.
+	holderID []byte
+	// pid is the backend PID of the session, shown in pg_locks.
+	pid uint32
+	// sessionData returns the current session data, which determines the
+	// lock_timeout.
+	sessionData func() *sessiondata.SessionData
+	// locks contains the locks which have a row in system.advisory_locks.
+	// This includes the locks which are no longer held if deleting their row
+	// failed, so that it is retried by the next release.
+	locks map[eval.AdvisoryLockKey]*advisoryLock
+}
+
+// advisoryLock is an advisory lock held by the session.
+type advisoryLock struct {
+	// exclusive is set if the row of the lock holds it in exclusive mode.
+	exclusive bool
+	// The number of times the advisory lock is held by the session and by the
+	// current transaction in each mode.
+	sessionExclusive, sessionShared int
+	xactExclusive, xactShared       int
+}
+
+func (l *advisoryLock) held() bool {
+	return l.sessionExclusive+l.sessionShared+l.xactExclusive+l.xactShared > 0
+}
+
+func (l *advisoryLock) heldExclusive() bool {
+	return l.sessionExclusive+l.xactExclusive > 0
+}
+
+var _ eval.AdvisoryLocks = &advisoryLockManager{}
+
+func newAdvisoryLockManager(
+	db isql.DB,
+	settings *cluster.Settings,
+	liveness sqlliveness.Provider,
+	registry *SessionRegistry,
+	sessionID clusterunique.ID,
+	pid uint32,
+	sessionData func() *sessiondata.SessionData,
+) *advisoryLockManager {
+	return &advisoryLockManager{
+		db:          db,
+		settings:    settings,
+		liveness:    liveness,
+		registry:    registry,
+		holderID:    sessionID.GetBytes(),
+		pid:         pid,
+		sessionData: sessionData,
+		locks:       make(map[eval.AdvisoryLockKey]*advisoryLock),
+	}
+}
+
+// Lock is part of the eval.AdvisoryLocks interface.
+func (m *advisoryLockManager) Lock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared, xact, wait bool,
+) (bool, error) {
+	l, ok := m.locks[key]
+	if !ok || (!shared && !l.exclusive) {
+		if err := checkAdvisoryLocksSupported(ctx, m.settings); err != nil {
+			return false, err
+		}
+		if m.liveness == nil {
+			return false, errors.AssertionFailedf("advisory locks require sqlliveness")
+		}
+		if err := m.acquire(ctx, key, !shared, wait); err != nil {
+			if errors.Is(err, errAdvisoryLockNotAvailable) {
+				return false, nil
+			}
+			return false, err
+		}
+		if !ok {
+			l = &advisoryLock{}
+			m.locks[key] = l
+		}
+		l.exclusive = l.exclusive || !shared
+	}
+	switch {
+	case xact && shared:
+		l.xactShared++
+	case xact:
+		l.xactExclusive++
+	case shared:
+		l.sessionShared++
+	default:
+		l.sessionExclusive++
+	}
+	return true, nil
+}
+
+// Unlock is part of the eval.AdvisoryLocks interface.
+func (m *advisoryLockManager) Unlock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared bool,
+) (bool, error) {
+	l, ok := m.locks[key]
+	if !ok {
+		return false, nil
+	}
+	count := &l.sessionExclusive
+	if shared {
+		count = &l.sessionShared
+	}
+	if *count == 0 {
+		return false, nil
+	}
+	*count--
+	return true, m.release(ctx)
+}
+
+// UnlockAll is part of the eval.AdvisoryLocks interface.
+func (m *advisoryLockManager) UnlockAll(ctx context.Context) error {
+	for _, l := range m.locks {
+		l.sessionExclusive, l.sessionShared = 0, 0
+	}
+	return m.release(ctx)
+}
+
+// releaseXactLocks releases all the transaction-level advisory locks. It is
+// called at the end of every transaction.
+func (m *advisoryLockManager) releaseXactLocks(ctx context.Context) {
+	for _, l := range m.locks {
+		l.xactExclusive, l.xactShared = 0, 0
+	}
+	if err := m.release(ctx); err != nil {
+		// The release is retried at the end of the next transaction, and
+		// when the session closes.
+		log.Warningf(ctx, "failed to release transaction-level advisory locks: %v", err)
+	}
+}
+
+// close releases all the advisory locks of the session.
+func (m *advisoryLockManager) close(ctx context.Context) {
+	for _, l := range m.locks {
+		l.sessionExclusive, l.sessionShared = 0, 0
+		l.xactExclusive, l.xactShared = 0, 0
+	}
+	if err := m.release(ctx); err != nil {
+		// The rows are removed by the SQL instance once the session is gone
+		// (see reapOrphanedAdvisoryLocks).
+		log.Warningf(ctx, "failed to release advisory locks: %v", err)
+	}
+}
+
+// release deletes the rows of the locks the session no longer holds, and
+// downgrades the rows of the locks it now only holds in shared mode. The
+// locks are only forgotten once their rows have been updated, so if release
+// fails, the next call retries it.
+func (m *advisoryLockManager) release(ctx context.Context) error {
+	var deleted, downgraded []eval.AdvisoryLockKey
+	for key, l := range m.locks {
+		switch {
+		case !l.held():
+			deleted = append(deleted, key)
+		case l.exclusive && !l.heldExclusive():
+			downgraded = append(downgraded, key)
+		}
+	}
+	if len(deleted) == 0 && len(downgraded) == 0 {
+		return nil
+	}
+	// The locks must be released even if the statement is canceled.
+	ctx = context.WithoutCancel(ctx)
+	var err error
+	for r := retry.StartWithCtx(ctx, advisoryLockReleaseRetryOptions); r.Next(); {
+		if err = m.releaseOnce(ctx, deleted, downgraded); err == nil {
+			break
+		}
+		log.VEventf(ctx, 2, "failed to release advisory locks: %v", err)
+	}
+	if err != nil {
+		return errors.Wrap(err, "releasing advisory locks")
+	}
+	for _, key := range deleted {
+		delete(m.locks, key)
+	}
+	for _, key := range downgraded {
+		m.locks[key].exclusive = false
+	}
+	return nil
+}
+
+// releaseOnce deletes the rows of the given locks and downgrades the rows of
+// the given locks to shared mode, in a transaction.
+func (m *advisoryLockManager) releaseOnce(
+	ctx context.Context, deleted, downgraded []eval.AdvisoryLockKey,
+) error {
+	return m.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		for _, key := range deleted {
+			if _, err := txn.ExecEx(
+				ctx, "advisory-lock-release", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				`DELETE FROM system.advisory_locks
+WHERE database_id = $1 AND class_id = $2 AND obj_id = $3 AND obj_sub_id = $4
+  AND holder_session_id = $5`,
+				key.DatabaseID, key.ClassID, key.ObjID, key.ObjSubID, m.holderID,
+			); err != nil {
+				return err
+			}
+		}
+		for _, key := range downgraded {
+			if _, err := txn.ExecEx(
+				ctx, "advisory-lock-downgrade", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				`UPDATE system.advisory_locks SET exclusive = false
+WHERE database_id = $1 AND class_id = $2 AND obj_id = $3 AND obj_sub_id = $4
+  AND holder_session_id = $5`,
+				key.DatabaseID, key.ClassID, key.ObjID, key.ObjSubID, m.holderID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// errAdvisoryLockNotAvailable is returned by acquire when the lock is held by
+// another session and waiting is not allowed.
+var errAdvisoryLockNotAvailable = errors.New("advisory lock not available")
+
+// acquire acquires the given advisory lock in the given mode, waiting for it
+// to be released by the other sessions if wait is set.
+func (m *advisoryLockManager) acquire(
+	ctx context.Context, key eval.AdvisoryLockKey, exclusive, wait bool,
+) error {
+	var deadline time.Time
+	if timeout := m.sessionData().LockTimeout; wait && timeout != 0 {
+		deadline = timeutil.Now().Add(timeout)
+	}
+	for r := retry.StartWithCtx(ctx, advisoryLockRetryOptions); r.Next(); {
+		acquired, err := m.tryAcquire(ctx, key, exclusive)
+		if err != nil || acquired {
+			return err
+		}
+		if !wait {
+			return errAdvisoryLockNotAvailable
+		}
+		if !deadline.IsZero() && timeutil.Now().After(deadline) {
+			return pgerror.New(pgcode.LockNotAvailable,
+				"canceling statement due to lock timeout on advisory lock")
+		}
+	}
+	return ctx.Err()
+}
+
+// tryAcquire writes the row of the given advisory lock in the given mode if no
+// other session holds the lock in a conflicting mode. It returns false if the
+// lock is not available.
+func (m *advisoryLockManager) tryAcquire(
+	ctx context.Context, key eval.AdvisoryLockKey, exclusive bool,
+) (acquired bool, _ error) {
+	session, err := m.liveness.Session(ctx)
+	if err != nil {
+		return false, err
+	}
+	reader := m.liveness.BlockingReader()
+	err = m.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		acquired = false
+		// Other sessions only consider the lock held while the sqlliveness
+		// session recorded in its row is alive, so the row must be written
+		// before it expires.
+		if err := txn.KV().UpdateDeadline(ctx, session.Expiration()); err != nil {
+			return err
+		}
+		rows, err := txn.QueryBufferedEx(
+			ctx, "advisory-lock-holders", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT holder_session_id, sqlliveness_session_id, exclusive
+FROM system.advisory_locks
+WHERE database_id = $1 AND class_id = $2 AND obj_id = $3 AND obj_sub_id = $4
+FOR UPDATE`,
+			key.DatabaseID, key.ClassID, key.ObjID, key.ObjSubID,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			holderID := []byte(tree.MustBeDBytes(row[0]))
+			if bytes.Equal(holderID, m.holderID) {
+				continue
+			}
+			instanceSession := []byte(tree.MustBeDBytes(row[1]))
+			alive, err := reader.IsAlive(ctx, sqlliveness.SessionID(instanceSession))
+			if err != nil {
+				return err
+			}
+			if alive && bytes.Equal(instanceSession, session.ID().UnsafeBytes()) {
+				// The session holding the lock belongs to this SQL instance. If it
+				// is gone, it closed without managing to release the lock.
+				_, alive = m.registry.GetSessionByID(clusterunique.IDFromBytes(holderID))
+			}
+			if !alive {
+				// The session holding the lock, or its SQL instance, died.
+				if _, err := txn.ExecEx(
+					ctx, "advisory-lock-remove-expired", txn.KV(),
+					sessiondata.NodeUserSessionDataOverride,
+					`DELETE FROM system.advisory_locks
+WHERE database_id = $1 AND class_id = $2 AND obj_id = $3 AND obj_sub_id = $4
+  AND holder_session_id = $5`,
+					key.DatabaseID, key.ClassID, key.ObjID, key.ObjSubID, holderID,
+				); err != nil {
+					return err
+				}
+				continue
+			}
+			if exclusive || bool(tree.MustBeDBool(row[2])) {
+				return nil
+			}
+		}
+		if _, err := txn.ExecEx(
+			ctx, "advisory-lock-acquire", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPSERT INTO system.advisory_locks
+  (database_id, class_id, obj_id, obj_sub_id, holder_session_id, sqlliveness_session_id, pid, exclusive)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			key.DatabaseID, key.ClassID, key.ObjID, key.ObjSubID, m.holderID,
+			session.ID().UnsafeBytes(), m.pid, exclusive,
+		); err != nil {
+			return err
+		}
+		acquired = true
+		return nil
+	})
+	return acquired, err
+}
+
+// checkAdvisoryLocksSupported returns an error if the system table which
+// stores the advisory locks has not been created yet.
+func checkAdvisoryLocksSupported(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_AdvisoryLocks) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use advisory locks",
+			clusterversion.V24_3_AdvisoryLocks.Version())
+	}
+	return nil
+}
+
+// startAdvisoryLockReaper starts the task which periodically removes the rows
+// of the advisory locks of the sessions of this SQL instance which no longer
+// exist.
+func (s *Server) startAdvisoryLockReaper(ctx context.Context, stopper *stop.Stopper) {
+	if s.cfg.SQLLiveness == nil {
+		return
+	}
+	_ = stopper.RunAsyncTask(ctx, "advisory-lock-reaper", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(advisoryLockReapInterval.Get(&s.cfg.Settings.SV))
+			select {
+			case <-timer.C:
+				timer.Read = true
+				if err := reapOrphanedAdvisoryLocks(ctx, s.cfg); err != nil {
+					log.Warningf(ctx, "failed to remove orphaned advisory locks: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// reapOrphanedAdvisoryLocks removes the rows of the advisory locks held by the
+// sessions of this SQL instance which are not in its session registry. These
+// are left behind by sessions which closed without managing to release their
+// locks.
+func reapOrphanedAdvisoryLocks(ctx context.Context, cfg *ExecutorConfig) error {
+	if checkAdvisoryLocksSupported(ctx, cfg.Settings) != nil {
+		return nil
+	}
+	session, err := cfg.SQLLiveness.Session(ctx)
+	if err != nil {
+		return err
+	}
+	return cfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		rows, err := txn.QueryBufferedEx(
+			ctx, "advisory-lock-instance-holders", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT DISTINCT holder_session_id FROM system.advisory_locks
+WHERE sqlliveness_session_id = $1`,
+			session.ID().UnsafeBytes(),
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			holderID := []byte(tree.MustBeDBytes(row[0]))
+			if _, ok := cfg.SessionRegistry.GetSessionByID(clusterunique.IDFromBytes(holderID)); ok {
+				continue
+			}
+			if _, err := txn.ExecEx(
+				ctx, "advisory-lock-remove-orphaned", txn.KV(),
+				sessiondata.NodeUserSessionDataOverride,
+				`DELETE FROM system.advisory_locks
+WHERE holder_session_id = $1 AND sqlliveness_session_id = $2`,
+				holderID, session.ID().UnsafeBytes(),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// advisoryLockRow is an advisory lock held by a session, as shown in pg_locks.
+type advisoryLockRow struct {
+	key       eval.AdvisoryLockKey
+	pid       uint32
+	exclusive bool
+}
+
+// forEachAdvisoryLock calls fn for every advisory lock held by a session of
+// the cluster whose SQL instance is alive.
+func (p *planner) forEachAdvisoryLock(
+	ctx context.Context, fn func(advisoryLockRow) error,
+) error {
+	if checkAdvisoryLocksSupported(ctx, p.ExecCfg().Settings) != nil {
+		return nil
+	}
+	rows, err := p.InternalSQLTxn().QueryBufferedEx(
+		ctx,
+		"pg-locks-advisory",
+		p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT database_id, class_id, obj_id, obj_sub_id, pid, exclusive, sqlliveness_session_id
+FROM system.advisory_locks`,
+	)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if reader := p.EvalContext().SQLLivenessReader; reader != nil {
+			alive, err := reader.IsAlive(ctx, sqlliveness.SessionID(tree.MustBeDBytes(row[6])))
+			if err != nil {
+				return err
+			}
+			if !alive {
+				continue
+			}
+		}
+		if err := fn(advisoryLockRow{
+			key: eval.AdvisoryLockKey{
+				DatabaseID: uint32(tree.MustBeDInt(row[0])),
+				ClassID:    uint32(tree.MustBeDInt(row[1])),
+				ObjID:      uint32(tree.MustBeDInt(row[2])),
+				ObjSubID:   uint16(tree.MustBeDInt(row[3])),
+			},
+			pid:       uint32(tree.MustBeDInt(row[4])),
+			exclusive: bool(tree.MustBeDBool(row[5])),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
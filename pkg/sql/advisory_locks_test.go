@@ -0,0 +1,206 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness/slstorage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisoryLocksContention(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	newConn := func() *sqlutils.SQLRunner {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		return sqlutils.MakeSQLRunner(conn)
+	}
+	a, b, c := newConn(), newConn(), newConn()
+
+	t.Run("blocking", func(t *testing.T) {
+		a.Exec(t, `SELECT pg_advisory_lock(1)`)
+		b.CheckQueryResults(t, `SELECT pg_try_advisory_lock(1)`, [][]string{{"false"}})
+
+		// A session waiting for the lock acquires it once it is released.
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := b.DB.ExecContext(ctx, `SELECT pg_advisory_lock(1)`)
+			errCh <- err
+		}()
+		c.CheckQueryResultsRetry(t, `
+SELECT count(*) FROM [SHOW CLUSTER QUERIES]
+WHERE query = 'SELECT pg_advisory_lock(1)'`, [][]string{{"1"}})
+		select {
+		case err := <-errCh:
+			t.Fatalf("acquired the lock while it was held: %v", err)
+		default:
+		}
+		a.CheckQueryResults(t, `SELECT pg_advisory_unlock(1)`, [][]string{{"true"}})
+		require.NoError(t, <-errCh)
+
+		a.CheckQueryResults(t, `SELECT pg_try_advisory_lock(1)`, [][]string{{"false"}})
+		b.CheckQueryResults(t, `SELECT pg_advisory_unlock(1)`, [][]string{{"true"}})
+	})
+
+	t.Run("lock timeout", func(t *testing.T) {
+		a.Exec(t, `SELECT pg_advisory_lock(2)`)
+		b.Exec(t, `SET lock_timeout = '100ms'`)
+		b.ExpectErr(t, "lock timeout on advisory lock", `SELECT pg_advisory_lock(2)`)
+		b.Exec(t, `RESET lock_timeout`)
+		a.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+
+	t.Run("statement timeout", func(t *testing.T) {
+		a.Exec(t, `SELECT pg_advisory_lock(4)`)
+		b.Exec(t, `SET statement_timeout = '100ms'`)
+		b.ExpectErr(t, "statement timeout", `SELECT pg_advisory_lock(4)`)
+		b.Exec(t, `RESET statement_timeout`)
+		a.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+
+	t.Run("deadlock", func(t *testing.T) {
+		// Deadlocks are not detected, but lock_timeout breaks the cycle.
+		a.Exec(t, `SELECT pg_advisory_lock(5)`)
+		b.Exec(t, `SELECT pg_advisory_lock(6)`)
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := a.DB.ExecContext(ctx, `SELECT pg_advisory_lock(6)`)
+			errCh <- err
+		}()
+		c.CheckQueryResultsRetry(t, `
+SELECT count(*) FROM [SHOW CLUSTER QUERIES]
+WHERE query = 'SELECT pg_advisory_lock(6)'`, [][]string{{"1"}})
+		b.Exec(t, `SET lock_timeout = '100ms'`)
+		b.ExpectErr(t, "lock timeout on advisory lock", `SELECT pg_advisory_lock(5)`)
+		b.Exec(t, `RESET lock_timeout`)
+		b.Exec(t, `SELECT pg_advisory_unlock_all()`)
+		require.NoError(t, <-errCh)
+		a.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		a.Exec(t, `SELECT pg_advisory_lock_shared(3)`)
+		b.CheckQueryResults(t, `SELECT pg_try_advisory_lock_shared(3)`, [][]string{{"true"}})
+		c.CheckQueryResults(t, `SELECT pg_try_advisory_lock(3)`, [][]string{{"false"}})
+		a.Exec(t, `SELECT pg_advisory_unlock_all()`)
+		c.CheckQueryResults(t, `SELECT pg_try_advisory_lock(3)`, [][]string{{"false"}})
+		b.Exec(t, `SELECT pg_advisory_unlock_all()`)
+		c.CheckQueryResults(t, `SELECT pg_try_advisory_lock(3)`, [][]string{{"true"}})
+		c.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+}
+
+func TestAdvisoryLocksReleased(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	// Advisory locks are held by sessions, so all the statements must run on
+	// the same connection.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	sqlDB := sqlutils.MakeSQLRunner(conn)
+
+	t.Run("session closed", func(t *testing.T) {
+		other := s.ApplicationLayer().SQLConn(t)
+		other.SetMaxOpenConns(1)
+		_, err := other.Exec(`SELECT pg_advisory_lock(1), pg_advisory_lock(2)`)
+		require.NoError(t, err)
+		sqlDB.CheckQueryResults(t, `SELECT pg_try_advisory_lock(1)`, [][]string{{"false"}})
+		require.NoError(t, other.Close())
+
+		testutils.SucceedsSoon(t, func() error {
+			var acquired bool
+			sqlDB.QueryRow(t, `SELECT pg_try_advisory_lock(1)`).Scan(&acquired)
+			if !acquired {
+				return errors.New("lock still held")
+			}
+			return nil
+		})
+		sqlDB.CheckQueryResults(t, `SELECT pg_try_advisory_lock(2)`, [][]string{{"true"}})
+		sqlDB.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+
+	t.Run("sqlliveness session expired", func(t *testing.T) {
+		// Simulate a lock held by a session of a SQL instance which died
+		// without releasing it.
+		deadSession, err := slstorage.MakeSessionID(enum.One, uuid.MakeV4())
+		require.NoError(t, err)
+		sqlDB.Exec(t, `
+INSERT INTO system.advisory_locks
+SELECT oid::INT8, 0, 3, 1, 'dead', $1, 42, true
+FROM pg_database WHERE datname = current_database()`,
+			deadSession.UnsafeBytes(),
+		)
+
+		// The lock is not shown as held, and can be acquired.
+		sqlDB.CheckQueryResultsRetry(t, `SELECT count(*) FROM pg_locks`, [][]string{{"0"}})
+		sqlDB.CheckQueryResults(t, `SELECT pg_try_advisory_lock(3)`, [][]string{{"true"}})
+		sqlDB.CheckQueryResults(t, `
+SELECT count(*) FROM system.advisory_locks WHERE holder_session_id = 'dead'`,
+			[][]string{{"0"}},
+		)
+		sqlDB.Exec(t, `SELECT pg_advisory_unlock_all()`)
+	})
+
+	t.Run("session gone", func(t *testing.T) {
+		// Simulate locks held by sessions of this SQL instance which closed
+		// without managing to release them.
+		execCfg := s.ApplicationLayer().ExecutorConfig().(ExecutorConfig)
+		session, err := execCfg.SQLLiveness.Session(ctx)
+		require.NoError(t, err)
+		insertOrphan := func(objID int, holder clusterunique.ID) {
+			sqlDB.Exec(t, `
+INSERT INTO system.advisory_locks
+SELECT oid::INT8, 0, $1, 1, $2, $3, 42, true
+FROM pg_database WHERE datname = current_database()`,
+				objID, holder.GetBytes(), session.ID().UnsafeBytes(),
+			)
+		}
+		gone := func() clusterunique.ID {
+			return clusterunique.GenerateID(s.Clock().Now(), execCfg.NodeInfo.NodeID.SQLInstanceID())
+		}
+
+		// The lock is not held, so it can be acquired by another session.
+		insertOrphan(4, gone())
+		sqlDB.CheckQueryResults(t, `SELECT pg_try_advisory_lock(4)`, [][]string{{"true"}})
+		sqlDB.Exec(t, `SELECT pg_advisory_unlock_all()`)
+
+		// The rows are removed by the SQL instance even if nobody acquires the
+		// locks.
+		insertOrphan(5, gone())
+		insertOrphan(6, gone())
+		require.NoError(t, reapOrphanedAdvisoryLocks(ctx, &execCfg))
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM system.advisory_locks`, [][]string{{"0"}})
+	})
+}
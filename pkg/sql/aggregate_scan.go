@@ -0,0 +1,63 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// aggregateScanNode represents a scan of an index that computes a set of
+// scalar aggregations over the rows that satisfy a filter. The aggregations
+// are computed in the KV layer, where each range computes partial aggregates
+// over its rows, so that only one row per range is returned to SQL. The
+// partial aggregates are combined by the aggregateScanner processor (see
+// rowexec/aggregate_scanner.go).
+type aggregateScanNode struct {
+	// scan contains the table and index descriptors, the spans and the columns
+	// being scanned.
+	scan *scanNode
+
+	// filter is the filter on the scanned rows, or nil if there is none. Its
+	// indexed vars refer to scan.cols. It can be fully evaluated by the KV
+	// layer.
+	filter tree.TypedExpr
+
+	// aggregations are the aggregations computed over the scanned rows. Their
+	// argument columns refer to scan.cols.
+	aggregations []exec.AggInfo
+
+	// columns are the produced columns, one per aggregation.
+	columns colinfo.ResultColumns
+}
+
+// startExec is part of the planNode interface.
+func (n *aggregateScanNode) startExec(params runParams) error {
+	panic("aggregate scans cannot be executed outside of distsql")
+}
+
+// Next is part of the planNode interface.
+func (n *aggregateScanNode) Next(params runParams) (bool, error) {
+	panic("aggregate scans cannot be executed outside of distsql")
+}
+
+// Values is part of the planNode interface.
+func (n *aggregateScanNode) Values() tree.Datums {
+	panic("aggregate scans cannot be executed outside of distsql")
+}
+
+// Close is part of the planNode interface.
+func (n *aggregateScanNode) Close(ctx context.Context) {
+	n.scan.Close(ctx)
+}
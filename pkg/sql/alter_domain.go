@@ -0,0 +1,343 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+// AlterDomain alters a domain.
+// Privileges: ownership of the domain.
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", desc.GetName())
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	return &alterDomainNode{
+		n:    n,
+		desc: desc,
+	}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	domain := n.desc.Domain
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = nil
+			break
+		}
+		defaultExpr, err := makeDomainDefaultExpr(params.ctx, params.p, t.Default, domain.BaseType)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = &defaultExpr
+
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull == domain.NotNull {
+			return nil
+		}
+		domain.NotNull = t.NotNull
+		if t.NotNull {
+			// The existing values of the domain are validated by the type schema
+			// change job. In the meantime, the constraint is enforced on new values.
+			domain.NotNullValidity = descpb.ConstraintValidity_Validating
+		} else {
+			domain.NotNullValidity = descpb.ConstraintValidity_Validated
+		}
+
+	case *tree.AlterDomainAddConstraint:
+		check, err := makeDomainCheckConstraint(params.ctx, params.p, n.desc.GetName(), domain, t.Check)
+		if err != nil {
+			return err
+		}
+		if t.NotValid {
+			check.Validity = descpb.ConstraintValidity_Unvalidated
+		} else {
+			// The existing values of the domain are validated by the type schema
+			// change job. In the meantime, the constraint is enforced on new values.
+			check.Validity = descpb.ConstraintValidity_Validating
+		}
+		domain.Checks = append(domain.Checks, check)
+
+	case *tree.AlterDomainDropConstraint:
+		name := string(t.Constraint)
+		if findDomainCheckConstraint(domain, name) == nil {
+			if t.IfExists {
+				params.p.BufferClientNotice(
+					params.ctx,
+					pgnotice.Newf("constraint %q of domain %q does not exist, skipping", name, n.desc.GetName()),
+				)
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", name, n.desc.GetName())
+		}
+		checks := domain.Checks[:0]
+		for _, c := range domain.Checks {
+			if c.Name != name {
+				checks = append(checks, c)
+			}
+		}
+		domain.Checks = checks
+
+	case *tree.AlterDomainValidateConstraint:
+		name := string(t.Constraint)
+		check := findDomainCheckConstraint(domain, name)
+		if check == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", name, n.desc.GetName())
+		}
+		switch check.Validity {
+		case descpb.ConstraintValidity_Validated:
+			// Nothing to do.
+			return nil
+		case descpb.ConstraintValidity_Validating:
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"constraint %q in the middle of being added, try again later", name)
+		}
+		// As for tables, the constraint is validated in the user transaction.
+		if err := validateDomainConstraintInTxn(
+			params.ctx, params.p.InternalSQLTxn(), n.desc, check,
+		); err != nil {
+			return err
+		}
+		check.Validity = descpb.ConstraintValidity_Validated
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+		})
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
+
+// validateDomainConstraintInTxn checks that the existing values of the domain,
+// which are stored in the columns of the tables referencing it, satisfy the
+// given CHECK constraint of the domain. If check is nil, the NOT NULL
+// constraint of the domain is validated instead.
+func validateDomainConstraintInTxn(
+	ctx context.Context,
+	txn descs.Txn,
+	typeDesc catalog.TypeDescriptor,
+	check *descpb.TypeDescriptor_Domain_CheckConstraint,
+) error {
+	domain := typeDesc.AsDomainTypeDescriptor()
+	if domain == nil {
+		return errors.AssertionFailedf("type %q is not a domain", typeDesc.GetName())
+	}
+	var checkExpr tree.Expr
+	if check != nil {
+		var err error
+		if checkExpr, err = parser.ParseExpr(check.Expr); err != nil {
+			return err
+		}
+	}
+
+	// We need to override the internal executor's current database, as the
+	// constraint may refer to objects by names that are resolved in the context
+	// of the domain's database.
+	dbDesc, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutNonPublic().Get().Database(
+		ctx, typeDesc.GetParentID(),
+	)
+	if err != nil {
+		return err
+	}
+	override := sessiondata.InternalExecutorOverride{
+		User:     username.NodeUserName(),
+		Database: dbDesc.GetName(),
+	}
+	domainOID := catid.TypeIDToOID(typeDesc.GetID())
+	for _, id := range typeDesc.GetReferencingDescriptorIDs() {
+		desc, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		table, ok := desc.(catalog.TableDescriptor)
+		if !ok || table.IsView() {
+			continue
+		}
+		for _, col := range table.PublicColumns() {
+			if col.GetType().Oid() != domainOID {
+				continue
+			}
+			colName := col.ColName()
+			cond := fmt.Sprintf("t.%s IS NULL", colName.String())
+			if checkExpr != nil {
+				// Refer to the column as a value of the base type of the domain
+				// in the expression of the constraint.
+				value := &tree.CastExpr{
+					Expr:       tree.NewColumnItem(tree.NewUnqualifiedTableName("t"), colName),
+					Type:       domain.GetBaseType(),
+					SyntaxMode: tree.CastShort,
+				}
+				expr, err := tree.ReplaceDomainValue(checkExpr, value)
+				if err != nil {
+					return err
+				}
+				cond = fmt.Sprintf("(%s) IS FALSE", tree.AsStringWithFlags(expr, tree.FmtSerializable))
+			}
+			query := fmt.Sprintf("SELECT 1 FROM [%d AS t] WHERE %s LIMIT 1", id, cond)
+			row, err := txn.QueryRowEx(ctx, "validate-domain-constraint", txn.KV(), override, query)
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				continue
+			}
+			if check == nil {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", colName, table.GetName())
+			}
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				colName, table.GetName())
+		}
+	}
+	return nil
+}
+
+// validateDomainConstraints validates the constraints that were added to a
+// domain by ALTER DOMAIN against the existing values of the domain, and then
+// marks them as validated.
+func (t *typeSchemaChanger) validateDomainConstraints(ctx context.Context) error {
+	// The validation queries can take arbitrarily long, so they are run in a
+	// separate txn from the one that mutates the descriptor.
+	validate := func(ctx context.Context, txn descs.Txn) error {
+		typeDesc, err := txn.Descriptors().ByIDWithoutLeased(txn.KV()).Get().Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		domain := typeDesc.AsDomainTypeDescriptor().DomainDesc()
+		if domain.NotNull && domain.NotNullValidity == descpb.ConstraintValidity_Validating {
+			if err := validateDomainConstraintInTxn(ctx, txn, typeDesc, nil /* check */); err != nil {
+				return err
+			}
+		}
+		for i := range domain.Checks {
+			if domain.Checks[i].Validity == descpb.ConstraintValidity_Validating {
+				if err := validateDomainConstraintInTxn(ctx, txn, typeDesc, &domain.Checks[i]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := t.execCfg.InternalDB.DescsTxn(ctx, validate); err != nil {
+		return err
+	}
+
+	run := func(ctx context.Context, txn descs.Txn) error {
+		typeDesc, err := txn.Descriptors().MutableByID(txn.KV()).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		if typeDesc.Domain.NotNullValidity == descpb.ConstraintValidity_Validating {
+			typeDesc.Domain.NotNullValidity = descpb.ConstraintValidity_Validated
+		}
+		for i := range typeDesc.Domain.Checks {
+			if typeDesc.Domain.Checks[i].Validity == descpb.ConstraintValidity_Validating {
+				typeDesc.Domain.Checks[i].Validity = descpb.ConstraintValidity_Validated
+			}
+		}
+		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, typeDesc, txn.KV())
+	}
+	return t.execCfg.InternalDB.DescsTxn(ctx, run)
+}
+
+// cleanupDomainConstraints is called when a type schema change job that was
+// validating the constraints of a domain fails. The CHECK constraints being
+// validated are removed from the domain, and a NOT NULL constraint being
+// validated is dropped.
+func (t *typeSchemaChanger) cleanupDomainConstraints(ctx context.Context) error {
+	cleanup := func(ctx context.Context, txn descs.Txn) error {
+		typeDesc, err := txn.Descriptors().MutableByID(txn.KV()).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		// No cleanup required.
+		if typeDesc.Kind != descpb.TypeDescriptor_DOMAIN || !typeDesc.HasPendingSchemaChanges() {
+			return nil
+		}
+		domain := typeDesc.Domain
+		if domain.NotNullValidity == descpb.ConstraintValidity_Validating {
+			domain.NotNull = false
+			domain.NotNullValidity = descpb.ConstraintValidity_Validated
+		}
+		checks := domain.Checks[:0]
+		for _, c := range domain.Checks {
+			if c.Validity != descpb.ConstraintValidity_Validating {
+				checks = append(checks, c)
+			}
+		}
+		domain.Checks = checks
+		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, typeDesc, txn.KV())
+	}
+	return t.execCfg.InternalDB.DescsTxn(ctx, cleanup)
+}
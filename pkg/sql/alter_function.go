@@ -74,6 +74,11 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if fnDesc.IsAggregate() {
+		// The options of an aggregate are derived from its transition and final
+		// functions.
+		return pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", fnDesc.GetName())
+	}
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references. Need to think about in what condition a function can be altered
@@ -532,6 +537,8 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		ReturnType:  fnDesc.ReturnType.Type,
 		ReturnSet:   fnDesc.ReturnType.ReturnSet,
 		IsProcedure: fnDesc.IsProcedure(),
+		IsAggregate: fnDesc.IsAggregate(),
+		IsVariadic:  fnDesc.IsVariadic(),
 	}
 	for paramIdx, param := range fnDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -142,6 +143,31 @@ func (p *planner) checkPasswordOptionConstraints(
 	return nil
 }
 
+// checkBypassRLSOptionConstraints returns an error if the role options contain
+// BYPASSRLS or NOBYPASSRLS and the current user is not an admin. As in
+// Postgres, only superusers can change whether a role bypasses row-level
+// security policies.
+func (p *planner) checkBypassRLSOptionConstraints(
+	ctx context.Context, roleOptions roleoption.List,
+) error {
+	if !roleOptions.Contains(roleoption.BYPASSRLS) && !roleOptions.Contains(roleoption.NOBYPASSRLS) {
+		return nil
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_3) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"BYPASSRLS role option is only supported after v24.3 upgrade is finalized")
+	}
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAdmin {
+		return pgerror.New(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to change the BYPASSRLS attribute")
+	}
+	return nil
+}
+
 func (n *alterRoleNode) startExec(params runParams) error {
 	var opName string
 	if n.isRole {
@@ -189,6 +215,9 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		if err := params.p.checkPasswordOptionConstraints(params.ctx, n.roleOptions, false /* newUser */); err != nil {
 			return err
 		}
+		if err := params.p.checkBypassRLSOptionConstraints(params.ctx, n.roleOptions); err != nil {
+			return err
+		}
 	}
 
 	// Check if role exists.
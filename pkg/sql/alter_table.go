@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -208,12 +209,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err != nil {
 				return err
 			}
+			if err := params.p.addColumnToInheritingTables(params, n, t); err != nil {
+				return err
+			}
 		case *tree.AlterTableAddConstraint:
 			if skip, err := validateConstraintNameIsNotUsed(n.tableDesc, t); err != nil {
 				return err
 			} else if skip {
 				continue
 			}
+			if err := checkDeferrableConstraintDef(
+				params.ctx, params.ExecCfg().Settings, t.ConstraintDef,
+			); err != nil {
+				return err
+			}
 			switch d := t.ConstraintDef.(type) {
 			case *tree.UniqueConstraintTableDef:
 				if d.WithoutIndex {
@@ -367,14 +376,30 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
+				var ck *descpb.TableDescriptor_CheckConstraint
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
 					ckBuilder := schemaexpr.MakeCheckConstraintBuilder(params.ctx, *tn, n.tableDesc, &params.p.semaCtx)
 					for _, c := range n.tableDesc.AllConstraints() {
 						ckBuilder.MarkNameInUse(c.GetName())
 					}
-					ck, buildErr := ckBuilder.Build(d, params.ExecCfg().Settings.Version.ActiveVersion(params.ctx))
+					var buildErr error
+					ck, buildErr = ckBuilder.Build(d, params.ExecCfg().Settings.Version.ActiveVersion(params.ctx))
 					if buildErr != nil {
 						err = buildErr
 						return
@@ -389,6 +414,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 				if err != nil {
 					return err
 				}
+				if err := params.p.addCheckToInheritingTables(params, n, d, ck); err != nil {
+					return err
+				}
 
 			case *tree.ForeignKeyConstraintTableDef:
 				// There are two cases that we want to reject FKs related to
@@ -534,6 +562,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 				)
 			}
 
+			if inherited, err := isInheritedColumn(params.ctx, params.p, tableDesc, t.Column); err != nil {
+				return err
+			} else if inherited {
+				return pgerror.Newf(pgcode.InvalidTableDefinition,
+					"cannot drop inherited column %q", t.Column)
+			}
+
 			colDroppedViews, err := dropColumnImpl(params, tn, tableDesc, tableDesc.GetRowLevelTTL(), t)
 			if err != nil {
 				return err
@@ -643,6 +678,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 				tableDesc.GetRowLevelTTL().HasDurationExpr() {
 				return sqlerrors.NewAlterDependsOnDurationExprError("alter", "column", columnName, tn.Object())
 			}
+			if _, ok := t.(*tree.AlterTableAlterColumnType); ok {
+				if err := checkColumnChangeAllowedWithInheritance(
+					params.ctx, params.p, tableDesc, col.ColName(), "alter",
+				); err != nil {
+					return err
+				}
+			}
 			// Apply mutations to copy of column descriptor.
 			if err := applyColumnMutation(params.ctx, tableDesc, col, t, params, n.n.Cmds, tn); err != nil {
 				return err
@@ -789,6 +831,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 					columnName,
 				)
 			}
+			if err := checkColumnChangeAllowedWithInheritance(
+				params.ctx, params.p, tableDesc, columnName, "rename",
+			); err != nil {
+				return err
+			}
 			descChanged, err := params.p.renameColumn(params.ctx, tableDesc, columnName, t.NewName)
 			if err != nil {
 				return err
@@ -845,6 +892,44 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return err
 			}
 			descriptorChanged = true
+
+		case *tree.AlterTableSetRLSMode:
+			if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_3) {
+				return pgerror.New(pgcode.FeatureNotSupported,
+					"row-level security is not supported until the cluster is fully upgraded")
+			}
+			hasOwnership, err := params.p.HasOwnership(params.ctx, n.tableDesc)
+			if err != nil {
+				return err
+			}
+			if !hasOwnership {
+				return pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of table %s", tree.Name(n.tableDesc.GetName()))
+			}
+			switch t.Mode {
+			case tree.TableRLSEnable:
+				n.tableDesc.RowLevelSecurityEnabled = true
+			case tree.TableRLSDisable:
+				n.tableDesc.RowLevelSecurityEnabled = false
+			case tree.TableRLSForce:
+				n.tableDesc.RowLevelSecurityForced = true
+			case tree.TableRLSNoForce:
+				n.tableDesc.RowLevelSecurityForced = false
+			}
+			descriptorChanged = true
+
+		case *tree.AlterTableInherit:
+			if err := params.p.alterTableInherit(params.ctx, n.tableDesc, t); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
+		case *tree.AlterTableNoInherit:
+			if err := params.p.alterTableNoInherit(params.ctx, n.tableDesc, t); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		default:
 			return errors.AssertionFailedf("unsupported alter command: %T", cmd)
 		}
@@ -1693,6 +1778,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1872,6 +1960,26 @@ func dropColumnImpl(
 		)
 	}
 
+	// Drop, or block on, row-level security policies referencing the column.
+	var policies []descpb.PolicyDescriptor
+	for _, p := range tableDesc.Policies {
+		if catalog.MakeTableColSet(p.ColumnIDs...).Contains(colToDrop.GetID()) {
+			if t.DropBehavior != tree.DropCascade {
+				return nil, sqlerrors.NewDependentBlocksOpError("drop", "column", string(t.Column), "policy", p.Name)
+			}
+			continue
+		}
+		policies = append(policies, p)
+	}
+	tableDesc.Policies = policies
+
+	// Drop, or block on, triggers referencing the column.
+	if err := dropTriggersReferencingColumn(
+		params.ctx, params.p, tableDesc, t.Column, t.DropBehavior,
+	); err != nil {
+		return nil, err
+	}
+
 	if err := params.p.disallowDroppingPrimaryIndexReferencedInUDFOrView(params.ctx, tableDesc); err != nil {
 		return nil, err
 	}
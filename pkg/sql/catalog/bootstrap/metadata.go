@@ -457,6 +457,9 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 
 	// Tables introduced in 24.3
 	target.AddDescriptor(systemschema.TableMetadata)
+	target.AddDescriptor(systemschema.ReplicationSlotsTable)
+	target.AddDescriptor(systemschema.PublicationsTable)
+	target.AddDescriptor(systemschema.AdvisoryLocksTable)
 
 	// Adding a new system table? It should be added here to the metadata schema,
 	// and also created as a migration for older clusters.
@@ -470,7 +473,7 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 // NumSystemTablesForSystemTenant is the number of system tables defined on
 // the system tenant. This constant is only defined to avoid having to manually
 // update auto stats tests every time a new system table is added.
-const NumSystemTablesForSystemTenant = 57
+const NumSystemTablesForSystemTenant = 60
 
 // addSplitIDs adds a split point for each of the PseudoTableIDs to the supplied
 // MetadataSchema.
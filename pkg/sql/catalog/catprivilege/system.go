@@ -79,6 +79,9 @@ var (
 		catconstants.TxnExecInsightsTableName,
 		catconstants.StmtExecInsightsTableName,
 		catconstants.TableMetadata,
+		catconstants.ReplicationSlotsTableName,
+		catconstants.PublicationsTableName,
+		catconstants.AdvisoryLocksTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -121,6 +121,22 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 			return unimplemented.NewWithIssue(70099, "cannot use table record type as table column")
 		}
 
+	case types.RangeFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"range types not supported until version 24.3",
+			)
+		}
+
+	case types.JsonpathFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_3) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"jsonpath not supported until version 24.3",
+			)
+		}
+
 	case types.PGVectorFamily:
 		if !st.Version.IsActive(ctx, clusterversion.V24_2) {
 			return pgerror.Newf(
@@ -165,7 +181,7 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
 	case types.ArrayFamily:
 		return t.ArrayContents().Family() != types.RefCursorFamily
-	case types.JsonFamily, types.StringFamily:
+	case types.JsonFamily, types.StringFamily, types.RangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -205,6 +221,8 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		return true
 	case types.TSVectorFamily, types.TSQueryFamily:
 		return true
+	case types.JsonpathFamily:
+		return true
 	case types.PGVectorFamily:
 		return true
 	}
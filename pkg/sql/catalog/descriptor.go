@@ -324,6 +324,9 @@ type TableDescriptor interface {
 	IsSequence() bool
 	// IsTemporary returns true if this is a temporary table.
 	IsTemporary() bool
+	// GetOnCommit returns the action taken on a temporary table when a
+	// transaction that uses it commits.
+	GetOnCommit() descpb.TableDescriptor_OnCommit
 	// IsVirtualTable returns true if the TableDescriptor describes a
 	// virtual Table (like the information_schema tables) and thus doesn't
 	// need to be physically stored.
@@ -767,6 +770,25 @@ type TableDescriptor interface {
 	// IsSchemaLocked returns true if we don't allow performing schema changes
 	// on this table descriptor.
 	IsSchemaLocked() bool
+	// GetPolicies returns the row-level security policies of the table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// GetNextPolicyID returns the next unused policy ID for this table.
+	GetNextPolicyID() descpb.PolicyID
+	// IsRowLevelSecurityEnabled returns true if the row-level security
+	// policies of the table are enforced.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if the row-level security
+	// policies of the table are also enforced for the table owner.
+	IsRowLevelSecurityForced() bool
+	// GetTriggers returns the triggers of the table.
+	GetTriggers() []descpb.TriggerDescriptor
+	// GetNextTriggerID returns the next unused trigger ID for this table.
+	GetNextTriggerID() descpb.TriggerID
+	// GetInherits returns the IDs of the parent tables of this table.
+	GetInherits() []descpb.ID
+	// GetInheritedBy returns the IDs of the tables that inherit from this
+	// table.
+	GetInheritedBy() []descpb.ID
 	// IsPrimaryKeySwapMutation returns true if the mutation is a primary key
 	// swap mutation or a secondary index used by the declarative schema changer
 	// for a primary index swap.
@@ -831,6 +853,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -918,6 +944,18 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// GetBaseType returns the type underlying the domain.
+	GetBaseType() *types.T
+
+	// DomainDesc returns the underlying protobuf descriptor of the domain,
+	// which contains its constraints.
+	DomainDesc() *descpb.TypeDescriptor_Domain
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -1003,6 +1041,14 @@ type FunctionDescriptor interface {
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
 
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate function.
+	IsAggregate() bool
+
+	// IsVariadic returns true if the last input parameter of the routine has
+	// the VARIADIC class.
+	IsVariadic() bool
+
 	// GetSecurity returns the security specification of this function.
 	GetSecurity() catpb.Function_Security
 }
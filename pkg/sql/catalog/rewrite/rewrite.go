@@ -86,6 +86,10 @@ func TableDescs(
 			return err
 		}
 
+		// Drop triggers whose functions are not restored, and remap the
+		// function IDs of the others.
+		rewriteTriggers(table, descriptorRewrites)
+
 		// Remap type IDs and sequence IDs in all serialized expressions within the TableDescriptor.
 		// TODO (rohany): This needs tests once partial indexes are ready.
 		if err := tabledesc.ForEachExprStringInTableDesc(table, func(expr *string) error {
@@ -198,6 +202,11 @@ func TableDescs(
 			}
 		}
 
+		// Rewrite the table inheritance references, dropping those to the tables
+		// that are not being restored.
+		table.Inherits = rewriteIDs(table.Inherits, descriptorRewrites)
+		table.InheritedBy = rewriteIDs(table.InheritedBy, descriptorRewrites)
+
 		// Rewrite unique_without_index in both `UniqueWithoutIndexConstraints`
 		// and `Mutations` slice.
 		origUniqueWithoutIndexConstraints := table.UniqueWithoutIndexConstraints
@@ -372,6 +381,18 @@ func rewriteFunctionBodyDBNames(
 	}
 }
 
+// rewriteIDs rewrites the given descriptor IDs, omitting the IDs of the
+// descriptors that are not being rewritten.
+func rewriteIDs(ids []descpb.ID, rewrites jobspb.DescRewriteMap) []descpb.ID {
+	var ret []descpb.ID
+	for _, id := range ids {
+		if rewrite, ok := rewrites[id]; ok {
+			ret = append(ret, rewrite.ID)
+		}
+	}
+	return ret
+}
+
 // rewriteTypesInExpr rewrites all explicit ID type references in the input
 // expression string according to rewrites.
 func rewriteTypesInExpr(expr string, rewrites jobspb.DescRewriteMap) (string, error) {
@@ -657,7 +678,8 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 			}
 		}
 		switch t := typ.Kind; t {
-		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM:
+		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM,
+			descpb.TypeDescriptor_DOMAIN:
 			if rw, ok := descriptorRewrites[typ.ArrayTypeID]; ok {
 				typ.ArrayTypeID = rw.ID
 			}
@@ -953,6 +975,21 @@ func dropColumnExpressionsMissingDeps(
 	return nil
 }
 
+// rewriteTriggers drops the triggers of the table whose functions are not
+// being restored, and rewrites the function IDs of the remaining triggers.
+func rewriteTriggers(table *tabledesc.Mutable, descriptorRewrites jobspb.DescRewriteMap) {
+	triggers := table.Triggers[:0]
+	for _, trig := range table.Triggers {
+		rewrite, ok := descriptorRewrites[trig.FuncID]
+		if !ok {
+			continue
+		}
+		trig.FuncID = rewrite.ID
+		triggers = append(triggers, trig)
+	}
+	table.Triggers = triggers
+}
+
 // DatabaseDescs rewrites all ID's in the input slice of DatabaseDescriptors
 // using the input ID rewrite mapping. The function elides remapping offline schemas,
 // since they will not get restored into the cluster.
@@ -1079,6 +1116,24 @@ func FunctionDescs(
 			}
 		}
 
+		if agg := fnDesc.Aggregate; agg != nil {
+			if err := rewriteIDsInTypesT(agg.StateType, descriptorRewrites); err != nil {
+				return err
+			}
+			for _, funcID := range []*descpb.ID{&agg.TransitionFunctionID, &agg.FinalFunctionID} {
+				if *funcID == descpb.InvalidID {
+					continue
+				}
+				if funcRewrite, ok := descriptorRewrites[*funcID]; ok {
+					*funcID = funcRewrite.ID
+				} else {
+					return errors.AssertionFailedf(
+						"cannot restore aggregate %q because referenced function %d was not found",
+						fnDesc.Name, *funcID)
+				}
+			}
+		}
+
 		// Rewrite back reference IDs.
 		for i, dep := range fnDesc.DependedOnBy {
 			if depRewrite, ok := descriptorRewrites[dep.ID]; ok {
@@ -1176,6 +1176,55 @@ CREATE TABLE system.mvcc_statistics (
 		)
 	);`
 
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with CREATE_REPLICATION_SLOT. confirmed_flush_lsn is the position up to
+	// which the client has acknowledged receipt of the changes, and
+	// protected_timestamp_record_id protects the data of the database of the
+	// slot from garbage collection past that position.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name                     STRING NOT NULL,
+	plugin                        STRING NOT NULL,
+	database_id                   INT8 NOT NULL,
+	confirmed_flush_lsn           INT8 NOT NULL,
+	protected_timestamp_record_id UUID NOT NULL,
+	created                       TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, plugin, database_id, confirmed_flush_lsn, protected_timestamp_record_id, created)
+);`
+
+	// PublicationsTableSchema stores the publications created with CREATE
+	// PUBLICATION. table_ids is only used if all_tables is false.
+	PublicationsTableSchema = `
+CREATE TABLE system.publications (
+	database_id      INT8 NOT NULL,
+	publication_name STRING NOT NULL,
+	owner            STRING NOT NULL,
+	all_tables       BOOL NOT NULL,
+	table_ids        INT8[] NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, publication_name),
+	FAMILY "primary" (database_id, publication_name, owner, all_tables, table_ids)
+);`
+
+	// AdvisoryLocksTableSchema stores the advisory locks held by SQL sessions
+	// (see pg_advisory_lock). holder_session_id is the ID of the SQL session
+	// holding the lock, and sqlliveness_session_id is the sqlliveness session
+	// of its SQL instance. The locks of an expired sqlliveness session are no
+	// longer held, and their rows are removed by the sessions acquiring them.
+	AdvisoryLocksTableSchema = `
+CREATE TABLE system.advisory_locks (
+	database_id            INT8 NOT NULL,
+	class_id               INT8 NOT NULL,
+	obj_id                 INT8 NOT NULL,
+	obj_sub_id             INT8 NOT NULL,
+	holder_session_id      BYTES NOT NULL,
+	sqlliveness_session_id BYTES NOT NULL,
+	pid                    INT8 NOT NULL,
+	exclusive              BOOL NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, class_id, obj_id, obj_sub_id, holder_session_id),
+	FAMILY "primary" (database_id, class_id, obj_id, obj_sub_id, holder_session_id, sqlliveness_session_id, pid, exclusive)
+);`
+
 	crdbInternalTableIdLastUpdatedShard = "mod(fnv32(md5(crdb_internal.datums_to_bytes(table_id, last_updated))), 16:::INT8)"
 	TableMetadataTableSchema            = ` CREATE TABLE system.table_metadata (
 	  db_id INT8 NOT NULL,
@@ -1275,7 +1324,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_3_TenantExcludeDataFromBackup.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_3_AdvisoryLocks.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1467,6 +1516,9 @@ func MakeSystemTables() []SystemTable {
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
 		TableMetadata,
+		ReplicationSlotsTable,
+		PublicationsTable,
+		AdvisoryLocksTable,
 	}
 }
 
@@ -5003,6 +5055,145 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "plugin", ID: 2, Type: types.String},
+				{Name: "database_id", ID: 3, Type: types.Int},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+				{Name: "protected_timestamp_record_id", ID: 5, Type: types.Uuid},
+				{Name: "created", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name: "primary",
+					ID:   0,
+					ColumnNames: []string{
+						"slot_name",
+						"plugin",
+						"database_id",
+						"confirmed_flush_lsn",
+						"protected_timestamp_record_id",
+						"created",
+					},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           tabledesc.LegacyPrimaryKeyIndexName,
+				ID:             1,
+				Unique:         true,
+				Version:        descpb.StrictIndexColumnIDGuaranteesVersion,
+				KeyColumnNames: []string{"slot_name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1},
+			},
+		),
+	)
+
+	PublicationsTable = makeSystemTable(
+		PublicationsTableSchema,
+		systemTable(
+			catconstants.PublicationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "publication_name", ID: 2, Type: types.String},
+				{Name: "owner", ID: 3, Type: types.String},
+				{Name: "all_tables", ID: 4, Type: types.Bool},
+				{Name: "table_ids", ID: 5, Type: types.IntArray},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name: "primary",
+					ID:   0,
+					ColumnNames: []string{
+						"database_id",
+						"publication_name",
+						"owner",
+						"all_tables",
+						"table_ids",
+					},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           tabledesc.LegacyPrimaryKeyIndexName,
+				ID:             1,
+				Unique:         true,
+				Version:        descpb.StrictIndexColumnIDGuaranteesVersion,
+				KeyColumnNames: []string{"database_id", "publication_name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	AdvisoryLocksTable = makeSystemTable(
+		AdvisoryLocksTableSchema,
+		systemTable(
+			catconstants.AdvisoryLocksTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "class_id", ID: 2, Type: types.Int},
+				{Name: "obj_id", ID: 3, Type: types.Int},
+				{Name: "obj_sub_id", ID: 4, Type: types.Int},
+				{Name: "holder_session_id", ID: 5, Type: types.Bytes},
+				{Name: "sqlliveness_session_id", ID: 6, Type: types.Bytes},
+				{Name: "pid", ID: 7, Type: types.Int},
+				{Name: "exclusive", ID: 8, Type: types.Bool},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name: "primary",
+					ID:   0,
+					ColumnNames: []string{
+						"database_id",
+						"class_id",
+						"obj_id",
+						"obj_sub_id",
+						"holder_session_id",
+						"sqlliveness_session_id",
+						"pid",
+						"exclusive",
+					},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:    tabledesc.LegacyPrimaryKeyIndexName,
+				ID:      1,
+				Unique:  true,
+				Version: descpb.StrictIndexColumnIDGuaranteesVersion,
+				KeyColumnNames: []string{
+					"database_id",
+					"class_id",
+					"obj_id",
+					"obj_sub_id",
+					"holder_session_id",
+				},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -599,6 +599,16 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusion returns true iff the constraint is an exclusion constraint,
+	// in which case its columns are compared with the operators returned by
+	// ExclusionOperators rather than with equality only.
+	IsExclusion() bool
+
+	// ExclusionOperators returns the operators of an exclusion constraint, one
+	// per key column. It is empty if the constraint is not an exclusion
+	// constraint.
+	ExclusionOperators() []string
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -525,6 +525,38 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policy expressions.
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := renameInExpr(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := renameInExpr(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rename the column in trigger WHEN conditions and UPDATE OF column lists.
+	for i := range tableDesc.Triggers {
+		trig := &tableDesc.Triggers[i]
+		if trig.WhenExpr != "" {
+			if err := renameInExpr(&trig.WhenExpr); err != nil {
+				return err
+			}
+		}
+		for j := range trig.Events {
+			for k, name := range trig.Events[j].ColumnNames {
+				if name == string(col.ColName()) {
+					trig.Events[j].ColumnNames[k] = string(newName)
+				}
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
@@ -115,9 +115,19 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, id := range desc.GetDependsOnFunctions() {
 		ids.Add(id)
 	}
+	for i := range desc.Triggers {
+		ids.Add(desc.Triggers[i].FuncID)
+	}
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add parent and child tables of table inheritance.
+	for _, id := range desc.GetInherits() {
+		ids.Add(id)
+	}
+	for _, id := range desc.GetInheritedBy() {
+		ids.Add(id)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -199,11 +209,23 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
 	}
 
+	// Check that the parent tables exist.
+	for _, id := range desc.Inherits {
+		if _, err := vdg.GetTableDescriptor(id); err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid inherited table reference"))
+		}
+	}
+
 	// Check partitioning is correctly set.
 	// We only check these for active indexes, as inactive indexes may be in the
 	// process of being backfilled without PartitionAllBy.
@@ -263,6 +285,17 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in trigger functions.
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		fn, err := vdg.GetFunctionDescriptor(trig.FuncID)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, trig.ID))
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -275,6 +308,27 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check that the parent tables have back-references to this table and
+	// vice versa.
+	for _, id := range desc.Inherits {
+		parent, _ := vdg.GetTableDescriptor(id)
+		if parent == nil || parent.Dropped() {
+			continue
+		}
+		vea.Report(validateInheritanceReference(parent, parent.GetInheritedBy(), desc.GetID(), "inherited-by"))
+	}
+	for _, id := range desc.InheritedBy {
+		child, err := vdg.GetTableDescriptor(id)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid inherited-by table back reference"))
+			continue
+		}
+		if child.Dropped() {
+			continue
+		}
+		vea.Report(validateInheritanceReference(child, child.GetInherits(), desc.GetID(), "inherits"))
+	}
+
 	for _, id := range desc.DependsOn {
 		ref, _ := vdg.GetTableDescriptor(id)
 		if ref == nil {
@@ -307,6 +361,20 @@ func (desc *wrapper) ValidateBackReferences(
 	}
 }
 
+// validateInheritanceReference checks that the list of IDs of the table
+// inheritance reference of the given kind contains the expected ID.
+func validateInheritanceReference(
+	ref catalog.TableDescriptor, ids []descpb.ID, expected descpb.ID, kind string,
+) error {
+	for _, id := range ids {
+		if id == expected {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("table %q (%d) is missing %s reference to table %d",
+		ref.GetName(), ref.GetID(), kind, expected)
+}
+
 func (desc *wrapper) validateOutboundTypeRef(id descpb.ID, vdg catalog.ValidationDescGetter) error {
 	typ, err := vdg.GetTypeDescriptor(id)
 	if err != nil {
@@ -376,6 +444,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -760,6 +845,18 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		}
 	}
 
+	if (len(desc.Inherits) > 0 || len(desc.InheritedBy) > 0) && !desc.IsTable() {
+		vea.Report(errors.AssertionFailedf(
+			"has table inheritance references despite not being a table"))
+	}
+	for _, ids := range [][]descpb.ID{desc.Inherits, desc.InheritedBy} {
+		for _, id := range ids {
+			if id == descpb.InvalidID || id == desc.ID {
+				vea.Report(errors.AssertionFailedf("invalid table ID %d in table inheritance references", id))
+			}
+		}
+	}
+
 	desc.validateAutoStatsSettings(vea)
 
 	if desc.IsSequence() {
@@ -869,6 +966,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
+			desc.validatePolicies(columnsByID),
+			desc.validateTriggers(columnsByID),
 			desc.validateTableIndexes(columnsByID, vea.IsActive),
 			desc.validatePartitioning(),
 		}
@@ -1328,6 +1427,106 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validatePolicies validates that row-level security policies are well
+// formed. Checks include validating the policy names and IDs, the column IDs,
+// and verifying that the policy expressions do not reference non-existent
+// columns.
+func (desc *wrapper) validatePolicies(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if len(p.Name) == 0 {
+			return pgerror.Newf(pgcode.Syntax, "empty policy name")
+		}
+		if _, ok := names[p.Name]; ok {
+			return errors.AssertionFailedf("duplicate policy name %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if _, ok := ids[p.ID]; ok {
+			return errors.AssertionFailedf("duplicate policy ID %d", p.ID)
+		}
+		ids[p.ID] = struct{}{}
+		if p.ID == 0 || p.ID >= desc.NextPolicyID {
+			return errors.AssertionFailedf(
+				"policy %q has ID %d not less than NextPolicyID value %d for table",
+				p.Name, p.ID, desc.NextPolicyID)
+		}
+		for _, colID := range p.ColumnIDs {
+			if _, ok := columnsByID[colID]; !ok {
+				return errors.Newf("policy %q contains unknown column \"%d\"", p.Name, colID)
+			}
+		}
+		for _, e := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if e == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf("policy %q refers to unknown columns in expression: %s", p.Name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTriggers validates that triggers are well formed. Checks include
+// validating the trigger names and IDs, the events, and verifying that the
+// WHEN condition parses.
+func (desc *wrapper) validateTriggers(columnsByID map[descpb.ColumnID]catalog.Column) error {
+	if len(desc.Triggers) > 0 && !desc.IsTable() {
+		return errors.AssertionFailedf("only tables can have triggers")
+	}
+	names := make(map[string]struct{}, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(desc.Triggers))
+	colNames := make(map[string]struct{}, len(columnsByID))
+	for _, col := range columnsByID {
+		colNames[col.GetName()] = struct{}{}
+	}
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		if len(trig.Name) == 0 {
+			return pgerror.Newf(pgcode.Syntax, "empty trigger name")
+		}
+		if _, ok := names[trig.Name]; ok {
+			return errors.AssertionFailedf("duplicate trigger name %q", trig.Name)
+		}
+		names[trig.Name] = struct{}{}
+		if _, ok := ids[trig.ID]; ok {
+			return errors.AssertionFailedf("duplicate trigger ID %d", trig.ID)
+		}
+		ids[trig.ID] = struct{}{}
+		if trig.ID == 0 || trig.ID >= desc.NextTriggerID {
+			return errors.AssertionFailedf(
+				"trigger %q has ID %d not less than NextTriggerID value %d for table",
+				trig.Name, trig.ID, desc.NextTriggerID)
+		}
+		if len(trig.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", trig.Name)
+		}
+		for j := range trig.Events {
+			for _, name := range trig.Events[j].ColumnNames {
+				if _, ok := colNames[name]; !ok {
+					return errors.Newf("trigger %q contains unknown column %q", trig.Name, name)
+				}
+			}
+		}
+		if trig.WhenExpr != "" {
+			if _, err := parser.ParseExpr(trig.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -1365,6 +1564,26 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if c.IsExclusion() {
+			ops := c.ExclusionOperators()
+			if len(ops) != c.NumKeyColumns() {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.GetName(), len(ops), c.NumKeyColumns(),
+				)
+			}
+			for _, op := range ops {
+				if op != "=" && op != "&&" {
+					return errors.Newf(
+						"exclusion constraint %q contains unsupported operator %q", c.GetName(), op,
+					)
+				}
+			}
+			if c.UniqueWithoutIndexDesc().Deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+				return errors.Newf("exclusion constraint %q cannot be deferrable", c.GetName())
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
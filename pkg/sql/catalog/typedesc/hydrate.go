@@ -14,6 +14,8 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
@@ -72,13 +74,16 @@ func ensureTypeIsHydratedRecursive(
 	maybeDesc catalog.TypeDescriptor,
 	res catalog.TypeDescriptorResolver,
 ) error {
-	switch t.Family() {
-	case types.ArrayFamily:
+	// The base type of a domain, which is described by the family of the
+	// domain, never contains user-defined types, so it needs no hydration.
+	switch family := t.Family(); {
+	case t.IsDomain():
+	case family == types.ArrayFamily:
 		e := t.ArrayContents()
 		if err := ensureTypeIsHydratedRecursive(ctx, e, maybeName, maybeDesc, res); err != nil {
 			return err
 		}
-	case types.TupleFamily:
+	case family == types.TupleFamily:
 		for _, e := range t.TupleContents() {
 			if err := ensureTypeIsHydratedRecursive(ctx, e, maybeName, maybeDesc, res); err != nil {
 				return err
@@ -136,6 +141,10 @@ func ensureTypeMetadataIsHydrated(
 		tm.ImplicitRecordType = true
 		return
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = makeDomainMetadata(d.DomainDesc())
+		return
+	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		if imm, ok := e.(*immutable); ok {
 			// Fast-path for immutable enum descriptors. We can use a pointer into the
@@ -160,3 +169,55 @@ func ensureTypeMetadataIsHydrated(
 		}
 	}
 }
+
+// makeDomainMetadata returns the metadata of a domain with the given
+// descriptor. Constraints that are still being validated are enforced on new
+// values too. The check expressions are parsed and type-checked once here
+// rather than every time a value is checked.
+func makeDomainMetadata(d *descpb.TypeDescriptor_Domain) *types.DomainMetadata {
+	md := &types.DomainMetadata{NotNull: d.NotNull}
+	if d.DefaultExpr != nil {
+		md.DefaultExpr = *d.DefaultExpr
+	}
+	for _, c := range d.Checks {
+		if c.Validity == descpb.ConstraintValidity_Dropping {
+			continue
+		}
+		check := types.DomainCheck{Name: c.Name, Expr: c.Expr}
+		if typedExpr, err := typeCheckDomainCheck(c.Expr, d.BaseType); err != nil {
+			check.Err = errors.Wrapf(err, "check constraint %q", c.Name)
+		} else {
+			check.TypedExpr = typedExpr
+		}
+		md.Checks = append(md.Checks, check)
+	}
+	return md
+}
+
+// typeCheckDomainCheck parses and type-checks the given serialized CHECK
+// expression of a domain with the given base type. VALUE is replaced with the
+// indexed variable with index 0.
+func typeCheckDomainCheck(expr string, baseType *types.T) (tree.TypedExpr, error) {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err = tree.ReplaceDomainValue(parsed, tree.NewTypedOrdinalReference(0, baseType))
+	if err != nil {
+		return nil, err
+	}
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	semaCtx.IVarContainer = domainValueType{baseType}
+	return tree.TypeCheck(context.Background(), parsed, &semaCtx, types.Bool)
+}
+
+// domainValueType is the tree.IndexedVarContainer of the VALUE of a domain in
+// its CHECK expressions.
+type domainValueType struct {
+	typ *types.T
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (v domainValueType) IndexedVarResolvedType(int) *types.T {
+	return v.typ
+}
@@ -43,6 +43,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -498,6 +499,14 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil domain"))
+		} else if desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		} else {
+			desc.validateDomainConstraints(vea)
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -505,6 +514,23 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 }
 
+// validateDomainConstraints performs domain constraint checks.
+func (desc *immutable) validateDomainConstraints(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Domain.Checks))
+	for _, c := range desc.Domain.Checks {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("domain check constraint has empty name"))
+		}
+		if c.Expr == "" {
+			vea.Report(errors.AssertionFailedf("domain check constraint %q has empty expression", c.Name))
+		}
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain check constraint %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+	}
+}
+
 // validateEnumMembers performs enum member checks.
 // Returns true iff the enums are sorted.
 func (desc *immutable) validateEnumMembers(vea catalog.ValidationErrorAccumulator) (isSorted bool) {
@@ -617,6 +643,14 @@ func (desc *immutable) ValidateForwardReferences(
 			}
 		}
 	}
+
+	if d := desc.AsDomainTypeDescriptor(); d != nil && d.GetBaseType().UserDefined() {
+		// User-defined base types are currently not supported, but this should be
+		// validated elsewhere.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain %q",
+			d.GetBaseType().String(), desc.GetName(),
+		))
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -765,6 +799,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -847,6 +887,18 @@ func (desc *immutable) HasPendingSchemaChanges() bool {
 			}
 		}
 		return false
+	case descpb.TypeDescriptor_DOMAIN:
+		// If there are any constraints that are being validated, then a type
+		// schema change is needed to validate them.
+		if desc.Domain.NotNull && desc.Domain.NotNullValidity == descpb.ConstraintValidity_Validating {
+			return true
+		}
+		for i := range desc.Domain.Checks {
+			if desc.Domain.Checks[i].Validity == descpb.ConstraintValidity_Validating {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
@@ -1003,6 +1055,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1029,6 +1089,16 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// GetBaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetBaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// DomainDesc implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainDesc() *descpb.TypeDescriptor_Domain {
+	return desc.Domain
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
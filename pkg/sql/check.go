@@ -279,7 +279,7 @@ func nonMatchingRowQuery(
 func validateForeignKey(
 	ctx context.Context,
 	txn isql.Txn,
-	srcTable *tabledesc.Mutable,
+	srcTable catalog.TableDescriptor,
 	targetTable catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	indexIDForValidation descpb.IndexID,
@@ -304,7 +304,7 @@ func validateForeignKey(
 
 		log.Infof(ctx, "validating MATCH FULL FK %q (%q [%v] -> %q [%v]) with query %q",
 			fk.Name,
-			srcTable.Name, colNames,
+			srcTable.GetName(), colNames,
 			targetTable.GetName(), referencedColumnNames,
 			query,
 		)
@@ -329,7 +329,7 @@ func validateForeignKey(
 
 	log.Infof(ctx, "validating FK %q (%q [%v] -> %q [%v]) with query %q",
 		fk.Name,
-		srcTable.Name, colNames, targetTable.GetName(), referencedColumnNames,
+		srcTable.GetName(), colNames, targetTable.GetName(), referencedColumnNames,
 		query,
 	)
 
@@ -341,7 +341,7 @@ func validateForeignKey(
 	if values.Len() > 0 {
 		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
 			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+			srcTable.GetName(), formatValues(colNames, values), targetTable.GetName()), fk.Name)
 	}
 	return nil
 }
@@ -419,6 +419,89 @@ func duplicateRowQuery(
 	return query, colNames, nil
 }
 
+// conflictingRowQuery generates and returns a query for pairs of rows that
+// violate the specified exclusion constraint. Rows in the table with any null
+// values in the key are excluded from matching.
+//
+// For example, an exclusion constraint on columns (a WITH =, b WITH &&) on the
+// table "tbl" with primary key k would require the following query:
+//
+// SELECT l.c0, l.c1, r.c0, r.c1
+// FROM (SELECT k AS k0, a AS c0, b AS c1 FROM tbl WHERE ...) AS l
+// JOIN (SELECT k AS k0, a AS c0, b AS c1 FROM tbl WHERE ...) AS r
+// ON l.c0 = r.c0 AND l.c1 && r.c1 AND (l.k0) != (r.k0)
+// LIMIT 1
+//
+// where the WHERE clause of both subqueries is a IS NOT NULL AND b IS NOT NULL.
+//
+// The pred and indexIDForValidation arguments have the same meaning as in
+// duplicateRowQuery.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	columnIDs []descpb.ColumnID,
+	ops []string,
+	pred string,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, columnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The columns of the key and of the constraint are given new names in the
+	// subqueries, since a column can be in both.
+	selectExprs := make([]string, 0, len(pkColNames)+len(colNames))
+	srcWhere := make([]string, 0, len(colNames)+1)
+	lhsPK := make([]string, len(pkColNames))
+	rhsPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		selectExprs = append(selectExprs, fmt.Sprintf("%s AS k%d", tree.NameString(n), i))
+		lhsPK[i] = fmt.Sprintf("l.k%d", i)
+		rhsPK[i] = fmt.Sprintf("r.k%d", i)
+	}
+	resultCols := make([]string, 0, 2*len(colNames))
+	onExprs := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		selectExprs = append(selectExprs, fmt.Sprintf("%s AS c%d", tree.NameString(n), i))
+		srcWhere = append(srcWhere, fmt.Sprintf("%s IS NOT NULL", tree.NameString(n)))
+		resultCols = append(resultCols, fmt.Sprintf("l.c%d", i))
+		onExprs = append(onExprs, fmt.Sprintf("l.c%[1]d %[2]s r.c%[1]d", i, ops[i]))
+	}
+	for i := range colNames {
+		resultCols = append(resultCols, fmt.Sprintf("r.c%d", i))
+	}
+	onExprs = append(onExprs, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(lhsPK, ", "), strings.Join(rhsPK, ", "),
+	))
+
+	// Wrap the predicate in parentheses.
+	if pred != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", pred))
+	}
+
+	src := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("%s@[%d]", src, indexIDForValidation)
+	}
+	subquery := fmt.Sprintf(
+		`SELECT %s FROM %s WHERE %s`,
+		strings.Join(selectExprs, ", "), src, strings.Join(srcWhere, " AND "),
+	)
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS l JOIN (%[2]s) AS r ON %[3]s LIMIT 1`,
+		strings.Join(resultCols, ", "), // 1
+		subquery,                       // 2
+		strings.Join(onExprs, " AND "), // 3
+	)
+	return query, colNames, nil
+}
+
 // RevalidateUniqueConstraintsInCurrentDB verifies that all unique constraints
 // defined on tables in the current database are valid. In other words, it
 // verifies that for every table in the database with one or more unique
@@ -505,12 +588,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -585,12 +666,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -702,6 +781,101 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given unique constraint without an index, which may be
+// an exclusion constraint. The remaining arguments have the same meaning as in
+// validateUniqueConstraint.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc.UniqueWithoutIndexDesc(), indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.GetName(),
+		uc.CollectKeyColumnIDs().Ordered(),
+		uc.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(
+		srcTable, uc.ColumnIDs, uc.ExclusionOperators, uc.Predicate, indexIDForValidation,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := txn.QueryRowEx(ctx, "validate exclusion constraint", txn.KV(), sessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
+				),
+				uc.Name,
+			),
+			fmt.Sprintf(
+				"Key (%[1]s)=(%[2]s) conflicts with key (%[1]s)=(%[3]s).",
+				strings.Join(colNames, ", "),
+				strings.Join(valuesStr[:n], ", "),
+				strings.Join(valuesStr[n:], ", "),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
@@ -857,12 +1031,22 @@ func checkMutationInput(
 	tabDesc catalog.TableDescriptor,
 	checkOrds checkSet,
 	checkVals tree.Datums,
+	checkPolicy bool,
 ) error {
 	if len(checkVals) < checkOrds.Len() {
 		return errors.AssertionFailedf(
 			"mismatched check constraint columns: expected %d, got %d", checkOrds.Len(), len(checkVals))
 	}
 
+	// The result of the row-level security policies of the table is stored in
+	// the last check column, after the check constraints.
+	if checkPolicy {
+		if checkVals[checkOrds.Len()-1] != tree.DBoolTrue {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"new row violates row-level security policy for table %q", tabDesc.GetName())
+		}
+	}
+
 	checks := tabDesc.EnforcedCheckConstraints()
 	colIdx := 0
 	for i := range checks {
@@ -47,6 +47,9 @@ type BatchEncoder struct {
 	b coldata.Batch
 	// The destination for KVs.
 	p row.Putter
+	// The columns of the table that correspond to the vectors of the input
+	// batch.
+	cols []catalog.Column
 	// The map of columns in the input batch to column ids on the table.
 	colMap catalog.TableColMap
 	// Map of index id to a slice of bools that contain partial index predicates.
@@ -75,6 +78,10 @@ type BatchEncoder struct {
 	// memoryUsageCheck is provided externally and should return ErrOverMemLimit
 	// if memory usage limit has been exceeded.
 	memoryUsageCheck func() error
+	// includeEmpty, if set, makes the encoder produce the KVs for all column
+	// families of the rows, even for the ones without any values. It is used
+	// when determining all keys of the rows that are being deleted.
+	includeEmpty bool
 }
 
 func MakeEncoder(
@@ -90,7 +97,7 @@ func MakeEncoder(
 	rh := row.NewRowHelper(codec, desc, desc.WritableNonPrimaryIndexes(), sv, false /*internal*/, metrics)
 	rh.Init()
 	colMap := row.ColIDtoRowIndexFromCols(insCols)
-	return BatchEncoder{rh: &rh, b: b, colMap: colMap,
+	return BatchEncoder{rh: &rh, b: b, cols: insCols, colMap: colMap,
 		partialIndexes: partialIndexes, memoryUsageCheck: memoryUsageCheck}
 }
 
@@ -103,6 +110,12 @@ func (b *BatchEncoder) PrepareBatch(ctx context.Context, p row.Putter, start, en
 	if err := b.checkNotNullColumns(); err != nil {
 		return err
 	}
+	return b.prepare(ctx, p, start, end)
+}
+
+// prepare is the part of PrepareBatch that doesn't validate the set of columns
+// in the batch.
+func (b *BatchEncoder) prepare(ctx context.Context, p row.Putter, start, end int) error {
 	b.p = p
 	if start >= end {
 		colexecerror.InternalError(errors.AssertionFailedf("PrepareBatch illegal arguments: start=%d,end=%d", start, end))
@@ -241,7 +254,6 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 	keyAndSuffixCols := desc.IndexFetchSpecKeyAndSuffixColumns(ind)
 	keyCols := keyAndSuffixCols[:ind.NumKeyColumns()]
 	families := desc.GetFamilies()
-	fetchedCols := desc.PublicColumns()
 
 	b.setupPrefixes(ind, b.rh.PrimaryIndexKeyPrefix)
 	kys := b.keys
@@ -282,7 +294,7 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 		// * The column in family 0 is dropped, leaving the 0'th family empty.
 		// In this case, we must keep the empty 0'th column family in order to ensure that column family 0
 		// is always encoded as the sentinel k/v for a row.
-		if !update && len(family.ColumnIDs) != 0 {
+		if !update && len(family.ColumnIDs) != 0 && !b.includeEmpty {
 			continue
 		}
 		familySortedColumnIDs, ok := b.rh.SortedColumnFamily(family.ID)
@@ -300,11 +312,18 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 			// backwards compatible with the original BaseFormatVersion.
 			idx, ok := b.colMap.Get(family.DefaultColumnID)
 			if !ok {
+				if b.includeEmpty {
+					// The key is all we need.
+					b.p.CPutValuesEmpty(kys, make([]roachpb.Value, len(b.keys)))
+					if err := b.checkMemory(); err != nil {
+						return err
+					}
+				}
 				// Column not being updated or inserted.
 				continue
 			}
 			values := make([]roachpb.Value, len(b.keys))
-			typ := fetchedCols[idx].GetType()
+			typ := b.cols[idx].GetType()
 			vec := vecs[idx]
 			for row := 0; row < b.count; row++ {
 				// Elided partial index keys will be nil.
@@ -319,9 +338,14 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 					return err
 				}
 				if marshaled.RawBytes == nil {
-					// Tell CPutValues to ignore this KV. We use empty slice
-					// instead of nil b/c nil is the partial index skip indicator.
-					kys[row] = kys[row][:0]
+					if !b.includeEmpty {
+						// Tell CPutValues to ignore this KV. We use empty slice
+						// instead of nil b/c nil is the partial index skip
+						// indicator.
+						kys[row] = kys[row][:0]
+					}
+				} else if b.includeEmpty {
+					values[row] = marshaled
 				} else {
 					// We only output non-NULL values. Non-existent column keys are
 					// considered NULL during scanning and the row sentinel ensures we know
@@ -348,7 +372,7 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 				continue
 			}
 
-			col := fetchedCols[idx]
+			col := b.cols[idx]
 			vec := vecs[idx]
 			nulls := vec.Nulls()
 			lastColIDs := b.lastColIDs
@@ -375,6 +399,11 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 				if err != nil {
 					return err
 				}
+				if b.includeEmpty {
+					// The row is not being written, so there is no need to
+					// check its size.
+					continue
+				}
 				if err := b.rh.CheckRowSize(ctx, &kys[row], values[row], family.ID); err != nil {
 					return err
 				}
@@ -382,7 +411,7 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 		}
 
 		// Skip empty kvs if we aren't family 0.
-		if family.ID != 0 {
+		if family.ID != 0 && !b.includeEmpty {
 			for row := 0; row < b.count; row++ {
 				if len(values[row]) == 0 {
 					// Tell CPutValues to ignore this KV. We use empty slice
@@ -541,9 +570,7 @@ func (b *BatchEncoder) encodeSecondaryIndexWithFamilies(
 			return err
 		}
 		for row := 0; row < len(kys); row++ {
-			// TODO(cucaroach): COPY doesn't need includeEmpty support but
-			// updates and deletes will if/when we support them.
-			if familyID != 0 && len(values[row]) == 0 /* && !includeEmpty */ {
+			if familyID != 0 && len(values[row]) == 0 && !b.includeEmpty {
 				kys[row] = kys[row][:0]
 				continue
 			}
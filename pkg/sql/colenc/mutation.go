@@ -0,0 +1,339 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colenc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/errors"
+)
+
+// Rows describes the rows of a table stored in a coldata.Batch.
+type Rows struct {
+	// Batch contains the values of the rows.
+	Batch coldata.Batch
+	// Cols are the columns of the table that correspond to the vectors of
+	// Batch.
+	Cols []catalog.Column
+	// PartialIndexes maps the IDs of the partial indexes to the slices that
+	// indicate whether the rows satisfy the predicates of those indexes.
+	PartialIndexes map[descpb.IndexID][]bool
+}
+
+// MutationEncoder encodes the KVs for mutations that modify existing rows
+// (updates and deletes). It uses a BatchEncoder to produce all KVs of the rows
+// before and after the mutation and then issues only the operations needed to
+// get from the former to the latter.
+type MutationEncoder struct {
+	codec   keys.SQLCodec
+	desc    catalog.TableDescriptor
+	sv      *settings.Values
+	metrics *rowinfra.Metrics
+	// mutationQuota is the number of bytes of the KVs that we'll allow to
+	// accumulate before returning ErrOverMemLimit.
+	mutationQuota int
+
+	old, new kvCollector
+	// oldKeys maps the keys of the old KVs to their positions in old.kvs, and
+	// usedOld tracks which of the old KVs have been matched with a new one.
+	oldKeys map[string]int
+	usedOld []bool
+}
+
+// MakeMutationEncoder creates a new MutationEncoder.
+func MakeMutationEncoder(
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	sv *settings.Values,
+	metrics *rowinfra.Metrics,
+	mutationQuota int,
+) MutationEncoder {
+	return MutationEncoder{
+		codec:         codec,
+		desc:          desc,
+		sv:            sv,
+		metrics:       metrics,
+		mutationQuota: mutationQuota,
+	}
+}
+
+// SetMutationQuota updates the limit on the number of bytes of the KVs that
+// can be accumulated at once.
+func (m *MutationEncoder) SetMutationQuota(mutationQuota int) {
+	m.mutationQuota = mutationQuota
+}
+
+// PrepareDelete encodes the deletion of the rows in [start, end) range to the
+// given row.Putter. The batch must contain all columns needed to determine the
+// keys of the rows in all indexes.
+func (m *MutationEncoder) PrepareDelete(
+	ctx context.Context, p row.Putter, rows Rows, start, end int,
+) error {
+	m.reset()
+	if err := m.collect(ctx, &m.old, rows, start, end, true /* includeEmpty */); err != nil {
+		return err
+	}
+	for i := range m.old.kvs {
+		p.Del(m.old.kvs[i].key)
+	}
+	return nil
+}
+
+// PrepareUpdate encodes the modification of the rows in [start, end) range
+// from the values in oldRows to the values in newRows to the given row.Putter.
+// Both batches must contain the same set of columns which includes all columns
+// of the modified column families and indexes (columns of the families and
+// indexes that are not modified might be omitted). If oldRows.Batch is nil,
+// then the rows in newRows are inserted.
+//
+// Note that the updates of all rows in the range are considered at once, so a
+// key removed by one row can be reused by another.
+func (m *MutationEncoder) PrepareUpdate(
+	ctx context.Context, p row.Putter, oldRows, newRows Rows, start, end int,
+) error {
+	m.reset()
+	if oldRows.Batch != nil {
+		if err := m.collect(ctx, &m.old, oldRows, start, end, false /* includeEmpty */); err != nil {
+			return err
+		}
+	}
+	if err := m.collect(ctx, &m.new, newRows, start, end, false /* includeEmpty */); err != nil {
+		return err
+	}
+	if m.oldKeys == nil {
+		m.oldKeys = make(map[string]int, len(m.old.kvs))
+	}
+	if cap(m.usedOld) < len(m.old.kvs) {
+		m.usedOld = make([]bool, len(m.old.kvs))
+	}
+	m.usedOld = m.usedOld[:len(m.old.kvs)]
+	for i := range m.old.kvs {
+		m.oldKeys[string(m.old.kvs[i].key)] = i
+		m.usedOld[i] = false
+	}
+	for i := range m.new.kvs {
+		kv := &m.new.kvs[i]
+		if oldIdx, ok := m.oldKeys[string(kv.key)]; ok && !m.usedOld[oldIdx] {
+			m.usedOld[oldIdx] = true
+			if bytes.Equal(m.old.kvs[oldIdx].value.TagAndDataBytes(), kv.value.TagAndDataBytes()) {
+				// The KV is unchanged.
+				continue
+			}
+			p.Put(kv.key, &kv.value)
+			continue
+		}
+		// This is a new key, so we issue the same operation as the insert
+		// would. Note that if multiple new rows have the same key, then the
+		// operations for all but the first one are issued here, and they will
+		// result in the same error as when inserting such rows.
+		switch kv.op {
+		case kvOpCPut:
+			p.CPut(kv.key, &kv.value, nil /* expValue */)
+		case kvOpInitPut:
+			p.InitPut(kv.key, &kv.value, false /* failOnTombstones */)
+		default:
+			p.Put(kv.key, &kv.value)
+		}
+	}
+	for i := range m.old.kvs {
+		if !m.usedOld[i] {
+			p.Del(m.old.kvs[i].key)
+		}
+	}
+	return nil
+}
+
+func (m *MutationEncoder) reset() {
+	m.old.reset()
+	m.new.reset()
+	for k := range m.oldKeys {
+		delete(m.oldKeys, k)
+	}
+}
+
+// collect encodes all KVs of the given rows into c.
+func (m *MutationEncoder) collect(
+	ctx context.Context, c *kvCollector, rows Rows, start, end int, includeEmpty bool,
+) error {
+	enc := MakeEncoder(
+		m.codec, m.desc, m.sv, rows.Batch, rows.Cols, m.metrics, rows.PartialIndexes,
+		func() error {
+			if m.old.size+m.new.size > m.mutationQuota {
+				return ErrOverMemLimit
+			}
+			return nil
+		})
+	enc.includeEmpty = includeEmpty
+	if err := row.CheckPrimaryKeyColumns(m.desc, enc.colMap); err != nil {
+		return err
+	}
+	return enc.prepare(ctx, c, start, end)
+}
+
+type kvOp uint8
+
+const (
+	kvOpPut kvOp = iota
+	kvOpCPut
+	kvOpInitPut
+)
+
+type collectedKV struct {
+	key   roachpb.Key
+	value roachpb.Value
+	op    kvOp
+}
+
+// kvCollector is a row.Putter that accumulates the KVs in memory.
+type kvCollector struct {
+	kvs []collectedKV
+	// size is the total size of the keys and values in kvs.
+	size int
+}
+
+var _ row.Putter = &kvCollector{}
+
+func (c *kvCollector) reset() {
+	for i := range c.kvs {
+		c.kvs[i] = collectedKV{}
+	}
+	c.kvs = c.kvs[:0]
+	c.size = 0
+}
+
+func (c *kvCollector) add(key roachpb.Key, value roachpb.Value, op kvOp) {
+	c.kvs = append(c.kvs, collectedKV{key: key, value: value, op: op})
+	c.size += len(key) + len(value.RawBytes)
+}
+
+func toKey(key interface{}) roachpb.Key {
+	switch k := key.(type) {
+	case roachpb.Key:
+		return k
+	case *roachpb.Key:
+		return *k
+	default:
+		colexecerror.InternalError(errors.AssertionFailedf("unexpected key type %T", key))
+		// Unreachable.
+		return nil
+	}
+}
+
+func toValue(value interface{}) roachpb.Value {
+	switch v := value.(type) {
+	case roachpb.Value:
+		return v
+	case *roachpb.Value:
+		return *v
+	case []byte:
+		var val roachpb.Value
+		val.SetBytes(v)
+		return val
+	default:
+		colexecerror.InternalError(errors.AssertionFailedf("unexpected value type %T", value))
+		// Unreachable.
+		return roachpb.Value{}
+	}
+}
+
+// CPut is part of the row.Putter interface.
+func (c *kvCollector) CPut(key, value interface{}, expValue []byte) {
+	if expValue != nil {
+		colexecerror.InternalError(errors.AssertionFailedf("unexpected expected value"))
+	}
+	c.add(toKey(key), toValue(value), kvOpCPut)
+}
+
+// Put is part of the row.Putter interface.
+func (c *kvCollector) Put(key, value interface{}) {
+	c.add(toKey(key), toValue(value), kvOpPut)
+}
+
+// InitPut is part of the row.Putter interface.
+func (c *kvCollector) InitPut(key, value interface{}, failOnTombstones bool) {
+	c.add(toKey(key), toValue(value), kvOpInitPut)
+}
+
+// Del is part of the row.Putter interface.
+func (c *kvCollector) Del(key ...interface{}) {
+	colexecerror.InternalError(errors.AssertionFailedf("unexpected Del when encoding rows"))
+}
+
+// CPutValuesEmpty is part of the row.Putter interface.
+func (c *kvCollector) CPutValuesEmpty(kys []roachpb.Key, values []roachpb.Value) {
+	for i, k := range kys {
+		if len(k) == 0 {
+			continue
+		}
+		c.add(k, values[i], kvOpCPut)
+	}
+}
+
+// CPutTuplesEmpty is part of the row.Putter interface.
+func (c *kvCollector) CPutTuplesEmpty(kys []roachpb.Key, values [][]byte) {
+	c.addTuples(kys, values, kvOpCPut)
+}
+
+// PutBytes is part of the row.Putter interface.
+func (c *kvCollector) PutBytes(kys []roachpb.Key, values [][]byte) {
+	c.addBytes(kys, values, kvOpPut)
+}
+
+// InitPutBytes is part of the row.Putter interface.
+func (c *kvCollector) InitPutBytes(kys []roachpb.Key, values [][]byte) {
+	c.addBytes(kys, values, kvOpInitPut)
+}
+
+// PutTuples is part of the row.Putter interface.
+func (c *kvCollector) PutTuples(kys []roachpb.Key, values [][]byte) {
+	c.addTuples(kys, values, kvOpPut)
+}
+
+// InitPutTuples is part of the row.Putter interface.
+func (c *kvCollector) InitPutTuples(kys []roachpb.Key, values [][]byte) {
+	c.addTuples(kys, values, kvOpInitPut)
+}
+
+func (c *kvCollector) addBytes(kys []roachpb.Key, values [][]byte, op kvOp) {
+	for i, k := range kys {
+		if len(k) == 0 {
+			continue
+		}
+		// Note that SetBytes copies the value, which is needed since the
+		// encoder reuses the values across the column families and indexes.
+		var v roachpb.Value
+		v.SetBytes(values[i])
+		c.add(k, v, op)
+	}
+}
+
+func (c *kvCollector) addTuples(kys []roachpb.Key, values [][]byte, op kvOp) {
+	for i, k := range kys {
+		if len(k) == 0 {
+			continue
+		}
+		// Note that SetTuple copies the value, which is needed since the
+		// encoder reuses the values across the column families and indexes.
+		var v roachpb.Value
+		v.SetTuple(values[i])
+		c.add(k, v, op)
+	}
+}
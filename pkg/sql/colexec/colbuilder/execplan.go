@@ -160,7 +160,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 
 	case core.JoinReader != nil:
 		if !core.JoinReader.IsIndexJoin() {
-			return errLookupJoinUnsupported
+			if err := colfetcher.CanPlanLookupJoin(core.JoinReader); err != nil {
+				return errors.Wrap(err, "lookup join reader is unsupported in vectorized")
+			}
 		}
 		return nil
 
@@ -218,7 +220,7 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		// distinguish from other unsupported cores.
 		return errLocalPlanNodeWrap
 
-	case core.Insert != nil:
+	case core.Insert != nil, core.Update != nil, core.Upsert != nil, core.Delete != nil:
 		return nil
 
 	default:
@@ -239,7 +241,6 @@ var (
 	errSampleAggregatorWrap           = errors.New("core.SampleAggregator is not supported (not an execinfra.RowSource)")
 	errExperimentalWrappingProhibited = errors.Newf("wrapping for non-JoinReader and non-LocalPlanNode cores is prohibited in vectorize=%s", sessiondatapb.VectorizeExperimentalAlways)
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
-	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
@@ -255,6 +256,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	switch {
 	case core.Noop != nil:
 	case core.TableReader != nil:
+	case core.AggregateScanner != nil:
 	case core.JoinReader != nil:
 	case core.Sorter != nil:
 	case core.Aggregator != nil:
@@ -913,32 +915,88 @@ func NewColOperator(
 				return r, err
 			}
 			if !core.JoinReader.IsIndexJoin() {
-				return r, errors.AssertionFailedf("lookup join reader is unsupported in vectorized")
-			}
-			// We have to create a separate account in order for the cFetcher to
-			// be able to precisely track the size of its output batch. This
-			// memory account is "streaming" in its nature, so we create an
-			// unlimited one. We also need another unlimited account for the
-			// KV fetcher. Additionally, we might use the Streamer API which
-			// requires yet another separate memory account that is bound to an
-			// unlimited memory monitor.
-			accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
-				ctx, flowCtx, "index-join" /* opName */, spec.ProcessorID, 3, /* numAccounts */
-			)
-			streamerDiskMonitor := args.MonitorRegistry.CreateDiskMonitor(
-				ctx, flowCtx, "streamer" /* opName */, spec.ProcessorID,
-			)
-			indexJoinOp, err := colfetcher.NewColIndexJoin(
-				ctx, getStreamingAllocator(ctx, args, flowCtx),
-				colmem.NewAllocator(ctx, accounts[0], factory),
-				accounts[1], accounts[2], flowCtx, spec.ProcessorID,
-				inputs[0].Root, core.JoinReader, post, spec.Input[0].ColumnTypes,
-				streamerDiskMonitor, args.TypeResolver,
-			)
-			if err != nil {
-				return r, err
+				// We need unlimited accounts for the cFetcher, the KV fetcher,
+				// and the Streamer API (see the comment below for the index
+				// join) as well as another one for buffering the input tuples
+				// (whose number is limited by the chunk size).
+				accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+					ctx, flowCtx, "lookup-join" /* opName */, spec.ProcessorID, 4, /* numAccounts */
+				)
+				streamerDiskMonitor := args.MonitorRegistry.CreateDiskMonitor(
+					ctx, flowCtx, "streamer" /* opName */, spec.ProcessorID,
+				)
+				onExprPlanner := func(
+					input colexecop.Operator, inputTypes []*types.T,
+				) (colexecop.Operator, int, error) {
+					expr, err := processExpr(ctx, core.JoinReader.OnExpr, flowCtx.EvalCtx, args.SemaCtx, inputTypes)
+					if err != nil {
+						return nil, 0, err
+					}
+					op, resultIdx, _, err := planProjectionOperators(
+						ctx, flowCtx.EvalCtx, expr, inputTypes, input,
+						getStreamingAllocator(ctx, args, flowCtx), &result.Releasables,
+					)
+					if err != nil {
+						return nil, 0, errors.Wrapf(err, "unable to columnarize ON expression %q", core.JoinReader.OnExpr)
+					}
+					return op, resultIdx, nil
+				}
+				var lookedUpRowsArgs *colfetcher.LookedUpRowsBufferArgs
+				if colfetcher.BuffersLookedUpRows(core.JoinReader) {
+					// The looked-up rows have to be buffered until all lookups
+					// of a chunk are performed, so we need a dedicated
+					// unlimited account for the buffer and another one for its
+					// disk queue.
+					opName := redact.RedactableString("lookup-join-buffer")
+					bufferAccounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+						ctx, flowCtx, opName, spec.ProcessorID, 2, /* numAccounts */
+					)
+					lookedUpRowsArgs = &colfetcher.LookedUpRowsBufferArgs{
+						Allocator:       colmem.NewAllocator(ctx, bufferAccounts[0], factory),
+						DiskQueueCfg:    args.DiskQueueCfg,
+						FDSemaphore:     args.FDSemaphore,
+						DiskAcc:         args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, spec.ProcessorID),
+						DiskQueueMemAcc: bufferAccounts[1],
+					}
+				}
+				lookupJoinOp, err := colfetcher.NewColLookupJoin(
+					ctx, getStreamingAllocator(ctx, args, flowCtx),
+					colmem.NewAllocator(ctx, accounts[0], factory),
+					colmem.NewAllocator(ctx, accounts[3], factory),
+					accounts[1], accounts[2], flowCtx, spec.ProcessorID,
+					inputs[0].Root, core.JoinReader, post, spec.Input[0].ColumnTypes,
+					streamerDiskMonitor, args.TypeResolver, onExprPlanner, lookedUpRowsArgs,
+				)
+				if err != nil {
+					return r, err
+				}
+				result.finishScanPlanning(lookupJoinOp, lookupJoinOp.ResultTypes)
+			} else {
+				// We have to create a separate account in order for the cFetcher to
+				// be able to precisely track the size of its output batch. This
+				// memory account is "streaming" in its nature, so we create an
+				// unlimited one. We also need another unlimited account for the
+				// KV fetcher. Additionally, we might use the Streamer API which
+				// requires yet another separate memory account that is bound to an
+				// unlimited memory monitor.
+				accounts := args.MonitorRegistry.CreateUnlimitedMemAccounts(
+					ctx, flowCtx, "index-join" /* opName */, spec.ProcessorID, 3, /* numAccounts */
+				)
+				streamerDiskMonitor := args.MonitorRegistry.CreateDiskMonitor(
+					ctx, flowCtx, "streamer" /* opName */, spec.ProcessorID,
+				)
+				indexJoinOp, err := colfetcher.NewColIndexJoin(
+					ctx, getStreamingAllocator(ctx, args, flowCtx),
+					colmem.NewAllocator(ctx, accounts[0], factory),
+					accounts[1], accounts[2], flowCtx, spec.ProcessorID,
+					inputs[0].Root, core.JoinReader, post, spec.Input[0].ColumnTypes,
+					streamerDiskMonitor, args.TypeResolver,
+				)
+				if err != nil {
+					return r, err
+				}
+				result.finishScanPlanning(indexJoinOp, indexJoinOp.ResultTypes)
 			}
-			result.finishScanPlanning(indexJoinOp, indexJoinOp.ResultTypes)
 
 		case core.Filterer != nil:
 			if err := checkNumIn(inputs, 1); err != nil {
@@ -1696,6 +1754,36 @@ func NewColOperator(
 			)
 			result.ColumnTypes = spec.ResultTypes
 
+		case core.Update != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			result.Root = colexec.NewUpdateOp(
+				ctx, flowCtx, core.Update, inputs[0].Root, spec.Input[0].ColumnTypes,
+				spec.ResultTypes, getStreamingAllocator(ctx, args, flowCtx), args.SemaCtx,
+			)
+			result.ColumnTypes = spec.ResultTypes
+
+		case core.Upsert != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			result.Root = colexec.NewUpsertOp(
+				ctx, flowCtx, core.Upsert, inputs[0].Root, spec.Input[0].ColumnTypes,
+				spec.ResultTypes, getStreamingAllocator(ctx, args, flowCtx), args.SemaCtx,
+			)
+			result.ColumnTypes = spec.ResultTypes
+
+		case core.Delete != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			result.Root = colexec.NewDeleteOp(
+				ctx, flowCtx, core.Delete, inputs[0].Root, spec.Input[0].ColumnTypes,
+				spec.ResultTypes, getStreamingAllocator(ctx, args, flowCtx),
+			)
+			result.ColumnTypes = spec.ResultTypes
+
 		default:
 			return r, errors.AssertionFailedf("unsupported processor core %q", core)
 		}
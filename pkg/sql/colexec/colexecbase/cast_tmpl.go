@@ -123,6 +123,11 @@ func GetCastOperator(
 		outputIdx:                resultIdx,
 		evalCtx:                  evalCtx,
 	}
+	if toType.IsDomain() {
+		// Casts to domains need to check the constraints of the domain, so
+		// they are only supported by the row-by-row engine.
+		return nil, errors.Errorf("unhandled cast %s -> %s", fromType.SQLStringForError(), toType.SQLStringForError())
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return &castOpNullAny{castOpBase: base}, nil
 	}
@@ -190,6 +195,9 @@ func GetCastOperator(
 }
 
 func IsCastSupported(fromType, toType *types.T) bool {
+	if toType.IsDomain() {
+		return false
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return true
 	}
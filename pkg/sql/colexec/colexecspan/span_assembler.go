@@ -38,6 +38,42 @@ func NewColSpanAssembler(
 	fetchSpec *fetchpb.IndexFetchSpec,
 	splitFamilyIDs []descpb.FamilyID,
 	inputTypes []*types.T,
+) ColSpanAssembler {
+	return newColSpanAssembler(
+		codec, allocator, fetchSpec, fetchSpec.KeyColumns(), splitFamilyIDs, inputTypes,
+	)
+}
+
+// NewColLookupSpanAssembler returns a ColSpanAssembler operator that generates
+// lookup spans that constrain a prefix of the index key from input batches.
+// The i-th column of the input batches must contain the values for the i-th
+// key column of the index (including the key suffix columns for non-unique
+// indexes), and the number of input columns determines the length of the
+// constrained prefix. Every input row results in exactly one span.
+func NewColLookupSpanAssembler(
+	codec keys.SQLCodec,
+	allocator *colmem.Allocator,
+	fetchSpec *fetchpb.IndexFetchSpec,
+	inputTypes []*types.T,
+) ColSpanAssembler {
+	keyColumns := fetchSpec.KeyFullColumns()
+	if len(inputTypes) > len(keyColumns) {
+		colexecerror.InternalError(errors.AssertionFailedf(
+			"%d lookup columns exceed %d key columns", len(inputTypes), len(keyColumns),
+		))
+	}
+	return newColSpanAssembler(
+		codec, allocator, fetchSpec, keyColumns[:len(inputTypes)], nil /* splitFamilyIDs */, inputTypes,
+	)
+}
+
+func newColSpanAssembler(
+	codec keys.SQLCodec,
+	allocator *colmem.Allocator,
+	fetchSpec *fetchpb.IndexFetchSpec,
+	keyColumns []fetchpb.IndexFetchSpec_KeyColumn,
+	splitFamilyIDs []descpb.FamilyID,
+	inputTypes []*types.T,
 ) ColSpanAssembler {
 	sa := spanAssemblerPool.Get().(*spanAssembler)
 	if len(splitFamilyIDs) > 0 {
@@ -59,7 +95,6 @@ func NewColSpanAssembler(
 
 	// Add span encoders to encode each primary key column as bytes. The
 	// ColSpanAssembler will later append these together to form valid spans.
-	keyColumns := fetchSpec.KeyColumns()
 	for i := range keyColumns {
 		asc := keyColumns[i].Direction == catenumpb.IndexColumn_ASC
 		sa.spanEncoders = append(sa.spanEncoders, newSpanEncoder(allocator, inputTypes[i], asc, i))
@@ -0,0 +1,486 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+type mutationType int
+
+const (
+	mutationUpdate mutationType = iota
+	mutationUpsert
+	mutationDelete
+)
+
+// vectorMutator performs the UPDATE, UPSERT, and DELETE mutations of a table
+// using colenc to encode the KVs. Similar to the vectorInserter, it only
+// supports the statements that return the number of affected rows, so it
+// consumes all of its input and then outputs a single row with the count.
+type vectorMutator struct {
+	colexecop.OneInputHelper
+	mutationType mutationType
+	desc         catalog.TableDescriptor
+	flowCtx      *execinfra.FlowCtx
+	allocator    *colmem.Allocator
+	inputTypes   []*types.T
+	retBatch     coldata.Batch
+
+	// insertCols are only set for upserts.
+	insertCols []catalog.Column
+	fetchCols  []catalog.Column
+	// updateCols are not set for deletes.
+	updateCols []catalog.Column
+	// updateColFetchIdxs contains the ordinal of each update column among the
+	// fetch columns.
+	updateColFetchIdxs []int
+	// canaryOrdinal is the ordinal of the input column that determines whether
+	// a row is inserted or updated by an upsert.
+	canaryOrdinal int
+	// fetchOffset, updateOffset, checkOffset, and partialIndexOffset are the
+	// ordinals of the first input columns of the corresponding kinds.
+	fetchOffset, updateOffset, checkOffset, partialIndexOffset int
+
+	// checkOrds are the columns containing bool values with check expression
+	// results.
+	checkOrds intsets.Fast
+	// If we have checkOrds we need a sema context to format error messages.
+	semaCtx *tree.SemaContext
+
+	enc colenc.MutationEncoder
+	// oldRows and newRows are the batches that are fed into the encoder. They
+	// don't own any vectors; instead, they reference the vectors of the input
+	// batch.
+	oldRows, newRows coldata.Batch
+	// denseBatches are used to store the input rows when they have to be
+	// rearranged.
+	denseBatches [3]coldata.Batch
+	// rowCount is the number of rows processed so far.
+	rowCount int64
+	done     bool
+}
+
+var _ colexecop.Operator = &vectorMutator{}
+
+// NewUpdateOp allocates a new vector update operator. Only the statements
+// whose output is the row count are supported.
+func NewUpdateOp(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	spec *execinfrapb.UpdateSpec,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	typs []*types.T,
+	alloc *colmem.Allocator,
+	semaCtx *tree.SemaContext,
+) colexecop.Operator {
+	v := newVectorMutator(ctx, flowCtx, mutationUpdate, &spec.Table, input, inputTypes, typs, alloc)
+	v.fetchCols = v.findColumns(spec.FetchColumnIDs)
+	v.updateCols = v.findColumns(spec.UpdateColumnIDs)
+	v.updateOffset = len(v.fetchCols)
+	v.checkOffset = v.updateOffset + len(v.updateCols)
+	v.initCheckOrds(spec.CheckOrds, semaCtx)
+	v.partialIndexOffset = v.checkOffset + v.checkOrds.Len()
+	v.initFetchCols()
+	return v
+}
+
+// NewUpsertOp allocates a new vector upsert operator. Only the statements
+// whose output is the row count and that need to fetch the existing rows are
+// supported.
+func NewUpsertOp(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	spec *execinfrapb.UpsertSpec,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	typs []*types.T,
+	alloc *colmem.Allocator,
+	semaCtx *tree.SemaContext,
+) colexecop.Operator {
+	if spec.CanaryOrdinal < 0 {
+		colexecerror.InternalError(errors.AssertionFailedf("vector upsert requires a canary column"))
+	}
+	v := newVectorMutator(ctx, flowCtx, mutationUpsert, &spec.Table, input, inputTypes, typs, alloc)
+	v.insertCols = v.findColumns(spec.InsertColumnIDs)
+	v.fetchCols = v.findColumns(spec.FetchColumnIDs)
+	v.updateCols = v.findColumns(spec.UpdateColumnIDs)
+	v.canaryOrdinal = int(spec.CanaryOrdinal)
+	v.fetchOffset = len(v.insertCols)
+	v.updateOffset = v.fetchOffset + len(v.fetchCols)
+	// The canary column follows the update columns.
+	v.checkOffset = v.updateOffset + len(v.updateCols) + 1
+	v.initCheckOrds(spec.CheckOrds, semaCtx)
+	v.partialIndexOffset = v.checkOffset + v.checkOrds.Len()
+	v.initFetchCols()
+	return v
+}
+
+// NewDeleteOp allocates a new vector delete operator. Only the statements
+// whose output is the row count are supported.
+func NewDeleteOp(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	spec *execinfrapb.DeleteSpec,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	typs []*types.T,
+	alloc *colmem.Allocator,
+) colexecop.Operator {
+	v := newVectorMutator(ctx, flowCtx, mutationDelete, &spec.Table, input, inputTypes, typs, alloc)
+	v.fetchCols = v.findColumns(spec.FetchColumnIDs)
+	v.partialIndexOffset = len(v.fetchCols)
+	v.initFetchCols()
+	return v
+}
+
+func newVectorMutator(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	mutationType mutationType,
+	table *descpb.TableDescriptor,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	typs []*types.T,
+	alloc *colmem.Allocator,
+) *vectorMutator {
+	desc := flowCtx.TableDescriptor(ctx, table)
+	// See the comment in NewInsertOp for the choice of the quota.
+	mutationQuota := int(kvserverbase.MaxCommandSize.Get(&flowCtx.Cfg.Settings.SV) / 3)
+	return &vectorMutator{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		mutationType:   mutationType,
+		desc:           desc,
+		flowCtx:        flowCtx,
+		allocator:      alloc,
+		inputTypes:     inputTypes,
+		retBatch:       alloc.NewMemBatchWithFixedCapacity(typs, 1),
+		enc: colenc.MakeMutationEncoder(
+			flowCtx.Codec(), desc, &flowCtx.Cfg.Settings.SV, flowCtx.GetRowMetrics(), mutationQuota,
+		),
+	}
+}
+
+func (v *vectorMutator) findColumns(ids []descpb.ColumnID) []catalog.Column {
+	cols := make([]catalog.Column, len(ids))
+	for i, id := range ids {
+		col, err := catalog.MustFindColumnByID(v.desc, id)
+		if err != nil {
+			colexecerror.InternalError(err)
+		}
+		cols[i] = col
+	}
+	return cols
+}
+
+func (v *vectorMutator) initCheckOrds(checkOrds []byte, semaCtx *tree.SemaContext) {
+	if checkOrds != nil {
+		if err := v.checkOrds.Decode(bytes.NewReader(checkOrds)); err != nil {
+			colexecerror.InternalError(err)
+		}
+		v.semaCtx = semaCtx
+	}
+}
+
+// initFetchCols sets up the batches with the rows before and after the mutation
+// as well as the ordinals of the update columns among the fetch columns.
+func (v *vectorMutator) initFetchCols() {
+	v.updateColFetchIdxs = make([]int, len(v.updateCols))
+	for i, updateCol := range v.updateCols {
+		v.updateColFetchIdxs[i] = -1
+		for j, fetchCol := range v.fetchCols {
+			if fetchCol.GetID() == updateCol.GetID() {
+				v.updateColFetchIdxs[i] = j
+				break
+			}
+		}
+		if v.updateColFetchIdxs[i] == -1 {
+			colexecerror.InternalError(errors.AssertionFailedf(
+				"update column %q without a corresponding fetch column", updateCol.GetName(),
+			))
+		}
+	}
+	fetchTypes := make([]*types.T, len(v.fetchCols))
+	for i, col := range v.fetchCols {
+		fetchTypes[i] = col.GetType()
+	}
+	v.oldRows = coldata.NewMemBatchNoCols(fetchTypes, 0 /* capacity */)
+	v.newRows = coldata.NewMemBatchNoCols(fetchTypes, 0 /* capacity */)
+}
+
+// Next implements the colexecop.Operator interface.
+func (v *vectorMutator) Next() coldata.Batch {
+	if v.done {
+		return coldata.ZeroBatch
+	}
+	for {
+		b := v.Input.Next()
+		n := b.Length()
+		if n == 0 {
+			break
+		}
+		if sel := b.Selection(); sel != nil {
+			b = v.selectRows(0 /* denseIdx */, b, sel[:n])
+		}
+		if !v.checkOrds.Empty() {
+			if err := v.checkMutationInput(v.Ctx, b); err != nil {
+				colexecerror.ExpectedError(err)
+			}
+		}
+		switch v.mutationType {
+		case mutationUpdate:
+			v.checkNotNull(b, v.updateCols, v.updateOffset)
+			v.update(b)
+		case mutationUpsert:
+			v.upsert(b)
+		case mutationDelete:
+			v.delete(b)
+		}
+		v.rowCount += int64(n)
+		v.flowCtx.Cfg.StatsRefresher.NotifyMutation(v.desc, n)
+	}
+	v.done = true
+	v.retBatch.ResetInternalBatch()
+	v.retBatch.ColVec(0).Int64()[0] = v.rowCount
+	v.retBatch.SetLength(1)
+	return v.retBatch
+}
+
+// update modifies the rows of the batch from the fetched values to the values
+// of the update columns.
+func (v *vectorMutator) update(b coldata.Batch) {
+	for i := range v.fetchCols {
+		vec := b.ColVec(v.fetchOffset + i)
+		v.oldRows.ReplaceCol(vec, i)
+		v.newRows.ReplaceCol(vec, i)
+	}
+	for i, fetchIdx := range v.updateColFetchIdxs {
+		v.newRows.ReplaceCol(b.ColVec(v.updateOffset+i), fetchIdx)
+	}
+	n := b.Length()
+	v.oldRows.SetLength(n)
+	v.newRows.SetLength(n)
+	putPartialIndexes, delPartialIndexes := v.getPartialIndexMaps(b)
+	oldRows := colenc.Rows{Batch: v.oldRows, Cols: v.fetchCols, PartialIndexes: delPartialIndexes}
+	newRows := colenc.Rows{Batch: v.newRows, Cols: v.fetchCols, PartialIndexes: putPartialIndexes}
+	v.run(n, func(ctx context.Context, p row.Putter, start, end int) error {
+		return v.enc.PrepareUpdate(ctx, p, oldRows, newRows, start, end)
+	})
+}
+
+// upsert inserts the rows of the batch that don't have a conflict with an
+// existing row and updates the existing rows otherwise.
+func (v *vectorMutator) upsert(b coldata.Batch) {
+	n := b.Length()
+	canaryNulls := b.ColVec(v.canaryOrdinal).Nulls()
+	var insertSel, updateSel []int
+	if canaryNulls.MaybeHasNulls() {
+		for i := 0; i < n; i++ {
+			if canaryNulls.NullAt(i) {
+				insertSel = append(insertSel, i)
+			} else {
+				updateSel = append(updateSel, i)
+			}
+		}
+	} else {
+		updateSel = make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			updateSel = append(updateSel, i)
+		}
+	}
+	if len(updateSel) > 0 && len(v.updateCols) > 0 {
+		updateRows := b
+		if len(updateSel) < n {
+			updateRows = v.selectRows(1 /* denseIdx */, b, updateSel)
+		}
+		v.checkNotNull(updateRows, v.updateCols, v.updateOffset)
+		v.update(updateRows)
+	}
+	if len(insertSel) > 0 {
+		insertRows := b
+		if len(insertSel) < n {
+			insertRows = v.selectRows(2 /* denseIdx */, b, insertSel)
+		}
+		v.checkNotNull(insertRows, v.insertCols, 0 /* offset */)
+		insertTypes := v.inputTypes[:len(v.insertCols)]
+		newRows := coldata.NewMemBatchNoCols(insertTypes, 0 /* capacity */)
+		for i := range v.insertCols {
+			newRows.ReplaceCol(insertRows.ColVec(i), i)
+		}
+		numInserted := insertRows.Length()
+		newRows.SetLength(numInserted)
+		putPartialIndexes, _ := v.getPartialIndexMaps(insertRows)
+		rows := colenc.Rows{Batch: newRows, Cols: v.insertCols, PartialIndexes: putPartialIndexes}
+		v.run(numInserted, func(ctx context.Context, p row.Putter, start, end int) error {
+			return v.enc.PrepareUpdate(ctx, p, colenc.Rows{} /* oldRows */, rows, start, end)
+		})
+	}
+}
+
+// delete removes the rows of the batch.
+func (v *vectorMutator) delete(b coldata.Batch) {
+	for i := range v.fetchCols {
+		v.oldRows.ReplaceCol(b.ColVec(i), i)
+	}
+	n := b.Length()
+	v.oldRows.SetLength(n)
+	_, delPartialIndexes := v.getPartialIndexMaps(b)
+	rows := colenc.Rows{Batch: v.oldRows, Cols: v.fetchCols, PartialIndexes: delPartialIndexes}
+	v.run(n, func(ctx context.Context, p row.Putter, start, end int) error {
+		return v.enc.PrepareDelete(ctx, p, rows, start, end)
+	})
+}
+
+// run encodes the KVs for the first n rows using prepare and sends them to KV.
+// Similar to the vectorInserter, if the KVs of all rows exceed the memory
+// quota, the rows are processed in smaller chunks.
+func (v *vectorMutator) run(
+	n int, prepare func(ctx context.Context, p row.Putter, start, end int) error,
+) {
+	ctx := v.Ctx
+	kvba := row.KVBatchAdapter{}
+	var p row.Putter = &kvba
+	if v.flowCtx.TraceKV {
+		p = &row.TracePutter{Putter: p, Ctx: ctx}
+	}
+	start, end := 0, n
+	for start < n {
+		kvba.Batch = v.flowCtx.Txn.NewBatch()
+		if err := prepare(ctx, p, start, end); err != nil {
+			if errors.Is(err, colenc.ErrOverMemLimit) {
+				log.VEventf(ctx, 2, "vector mutation memory limit err %d, numrows: %d", start, end)
+				end = start + (end-start)/2
+				// If one row blows out memory limit, just do one row at a time.
+				if end <= start {
+					// Disable memory limit, if the system can't handle this row
+					// a KV error will be encountered below.
+					v.enc.SetMutationQuota(math.MaxInt)
+					end = start + 1
+				}
+				continue
+			}
+			colexecerror.ExpectedError(err)
+		}
+		log.VEventf(ctx, 2, "vector mutation running batch, numrows: %d", end-start)
+		if err := v.flowCtx.Txn.Run(ctx, kvba.Batch); err != nil {
+			colexecerror.ExpectedError(row.ConvertBatchError(ctx, v.desc, kvba.Batch))
+		}
+		numRows := end - start
+		start = end
+		end += numRows
+		if end > n {
+			end = n
+		}
+	}
+}
+
+// selectRows copies the rows of b at the positions in sel into a dense batch.
+func (v *vectorMutator) selectRows(denseIdx int, b coldata.Batch, sel []int) coldata.Batch {
+	n := len(sel)
+	dense, _ := v.allocator.ResetMaybeReallocateNoMemLimit(v.inputTypes, v.denseBatches[denseIdx], n)
+	v.denseBatches[denseIdx] = dense
+	v.allocator.PerformOperation(dense.ColVecs(), func() {
+		for i := range v.inputTypes {
+			dense.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       b.ColVec(i),
+				Sel:       sel,
+				SrcEndIdx: n,
+			})
+		}
+	})
+	dense.SetLength(n)
+	return dense
+}
+
+// getPartialIndexMaps returns the maps from the IDs of the partial indexes to
+// the values of the put and del predicates.
+func (v *vectorMutator) getPartialIndexMaps(
+	b coldata.Batch,
+) (put, del map[descpb.IndexID][]bool) {
+	pindexes := v.desc.PartialIndexes()
+	numPartialIndexes := len(pindexes)
+	if numPartialIndexes == 0 {
+		return nil, nil
+	}
+	offset := v.partialIndexOffset
+	if v.mutationType != mutationDelete {
+		put = make(map[descpb.IndexID][]bool, numPartialIndexes)
+		for i := range pindexes {
+			put[pindexes[i].GetID()] = b.ColVec(offset + i).Bool()
+		}
+		offset += numPartialIndexes
+	}
+	del = make(map[descpb.IndexID][]bool, numPartialIndexes)
+	for i := range pindexes {
+		del[pindexes[i].GetID()] = b.ColVec(offset + i).Bool()
+	}
+	return put, del
+}
+
+// checkNotNull returns an error if any of the non-nullable columns among cols
+// (which correspond to the input columns starting at offset) contains a NULL.
+func (v *vectorMutator) checkNotNull(b coldata.Batch, cols []catalog.Column, offset int) {
+	for i, col := range cols {
+		if col.IsNullable() {
+			continue
+		}
+		nulls := b.ColVec(offset + i).Nulls()
+		if !nulls.MaybeHasNulls() {
+			continue
+		}
+		for r := 0; r < b.Length(); r++ {
+			if nulls.NullAt(r) {
+				colexecerror.ExpectedError(sqlerrors.NewNonNullViolationError(col.GetName()))
+			}
+		}
+	}
+}
+
+func (v *vectorMutator) checkMutationInput(ctx context.Context, b coldata.Batch) error {
+	checks := v.desc.EnforcedCheckConstraints()
+	colIdx := 0
+	for i, ch := range checks {
+		if !v.checkOrds.Contains(i) {
+			continue
+		}
+		vec := b.ColVec(v.checkOffset + colIdx)
+		bools := vec.Bool()
+		nulls := vec.Nulls()
+		for r := 0; r < b.Length(); r++ {
+			if !bools[r] && !nulls.NullAt(r) {
+				return row.CheckFailed(ctx, v.flowCtx.EvalCtx, v.semaCtx, v.flowCtx.EvalCtx.SessionData(), v.desc, ch)
+			}
+		}
+		colIdx++
+	}
+	return nil
+}
@@ -66,6 +66,12 @@ type cFetcherWrapper struct {
 	detachedFetcherAcc *mon.BoundAccount
 	detachedFetcherMon *mon.BytesMonitor
 
+	// filter, if set, is the filter of the IndexFetchSpec that is evaluated on
+	// the batches produced by the fetcher. detachedFilterAcc tracks the
+	// footprint of the batches allocated by the filter.
+	filter            *kvFilter
+	detachedFilterAcc *mon.BoundAccount
+
 	// startKey is only used as an additional detail for some error messages.
 	startKey roachpb.Key
 
@@ -84,6 +90,7 @@ var _ storage.CFetcherWrapper = &cFetcherWrapper{}
 
 func init() {
 	storage.GetCFetcherWrapper = newCFetcherWrapper
+	storage.GetColBatchAggregator = newKVAggregator
 	kvpb.DeserializeColumnarBatchesFromArrow = deserializeColumnarBatchesFromArrow
 }
 
@@ -101,44 +108,74 @@ func (c *cFetcherWrapper) NextBatch(ctx context.Context) (_ []byte, _ coldata.Ba
 			retErr = storage.IncludeStartKeyIntoErr(c.startKey, retErr)
 		}
 	}()
-	prevBatchMemUsage := c.detachedFetcherAcc.Used()
-	// cFetcher propagates some errors as "internal" panics, so we have to wrap
-	// a call to cFetcher.NextBatch with a panic-catcher.
-	c.adapter.ctx = ctx
-	if err := colexecerror.CatchVectorizedRuntimeError(c.nextBatchAdapter); err != nil {
-		return nil, nil, err
-	}
-	if c.adapter.err != nil {
-		// If an error is propagated in a "regular" fashion, as a return
-		// parameter, then we don't include the start key - the pebble MVCC
-		// scanner has already done so if needed.
-		includeStartKeyIntoErr = false
-		return nil, nil, c.adapter.err
-	}
-	if c.adapter.batch.Length() == 0 {
-		return nil, nil, nil
+	prevBatchMemUsage := c.detachedMemUsage()
+	var batch coldata.Batch
+	for {
+		// cFetcher propagates some errors as "internal" panics, so we have to
+		// wrap a call to cFetcher.NextBatch with a panic-catcher.
+		c.adapter.ctx = ctx
+		if err := colexecerror.CatchVectorizedRuntimeError(c.nextBatchAdapter); err != nil {
+			return nil, nil, err
+		}
+		if c.adapter.err != nil {
+			// If an error is propagated in a "regular" fashion, as a return
+			// parameter, then we don't include the start key - the pebble MVCC
+			// scanner has already done so if needed.
+			includeStartKeyIntoErr = false
+			return nil, nil, c.adapter.err
+		}
+		batch = c.adapter.batch
+		if batch.Length() == 0 {
+			return nil, nil, nil
+		}
+		if c.filter == nil {
+			break
+		}
+		var filterErr error
+		if err := colexecerror.CatchVectorizedRuntimeError(func() {
+			batch, filterErr = c.filter.filter(ctx, batch)
+		}); err != nil {
+			return nil, nil, err
+		}
+		if filterErr != nil {
+			return nil, nil, filterErr
+		}
+		if batch.Length() > 0 {
+			break
+		}
+		// None of the rows in this batch satisfy the filter, so we move on to
+		// the next one since an empty batch indicates the end of the scan.
 	}
 	if !c.serialize {
 		// Perform the accounting for this batch. Note that when we're not
-		// serializing the response, the cFetcher always allocates a new batch,
-		// so we always grow the account by the footprint of the batch.
-		if err := c.acc.Grow(ctx, c.detachedFetcherAcc.Used()); err != nil {
+		// serializing the response, the cFetcher (as well as the filter)
+		// always allocates a new batch, so we always grow the account by the
+		// footprint of the batch.
+		if batch == c.adapter.batch {
+			if err := c.acc.Grow(ctx, c.detachedFetcherAcc.Used()); err != nil {
+				return nil, nil, err
+			}
+			return nil, batch, nil
+		}
+		filteredBatchMemUsage := c.detachedFilterAcc.Used()
+		c.filter.allocator.ReleaseAll()
+		if err := c.acc.Grow(ctx, filteredBatchMemUsage); err != nil {
 			return nil, nil, err
 		}
-		return nil, c.adapter.batch, nil
+		return nil, batch, nil
 	}
 	// Update the memory account based on possibly changed footprint of the
-	// batch (which the cFetcher reuses).
-	if err := c.acc.Resize(ctx, prevBatchMemUsage, c.detachedFetcherAcc.Used()); err != nil {
+	// batches (which the cFetcher and the filter reuse).
+	if err := c.acc.Resize(ctx, prevBatchMemUsage, c.detachedMemUsage()); err != nil {
 		return nil, nil, err
 	}
-	data, err := c.converter.BatchToArrow(ctx, c.adapter.batch)
+	data, err := c.converter.BatchToArrow(ctx, batch)
 	if err != nil {
 		return nil, nil, err
 	}
 	oldBufCap := c.buf.Cap()
 	c.buf.Reset()
-	_, _, err = c.serializer.Serialize(&c.buf, data, c.adapter.batch.Length())
+	_, _, err = c.serializer.Serialize(&c.buf, data, batch.Length())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +197,16 @@ func (c *cFetcherWrapper) NextBatch(ctx context.Context) (_ []byte, _ coldata.Ba
 	return b, nil, nil
 }
 
+// detachedMemUsage returns the footprint of the batches currently held by the
+// fetcher and the filter.
+func (c *cFetcherWrapper) detachedMemUsage() int64 {
+	usage := c.detachedFetcherAcc.Used()
+	if c.detachedFilterAcc != nil {
+		usage += c.detachedFilterAcc.Used()
+	}
+	return usage
+}
+
 // Close implements the storage.CFetcherWrapper interface.
 func (c *cFetcherWrapper) Close(ctx context.Context) {
 	if c.fetcher != nil {
@@ -167,9 +214,17 @@ func (c *cFetcherWrapper) Close(ctx context.Context) {
 		c.fetcher.Release()
 		c.fetcher = nil
 	}
+	if c.filter != nil {
+		c.filter.close()
+		c.filter = nil
+	}
 	if c.detachedFetcherMon != nil {
 		c.detachedFetcherAcc.Close(ctx)
 		c.detachedFetcherAcc = nil
+		if c.detachedFilterAcc != nil {
+			c.detachedFilterAcc.Close(ctx)
+			c.detachedFilterAcc = nil
+		}
 		c.detachedFetcherMon.Stop(ctx)
 		c.detachedFetcherMon = nil
 	}
@@ -257,8 +312,9 @@ func newCFetcherWrapper(
 	detachedFetcherAcc := detachedFetcherMon.MakeBoundAccount()
 
 	// We don't need to provide the eval context here since we will only decode
-	// bytes into datums and then serialize them, without ever comparing datums
-	// (at least until we implement the filter pushdown).
+	// bytes into datums and then serialize them. The only datums compared are
+	// those of the filter, which is limited to types that don't need the eval
+	// context for comparison.
 	allocator := colmem.NewAllocator(ctx, &detachedFetcherAcc, coldataext.NewExtendedColumnFactoryNoEvalCtx())
 	if err = fetcher.Init(allocator, nextKVer, tableArgs); err != nil {
 		return nil, err
@@ -272,6 +328,17 @@ func newCFetcherWrapper(
 		detachedFetcherAcc: &detachedFetcherAcc,
 		detachedFetcherMon: detachedFetcherMon,
 	}
+	if len(fetchSpec.Filter) > 0 {
+		detachedFilterAcc := detachedFetcherMon.MakeBoundAccount()
+		wrapper.detachedFilterAcc = &detachedFilterAcc
+		filterAllocator := colmem.NewAllocator(ctx, &detachedFilterAcc, coldataext.NewExtendedColumnFactoryNoEvalCtx())
+		// Batches can be reused by the filter only if we serialize them.
+		wrapper.filter, err = newKVFilter(fetchSpec, tableArgs.typs, filterAllocator, mustSerialize /* reuseOutput */)
+		if err != nil {
+			detachedFilterAcc.Close(ctx)
+			return nil, err
+		}
+	}
 	if mustSerialize {
 		// Note that it is ok to use the same memory account for the
 		// ArrowBatchConverter as for everything else since the converter only
@@ -0,0 +1,191 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// kvAggregator implements the storage.ColBatchAggregator interface. It
+// computes the partial aggregates of an AggregateScanRequest over the batches
+// produced by the cFetcherWrapper on the KV server side.
+type kvAggregator struct {
+	aggregates []kvAggregate
+	converter  *colconv.VecToDatumConverter
+}
+
+var _ storage.ColBatchAggregator = &kvAggregator{}
+
+// kvAggregate is the state of a single aggregate function.
+type kvAggregate struct {
+	fn     kvpb.AggregateScanRequest_Aggregate_Func
+	colIdx int
+	typ    *types.T
+	// count is the number of rows (for COUNT_ROWS) or non-NULL values seen so
+	// far.
+	count int64
+	// floatSum and decimalSum are the sums of FLOAT and of INT or DECIMAL
+	// values, respectively.
+	floatSum   float64
+	decimalSum apd.Decimal
+	tmpDecimal apd.Decimal
+	// value is the current MIN or MAX value, or nil if there is none.
+	value tree.Datum
+}
+
+func newKVAggregator(
+	fetchSpec *fetchpb.IndexFetchSpec, aggregates []kvpb.AggregateScanRequest_Aggregate,
+) (storage.ColBatchAggregator, error) {
+	a := &kvAggregator{aggregates: make([]kvAggregate, len(aggregates))}
+	var vecIdxsToConvert []int
+	var seen intsets.Fast
+	for i, agg := range aggregates {
+		a.aggregates[i].fn = agg.Func
+		if agg.Func == kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS {
+			continue
+		}
+		colIdx := int(agg.FetchedColumnOrdinal)
+		if colIdx >= len(fetchSpec.FetchedColumns) {
+			return nil, errors.AssertionFailedf(
+				"invalid fetched column ordinal %d in aggregate of fetch spec with %d columns",
+				colIdx, len(fetchSpec.FetchedColumns),
+			)
+		}
+		typ := fetchSpec.FetchedColumns[colIdx].Type
+		if !rowenc.IndexFetchFilterSupportsType(typ) {
+			return nil, errors.AssertionFailedf("unsupported aggregate argument type %s", typ.SQLStringForError())
+		}
+		if agg.Func == kvpb.AggregateScanRequest_Aggregate_SUM {
+			switch typ.Family() {
+			case types.IntFamily, types.FloatFamily, types.DecimalFamily:
+			default:
+				return nil, errors.AssertionFailedf("unsupported SUM argument type %s", typ.SQLStringForError())
+			}
+		}
+		a.aggregates[i].colIdx = colIdx
+		a.aggregates[i].typ = typ
+		if !seen.Contains(colIdx) {
+			seen.Add(colIdx)
+			vecIdxsToConvert = append(vecIdxsToConvert, colIdx)
+		}
+	}
+	a.converter = colconv.NewVecToDatumConverter(
+		len(fetchSpec.FetchedColumns), vecIdxsToConvert, false, /* willRelease */
+	)
+	return a, nil
+}
+
+// Add implements the storage.ColBatchAggregator interface.
+func (a *kvAggregator) Add(ctx context.Context, batch coldata.Batch) error {
+	n := batch.Length()
+	a.converter.ConvertBatch(batch)
+	for i := range a.aggregates {
+		agg := &a.aggregates[i]
+		if agg.fn == kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS {
+			agg.count += int64(n)
+			continue
+		}
+		col := a.converter.GetDatumColumn(agg.colIdx)
+		for _, d := range col[:n] {
+			if d == tree.DNull {
+				continue
+			}
+			agg.count++
+			switch agg.fn {
+			case kvpb.AggregateScanRequest_Aggregate_COUNT:
+			case kvpb.AggregateScanRequest_Aggregate_SUM:
+				if err := agg.addToSum(d); err != nil {
+					return err
+				}
+			case kvpb.AggregateScanRequest_Aggregate_MIN, kvpb.AggregateScanRequest_Aggregate_MAX:
+				if agg.value == nil {
+					agg.value = d
+					continue
+				}
+				cmp, err := d.Compare(ctx, kvCompareContext{}, agg.value)
+				if err != nil {
+					return err
+				}
+				if (agg.fn == kvpb.AggregateScanRequest_Aggregate_MIN && cmp < 0) ||
+					(agg.fn == kvpb.AggregateScanRequest_Aggregate_MAX && cmp > 0) {
+					agg.value = d
+				}
+			default:
+				return errors.AssertionFailedf("unknown aggregate function %s", agg.fn)
+			}
+		}
+	}
+	return nil
+}
+
+func (agg *kvAggregate) addToSum(d tree.Datum) error {
+	switch t := d.(type) {
+	case *tree.DInt:
+		agg.tmpDecimal.SetInt64(int64(*t))
+		_, err := tree.ExactCtx.Add(&agg.decimalSum, &agg.decimalSum, &agg.tmpDecimal)
+		return err
+	case *tree.DFloat:
+		agg.floatSum += float64(*t)
+		return nil
+	case *tree.DDecimal:
+		_, err := tree.ExactCtx.Add(&agg.decimalSum, &agg.decimalSum, &t.Decimal)
+		return err
+	}
+	return errors.AssertionFailedf("unexpected SUM argument %T", d)
+}
+
+// Result implements the storage.ColBatchAggregator interface.
+func (a *kvAggregator) Result() ([]byte, error) {
+	var res []byte
+	for i := range a.aggregates {
+		agg := &a.aggregates[i]
+		var d tree.Datum
+		switch agg.fn {
+		case kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS, kvpb.AggregateScanRequest_Aggregate_COUNT:
+			d = tree.NewDInt(tree.DInt(agg.count))
+		case kvpb.AggregateScanRequest_Aggregate_SUM:
+			switch {
+			case agg.count == 0:
+				d = tree.DNull
+			case agg.typ.Family() == types.FloatFamily:
+				d = tree.NewDFloat(tree.DFloat(agg.floatSum))
+			default:
+				dd := &tree.DDecimal{}
+				dd.Set(&agg.decimalSum)
+				d = dd
+			}
+		default:
+			d = agg.value
+			if d == nil {
+				d = tree.DNull
+			}
+		}
+		var err error
+		res, err = valueside.Encode(res, valueside.NoColumnID, d, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// kvFilter evaluates the filter of a fetchpb.IndexFetchSpec on the batches
+// produced by the cFetcher on the KV server side, so that only the rows that
+// satisfy it are returned to the client.
+type kvFilter struct {
+	predicates []kvPredicate
+	typs       []*types.T
+	converter  *colconv.VecToDatumConverter
+	sel        []int
+
+	// allocator is used to allocate the batches containing the rows that
+	// satisfy the filter.
+	allocator *colmem.Allocator
+	// reuseOutput indicates whether the output batch can be reused across
+	// filter calls (which is the case when the batches are serialized).
+	reuseOutput bool
+	output      coldata.Batch
+}
+
+// kvPredicate is the decoded form of a fetchpb.IndexFetchSpec_Predicate.
+type kvPredicate struct {
+	colIdx int
+	op     fetchpb.IndexFetchSpec_Predicate_Operator
+	values tree.Datums
+}
+
+// newKVFilter returns a kvFilter for the filter of the given fetch spec, or
+// nil if the spec has no filter. typs are the types of the fetched columns.
+func newKVFilter(
+	fetchSpec *fetchpb.IndexFetchSpec,
+	typs []*types.T,
+	allocator *colmem.Allocator,
+	reuseOutput bool,
+) (*kvFilter, error) {
+	if len(fetchSpec.Filter) == 0 {
+		return nil, nil
+	}
+	f := &kvFilter{
+		predicates:  make([]kvPredicate, len(fetchSpec.Filter)),
+		typs:        typs,
+		allocator:   allocator,
+		reuseOutput: reuseOutput,
+	}
+	var da tree.DatumAlloc
+	var vecIdxsToConvert []int
+	var seen intsets.Fast
+	for i := range fetchSpec.Filter {
+		p := &fetchSpec.Filter[i]
+		colIdx := int(p.FetchedColumnOrdinal)
+		if colIdx >= len(typs) {
+			return nil, errors.AssertionFailedf(
+				"invalid fetched column ordinal %d in filter of fetch spec with %d columns",
+				colIdx, len(typs),
+			)
+		}
+		f.predicates[i] = kvPredicate{colIdx: colIdx, op: p.Op}
+		for _, b := range p.Values {
+			d, _, err := valueside.Decode(&da, typs[colIdx], b)
+			if err != nil {
+				return nil, err
+			}
+			f.predicates[i].values = append(f.predicates[i].values, d)
+		}
+		if !seen.Contains(colIdx) {
+			seen.Add(colIdx)
+			vecIdxsToConvert = append(vecIdxsToConvert, colIdx)
+		}
+	}
+	f.converter = colconv.NewVecToDatumConverter(len(typs), vecIdxsToConvert, true /* willRelease */)
+	return f, nil
+}
+
+// filter returns a batch containing the rows of the given batch that satisfy
+// the filter. The returned batch has no selection vector and might be empty.
+// The given batch is returned as is if all its rows satisfy the filter.
+func (f *kvFilter) filter(ctx context.Context, batch coldata.Batch) (coldata.Batch, error) {
+	n := batch.Length()
+	f.converter.ConvertBatch(batch)
+	f.sel = f.sel[:0]
+	for rowIdx := 0; rowIdx < n; rowIdx++ {
+		ok, err := f.matches(ctx, rowIdx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			f.sel = append(f.sel, rowIdx)
+		}
+	}
+	if len(f.sel) == n {
+		return batch, nil
+	}
+	if f.reuseOutput {
+		f.output, _ = f.allocator.ResetMaybeReallocateNoMemLimit(f.typs, f.output, len(f.sel))
+	} else {
+		f.output = f.allocator.NewMemBatchWithFixedCapacity(f.typs, len(f.sel))
+	}
+	f.allocator.PerformOperation(f.output.ColVecs(), func() {
+		for i := range f.typs {
+			f.output.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       batch.ColVec(i),
+				Sel:       f.sel,
+				SrcEndIdx: len(f.sel),
+			})
+		}
+	})
+	f.output.SetLength(len(f.sel))
+	return f.output, nil
+}
+
+// matches returns whether the row at the given index of the last converted
+// batch satisfies all the predicates.
+func (f *kvFilter) matches(ctx context.Context, rowIdx int) (bool, error) {
+	for i := range f.predicates {
+		p := &f.predicates[i]
+		d := f.converter.GetDatumColumn(p.colIdx)[rowIdx]
+		switch p.op {
+		case fetchpb.IndexFetchSpec_Predicate_IS_NULL:
+			if d != tree.DNull {
+				return false, nil
+			}
+			continue
+		case fetchpb.IndexFetchSpec_Predicate_IS_NOT_NULL:
+			if d == tree.DNull {
+				return false, nil
+			}
+			continue
+		}
+		// All other predicates are false (or NULL) for NULL values.
+		if d == tree.DNull || len(p.values) == 0 {
+			return false, nil
+		}
+		if p.op == fetchpb.IndexFetchSpec_Predicate_IN {
+			found := false
+			for _, v := range p.values {
+				cmp, err := d.Compare(ctx, kvCompareContext{}, v)
+				if err != nil {
+					return false, err
+				}
+				if cmp == 0 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+			continue
+		}
+		cmp, err := d.Compare(ctx, kvCompareContext{}, p.values[0])
+		if err != nil {
+			return false, err
+		}
+		var ok bool
+		switch p.op {
+		case fetchpb.IndexFetchSpec_Predicate_EQ:
+			ok = cmp == 0
+		case fetchpb.IndexFetchSpec_Predicate_NE:
+			ok = cmp != 0
+		case fetchpb.IndexFetchSpec_Predicate_LT:
+			ok = cmp < 0
+		case fetchpb.IndexFetchSpec_Predicate_LE:
+			ok = cmp <= 0
+		case fetchpb.IndexFetchSpec_Predicate_GT:
+			ok = cmp > 0
+		case fetchpb.IndexFetchSpec_Predicate_GE:
+			ok = cmp >= 0
+		default:
+			return false, errors.AssertionFailedf("unknown filter operator %s", p.op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// close releases the resources held by the kvFilter.
+func (f *kvFilter) close() {
+	if f.converter != nil {
+		f.converter.Release()
+		f.converter = nil
+	}
+	f.output = nil
+}
+
+// kvCompareContext is the tree.CompareContext used to compare datums on the KV
+// server, where no session context is available. Only the values of the types
+// that can be compared without the session context are compared (see
+// rowenc.IndexFetchFilterSupportsType).
+type kvCompareContext struct{}
+
+var _ tree.CompareContext = kvCompareContext{}
+
+// UnwrapDatum is part of the tree.CompareContext interface.
+func (kvCompareContext) UnwrapDatum(_ context.Context, d tree.Datum) tree.Datum {
+	return tree.UnwrapDOidWrapper(d)
+}
+
+// GetLocation is part of the tree.CompareContext interface.
+func (kvCompareContext) GetLocation() *time.Location {
+	return time.UTC
+}
+
+// GetRelativeParseTime is part of the tree.CompareContext interface.
+func (kvCompareContext) GetRelativeParseTime() time.Time {
+	return timeutil.Now()
+}
+
+// MustGetPlaceholderValue is part of the tree.CompareContext interface.
+func (kvCompareContext) MustGetPlaceholderValue(
+	context.Context, *tree.Placeholder,
+) tree.Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder in filter"))
+}
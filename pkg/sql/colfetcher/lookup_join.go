@@ -0,0 +1,1372 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecspan"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+	"github.com/marusama/semaphore"
+)
+
+// ColLookupJoin operators are used to execute lookup joins whose lookup
+// condition is a conjunction of equalities between input columns and a prefix
+// of the index key columns (i.e. lookup joins with non-empty LookupColumns).
+//
+// The ColLookupJoin buffers a chunk of input tuples (the size of the chunk is
+// limited in the same way as the input batches of the ColIndexJoin), performs
+// the lookups for all distinct keys in the chunk at once, and then matches the
+// looked-up rows with the buffered input tuples using the key encodings of the
+// lookup columns. The joined tuples are emitted as soon as they are formed,
+// unless the join outputs the looked-up columns and has to maintain the input
+// ordering. In that case, the looked-up rows that are part of the output are
+// buffered in a SpillingBuffer, which spills to disk if necessary, and the
+// joined tuples are emitted in the order of the input tuples once all lookups
+// of the chunk have been performed.
+//
+// The operator supports both joins of paired joins. As the first join, it
+// maintains the ordering and appends the continuation column to the output.
+// As the second join, it treats consecutive input tuples of the same group
+// (according to the continuation column) as a single left tuple, and the
+// chunks are always extended to contain whole groups.
+type ColLookupJoin struct {
+	colexecop.InitHelper
+	colexecop.OneInputNode
+
+	state lookupJoinState
+
+	joinType descpb.JoinType
+	// pairedJoiner is true when this join is the second join of the paired
+	// joins, so the last input column is the continuation column.
+	pairedJoiner bool
+	// outputContinuation is true when this join is the first join of the
+	// paired joins, so the continuation column is appended to the output.
+	outputContinuation bool
+	// outputsLookupCols is false for semi and anti joins.
+	outputsLookupCols bool
+	// buffersLookedUpRows is true when the joined tuples have to be emitted in
+	// the order of the input tuples and contain the looked-up columns.
+	buffersLookedUpRows bool
+
+	inputTypes []*types.T
+	lookupCols []uint32
+	// numFetchedCols is the number of the fetched columns that are part of
+	// the output. The fetcher might produce additional key columns that are
+	// needed only for matching the looked-up rows.
+	numFetchedCols int
+
+	// spanAssembler generates a lookup span for each buffered input tuple. The
+	// start key of each span is also used to match the looked-up rows.
+	spanAssembler colexecspan.ColSpanAssembler
+	// lookupKeyAssembler encodes the key columns of the looked-up rows in the
+	// same way as spanAssembler encodes the lookup columns of the input tuples.
+	lookupKeyAssembler colexecspan.ColSpanAssembler
+	// chunkLookupCols and lookupKeyCols are the "projections" of the lookup
+	// columns of the chunk and of the key columns of the looked-up rows,
+	// respectively, that are fed into the span assemblers.
+	chunkLookupCols coldata.Batch
+	lookupKeyCols   coldata.Batch
+	// fetchedKeyColIdxs are the ordinals of the key columns among the fetched
+	// columns.
+	fetchedKeyColIdxs []int
+
+	// batch keeps track of the input batch currently being buffered, and
+	// startIdx is the index of the next tuple in it that hasn't been buffered
+	// yet.
+	batch     coldata.Batch
+	startIdx  int
+	inputDone bool
+
+	// limitHintHelper is used in limiting the size of the chunks in the
+	// presence of hard and soft limits.
+	limitHintHelper execinfra.LimitHintHelper
+
+	// inputBatchSizeLimit is the limit on the size of a single chunk.
+	inputBatchSizeLimit int64
+
+	// chunk contains the buffered input tuples, and chunkSize is the estimated
+	// size of the data in it.
+	chunk     *colexecutils.AppendOnlyBufferedBatch
+	chunkSize int64
+
+	matching struct {
+		// keyToFirstRow maps the key encoding of the lookup columns to the
+		// first tuple in the chunk with that key, and nextRowWithKey links all
+		// tuples with the same key in the increasing order. The tuples that
+		// have NULLs in the lookup columns are never looked up.
+		keyToFirstRow  map[string]int
+		nextRowWithKey []int
+		keysMemUsage   int64
+		// lookupRows are the tuples of the chunk for which the lookup spans
+		// have been generated.
+		lookupRows []int
+		// matched tracks which tuples in the chunk have been matched with at
+		// least one looked-up row (that passed the ON expression).
+		matched []bool
+	}
+
+	// fetched is the batch of the looked-up rows that is currently being
+	// processed, and fetchedKeys are the key encodings of those rows.
+	fetched     coldata.Batch
+	fetchedKeys roachpb.Spans
+	// fetchedIdx is the index of the next looked-up row to be matched, and
+	// matchRow is the next tuple in the chunk to be matched with that row (-1
+	// if the matching of that row hasn't started yet).
+	fetchedIdx int
+	matchRow   int
+
+	// pairs contains the indices of the chunk tuples and of the looked-up rows
+	// that form the joined tuples currently being processed.
+	pairs struct {
+		inputSel  []int
+		lookupSel []int
+		// batch is used to evaluate the ON expression for semi and anti joins
+		// for which the joined tuples are not emitted.
+		batch coldata.Batch
+		types []*types.T
+	}
+
+	onExpr struct {
+		// op evaluates the ON expression on the batches set on feed, and the
+		// result of the evaluation is stored in the column resultIdx. op is nil
+		// if there is no ON expression.
+		op        colexecop.Operator
+		feed      *colexecop.FeedOperator
+		batch     coldata.Batch
+		resultIdx int
+	}
+
+	// emitInputSel contains the tuples of the chunk that remain to be emitted
+	// without a looked-up row once all lookups of the chunk have been
+	// performed: NULL-extended tuples of left outer joins and the output of
+	// semi and anti joins. emitIdx is the position of the next tuple to be
+	// emitted.
+	emitInputSel []int
+	emitIdx      int
+
+	// ordered is used when buffersLookedUpRows is true.
+	ordered struct {
+		// lookedUp contains the looked-up rows of the chunk that are part of
+		// at least one joined tuple, in the order in which they were fetched.
+		lookedUp *colexecutils.SpillingBuffer
+		// rowFirstMatch and rowLastMatch are the first and last matches of
+		// every tuple of the chunk (-1 if there are none). The matches of a
+		// tuple are linked by nextMatch in the order in which they were found,
+		// and matchLookedUpIdx is the position in lookedUp of the looked-up row
+		// of every match.
+		rowFirstMatch    []int
+		rowLastMatch     []int
+		nextMatch        []int
+		matchLookedUpIdx []int
+		// numLookedUp is the number of rows appended to lookedUp.
+		// lastFetchedIdx is the position of the last looked-up row appended
+		// from the current fetched batch (-1 if none), and lastLookedUpIdx is
+		// its position in lookedUp.
+		numLookedUp     int
+		lastFetchedIdx  int
+		lastLookedUpIdx int
+		// emitRow is the next tuple of the chunk to be emitted, and emitMatch
+		// is its next match to be emitted (-1 if all have been). groupMatched
+		// tracks whether the group of emitRow had any matches for the second
+		// join of paired joins.
+		emitRow      int
+		emitMatch    int
+		groupMatched bool
+		// inputSel, lookedUpSel and continuation describe the joined tuples
+		// of the output batch being formed. lookedUpSel is -1 for the
+		// NULL-extended tuples.
+		inputSel     []int
+		lookedUpSel  []int
+		continuation []bool
+	}
+
+	scratchMemUsage int64
+
+	output coldata.Batch
+
+	allocator       *colmem.Allocator
+	bufferAllocator *colmem.Allocator
+
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	cf          *cFetcher
+	// txn is the transaction used by the lookup joiner.
+	txn *kv.Txn
+
+	limitBatches    bool
+	batchBytesLimit rowinfra.BytesLimit
+	// estimatedRowsPerSpan is 1 if each lookup returns at most one row and 0
+	// otherwise.
+	estimatedRowsPerSpan uint64
+
+	// tracingSpan is created when the stats should be collected for the query
+	// execution, and it will be finished when closing the operator.
+	tracingSpan *tracing.Span
+	execstats.ContentionEventsListener
+	execstats.ScanStatsListener
+	execstats.TenantConsumptionListener
+	mu struct {
+		syncutil.Mutex
+		// rowsRead contains the number of total rows this ColLookupJoin has
+		// looked up so far.
+		rowsRead int64
+	}
+	// ResultTypes is the slice of resulting column types from this operator.
+	ResultTypes []*types.T
+
+	// usesStreamer indicates whether the ColLookupJoin is using the Streamer
+	// API.
+	usesStreamer bool
+}
+
+var _ ScanOperator = &ColLookupJoin{}
+
+// OnExprPlanner plans the operators that evaluate the ON expression of a
+// lookup join on top of input, whose batches contain the columns of the given
+// types. It returns the root of the planned operator chain and the index of
+// the boolean column with the result of the evaluation.
+type OnExprPlanner func(
+	input colexecop.Operator, inputTypes []*types.T,
+) (op colexecop.Operator, resultIdx int, _ error)
+
+// Init initializes a ColLookupJoin.
+func (s *ColLookupJoin) Init(ctx context.Context) {
+	if !s.InitHelper.Init(ctx) {
+		return
+	}
+	s.Ctx, s.tracingSpan = execinfra.ProcessorSpan(
+		s.Ctx, s.flowCtx, "collookupjoin", s.processorID,
+		&s.ContentionEventsListener, &s.ScanStatsListener, &s.TenantConsumptionListener,
+	)
+	s.Input.Init(s.Ctx)
+	if s.onExpr.op != nil {
+		s.onExpr.op.Init(s.Ctx)
+	}
+}
+
+type lookupJoinState uint8
+
+const (
+	lookupJoinReadingInput lookupJoinState = iota
+	lookupJoinFetching
+	lookupJoinEmitting
+	lookupJoinDone
+)
+
+// Next is part of the Operator interface.
+func (s *ColLookupJoin) Next() coldata.Batch {
+	for {
+		switch s.state {
+		case lookupJoinReadingInput:
+			s.resetChunk()
+			s.readInputChunk()
+			if s.chunk.Length() == 0 {
+				s.state = lookupJoinDone
+				continue
+			}
+			spans := s.generateSpans()
+			s.accountForScratch()
+			if len(spans) == 0 {
+				// None of the tuples in the chunk have to be looked up.
+				s.spanAssembler.AccountForSpans()
+				s.finishChunk()
+				s.state = lookupJoinEmitting
+				continue
+			}
+			s.cf.setEstimatedRowCount(uint64(len(spans)) * s.estimatedRowsPerSpan)
+			// Note that the fetcher takes ownership of the spans slice, and we
+			// don't double count for any memory of spans because the
+			// spanAssembler released all of the relevant memory from its
+			// account in GetSpans().
+			if err := s.cf.StartScan(
+				s.Ctx,
+				spans,
+				s.limitBatches,
+				s.batchBytesLimit,
+				rowinfra.NoRowLimit,
+			); err != nil {
+				colexecerror.InternalError(err)
+			}
+			s.fetched = nil
+			s.state = lookupJoinFetching
+
+		case lookupJoinFetching:
+			if s.fetched == nil || s.fetchedIdx >= s.fetched.Length() {
+				batch, err := s.cf.NextBatch(s.Ctx)
+				if err != nil {
+					colexecerror.InternalError(err)
+				}
+				if batch.Selection() != nil {
+					colexecerror.InternalError(
+						errors.AssertionFailedf("unexpected selection vector on the batch coming from CFetcher"))
+				}
+				n := batch.Length()
+				if n == 0 {
+					// NB: the fetcher has just been closed automatically, so it
+					// released all of the resources. We now have to tell the
+					// ColSpanAssembler to account for the spans slice since it
+					// still has the references to it.
+					s.spanAssembler.AccountForSpans()
+					s.fetched = nil
+					s.finishChunk()
+					s.state = lookupJoinEmitting
+					continue
+				}
+				s.mu.Lock()
+				s.mu.rowsRead += int64(n)
+				s.mu.Unlock()
+				s.setFetched(batch)
+			}
+			if s.onExpr.op == nil && !s.outputsLookupCols {
+				// Semi and anti joins without an ON expression only need to
+				// know which tuples have a match.
+				s.markMatchedFetched()
+				continue
+			}
+			s.collectPairs()
+			if len(s.pairs.inputSel) == 0 {
+				continue
+			}
+			if out := s.processPairs(); out != nil {
+				return out
+			}
+
+		case lookupJoinEmitting:
+			if s.buffersLookedUpRows {
+				if out := s.emitOrdered(); out != nil {
+					return out
+				}
+				s.state = lookupJoinReadingInput
+				continue
+			}
+			if s.emitIdx >= len(s.emitInputSel) {
+				s.state = lookupJoinReadingInput
+				continue
+			}
+			endIdx := s.emitIdx + coldata.BatchSize()
+			if endIdx > len(s.emitInputSel) {
+				endIdx = len(s.emitInputSel)
+			}
+			s.output, _ = s.allocator.ResetMaybeReallocateNoMemLimit(s.ResultTypes, s.output, endIdx-s.emitIdx)
+			s.populate(
+				s.output, s.emitInputSel[s.emitIdx:endIdx], nil /* lookupBatch */, nil, /* lookupSel */
+				s.outputsLookupCols,
+			)
+			s.emitIdx = endIdx
+			return s.output
+
+		case lookupJoinDone:
+			// Eagerly close the lookup joiner. Note that closeInternal() is
+			// idempotent, so it's ok if it'll be closed again.
+			s.closeInternal()
+			return coldata.ZeroBatch
+		}
+	}
+}
+
+// resetChunk prepares the lookup joiner for buffering the next chunk.
+func (s *ColLookupJoin) resetChunk() {
+	s.chunk.ResetInternalBatch()
+	s.chunkSize = 0
+	for k := range s.matching.keyToFirstRow {
+		delete(s.matching.keyToFirstRow, k)
+	}
+	s.bufferAllocator.ReleaseMemory(s.matching.keysMemUsage)
+	s.matching.keysMemUsage = 0
+	s.matching.lookupRows = s.matching.lookupRows[:0]
+	s.emitInputSel = s.emitInputSel[:0]
+	s.emitIdx = 0
+	if s.buffersLookedUpRows {
+		s.ordered.lookedUp.Reset(s.Ctx)
+		s.ordered.nextMatch = s.ordered.nextMatch[:0]
+		s.ordered.matchLookedUpIdx = s.ordered.matchLookedUpIdx[:0]
+		s.ordered.numLookedUp = 0
+		s.ordered.groupMatched = false
+	}
+}
+
+// readInputChunk buffers the next chunk of input tuples into s.chunk.
+func (s *ColLookupJoin) readInputChunk() {
+	var rowCount int64
+	for !s.inputDone {
+		if s.batch == nil || s.startIdx >= s.batch.Length() {
+			s.startIdx = 0
+			s.batch = s.Input.Next()
+			if s.batch.Length() == 0 {
+				s.inputDone = true
+				break
+			}
+		}
+		n := s.batch.Length()
+		limitHint := s.limitHintHelper.LimitHint()
+		if rowCount > 0 && (s.chunkSize >= s.inputBatchSizeLimit || (limitHint != 0 && rowCount >= limitHint)) {
+			// The chunk is full. However, the second join of the paired joins
+			// must see all tuples of a group at once, so we extend the chunk
+			// with the remainder of the last group.
+			if !s.pairedJoiner || !s.isContinuation(s.batch, s.startIdx) {
+				break
+			}
+			endIdx := s.startIdx + 1
+			for endIdx < n && s.isContinuation(s.batch, endIdx) {
+				endIdx++
+			}
+			rowCount += int64(endIdx - s.startIdx)
+			s.appendToChunk(endIdx)
+			if endIdx < n {
+				break
+			}
+			continue
+		}
+		endIdx := n
+		if limitHint != 0 && rowCount+int64(endIdx-s.startIdx) > limitHint {
+			endIdx = s.startIdx + int(limitHint-rowCount)
+		}
+		rowCount += int64(endIdx - s.startIdx)
+		s.appendToChunk(endIdx)
+	}
+	if err := s.limitHintHelper.ReadSomeRows(rowCount); err != nil {
+		colexecerror.InternalError(err)
+	}
+}
+
+// appendToChunk buffers the input tuples in [s.startIdx, endIdx) range of the
+// current input batch.
+func (s *ColLookupJoin) appendToChunk(endIdx int) {
+	s.chunkSize += colmem.GetProportionalBatchMemSize(s.batch, int64(endIdx-s.startIdx))
+	s.chunk.AppendTuples(s.batch, s.startIdx, endIdx)
+	s.startIdx = endIdx
+}
+
+// isContinuation returns whether the tuple at the given position of the batch
+// continues the group of the previous tuple. It must only be called by the
+// second join of the paired joins.
+func (s *ColLookupJoin) isContinuation(batch coldata.Batch, idx int) bool {
+	if sel := batch.Selection(); sel != nil {
+		idx = sel[idx]
+	}
+	vec := batch.ColVec(len(s.inputTypes) - 1)
+	return !vec.Nulls().NullAt(idx) && vec.Bool().Get(idx)
+}
+
+// generateSpans populates the matching state for the current chunk and returns
+// the deduplicated lookup spans.
+func (s *ColLookupJoin) generateSpans() roachpb.Spans {
+	n := s.chunk.Length()
+	var hasNulls bool
+	for i, colIdx := range s.lookupCols {
+		vec := s.chunk.ColVec(int(colIdx))
+		s.chunkLookupCols.ReplaceCol(vec, i)
+		hasNulls = hasNulls || vec.Nulls().MaybeHasNulls()
+	}
+	s.chunkLookupCols.SetLength(n)
+	lookupRows := s.matching.lookupRows[:0]
+	if !hasNulls {
+		s.spanAssembler.ConsumeBatch(s.chunkLookupCols, 0 /* startIdx */, n)
+		for i := 0; i < n; i++ {
+			lookupRows = append(lookupRows, i)
+		}
+	} else {
+		// Tuples with NULLs in the lookup columns cannot have any matches, so
+		// we only generate spans for the runs of tuples without NULLs.
+		for i := 0; i < n; {
+			if s.lookupColsNullAt(i) {
+				i++
+				continue
+			}
+			runEnd := i + 1
+			for runEnd < n && !s.lookupColsNullAt(runEnd) {
+				runEnd++
+			}
+			s.spanAssembler.ConsumeBatch(s.chunkLookupCols, i, runEnd)
+			for ; i < runEnd; i++ {
+				lookupRows = append(lookupRows, i)
+			}
+		}
+	}
+	s.matching.lookupRows = lookupRows
+	spans := s.spanAssembler.GetSpans()
+
+	s.matching.matched = colexecutils.MaybeAllocateBoolArray(s.matching.matched, n)
+	if s.buffersLookedUpRows {
+		s.ordered.rowFirstMatch = resetMatches(s.ordered.rowFirstMatch, n)
+		s.ordered.rowLastMatch = resetMatches(s.ordered.rowLastMatch, n)
+	}
+	if cap(s.matching.nextRowWithKey) < n {
+		s.matching.nextRowWithKey = make([]int, n)
+	}
+	s.matching.nextRowWithKey = s.matching.nextRowWithKey[:n]
+	// Iterate backwards so that the tuples with the same key are linked in the
+	// increasing order.
+	for i := len(spans) - 1; i >= 0; i-- {
+		row, key := lookupRows[i], spans[i].Key
+		if first, ok := s.matching.keyToFirstRow[string(key)]; ok {
+			s.matching.nextRowWithKey[row] = first
+		} else {
+			s.matching.nextRowWithKey[row] = -1
+			keyMemUsage := memsize.MapEntryOverhead + memsize.String + memsize.Int + int64(len(key))
+			s.bufferAllocator.AdjustMemoryUsage(keyMemUsage)
+			s.matching.keysMemUsage += keyMemUsage
+		}
+		s.matching.keyToFirstRow[string(key)] = row
+	}
+
+	// Multiple tuples might have the same key, but each key needs to be looked
+	// up only once, so we sort the spans and remove the duplicates. Sorting
+	// also allows lower layers to optimize iteration over the data, and it
+	// doesn't affect the output ordering since the looked-up rows are matched
+	// with the tuples of the chunk explicitly.
+	sort.Sort(spans)
+	if len(spans) > 1 {
+		deduped := spans[:1]
+		for i := 1; i < len(spans); i++ {
+			if !spans[i].Key.Equal(deduped[len(deduped)-1].Key) {
+				deduped = append(deduped, spans[i])
+			}
+		}
+		spans = deduped
+	}
+	return spans
+}
+
+// resetMatches returns a slice of length n, reusing the given one if possible,
+// which indicates that none of the tuples of the chunk has matches.
+func resetMatches(matches []int, n int) []int {
+	if cap(matches) < n {
+		matches = make([]int, n)
+	}
+	matches = matches[:n]
+	for i := range matches {
+		matches[i] = -1
+	}
+	return matches
+}
+
+// lookupColsNullAt returns whether any of the lookup columns of the chunk tuple
+// at the given position is NULL.
+func (s *ColLookupJoin) lookupColsNullAt(idx int) bool {
+	for _, vec := range s.chunkLookupCols.ColVecs() {
+		if vec.Nulls().NullAt(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// setFetched sets the batch of looked-up rows to be processed and computes the
+// key encodings of those rows.
+func (s *ColLookupJoin) setFetched(batch coldata.Batch) {
+	n := batch.Length()
+	for i, colIdx := range s.fetchedKeyColIdxs {
+		s.lookupKeyCols.ReplaceCol(batch.ColVec(colIdx), i)
+	}
+	s.lookupKeyCols.SetLength(n)
+	s.lookupKeyAssembler.ConsumeBatch(s.lookupKeyCols, 0 /* startIdx */, n)
+	// The spans (and the memory they take up) are owned by the
+	// lookupKeyAssembler, and they will be reused on the next call to
+	// ConsumeBatch.
+	s.fetchedKeys = s.lookupKeyAssembler.GetSpans()
+	s.fetched = batch
+	s.fetchedIdx = 0
+	s.matchRow = -1
+	s.ordered.lastFetchedIdx = -1
+}
+
+// firstMatch returns the first tuple in the chunk with the same key as the
+// looked-up row at the given position, or -1 if there is no such tuple.
+func (s *ColLookupJoin) firstMatch(fetchedIdx int) int {
+	if first, ok := s.matching.keyToFirstRow[string(s.fetchedKeys[fetchedIdx].Key)]; ok {
+		return first
+	}
+	return -1
+}
+
+// markMatchedFetched marks all tuples in the chunk that match any of the rows
+// in the current batch of looked-up rows as matched.
+func (s *ColLookupJoin) markMatchedFetched() {
+	for ; s.fetchedIdx < s.fetched.Length(); s.fetchedIdx++ {
+		for row := s.firstMatch(s.fetchedIdx); row >= 0; row = s.matching.nextRowWithKey[row] {
+			if s.matching.matched[row] {
+				// All tuples with the same key are marked at once.
+				break
+			}
+			s.matching.matched[row] = true
+		}
+	}
+}
+
+// collectPairs populates s.pairs with at most coldata.BatchSize() joined
+// tuples formed from the current batch of looked-up rows.
+func (s *ColLookupJoin) collectPairs() {
+	s.pairs.inputSel = s.pairs.inputSel[:0]
+	s.pairs.lookupSel = s.pairs.lookupSel[:0]
+	maxPairs := coldata.BatchSize()
+	for s.fetchedIdx < s.fetched.Length() && len(s.pairs.inputSel) < maxPairs {
+		if s.matchRow < 0 {
+			s.matchRow = s.firstMatch(s.fetchedIdx)
+		}
+		for ; s.matchRow >= 0 && len(s.pairs.inputSel) < maxPairs; s.matchRow = s.matching.nextRowWithKey[s.matchRow] {
+			s.pairs.inputSel = append(s.pairs.inputSel, s.matchRow)
+			s.pairs.lookupSel = append(s.pairs.lookupSel, s.fetchedIdx)
+		}
+		if s.matchRow < 0 {
+			// All matches of the current looked-up row have been collected.
+			s.fetchedIdx++
+		}
+	}
+}
+
+// processPairs evaluates the ON expression on the joined tuples in s.pairs and
+// marks the tuples of the chunk that have a match. The output batch is returned
+// if the joined tuples have to be emitted right away.
+func (s *ColLookupJoin) processPairs() coldata.Batch {
+	var batch coldata.Batch
+	if s.outputsLookupCols && !s.buffersLookedUpRows {
+		s.output, _ = s.allocator.ResetMaybeReallocateNoMemLimit(s.ResultTypes, s.output, len(s.pairs.inputSel))
+		batch = s.output
+	} else if s.onExpr.op != nil {
+		s.pairs.batch, _ = s.allocator.ResetMaybeReallocateNoMemLimit(s.pairs.types, s.pairs.batch, len(s.pairs.inputSel))
+		batch = s.pairs.batch
+	}
+	if batch != nil {
+		s.populate(batch, s.pairs.inputSel, s.fetched, s.pairs.lookupSel, true /* withLookupCols */)
+		if s.onExpr.op != nil {
+			s.applyOnExpr(batch)
+		}
+	}
+
+	// Mark the tuples of the pairs that passed the ON expression as matched.
+	if batch != nil && batch.Selection() != nil {
+		for _, pairIdx := range batch.Selection()[:batch.Length()] {
+			s.matching.matched[s.pairs.inputSel[pairIdx]] = true
+		}
+	} else {
+		for _, row := range s.pairs.inputSel {
+			s.matching.matched[row] = true
+		}
+	}
+	if s.buffersLookedUpRows {
+		s.bufferMatches(batch)
+		return nil
+	}
+	if s.outputsLookupCols && s.output.Length() > 0 {
+		return s.output
+	}
+	return nil
+}
+
+// bufferMatches appends the joined tuples in s.pairs that passed the ON
+// expression, which was evaluated on the given batch (if non-nil), to the
+// matches of the tuples of the chunk, and buffers their looked-up rows.
+func (s *ColLookupJoin) bufferMatches(batch coldata.Batch) {
+	var sel []int
+	n := len(s.pairs.inputSel)
+	if batch != nil && batch.Selection() != nil {
+		sel = batch.Selection()[:batch.Length()]
+		n = len(sel)
+	}
+	// The pairs are ordered by the looked-up rows, so the rows to buffer form
+	// runs of consecutive rows of the fetched batch, each of which is
+	// appended at once.
+	var runStart, runEnd int
+	flush := func() {
+		if runEnd > runStart {
+			s.ordered.lookedUp.AppendTuples(s.Ctx, s.fetched, runStart, runEnd)
+		}
+	}
+	for i := 0; i < n; i++ {
+		pairIdx := i
+		if sel != nil {
+			pairIdx = sel[i]
+		}
+		if fetchedIdx := s.pairs.lookupSel[pairIdx]; fetchedIdx != s.ordered.lastFetchedIdx {
+			if fetchedIdx != runEnd {
+				flush()
+				runStart = fetchedIdx
+			}
+			runEnd = fetchedIdx + 1
+			s.ordered.lastFetchedIdx = fetchedIdx
+			s.ordered.lastLookedUpIdx = s.ordered.numLookedUp
+			s.ordered.numLookedUp++
+		}
+		row, match := s.pairs.inputSel[pairIdx], len(s.ordered.nextMatch)
+		s.ordered.nextMatch = append(s.ordered.nextMatch, -1)
+		s.ordered.matchLookedUpIdx = append(s.ordered.matchLookedUpIdx, s.ordered.lastLookedUpIdx)
+		if last := s.ordered.rowLastMatch[row]; last >= 0 {
+			s.ordered.nextMatch[last] = match
+		} else {
+			s.ordered.rowFirstMatch[row] = match
+		}
+		s.ordered.rowLastMatch[row] = match
+	}
+	flush()
+	s.accountForScratch()
+}
+
+// applyOnExpr evaluates the ON expression on the joined tuples in the given
+// batch and sets the selection vector on it to include only the tuples that
+// passed.
+func (s *ColLookupJoin) applyOnExpr(batch coldata.Batch) {
+	n := batch.Length()
+	for i := range s.pairs.types {
+		s.onExpr.batch.ReplaceCol(batch.ColVec(i), i)
+	}
+	s.onExpr.batch.SetLength(n)
+	s.onExpr.feed.SetBatch(s.onExpr.batch)
+	result := s.onExpr.op.Next().ColVec(s.onExpr.resultIdx)
+	bools, nulls := result.Bool(), result.Nulls()
+	batch.SetSelection(true)
+	sel := batch.Selection()
+	var idx int
+	for i := 0; i < n; i++ {
+		if bools.Get(i) && !nulls.NullAt(i) {
+			sel[idx] = i
+			idx++
+		}
+	}
+	batch.SetLength(idx)
+}
+
+// finishChunk is called once all lookups for the current chunk have been
+// performed. It determines the tuples that have to be emitted without a
+// looked-up row for the chunk.
+func (s *ColLookupJoin) finishChunk() {
+	n := s.chunk.Length()
+	if s.buffersLookedUpRows {
+		// The NULL-extended tuples are emitted along with the joined tuples.
+		s.ordered.emitRow = 0
+		s.ordered.emitMatch = s.ordered.rowFirstMatch[0]
+	} else if s.joinType != descpb.InnerJoin {
+		semi := s.joinType == descpb.LeftSemiJoin
+		if !s.pairedJoiner {
+			for row := 0; row < n; row++ {
+				if s.matching.matched[row] == semi {
+					s.emitInputSel = append(s.emitInputSel, row)
+				}
+			}
+		} else {
+			// A left outer or anti join emits the last tuple of each group
+			// that doesn't have a match while a semi join emits the first
+			// tuple with a match.
+			for groupStart := 0; groupStart < n; {
+				groupEnd := groupStart + 1
+				for groupEnd < n && s.isContinuation(s.chunk, groupEnd) {
+					groupEnd++
+				}
+				firstMatched := -1
+				for row := groupStart; row < groupEnd; row++ {
+					if s.matching.matched[row] {
+						firstMatched = row
+						break
+					}
+				}
+				if semi && firstMatched >= 0 {
+					s.emitInputSel = append(s.emitInputSel, firstMatched)
+				} else if !semi && firstMatched < 0 {
+					s.emitInputSel = append(s.emitInputSel, groupEnd-1)
+				}
+				groupStart = groupEnd
+			}
+		}
+	}
+	s.accountForScratch()
+}
+
+// emitOrdered emits the next batch of joined tuples of the chunk, in the order
+// of the chunk tuples, when the looked-up rows are buffered. The NULL-extended
+// tuples of left outer joins are emitted after the matches of their tuple (or
+// group, for the second join of paired joins). It returns nil once all tuples
+// have been emitted.
+func (s *ColLookupJoin) emitOrdered() coldata.Batch {
+	n := s.chunk.Length()
+	inputSel := s.ordered.inputSel[:0]
+	lookedUpSel := s.ordered.lookedUpSel[:0]
+	continuation := s.ordered.continuation[:0]
+	emit := func(row, lookedUpIdx int, cont bool) {
+		inputSel = append(inputSel, row)
+		lookedUpSel = append(lookedUpSel, lookedUpIdx)
+		continuation = append(continuation, cont)
+	}
+	for s.ordered.emitRow < n && len(inputSel) < coldata.BatchSize() {
+		row := s.ordered.emitRow
+		if match := s.ordered.emitMatch; match >= 0 {
+			emit(row, s.ordered.matchLookedUpIdx[match], match != s.ordered.rowFirstMatch[row])
+			s.ordered.emitMatch = s.ordered.nextMatch[match]
+			continue
+		}
+		// All matches of the tuple have been emitted.
+		if s.joinType == descpb.LeftOuterJoin {
+			matched := s.matching.matched[row]
+			if !s.pairedJoiner {
+				if !matched {
+					emit(row, -1 /* lookedUpIdx */, false /* cont */)
+				}
+			} else {
+				// Only the last tuple of a group without any matches is
+				// NULL-extended.
+				s.ordered.groupMatched = s.ordered.groupMatched || matched
+				if row == n-1 || !s.isContinuation(s.chunk, row+1) {
+					if !s.ordered.groupMatched {
+						emit(row, -1 /* lookedUpIdx */, false /* cont */)
+					}
+					s.ordered.groupMatched = false
+				}
+			}
+		}
+		s.ordered.emitRow++
+		if s.ordered.emitRow < n {
+			s.ordered.emitMatch = s.ordered.rowFirstMatch[s.ordered.emitRow]
+		}
+	}
+	s.ordered.inputSel, s.ordered.lookedUpSel, s.ordered.continuation = inputSel, lookedUpSel, continuation
+	s.accountForScratch()
+	if len(inputSel) == 0 {
+		return nil
+	}
+
+	numTuples := len(inputSel)
+	numInputCols := len(s.inputTypes)
+	s.output, _ = s.allocator.ResetMaybeReallocateNoMemLimit(s.ResultTypes, s.output, numTuples)
+	s.allocator.PerformOperation(s.output.ColVecs(), func() {
+		for i := 0; i < numInputCols; i++ {
+			s.output.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       s.chunk.ColVec(i),
+				Sel:       inputSel,
+				SrcEndIdx: numTuples,
+			})
+		}
+		for j := 0; j < s.numFetchedCols; j++ {
+			s.copyLookedUpCol(s.output.ColVec(numInputCols+j), j, lookedUpSel)
+		}
+		if s.outputContinuation {
+			cont := s.output.ColVec(numInputCols + s.numFetchedCols).Bool()
+			for i, c := range continuation {
+				cont.Set(i, c)
+			}
+		}
+	})
+	s.output.SetLength(numTuples)
+	return s.output
+}
+
+// copyLookedUpCol copies the given column of the buffered looked-up rows in
+// lookedUpSel into dst. The rows for which lookedUpSel is -1 are set to NULL.
+func (s *ColLookupJoin) copyLookedUpCol(dst *coldata.Vec, colIdx int, lookedUpSel []int) {
+	for i := 0; i < len(lookedUpSel); {
+		idx := lookedUpSel[i]
+		if idx < 0 {
+			dst.Nulls().SetNull(i)
+			i++
+			continue
+		}
+		src, srcIdx, srcLen := s.ordered.lookedUp.GetVecWithTuple(s.Ctx, colIdx, idx)
+		// Copy the run of consecutive looked-up rows that are in the same
+		// vector at once.
+		runLen := 1
+		for i+runLen < len(lookedUpSel) && lookedUpSel[i+runLen] == idx+runLen && srcIdx+runLen < srcLen {
+			runLen++
+		}
+		dst.Copy(coldata.SliceArgs{
+			Src:         src,
+			DestIdx:     i,
+			SrcStartIdx: srcIdx,
+			SrcEndIdx:   srcIdx + runLen,
+		})
+		i += runLen
+	}
+}
+
+// populate sets the tuples formed by the chunk tuples in inputSel and the rows
+// of lookupBatch in lookupSel on the given batch. If lookupBatch is nil, the
+// looked-up columns are NULL.
+func (s *ColLookupJoin) populate(
+	batch coldata.Batch, inputSel []int, lookupBatch coldata.Batch, lookupSel []int, withLookupCols bool,
+) {
+	n := len(inputSel)
+	numInputCols := len(s.inputTypes)
+	s.allocator.PerformOperation(batch.ColVecs(), func() {
+		for i := 0; i < numInputCols; i++ {
+			batch.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       s.chunk.ColVec(i),
+				Sel:       inputSel,
+				SrcEndIdx: n,
+			})
+		}
+		if !withLookupCols {
+			return
+		}
+		for j := 0; j < s.numFetchedCols; j++ {
+			if lookupBatch == nil {
+				batch.ColVec(numInputCols+j).Nulls().SetNullRange(0, n)
+				continue
+			}
+			batch.ColVec(numInputCols + j).Copy(coldata.SliceArgs{
+				Src:       lookupBatch.ColVec(j),
+				Sel:       lookupSel,
+				SrcEndIdx: n,
+			})
+		}
+	})
+	batch.SetLength(n)
+}
+
+// accountForScratch updates the memory account to reflect the size of the
+// scratch slices used by the lookup joiner.
+func (s *ColLookupJoin) accountForScratch() {
+	newMemUsage := memsize.Int*int64(cap(s.matching.nextRowWithKey)+cap(s.matching.lookupRows)+
+		cap(s.emitInputSel)+cap(s.pairs.inputSel)+cap(s.pairs.lookupSel)+
+		cap(s.ordered.rowFirstMatch)+cap(s.ordered.rowLastMatch)+cap(s.ordered.nextMatch)+
+		cap(s.ordered.matchLookedUpIdx)+cap(s.ordered.inputSel)+cap(s.ordered.lookedUpSel)) +
+		memsize.Bool*int64(cap(s.matching.matched)+cap(s.ordered.continuation))
+	s.bufferAllocator.AdjustMemoryUsage(newMemUsage - s.scratchMemUsage)
+	s.scratchMemUsage = newMemUsage
+}
+
+// DrainMeta is part of the colexecop.MetadataSource interface.
+func (s *ColLookupJoin) DrainMeta() []execinfrapb.ProducerMetadata {
+	var trailingMeta []execinfrapb.ProducerMetadata
+	if tfs := execinfra.GetLeafTxnFinalState(s.Ctx, s.txn); tfs != nil {
+		trailingMeta = append(trailingMeta, execinfrapb.ProducerMetadata{LeafTxnFinalState: tfs})
+	}
+	meta := execinfrapb.GetProducerMeta()
+	meta.Metrics = execinfrapb.GetMetricsMeta()
+	meta.Metrics.BytesRead = s.GetBytesRead()
+	meta.Metrics.RowsRead = s.GetRowsRead()
+	trailingMeta = append(trailingMeta, *meta)
+	if !s.flowCtx.Gateway {
+		if trace := tracing.SpanFromContext(s.Ctx).GetConfiguredRecording(); trace != nil {
+			trailingMeta = append(trailingMeta, execinfrapb.ProducerMetadata{TraceData: trace})
+		}
+	}
+	return trailingMeta
+}
+
+// GetBytesRead is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) GetBytesRead() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cf.getBytesRead()
+}
+
+// GetKVPairsRead is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) GetKVPairsRead() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cf.getKVPairsRead()
+}
+
+// GetRowsRead is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) GetRowsRead() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.rowsRead
+}
+
+// GetBatchRequestsIssued is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) GetBatchRequestsIssued() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cf.getBatchRequestsIssued()
+}
+
+// GetKVCPUTime is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) GetKVCPUTime() time.Duration {
+	return s.cf.cpuStopWatch.Elapsed()
+}
+
+// UsedStreamer is part of the colexecop.KVReader interface.
+func (s *ColLookupJoin) UsedStreamer() bool {
+	return s.usesStreamer
+}
+
+// LookedUpRowsBufferArgs contains the arguments for buffering the looked-up
+// rows when the ColLookupJoin has to (see BuffersLookedUpRows).
+type LookedUpRowsBufferArgs struct {
+	// Allocator must use an unlimited memory account that is not used by
+	// anything else.
+	Allocator       *colmem.Allocator
+	DiskQueueCfg    colcontainer.DiskQueueCfg
+	FDSemaphore     semaphore.Semaphore
+	DiskAcc         *mon.BoundAccount
+	DiskQueueMemAcc *mon.BoundAccount
+}
+
+// BuffersLookedUpRows returns whether the ColLookupJoin executing the given
+// lookup join has to buffer the looked-up rows, which is the case for the
+// inner and left outer joins that maintain the ordering.
+func BuffersLookedUpRows(spec *execinfrapb.JoinReaderSpec) bool {
+	return spec.MaintainOrdering && spec.Type.ShouldIncludeRightColsInOutput()
+}
+
+// NewColLookupJoin creates a new ColLookupJoin operator.
+//
+// allocator is used for the output batches and the span assemblers whereas
+// bufferAllocator (which must use an unlimited memory account) is used for
+// buffering the input tuples, whose size is limited by the chunk size.
+// onExprPlanner must be non-nil if the spec contains an ON expression, and
+// lookedUpRowsArgs must be non-nil if BuffersLookedUpRows returns true.
+func NewColLookupJoin(
+	ctx context.Context,
+	allocator *colmem.Allocator,
+	fetcherAllocator *colmem.Allocator,
+	bufferAllocator *colmem.Allocator,
+	kvFetcherMemAcc *mon.BoundAccount,
+	streamerBudgetAcc *mon.BoundAccount,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	input colexecop.Operator,
+	spec *execinfrapb.JoinReaderSpec,
+	post *execinfrapb.PostProcessSpec,
+	inputTypes []*types.T,
+	diskMonitor *mon.BytesMonitor,
+	typeResolver *descs.DistSQLTypeResolver,
+	onExprPlanner OnExprPlanner,
+	lookedUpRowsArgs *LookedUpRowsBufferArgs,
+) (*ColLookupJoin, error) {
+	// NB: we hit this with a zero NodeID (but !ok) with multi-tenancy.
+	if nodeID, ok := flowCtx.NodeID.OptionalNodeID(); nodeID == 0 && ok {
+		return nil, errors.Errorf("attempting to create a ColLookupJoin with uninitialized NodeID")
+	}
+	if err := CanPlanLookupJoin(spec); err != nil {
+		return nil, errors.NewAssertionErrorWithWrappedErrf(err, "unexpectedly planning ColLookupJoin")
+	}
+	if !spec.OnExpr.Empty() && onExprPlanner == nil {
+		return nil, errors.AssertionFailedf("ON expression planner is not provided")
+	}
+	buffersLookedUpRows := BuffersLookedUpRows(spec)
+	if buffersLookedUpRows && lookedUpRowsArgs == nil {
+		return nil, errors.AssertionFailedf("arguments for buffering the looked-up rows are not provided")
+	}
+
+	// Make sure that all key columns that we match on are fetched. The
+	// additional columns are appended after the columns that are part of the
+	// output.
+	fetchSpec := spec.FetchSpec
+	numFetchedCols := len(fetchSpec.FetchedColumns)
+	keyCols := fetchSpec.KeyFullColumns()[:len(spec.LookupColumns)]
+	fetchedKeyColIdxs := make([]int, len(keyCols))
+	lookupKeyTypes := make([]*types.T, len(keyCols))
+	for i := range keyCols {
+		fetchedKeyColIdxs[i] = -1
+		for j := range fetchSpec.FetchedColumns {
+			if fetchSpec.FetchedColumns[j].ColumnID == keyCols[i].ColumnID {
+				fetchedKeyColIdxs[i] = j
+				break
+			}
+		}
+		if fetchedKeyColIdxs[i] < 0 {
+			if len(fetchSpec.FetchedColumns) == numFetchedCols {
+				// Make sure not to modify the original spec.
+				fetchSpec.FetchedColumns = append(
+					fetchSpec.FetchedColumns[:numFetchedCols:numFetchedCols], keyCols[i].IndexFetchSpec_Column,
+				)
+			} else {
+				fetchSpec.FetchedColumns = append(fetchSpec.FetchedColumns, keyCols[i].IndexFetchSpec_Column)
+			}
+			fetchedKeyColIdxs[i] = len(fetchSpec.FetchedColumns) - 1
+		}
+		lookupKeyTypes[i] = keyCols[i].Type
+	}
+
+	tableArgs, err := populateTableArgs(ctx, &fetchSpec, typeResolver, false /* allowUnhydratedEnums */)
+	if err != nil {
+		return nil, err
+	}
+
+	totalMemoryLimit := execinfra.GetWorkMemLimit(flowCtx)
+	cFetcherMemoryLimit := totalMemoryLimit
+	lookedUpRowsMemoryLimit := totalMemoryLimit
+
+	var kvFetcher *row.KVFetcher
+	useStreamer, txn, err := flowCtx.UseStreamer()
+	if err != nil {
+		return nil, err
+	}
+	var limitBatches bool
+	batchBytesLimit := rowinfra.NoBytesLimit
+	if useStreamer {
+		if streamerBudgetAcc == nil {
+			return nil, errors.AssertionFailedf("streamer budget account is nil when the Streamer API is desired")
+		}
+		// Keep 1/16th of the memory limit for the output batch of the cFetcher,
+		// another 1/16th of the limit for the input tuples buffered by the
+		// lookup joiner, and we'll give the remaining memory to the streamer
+		// budget below.
+		cFetcherMemoryLimit = int64(math.Ceil(float64(totalMemoryLimit) / 16.0))
+		streamerBudgetLimit := 14 * cFetcherMemoryLimit
+		if buffersLookedUpRows {
+			// Take a quarter of the memory limit from the streamer budget
+			// for the looked-up rows buffered by the lookup joiner.
+			lookedUpRowsMemoryLimit = 4 * cFetcherMemoryLimit
+			streamerBudgetLimit -= lookedUpRowsMemoryLimit
+		}
+		// The looked-up rows are matched with the input tuples explicitly, so
+		// the streamer only needs to preserve the ordering of the spans when
+		// the looked-up rows of each input tuple must be output in the index
+		// order.
+		maintainOrdering := spec.MaintainLookupOrdering
+		if flowCtx.EvalCtx.SessionData().StreamerAlwaysMaintainOrdering {
+			maintainOrdering = true
+		}
+		if maintainOrdering && diskMonitor == nil {
+			return nil, errors.AssertionFailedf("diskMonitor is nil when ordering needs to be maintained")
+		}
+		kvFetcher = row.NewStreamingKVFetcher(
+			flowCtx.Cfg.DistSender,
+			flowCtx.Stopper(),
+			txn,
+			flowCtx.Cfg.Settings,
+			flowCtx.EvalCtx.SessionData(),
+			spec.LockingWaitPolicy,
+			spec.LockingStrength,
+			spec.LockingDurability,
+			streamerBudgetLimit,
+			streamerBudgetAcc,
+			maintainOrdering,
+			spec.LookupColumnsAreKey, /* singleRowLookup */
+			int(fetchSpec.MaxKeysPerRow),
+			rowcontainer.NewKVStreamerResultDiskBuffer(
+				flowCtx.Cfg.TempStorage, diskMonitor,
+			),
+			kvFetcherMemAcc,
+			fetchSpec.External,
+		)
+	} else {
+		// Similar to the row-by-row joinReader, we choose DistSender-level
+		// parallelism when each lookup returns at most one row and use the
+		// limits otherwise.
+		limitBatches = !spec.LookupColumnsAreKey
+		if flowCtx.EvalCtx.SessionData().ParallelizeMultiKeyLookupJoinsEnabled {
+			limitBatches = false
+		}
+		if spec.MaintainLookupOrdering {
+			// We need to disable parallelism for the traditional fetcher in
+			// order to ensure the lookups are ordered.
+			limitBatches = true
+		}
+		if limitBatches {
+			batchBytesLimit = rowinfra.BytesLimit(spec.LookupBatchBytesLimit)
+			if batchBytesLimit == 0 {
+				batchBytesLimit = rowinfra.GetDefaultBatchBytesLimit(flowCtx.EvalCtx.TestingKnobs.ForceProductionValues)
+			}
+		}
+		kvFetcher = row.NewKVFetcher(
+			txn,
+			nil,   /* bsHeader */
+			false, /* reverse */
+			spec.LockingStrength,
+			spec.LockingWaitPolicy,
+			spec.LockingDurability,
+			flowCtx.EvalCtx.SessionData().LockTimeout,
+			flowCtx.EvalCtx.SessionData().DeadlockTimeout,
+			kvFetcherMemAcc,
+			flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
+			fetchSpec.External,
+		)
+	}
+
+	fetcher := cFetcherPool.Get().(*cFetcher)
+	fetcher.cFetcherArgs = cFetcherArgs{
+		cFetcherMemoryLimit,
+		// Note that the estimated row count will be set by the lookup joiner
+		// for each set of spans to read.
+		0, /* estimatedRowCount */
+		flowCtx.TraceKV,
+		false, /* singleUse */
+		execstats.ShouldCollectStats(ctx, flowCtx.CollectStats),
+		false, /* alwaysReallocate */
+	}
+	if err = fetcher.Init(
+		fetcherAllocator, kvFetcher, tableArgs,
+	); err != nil {
+		fetcher.Release()
+		return nil, err
+	}
+
+	lookupColTypes := make([]*types.T, len(spec.LookupColumns))
+	for i, colIdx := range spec.LookupColumns {
+		lookupColTypes[i] = inputTypes[colIdx]
+	}
+	fetchedTypes := tableArgs.typs[:numFetchedCols]
+	var lookedUp *colexecutils.SpillingBuffer
+	if buffersLookedUpRows {
+		// Only the fetched columns that are part of the output are buffered.
+		lookedUpColIdxs := make([]int, numFetchedCols)
+		for i := range lookedUpColIdxs {
+			lookedUpColIdxs[i] = i
+		}
+		lookedUp = colexecutils.NewSpillingBuffer(
+			lookedUpRowsArgs.Allocator, lookedUpRowsMemoryLimit, lookedUpRowsArgs.DiskQueueCfg,
+			lookedUpRowsArgs.FDSemaphore, tableArgs.typs, lookedUpRowsArgs.DiskAcc,
+			lookedUpRowsArgs.DiskQueueMemAcc, lookedUpColIdxs...,
+		)
+	}
+	pairTypes := make([]*types.T, 0, len(inputTypes)+numFetchedCols+1)
+	pairTypes = append(pairTypes, inputTypes...)
+	pairTypes = append(pairTypes, fetchedTypes...)
+
+	op := &ColLookupJoin{
+		OneInputNode:        colexecop.NewOneInputNode(input),
+		joinType:            spec.Type,
+		pairedJoiner:        spec.LeftJoinWithPairedJoiner,
+		outputContinuation:  spec.OutputGroupContinuationForLeftRow,
+		outputsLookupCols:   spec.Type.ShouldIncludeRightColsInOutput(),
+		buffersLookedUpRows: buffersLookedUpRows,
+		inputTypes:          inputTypes,
+		lookupCols:          spec.LookupColumns,
+		numFetchedCols:      numFetchedCols,
+		spanAssembler: colexecspan.NewColLookupSpanAssembler(
+			flowCtx.Codec(), allocator, &fetchSpec, lookupColTypes,
+		),
+		lookupKeyAssembler: colexecspan.NewColLookupSpanAssembler(
+			flowCtx.Codec(), allocator, &fetchSpec, lookupKeyTypes,
+		),
+		chunkLookupCols:   coldata.NewMemBatchNoCols(lookupColTypes, 0 /* capacity */),
+		lookupKeyCols:     coldata.NewMemBatchNoCols(lookupKeyTypes, 0 /* capacity */),
+		fetchedKeyColIdxs: fetchedKeyColIdxs,
+		limitHintHelper:   execinfra.MakeLimitHintHelper(spec.LimitHint, post),
+		chunk:             colexecutils.NewAppendOnlyBufferedBatch(bufferAllocator, inputTypes, nil /* colsToStore */),
+		allocator:         allocator,
+		bufferAllocator:   bufferAllocator,
+		flowCtx:           flowCtx,
+		processorID:       processorID,
+		cf:                fetcher,
+		txn:               txn,
+		limitBatches:      limitBatches,
+		batchBytesLimit:   batchBytesLimit,
+		usesStreamer:      useStreamer,
+	}
+	if spec.LookupColumnsAreKey {
+		op.estimatedRowsPerSpan = 1
+	}
+	op.matching.keyToFirstRow = make(map[string]int)
+	op.ordered.lookedUp = lookedUp
+	op.pairs.types = pairTypes
+	if op.outputContinuation {
+		op.ResultTypes = append(pairTypes, types.Bool)
+	} else if op.outputsLookupCols {
+		op.ResultTypes = pairTypes
+	} else {
+		op.ResultTypes = inputTypes
+	}
+	if !spec.OnExpr.Empty() {
+		op.onExpr.feed = colexecop.NewFeedOperator()
+		op.onExpr.batch = coldata.NewMemBatchNoCols(pairTypes, coldata.BatchSize())
+		op.onExpr.op, op.onExpr.resultIdx, err = onExprPlanner(
+			op.onExpr.feed, pairTypes[:len(pairTypes):len(pairTypes)],
+		)
+		if err != nil {
+			op.Release()
+			return nil, err
+		}
+	}
+
+	op.inputBatchSizeLimit = getIndexJoinBatchSize(
+		useStreamer, flowCtx.EvalCtx.TestingKnobs.ForceProductionValues, flowCtx.EvalCtx.SessionData(),
+	)
+	if useStreamer && cFetcherMemoryLimit < op.inputBatchSizeLimit {
+		// If we have a low workmem limit, then we want to reduce the input
+		// batch size limit (see the comment in NewColIndexJoin for more
+		// details).
+		op.inputBatchSizeLimit = cFetcherMemoryLimit
+	}
+
+	return op, nil
+}
+
+// CanPlanLookupJoin returns an error if the lookup join described by the spec
+// cannot be executed by the ColLookupJoin.
+func CanPlanLookupJoin(spec *execinfrapb.JoinReaderSpec) error {
+	if spec.IsIndexJoin() {
+		return errors.New("index joins are executed by the ColIndexJoin")
+	}
+	if !spec.LookupExpr.Empty() || !spec.RemoteLookupExpr.Empty() {
+		return errors.New("lookup joins with lookup expressions are not supported")
+	}
+	switch spec.Type {
+	case descpb.InnerJoin, descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+	default:
+		return errors.Newf("%s lookup join is not supported", spec.Type)
+	}
+	if spec.OutputGroupContinuationForLeftRow {
+		if spec.LeftJoinWithPairedJoiner {
+			return errors.New("a lookup join cannot be both joins of paired joins")
+		}
+		// The continuation column is only meaningful if the joined tuples of
+		// each input tuple are emitted together.
+		if !BuffersLookedUpRows(spec) {
+			return errors.New("the first lookup join of paired joins must maintain the ordering")
+		}
+	}
+	if len(spec.LookupColumns) > len(spec.FetchSpec.KeyFullColumns()) {
+		return errors.New("more lookup columns than key columns")
+	}
+	return nil
+}
+
+// Release implements the execinfra.Releasable interface.
+func (s *ColLookupJoin) Release() {
+	s.cf.Release()
+	s.spanAssembler.Release()
+	s.lookupKeyAssembler.Release()
+	*s = ColLookupJoin{}
+}
+
+// Close implements the colexecop.Closer interface.
+func (s *ColLookupJoin) Close(context.Context) error {
+	s.closeInternal()
+	if s.tracingSpan != nil {
+		s.tracingSpan.Finish()
+		s.tracingSpan = nil
+	}
+	return nil
+}
+
+// closeInternal is a subset of Close() which doesn't finish the operator's
+// span.
+func (s *ColLookupJoin) closeInternal() {
+	// Note that we're using the context of the ColLookupJoin rather than the
+	// argument of Close() because the ColLookupJoin derives its own tracing
+	// span.
+	ctx := s.EnsureCtx()
+	s.cf.Close(ctx)
+	s.spanAssembler.Close()
+	s.lookupKeyAssembler.Close()
+	if s.ordered.lookedUp != nil {
+		s.ordered.lookedUp.Close(ctx)
+	}
+	s.batch = nil
+	s.fetched = nil
+}
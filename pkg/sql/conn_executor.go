@@ -368,6 +368,10 @@ type Server struct {
 
 	idxRecommendationsCache *idxrecommendations.IndexRecCache
 
+	// notifications delivers the notifications sent with NOTIFY to the sessions
+	// of this node listening on their channels.
+	notifications *notificationRegistry
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount     int64
@@ -464,6 +468,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 		idxRecommendationsCache: idxrecommendations.NewIndexRecommendationsCache(cfg.Settings),
+		notifications:           newNotificationRegistry(cfg.Stopper, cfg.SQLStatusServer),
 	}
 
 	telemetryLoggingMetrics := newTelemetryLoggingMetrics(cfg.TelemetryLoggingTestingKnobs, cfg.Settings)
@@ -625,6 +630,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.reportedStats.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.startAdvisoryLockReaper(ctx, stopper)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -667,6 +674,18 @@ func (s *Server) GetTxnIDCache() *txnidcache.Cache {
 	return s.txnIDCache
 }
 
+// DeliverNotifications delivers the notifications forwarded by another node to
+// the sessions of this node listening on their channels. If this node missed
+// earlier notifications from that node, the listening sessions are terminated.
+func (s *Server) DeliverNotifications(
+	ctx context.Context, req *serverpb.NotifyListenersRequest,
+) {
+	s.notifications.receive(ctx, req.SenderNodeID, notificationBatchID{
+		epoch: req.SenderEpoch,
+		seq:   req.Seq,
+	}, req.Notifications)
+}
+
 // GetScrubbedStmtStats returns the statement statistics by app, with the
 // queries scrubbed of their identifiers. Any statements which cannot be
 // scrubbed will be omitted from the returned map.
@@ -1170,17 +1189,32 @@ func (s *Server) newConnExecutor(
 		portals:      make(map[string]PreparedPortal),
 	}
 	ex.extraTxnState.prepStmtsNamespaceMemAcc = ex.sessionMon.MakeBoundAccount()
+	ex.extraTxnState.deferredConstraints.acc = ex.sessionMon.MakeBoundAccount()
 	dsdp := catsessiondata.NewDescriptorSessionDataStackProvider(sdMutIterator.sds)
 	ex.extraTxnState.descCollection = s.cfg.CollectionFactory.NewCollection(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
 	)
 	ex.extraTxnState.jobs = newTxnJobsCollection()
+	ex.extraTxnState.tempTableCommitActions = &tempTableCommitActions{}
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 		mode:   ex.sessionData().NewSchemaChangerMode,
 		memAcc: ex.sessionMon.MakeBoundAccount(),
 	}
 	ex.queryCancelKey = pgwirecancel.MakeBackendKeyData(ex.rng.internal, ex.server.cfg.NodeInfo.NodeID.SQLInstanceID())
+	ex.notifications = newNotificationManager(
+		s.notifications, ex.queryCancelKey.GetPGBackendPID(),
+		func(ctx context.Context) {
+			// Internal executors have no client to deliver notifications to.
+			if ex.executorType != executorTypeInternal {
+				_ = ex.stmtBuf.Push(ctx, DeliverNotifications{})
+			}
+		},
+	)
+	ex.advisoryLocks = newAdvisoryLockManager(
+		s.cfg.InternalDB, s.cfg.Settings, s.cfg.SQLLiveness, s.cfg.SessionRegistry, sessionID,
+		ex.queryCancelKey.GetPGBackendPID(), ex.sessionData,
+	)
 	ex.mu.ActiveQueries = make(map[clusterunique.ID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -1300,6 +1334,8 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType}, payloadErr)
+	ex.advisoryLocks.close(ctx)
+	ex.notifications.close()
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1327,6 +1363,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
+		ex.extraTxnState.deferredConstraints.acc.Close(ctx)
 	}
 
 	if ex.sessionTracing.Enabled() {
@@ -1468,6 +1505,14 @@ type connExecutor struct {
 		// validateDbZoneConfig should the DB zone config on commit.
 		validateDbZoneConfig bool
 
+		// deferredConstraints tracks the deferrable constraints whose checks are
+		// postponed until the transaction commits.
+		deferredConstraints deferredConstraints
+
+		// tempTableCommitActions tracks the temporary tables with ON COMMIT
+		// actions that are applied when the transaction commits.
+		tempTableCommitActions *tempTableCommitActions
+
 		// txnCounter keeps track of how many SQL txns have been open since
 		// the start of the session. This is used for logging, to
 		// distinguish statements that belong to separate SQL transactions.
@@ -1574,6 +1619,7 @@ type connExecutor struct {
 		rewindPosSnapshot struct {
 			savepoints       savepointStack
 			sessionDataStack *sessiondata.Stack
+			notifications    notificationSavepoint
 		}
 		// transactionStatementFingerprintIDs tracks all statement IDs that make up the current
 		// transaction. It's length is bound by the TxnStatsNumStmtFingerprintIDsToRecord
@@ -1757,6 +1803,14 @@ type connExecutor struct {
 	// temporary schema, which requires special cleanup on close.
 	hasCreatedTemporarySchema bool
 
+	// advisoryLocks manages the advisory locks held by the session. They are
+	// released on close.
+	advisoryLocks *advisoryLockManager
+
+	// notifications manages the notification channels the session listens on
+	// and the notifications it sends and receives.
+	notifications *notificationManager
+
 	// stmtDiagnosticsRecorder is used to track which queries need to have
 	// information collected.
 	stmtDiagnosticsRecorder *stmtdiagnostics.Registry
@@ -2069,6 +2123,16 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	ex.extraTxnState.upgradedToSerializable = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	ex.extraTxnState.deferredConstraints.reset(ctx)
+	ex.advisoryLocks.releaseXactLocks(ctx)
+	// On a restart, the notifications are rolled back to the savepoint or to
+	// the rewind position from which the transaction is retried.
+	switch ev.eventType {
+	case txnCommit:
+		ex.notifications.commit(ctx)
+	case txnRollback:
+		ex.notifications.rollback()
+	}
 
 	if ex.extraTxnState.skipResettingSchemaObjects {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -2077,6 +2141,7 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	} else {
 		ex.extraTxnState.descCollection.ReleaseAll(ctx)
 		ex.extraTxnState.jobs.reset()
+		ex.extraTxnState.tempTableCommitActions.reset()
 		ex.extraTxnState.validateDbZoneConfig = false
 		ex.extraTxnState.schemaChangerState.memAcc.Clear(ctx)
 		ex.extraTxnState.schemaChangerState = &SchemaChangerState{
@@ -2240,8 +2305,8 @@ func (ex *connExecutor) run(
 
 		var err error
 		if err = ex.execCmd(); err != nil {
-			// Both of these errors are normal ways for the connExecutor to exit.
-			if errors.IsAny(err, io.EOF, errDrainingComplete) {
+			// These errors are normal ways for the connExecutor to exit.
+			if errors.IsAny(err, io.EOF, errDrainingComplete, errNotificationsLost) {
 				return nil
 			}
 			return err
@@ -2523,6 +2588,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2536,6 +2609,26 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// A session that missed notifications is terminated right away, even in
+		// a transaction, so its client can resynchronize.
+		if ex.notifications.lost() {
+			errRes := ex.clientComm.CreateErrorResult(pos)
+			errRes.SetError(errNotificationsLost)
+			errRes.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
+			if err := ex.clientComm.Flush(pos); err != nil {
+				return err
+			}
+			return errNotificationsLost
+		}
+		// Notifications are only delivered outside of transactions. If one is
+		// open, they'll be delivered by the Sync that follows its end.
+		res = ex.clientComm.CreateFlushResult(pos)
+		if ex.idleConn() {
+			if err := ex.bufferNotifications(); err != nil {
+				return err
+			}
+		}
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2625,6 +2718,13 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// As in Postgres, the notifications received by the session are
+		// delivered before the ReadyForQuery message that ends a transaction.
+		if _, ok := cmd.(Sync); ok && ex.idleConn() {
+			if err := ex.bufferNotifications(); err != nil {
+				return err
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2657,6 +2757,7 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		// Note we use the Replace function instead of reassigning, as there are
 		// copies of the ex.sessionDataStack in the iterators and extendedEvalContext.
 		ex.sessionDataStack.Replace(ex.extraTxnState.rewindPosSnapshot.sessionDataStack)
+		ex.notifications.rollbackToSavepoint(ex.extraTxnState.rewindPosSnapshot.notifications)
 		advInfo.rewCap.rewindAndUnlock(ctx)
 	case stayInPlace:
 		// Nothing to do. The same statement will be executed again.
@@ -2703,6 +2804,17 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	return nil
 }
 
+// bufferNotifications buffers the notifications received by the session, which
+// are delivered to the client with the next flush.
+func (ex *connExecutor) bufferNotifications() error {
+	for _, n := range ex.notifications.takeQueued() {
+		if err := ex.clientComm.BufferNotification(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ex *connExecutor) idleConn() bool {
 	switch ex.machine.CurState().(type) {
 	case stateNoTxn:
@@ -2801,10 +2913,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				canAdvance = true
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -2831,6 +2947,7 @@ func (ex *connExecutor) setTxnRewindPos(ctx context.Context, pos CmdPos) error {
 	ex.stmtBuf.Ltrim(ctx, pos)
 	ex.extraTxnState.rewindPosSnapshot.savepoints = ex.extraTxnState.savepoints.clone()
 	ex.extraTxnState.rewindPosSnapshot.sessionDataStack = ex.sessionDataStack.Clone()
+	ex.extraTxnState.rewindPosSnapshot.notifications = ex.notifications.savepoint()
 	return ex.commitPrepStmtNamespace(ctx)
 }
 
@@ -3081,6 +3198,48 @@ func (ex *connExecutor) setCopyLoggingFields(stmt statements.Statement[tree.Stat
 // when this is called, the pgwire.conn is not reading from the network
 // connection any more until this returns. The copyMachine will do the reading
 // and writing up to the CommandComplete message.
+// execStartReplication streams the changes of the tables in the requested
+// publications to the client until the client ends the stream or the
+// connection is closed. Unlike COPY, the stream does not run in a transaction
+// of the session.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res ReplicationResult,
+) (retEv fsm.Event, retPayload fsm.EventPayload) {
+	// When we're done, unblock the network connection.
+	defer cmd.ReplicationDone.Once.Do(cmd.ReplicationDone.WaitGroup.Done)
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{
+			err: pgerror.New(pgcode.ActiveSQLTransaction,
+				"START_REPLICATION cannot be executed inside a transaction block"),
+		}
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if !payloadHasError(retPayload) {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		}
+		if p, ok := retPayload.(payloadWithError); ok {
+			log.SqlExec.Errorf(ctx, "error executing %s: %+v", cmd, p.errorCause())
+		}
+	}()
+
+	s := newReplicationStream(ex.server.cfg, ex.sessionData(), ex.sessionMon, cmd.Stmt, cmd.Conn, res)
+	if err := s.run(ctx); err != nil {
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{err: err}
+	}
+	return nil, nil
+}
+
 func (ex *connExecutor) execCopyIn(
 	ctx context.Context, cmd CopyIn, res CopyInResult,
 ) (retEv fsm.Event, retPayload fsm.EventPayload) {
@@ -3705,15 +3864,25 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 			ULIDEntropyFactory:             &ex.rng.ulidEntropy,
 			CidrLookup:                     p.execCfg.CidrLookup,
 		},
-		Tracing:              &ex.sessionTracing,
-		MemMetrics:           &ex.memMetrics,
-		Descs:                ex.extraTxnState.descCollection,
-		TxnModesSetter:       ex,
-		jobs:                 ex.extraTxnState.jobs,
-		validateDbZoneConfig: &ex.extraTxnState.validateDbZoneConfig,
-		statsProvider:        ex.server.sqlStats,
-		indexUsageStats:      ex.indexUsageStats,
-		statementPreparer:    ex,
+		Tracing:                &ex.sessionTracing,
+		MemMetrics:             &ex.memMetrics,
+		Descs:                  ex.extraTxnState.descCollection,
+		TxnModesSetter:         ex,
+		jobs:                   ex.extraTxnState.jobs,
+		validateDbZoneConfig:   &ex.extraTxnState.validateDbZoneConfig,
+		statsProvider:          ex.server.sqlStats,
+		indexUsageStats:        ex.indexUsageStats,
+		statementPreparer:      ex,
+		tempTableCommitActions: ex.extraTxnState.tempTableCommitActions,
+	}
+	// Internal executors that run under an outer transaction don't commit it,
+	// so they can't defer constraint checks or hold transaction-level advisory
+	// locks.
+	if !ex.extraTxnState.underOuterTxn {
+		evalCtx.deferredConstraints = &ex.extraTxnState.deferredConstraints
+		evalCtx.DeferredConstraints = evalCtx.deferredConstraints
+		evalCtx.AdvisoryLocks = ex.advisoryLocks
+		evalCtx.Notifications = ex.notifications
 	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
 }
@@ -1582,6 +1582,21 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Validate again the keys that violated the deferred constraints.
+	if err := ex.planner.validateDeferredConstraints(
+		ctx, ex.extraTxnState.deferredConstraints.takePending(ctx, nil /* names */),
+	); err != nil {
+		return err
+	}
+
+	// Apply the ON COMMIT actions of the temporary tables used by the
+	// transaction.
+	if err := ex.planner.applyTempTableCommitActions(
+		ctx, ex.extraTxnState.tempTableCommitActions,
+	); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
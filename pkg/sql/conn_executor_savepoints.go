@@ -105,6 +105,7 @@ func (ex *connExecutor) execSavepointInOpenState(
 		commitOnRelease: commitOnRelease,
 		kvToken:         token,
 		numDDL:          ex.extraTxnState.numDDL,
+		notifications:   ex.notifications.savepoint(),
 	}
 	savepoints.push(sp)
 	ex.sessionDataStack.PushTopClone()
@@ -209,6 +210,7 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 		ev, payload := ex.makeErrEvent(err, s)
 		return ev, payload
 	}
+	ex.notifications.rollbackToSavepoint(entry.notifications)
 
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -293,6 +295,7 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.notifications.rollbackToSavepoint(entry.notifications)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -357,6 +360,11 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// notifications records the LISTEN, UNLISTEN and NOTIFY operations that
+	// had been executed in the transaction, which are undone by rolling back
+	// to the savepoint.
+	notifications notificationSavepoint
 }
 
 type savepointStack []savepoint
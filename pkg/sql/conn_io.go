@@ -18,8 +18,10 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -434,6 +436,49 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for execution of the START_REPLICATION
+// replication protocol command, which streams changes to the client using the
+// Copy-both subprotocol.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Conn is the network connection. Streaming takes control of the
+	// connection, reading the status updates of the client from it until the
+	// client ends the stream.
+	Conn pgwirebase.Conn
+	// ReplicationDone is used to signal that control of the connection is being
+	// handed back to the network routine.
+	ReplicationDone struct {
+		// WaitGroup is decremented once streaming finishes.
+		*sync.WaitGroup
+		// Once is used to decrement the WaitGroup exactly once.
+		*sync.Once
+	}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (e StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -452,6 +497,26 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is a command asking for the notifications received by
+// the session on the channels it listens on to be delivered to the client. It
+// is pushed by the session's notificationManager when notifications arrive, so
+// they are delivered even if the client doesn't send any commands. If a
+// transaction is open, the notifications are instead delivered by the Sync
+// that follows its end.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (e DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -760,6 +825,8 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateReplicationResult creates a result for a StartReplication command.
+	CreateReplicationResult(cmd StartReplication, pos CmdPos) ReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
 
@@ -776,6 +843,10 @@ type ClientComm interface {
 	// Flush delivers all the previous results to the client. The results might
 	// have been buffered, in which case this flushes the buffer.
 	Flush(pos CmdPos) error
+
+	// BufferNotification buffers a notification received on a channel the
+	// session listens on. It is delivered to the client with the next flush.
+	BufferNotification(n serverpb.Notification) error
 }
 
 // CommandResult represents the result of a statement. It which needs to be
@@ -1004,6 +1075,24 @@ type CopyOutResult interface {
 	SendCopyDone(ctx context.Context) error
 }
 
+// ReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type ReplicationResult interface {
+	ResultBase
+
+	// SendCopyBoth sends the copy both response to the client, which starts
+	// the stream.
+	SendCopyBoth(ctx context.Context) error
+
+	// SendCopyData sends a message of the streaming replication protocol to
+	// the client. Unlike with CopyOutResult, the message is flushed to the
+	// network immediately.
+	SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error
+
+	// SendCopyDone sends the copy done response to the client.
+	SendCopyDone(ctx context.Context) error
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
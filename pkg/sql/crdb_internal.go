@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvflowcontrol/kvflowinspectpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
@@ -93,6 +94,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -3663,6 +3665,11 @@ func createRoutinePopulate(
 				// otherwise.
 				continue
 			}
+			if fnDesc.IsAggregate() {
+				// User-defined aggregates cannot be represented as CREATE
+				// FUNCTION statements.
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.Name.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -7935,7 +7942,12 @@ func genClusterLocksGenerator(
 		}
 
 		var spansToQuery roachpb.Spans
+		var advisoryLocksTableID descpb.ID
 		for _, desc := range descs {
+			if desc.GetParentID() == keys.SystemDatabaseID &&
+				desc.GetName() == string(catconstants.AdvisoryLocksTableName) {
+				advisoryLocksTableID = desc.GetID()
+			}
 			if ok, err := privCheckerFunc(desc); err != nil {
 				return nil, nil, err
 			} else if !ok {
@@ -7956,6 +7968,26 @@ func genClusterLocksGenerator(
 			}
 		}
 
+		// Advisory locks are not held in the lock tables: each of them is a row
+		// of system.advisory_locks. They are shown under the name of that table,
+		// in the database of the lock, after the other locks.
+		var advisoryLocks []advisoryLockRow
+		if advisoryLocksTableID != descpb.InvalidID &&
+			(filters.tableID == nil || descpb.ID(*filters.tableID) == advisoryLocksTableID) &&
+			(filters.tableName == nil || *filters.tableName == string(catconstants.AdvisoryLocksTableName)) &&
+			(filters.contended == nil || !*filters.contended) {
+			if err := p.forEachAdvisoryLock(ctx, func(l advisoryLockRow) error {
+				dbName, ok := dbNames[l.key.DatabaseID]
+				if !ok || (filters.databaseName != nil && *filters.databaseName != dbName) {
+					return nil
+				}
+				advisoryLocks = append(advisoryLocks, l)
+				return nil
+			}); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		spanIdx := 0
 		spansRemain := func() bool {
 			return spanIdx < len(spansToQuery)
@@ -8063,11 +8095,21 @@ func genClusterLocksGenerator(
 				waiterIdx = -1
 			}
 
-			// If we couldn't get any more locks from getNextLock(), we have finished
-			// generating result rows.
-			if curLock == nil || fErr != nil {
+			if fErr != nil {
 				return nil, fErr
 			}
+			// If we couldn't get any more locks from getNextLock(), only the
+			// advisory locks remain.
+			if curLock == nil {
+				if len(advisoryLocks) == 0 {
+					return nil, nil
+				}
+				l := advisoryLocks[0]
+				advisoryLocks = advisoryLocks[1:]
+				return makeAdvisoryLockClusterLocksRow(
+					p.execCfg.Codec, advisoryLocksTableID, dbNames[l.key.DatabaseID], l, shouldRedactKeys,
+				), nil
+			}
 			txnIDDatum := tree.DNull
 			tsDatum := tree.DNull
 			isolationLevelDatum := tree.DNull
@@ -8138,6 +8180,48 @@ func genClusterLocksGenerator(
 	}
 }
 
+// makeAdvisoryLockClusterLocksRow returns the row of crdb_internal.cluster_locks
+// of an advisory lock. Its key is the prefix of the rows of the lock in
+// system.advisory_locks.
+func makeAdvisoryLockClusterLocksRow(
+	codec keys.SQLCodec, tableID descpb.ID, dbName string, l advisoryLockRow, redactKeys bool,
+) tree.Datums {
+	var key roachpb.Key
+	var prettyKey string
+	if !redactKeys {
+		key = rowenc.MakeIndexKeyPrefix(codec, tableID, 1 /* indexID */)
+		key = encoding.EncodeVarintAscending(key, int64(l.key.DatabaseID))
+		key = encoding.EncodeVarintAscending(key, int64(l.key.ClassID))
+		key = encoding.EncodeVarintAscending(key, int64(l.key.ObjID))
+		key = encoding.EncodeVarintAscending(key, int64(l.key.ObjSubID))
+		key, _, _ = keys.DecodeTenantPrefix(key)
+		prettyKey = keys.PrettyPrint(nil /* valDirs */, key)
+	}
+	advisoryLocksTableName := string(catconstants.AdvisoryLocksTableName)
+	strength := lock.Shared
+	if l.exclusive {
+		strength = lock.Exclusive
+	}
+	return tree.Datums{
+		tree.NewDInt(0),                           /* range_id */
+		tree.NewDInt(tree.DInt(tableID)),          /* table_id */
+		tree.NewDString(dbName),                   /* database_name */
+		tree.NewDString(""),                       /* schema_name */
+		tree.NewDString(advisoryLocksTableName),   /* table_name */
+		tree.NewDString(""),                       /* index_name */
+		tree.NewDBytes(tree.DBytes(key)),          /* lock_key */
+		tree.NewDString(prettyKey),                /* lock_key_pretty */
+		tree.DNull,                                /* txn_id */
+		tree.DNull,                                /* ts */
+		tree.NewDString(strength.String()),        /* lock_strength */
+		tree.NewDString(lock.Replicated.String()), /* durability */
+		tree.DBoolTrue,                            /* granted */
+		tree.DBoolFalse,                           /* contended */
+		tree.DNull,                                /* duration */
+		tree.DNull,                                /* isolation_level */
+	}
+}
+
 func genPopulateClusterLocksWithIndex(
 	idxColumnName string, setFilters func(filters *clusterLocksFilters, idxConstraint tree.Datum),
 ) func(context.Context, tree.Datum, *planner, catalog.DatabaseDescriptor, func(...tree.Datum) error) (bool, error) {
@@ -0,0 +1,541 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate function.
+// Privileges: CREATE on the schema, and EXECUTE on the transition and final
+// functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Name.ToUnresolvedObjectName()
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+// aggregateDefinition is the resolved definition of a user-defined aggregate.
+type aggregateDefinition struct {
+	params     []descpb.FunctionDescriptor_Parameter
+	argTypes   []*types.T
+	stateType  *types.T
+	returnType *types.T
+	transition catalog.FunctionDescriptor
+	// final is nil if the aggregate has no final function.
+	final            catalog.FunctionDescriptor
+	initialCondition *string
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			def, err := n.resolveDefinition(params)
+			if err != nil {
+				return err
+			}
+			aggDesc, isReplace, err := n.getMutableAggregateDesc(params, mutScDesc, def)
+			if err != nil {
+				return err
+			}
+			aggDesc.Aggregate = &descpb.FunctionDescriptor_Aggregate{
+				TransitionFunctionID: def.transition.GetID(),
+				StateType:            def.stateType,
+				InitialCondition:     def.initialCondition,
+			}
+			if def.final != nil {
+				aggDesc.Aggregate.FinalFunctionID = def.final.GetID()
+			}
+			aggDesc.SetVolatility(aggregateVolatility(def.transition, def.final))
+			if err := n.addAggregateReferences(params, aggDesc, def); err != nil {
+				return err
+			}
+			if isReplace {
+				err = params.p.writeFuncSchemaChange(params.ctx, aggDesc)
+			} else {
+				err = n.createNewAggregate(params, mutScDesc, aggDesc)
+			}
+			if err != nil {
+				return err
+			}
+
+			fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.Object())
+			event := eventpb.CreateFunction{
+				FunctionName: fnName.FQString(),
+				IsReplace:    isReplace,
+			}
+			return params.p.logEvent(params.ctx, aggDesc.GetID(), &event)
+		}()
+	})
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// resolveDefinition validates the options of the CREATE AGGREGATE statement
+// and resolves the types and functions they refer to.
+func (n *createAggregateNode) resolveDefinition(params runParams) (aggregateDefinition, error) {
+	var def aggregateDefinition
+	if len(n.n.Params) == 0 {
+		return def, unimplemented.Newf("aggregate-without-args", "aggregates without arguments are not supported")
+	}
+	def.params = make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	def.argTypes = make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if !param.IsInParam() || param.IsOutParam() || param.Class == tree.RoutineParamVariadic {
+			return def, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate functions only support IN parameters")
+		}
+		if param.DefaultVal != nil {
+			return def, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate functions cannot have default values")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, params.p.SemaCtx(), param, params.p)
+		if err != nil {
+			return def, err
+		}
+		if err := checkAggregateType(pbParam.Type); err != nil {
+			return def, err
+		}
+		def.params[i] = pbParam
+		def.argTypes[i] = pbParam.Type
+	}
+
+	var transitionName, finalName *tree.RoutineName
+	var stateTypeRef tree.ResolvableTypeReference
+	seen := make(map[tree.AggregateOptionKind]struct{}, len(n.n.Options))
+	for i := range n.n.Options {
+		opt := &n.n.Options[i]
+		if _, ok := seen[opt.Kind]; ok {
+			return def, pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		seen[opt.Kind] = struct{}{}
+		switch opt.Kind {
+		case tree.AggregateOptionTransitionFunc:
+			transitionName = &opt.Func
+		case tree.AggregateOptionStateType:
+			stateTypeRef = opt.Type
+		case tree.AggregateOptionFinalFunc:
+			finalName = &opt.Func
+		case tree.AggregateOptionInitialCondition:
+			initCond := opt.InitialCondition
+			def.initialCondition = &initCond
+		}
+	}
+	if stateTypeRef == nil {
+		return def, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if transitionName == nil {
+		return def, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+
+	var err error
+	def.stateType, err = tree.ResolveType(params.ctx, stateTypeRef, params.p)
+	if err != nil {
+		return def, err
+	}
+	if err := checkAggregateType(def.stateType); err != nil {
+		return def, err
+	}
+	if def.initialCondition != nil {
+		if _, _, err := tree.ParseAndRequireString(
+			def.stateType, *def.initialCondition, params.EvalContext(),
+		); err != nil {
+			return def, err
+		}
+	}
+
+	// The transition function takes the state followed by the arguments of the
+	// aggregate, and must return the new state.
+	transitionTypes := make([]*types.T, 0, len(def.argTypes)+1)
+	transitionTypes = append(transitionTypes, def.stateType)
+	transitionTypes = append(transitionTypes, def.argTypes...)
+	def.transition, err = n.resolveSupportFunction(params, *transitionName, transitionTypes)
+	if err != nil {
+		return def, err
+	}
+	if retType := def.transition.GetReturnType().Type; !retType.Identical(def.stateType) {
+		return def, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s",
+			transitionName.Object(), def.stateType.SQLString(),
+		)
+	}
+	// A strict transition function is not called until the first non-NULL
+	// input, which becomes the initial state if there is no initial condition.
+	if def.initialCondition == nil &&
+		def.transition.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		!def.argTypes[0].Identical(def.stateType) {
+		return def, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and transition type is not compatible with input type",
+		)
+	}
+
+	def.returnType = def.stateType
+	if finalName != nil {
+		def.final, err = n.resolveSupportFunction(params, *finalName, []*types.T{def.stateType})
+		if err != nil {
+			return def, err
+		}
+		def.returnType = def.final.GetReturnType().Type
+	}
+	return def, nil
+}
+
+// checkAggregateType returns an error if the given type cannot be used as an
+// argument or state type of a user-defined aggregate.
+func checkAggregateType(typ *types.T) error {
+	if typ.IsPolymorphicType() {
+		return unimplemented.Newf("polymorphic-aggregate",
+			"aggregates with polymorphic types are not supported")
+	}
+	if typ.Identical(types.Trigger) {
+		return tree.CannotAcceptTriggerErr
+	}
+	return nil
+}
+
+// resolveSupportFunction resolves the transition or final function of an
+// aggregate, which must be a user-defined function with exactly the given
+// parameter types.
+func (n *createAggregateNode) resolveSupportFunction(
+	params runParams, name tree.RoutineName, paramTypes []*types.T,
+) (catalog.FunctionDescriptor, error) {
+	routineObj := tree.RoutineObj{
+		FuncName: name,
+		Params:   make(tree.RoutineParams, len(paramTypes)),
+	}
+	for i, typ := range paramTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedObjectName().ToUnresolvedName()), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(
+		params.ctx, params.p, &routineObj, &path, tree.UDFRoutine,
+		false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.Newf("aggregate-builtin-support-function",
+			"builtin function %s cannot be used by a user-defined aggregate", name.Object())
+	}
+	if ol.Class == tree.AggregateClass {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"function %s is an aggregate function", name.Object())
+	}
+	fnDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.Txn()).WithoutNonPublic().Get().Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function %s returns a set", name.Object())
+	}
+	if dbID := fnDesc.GetParentID(); dbID != n.dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+// aggregateVolatility returns the volatility of an aggregate, which is the
+// least restrictive volatility of its transition and final functions.
+func aggregateVolatility(
+	transition, final catalog.FunctionDescriptor,
+) catpb.Function_Volatility {
+	ret := catpb.Function_IMMUTABLE
+	for _, fn := range []catalog.FunctionDescriptor{transition, final} {
+		if fn == nil {
+			continue
+		}
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			ret = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if ret != catpb.Function_VOLATILE {
+				ret = catpb.Function_STABLE
+			}
+		}
+	}
+	return ret
+}
+
+// getMutableAggregateDesc returns a new descriptor for the aggregate, or the
+// descriptor of the existing aggregate with the same signature if it is being
+// replaced. In the latter case, the references of the existing aggregate are
+// removed.
+func (n *createAggregateNode) getMutableAggregateDesc(
+	params runParams, scDesc catalog.SchemaDescriptor, def aggregateDefinition,
+) (aggDesc *funcdesc.Mutable, isReplace bool, err error) {
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchRoutine(
+		params.ctx, &routineObj, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing != nil {
+		if !n.n.Replace {
+			return nil, false, pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		if !aggDesc.IsAggregate() {
+			formatStr := "%q is a function"
+			if aggDesc.IsProcedure() {
+				formatStr = "%q is a procedure"
+			}
+			return nil, false, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				formatStr,
+				aggDesc.Name,
+			)
+		}
+		if !def.returnType.Identical(aggDesc.ReturnType.Type) {
+			return nil, false, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot change return type of existing function")
+		}
+		if err := n.removeAggregateReferences(params, aggDesc); err != nil {
+			return nil, false, err
+		}
+		// Parameter names may change when the aggregate is replaced.
+		aggDesc.Params = def.params
+		return aggDesc, true, nil
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Routines,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	newDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		def.params,
+		def.returnType,
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	return &newDesc, false, nil
+}
+
+// createNewAggregate writes the descriptor of a new aggregate and adds its
+// signature to the parent schema.
+func (n *createAggregateNode) createNewAggregate(
+	params runParams, scDesc *schemadesc.Mutable, aggDesc *funcdesc.Mutable,
+) error {
+	if err := params.p.createDescriptor(
+		params.ctx,
+		aggDesc,
+		tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+	scDesc.AddFunction(aggDesc.GetName(), toSchemaOverloadSignature(aggDesc))
+	return params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate")
+}
+
+// addAggregateReferences adds references from the aggregate to its transition
+// and final functions and to the types it uses, along with the corresponding
+// back-references.
+func (n *createAggregateNode) addAggregateReferences(
+	params runParams, aggDesc *funcdesc.Mutable, def aggregateDefinition,
+) error {
+	aggDesc.DependsOnFunctions = aggDesc.DependsOnFunctions[:0]
+	for _, fn := range []catalog.FunctionDescriptor{def.transition, def.final} {
+		if fn == nil {
+			continue
+		}
+		aggDesc.DependsOnFunctions = append(aggDesc.DependsOnFunctions, fn.GetID())
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, fn.GetID())
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.AddFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+
+	typeIDs := catalog.DescriptorIDSet{}
+	for _, typ := range append([]*types.T{def.stateType, def.returnType}, def.argTypes...) {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(typeIDs.Add)
+	}
+	aggDesc.DependsOn = aggDesc.DependsOn[:0]
+	aggDesc.DependsOnTypes = aggDesc.DependsOnTypes[:0]
+	for _, id := range typeIDs.Ordered() {
+		isTable, err := params.p.descIsTable(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if !isTable {
+			aggDesc.DependsOnTypes = append(aggDesc.DependsOnTypes, id)
+			jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", id, aggDesc.ID)
+			if err := params.p.addTypeBackReference(params.ctx, id, aggDesc.ID, jobDesc); err != nil {
+				return err
+			}
+			continue
+		}
+		// The aggregate uses the implicit record type of a table.
+		tbl, err := params.p.Descriptors().MutableByID(params.p.Txn()).Table(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		aggDesc.DependsOn = append(aggDesc.DependsOn, id)
+		tbl.DependedOnBy = append(tbl.DependedOnBy, descpb.TableDescriptor_Reference{ID: aggDesc.ID})
+		if err := params.p.writeSchemaChange(
+			params.ctx, tbl, descpb.InvalidMutationID,
+			fmt.Sprintf("updating aggregate reference %q in table %s(%d)",
+				n.n.Name.String(), tbl.GetName(), tbl.GetID(),
+			),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAggregateReferences removes the back-references to an aggregate
+// which is being replaced.
+func (n *createAggregateNode) removeAggregateReferences(
+	params runParams, aggDesc *funcdesc.Mutable,
+) error {
+	for _, id := range aggDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.RemoveFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+	for _, id := range aggDesc.DependsOn {
+		tbl, err := params.p.Descriptors().MutableByID(params.p.Txn()).Table(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl.DependedOnBy = removeMatchingReferences(tbl.DependedOnBy, aggDesc.ID)
+		if err := params.p.writeSchemaChange(
+			params.ctx, tbl, descpb.InvalidMutationID,
+			fmt.Sprintf("removing aggregate reference %s(%d) in table %s(%d)",
+				aggDesc.Name, aggDesc.ID, tbl.Name, tbl.ID,
+			),
+		); err != nil {
+			return err
+		}
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", aggDesc.DependsOnTypes, aggDesc.ID)
+	return params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc)
+}
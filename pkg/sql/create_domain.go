@@ -0,0 +1,241 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+// CreateDomain creates a domain.
+// Privileges: CREATE on the schema.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(ctx, p, n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	schema, err := getCreateTypeParams(params.ctx, params.p, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+	typeDesc, err := createDomainTypeDesc(params, id, n.n, n.dbDesc, schema, n.typeName)
+	if err != nil {
+		return err
+	}
+	return params.p.finishCreateType(
+		params.ctx, params.EvalContext(), n.typeName, typeDesc, n.dbDesc, schema,
+	)
+}
+
+// createDomainTypeDesc creates a new domain type descriptor.
+func createDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateDomain,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := resolveDomainBaseType(params.ctx, params.p, n.Type)
+	if err != nil {
+		return nil, err
+	}
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType: baseType,
+		NotNull:  n.NotNull,
+	}
+	if n.Default != nil {
+		defaultExpr, err := makeDomainDefaultExpr(params.ctx, params.p, n.Default, baseType)
+		if err != nil {
+			return nil, err
+		}
+		domain.DefaultExpr = &defaultExpr
+	}
+	for _, c := range n.Checks {
+		check, err := makeDomainCheckConstraint(params.ctx, params.p, typeName.Type(), domain, c)
+		if err != nil {
+			return nil, err
+		}
+		domain.Checks = append(domain.Checks, check)
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// resolveDomainBaseType resolves the base type of a domain, and returns an
+// error if it is not supported.
+func resolveDomainBaseType(
+	ctx context.Context, p *planner, ref tree.ResolvableTypeReference,
+) (*types.T, error) {
+	typ, err := tree.ResolveType(ctx, ref, p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Identical(types.Trigger) {
+		return nil, tree.CannotAcceptTriggerErr
+	}
+	if err := tree.CheckUnsupportedType(ctx, &p.semaCtx, typ); err != nil {
+		return nil, err
+	}
+	if typ.Family() == types.AnyFamily || typ.Family() == types.VoidFamily {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", typ.SQLString())
+	}
+	if typ.UserDefined() || typ.TypeMeta.ImplicitRecordType {
+		return nil, unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	return typ, nil
+}
+
+// makeDomainDefaultExpr type-checks the default expression of a domain with the
+// given base type, and returns its serialized form.
+func makeDomainDefaultExpr(
+	ctx context.Context, p *planner, expr tree.Expr, baseType *types.T,
+) (string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, &p.semaCtx,
+		volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// makeDomainCheckConstraint type-checks the given CHECK constraint of a domain
+// and returns its descriptor representation. If the constraint is not named, a
+// name that does not conflict with the existing constraints of the domain is
+// generated, as in Postgres.
+func makeDomainCheckConstraint(
+	ctx context.Context,
+	p *planner,
+	domainName string,
+	domain *descpb.TypeDescriptor_Domain,
+	c tree.DomainCheck,
+) (descpb.TypeDescriptor_Domain_CheckConstraint, error) {
+	// Type-check the expression with VALUE standing in for a value of the base
+	// type. The expression is stored as written, since VALUE is substituted
+	// whenever it is evaluated.
+	value := tree.NewTypedCastExpr(tree.DNull, domain.BaseType)
+	replaced, err := tree.ReplaceDomainValue(c.Expr, value)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	if _, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, tree.DomainCheckExpr, &p.semaCtx,
+		volatility.Immutable, false, /* allowAssignmentCast */
+	); err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+
+	name := string(c.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s_check", domainName)
+		for i := 1; findDomainCheckConstraint(domain, name) != nil; i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if findDomainCheckConstraint(domain, name) != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+	return descpb.TypeDescriptor_Domain_CheckConstraint{
+		Name:     name,
+		Expr:     tree.Serialize(c.Expr),
+		Validity: descpb.ConstraintValidity_Validated,
+	}, nil
+}
+
+// findDomainCheckConstraint returns the CHECK constraint of the domain with the
+// given name, or nil if there is none.
+func findDomainCheckConstraint(
+	domain *descpb.TypeDescriptor_Domain, name string,
+) *descpb.TypeDescriptor_Domain_CheckConstraint {
+	for i := range domain.Checks {
+		if domain.Checks[i].Name == name {
+			return &domain.Checks[i]
+		}
+	}
+	return nil
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
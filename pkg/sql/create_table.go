@@ -20,6 +20,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/docs"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -29,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
@@ -64,6 +66,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	pbtypes "github.com/gogo/protobuf/types"
@@ -259,6 +262,9 @@ func (n *createTableNode) startExec(params runParams) error {
 	defsCopy = append(defsCopy, n.n.Defs...)
 	defer func(originalDefs tree.TableDefs) { n.n.Defs = originalDefs }(n.n.Defs)
 	n.n.Defs = defsCopy
+	// The storage parameters may be extended with those of the tables in LIKE
+	// clauses.
+	defer func(originalParams tree.StorageParams) { n.n.StorageParams = originalParams }(n.n.StorageParams)
 
 	for _, def := range n.n.Defs {
 		switch v := def.(type) {
@@ -308,15 +314,18 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 		return err
 	}
+	var onCommit descpb.TableDescriptor_OnCommit
 	if n.n.Persistence.IsTemporary() {
 		telemetry.Inc(sqltelemetry.CreateTempTableCounter)
 
-		// TODO(#46556): support ON COMMIT DROP and DELETE ROWS on TEMPORARY TABLE.
-		// If we do this, the n.n.OnCommit variable should probably be stored on the
-		// table descriptor.
-		// Note UNSET / PRESERVE ROWS behave the same way so we do not need to do that for now.
+		// Note UNSET and PRESERVE ROWS behave the same way.
 		switch n.n.OnCommit {
 		case tree.CreateTableOnCommitUnset, tree.CreateTableOnCommitPreserveRows:
+			onCommit = descpb.TableDescriptor_PRESERVE_ROWS
+		case tree.CreateTableOnCommitDeleteRows:
+			onCommit = descpb.TableDescriptor_DELETE_ROWS
+		case tree.CreateTableOnCommitDrop:
+			onCommit = descpb.TableDescriptor_DROP
 		default:
 			return errors.AssertionFailedf("ON COMMIT value %d is unrecognized", n.n.OnCommit)
 		}
@@ -371,6 +380,10 @@ func (n *createTableNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	// Temporary tables with an ON COMMIT action are populated synchronously,
+	// since the action is applied when the transaction commits.
+	asyncCTAS := n.n.As() && params.extendedEvalCtx.TxnIsSingleStmt &&
+		onCommit == descpb.TableDescriptor_PRESERVE_ROWS
 	if n.n.As() {
 		asCols := planColumns(n.sourcePlan)
 		if !n.n.AsHasUserSpecifiedPrimaryKey() {
@@ -389,7 +402,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 		// If we have a single statement txn we want to run CTAS async, and
 		// consequently ensure it gets queued as a SchemaChange.
-		if params.extendedEvalCtx.TxnIsSingleStmt {
+		if asyncCTAS {
 			desc.State = descpb.DescriptorState_ADD
 		}
 	} else {
@@ -422,6 +435,8 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	desc.OnCommit = onCommit
+
 	// Replace all UDF names with OIDs in check constraints and update back
 	// references in functions used.
 	for _, ck := range desc.CheckConstraints() {
@@ -445,6 +460,7 @@ func (n *createTableNode) startExec(params runParams) error {
 	); err != nil {
 		return err
 	}
+	params.p.registerTempTableCommitAction(desc)
 
 	for _, updated := range affected {
 		if err := params.p.writeSchemaChange(
@@ -508,6 +524,10 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	if err := params.p.copyLikeTableProperties(params.ctx, defsCopy, desc); err != nil {
+		return err
+	}
+
 	// Log Create Table event. This is an auditable log event and is
 	// recorded in the same transaction as the table descriptor update.
 	if err := params.p.logEvent(params.ctx,
@@ -520,7 +540,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	// If we are in a multi-statement txn or the source has placeholders, we
 	// execute the CTAS query synchronously.
-	if n.n.As() && !params.extendedEvalCtx.TxnIsSingleStmt {
+	if n.n.As() && !asyncCTAS {
 		err = func() error {
 			// The data fill portion of CREATE AS must operate on a read snapshot,
 			// so that it doesn't end up observing its own writes.
@@ -674,6 +694,8 @@ func addUniqueWithoutIndexColumnTableDef(
 		"", /* predicate */
 		ts,
 		validationBehavior,
+		tree.ConstraintNotDeferrable,
+		nil, /* exclusionOps */
 	); err != nil {
 		return err
 	}
@@ -735,13 +757,162 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior, d.Deferrable,
+		nil, /* exclusionOps */
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addExclusionConstraintTableDef runs various checks on the given
+// ExclusionConstraintTableDef before adding it as an exclusion constraint to
+// the given table descriptor. Exclusion constraints are stored as unique
+// constraints without an index that compare some of their columns with the
+// overlap operator instead of equality. The constraint does not create an
+// index, but the table must already have an index that the checks of the
+// constraint can use to find conflicting rows (see
+// findExclusionConstraintIndex).
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_3) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use exclusion constraints",
+			clusterversion.V24_3.Version())
+	}
+	if validationBehavior == tree.ValidationSkip {
+		return sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypeExclusion)
+	}
+
+	colNames := make([]string, len(d.Elems))
+	ops := make([]string, len(d.Elems))
+	var eqCols, overlapCols catalog.TableColSet
+	for i, elem := range d.Elems {
+		col, err := catalog.MustFindColumnByTreeName(desc, elem.Column)
+		if err != nil {
+			return err
+		}
+		if _, ok := tree.CmpOps[elem.Operator.Symbol].LookupImpl(col.GetType(), col.GetType()); !ok {
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s",
+				col.GetType().SQLString(), elem.Operator, col.GetType().SQLString(),
+			)
+		}
+		colNames[i] = string(elem.Column)
+		ops[i] = elem.Operator.String()
+		if elem.Operator.Symbol == treecmp.EQ {
+			eqCols.Add(col.GetID())
+		} else {
+			overlapCols.Add(col.GetID())
+		}
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if findExclusionConstraintIndex(desc, eqCols, overlapCols, predicate) == nil {
+		return newMissingExclusionConstraintIndexError(desc, d)
+	}
+
+	return ResolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		tree.ConstraintNotDeferrable, ops,
+	)
+}
+
+// findExclusionConstraintIndex returns a public index of the table that can be
+// used to find the rows which conflict with a given row according to an
+// exclusion constraint, or nil if there is no such index. eqCols are the
+// columns of the constraint compared with equality, and overlapCols are the
+// columns compared with the overlap operator.
+//
+// If overlapCols is not empty, the index must be an inverted index on one of
+// overlapCols whose prefix columns are all in eqCols. Otherwise, it must be a
+// forward index whose first key column is in eqCols. A partial index can only
+// be used if its predicate is the same as the predicate of the constraint.
+func findExclusionConstraintIndex(
+	desc *tabledesc.Mutable, eqCols, overlapCols catalog.TableColSet, predicate string,
+) catalog.Index {
+	for _, idx := range desc.ActiveIndexes() {
+		if idx.IsPartial() && idx.GetPredicate() != predicate {
+			continue
+		}
+		// Implicit partitioning columns are constrained by the optimizer using
+		// the values of the partitions, so they do not need to be compared by
+		// the constraint.
+		start := idx.ExplicitColumnStartIdx()
+		if overlapCols.Empty() {
+			if idx.GetType() != descpb.IndexDescriptor_INVERTED &&
+				start < idx.NumKeyColumns() && eqCols.Contains(idx.GetKeyColumnID(start)) {
+				return idx
+			}
+			continue
+		}
+		if idx.GetType() != descpb.IndexDescriptor_INVERTED ||
+			!overlapCols.Contains(idx.InvertedColumnID()) {
+			continue
+		}
+		usable := true
+		for i := start; i < idx.NumKeyColumns()-1; i++ {
+			if !eqCols.Contains(idx.GetKeyColumnID(i)) {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			return idx
+		}
+	}
+	return nil
+}
+
+// newMissingExclusionConstraintIndexError returns the error for an exclusion
+// constraint which cannot be checked using any of the indexes of the table.
+func newMissingExclusionConstraintIndexError(
+	desc *tabledesc.Mutable, d *tree.ExclusionConstraintTableDef,
+) error {
+	// Suggest an index with the equality columns as prefix columns, followed by
+	// the first column compared with the overlap operator.
+	var cols []string
+	for _, elem := range d.Elems {
+		if elem.Operator.Symbol == treecmp.EQ {
+			cols = append(cols, string(elem.Column))
+		}
+	}
+	kind := "an index"
+	for _, elem := range d.Elems {
+		if elem.Operator.Symbol != treecmp.EQ {
+			cols = append(cols, string(elem.Column))
+			kind = "an inverted index"
+			break
+		}
+	}
+	return errors.WithHintf(
+		pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"exclusion constraint on table %q requires an index to check for conflicting rows",
+			desc.GetName(),
+		),
+		"create %s on (%s) before adding the constraint.", kind, strings.Join(cols, ", "),
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -749,6 +920,9 @@ func addUniqueWithoutIndexTableDef(
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
 // added. This only applies for existing tables, not new tables.
+//
+// If exclusionOps is not empty, the constraint is an exclusion constraint, and
+// exclusionOps contains the operator used to compare each of the columns.
 func ResolveUniqueWithoutIndexConstraint(
 	ctx context.Context,
 	tbl *tabledesc.Mutable,
@@ -757,7 +931,15 @@ func ResolveUniqueWithoutIndexConstraint(
 	predicate string,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
+	deferrability tree.ConstraintDeferrability,
+	exclusionOps []string,
 ) error {
+	constraintKind := "unique"
+	defaultName := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+	if len(exclusionOps) > 0 {
+		constraintKind = "exclusion"
+		defaultName = fmt.Sprintf("%s_%s_excl", tbl.GetName(), strings.Join(colNames, "_"))
+	}
 	var colSet catalog.TableColSet
 	cols := make([]catalog.Column, len(colNames))
 	for i, name := range colNames {
@@ -768,7 +950,7 @@ func ResolveUniqueWithoutIndexConstraint(
 		// Ensure that the columns don't have duplicates.
 		if colSet.Contains(col.GetID()) {
 			return pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.GetName())
+				"column %q appears twice in %s constraint", col.GetName(), constraintKind)
 		}
 		colSet.Add(col.GetID())
 		cols[i] = col
@@ -777,7 +959,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			defaultName,
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -803,12 +985,16 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:          constraintName,
+		TableID:       tbl.ID,
+		ColumnIDs:     columnIDs,
+		Predicate:     predicate,
+		Validity:      validity,
+		ConstraintID:  tbl.NextConstraintID,
+		Deferrability: tree.ConstraintDeferrabilityValue[deferrability],
+	}
+	if len(exclusionOps) > 0 {
+		uc.ExclusionOperators = exclusionOps
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -820,6 +1006,35 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// checkDeferrableConstraintDef returns an error if the given definition is
+// marked DEFERRABLE but its checks cannot be deferred. Only foreign keys and
+// unique constraints without an index can be deferrable, since the uniqueness
+// of an index is enforced when rows are written.
+func checkDeferrableConstraintDef(
+	ctx context.Context, st *cluster.Settings, def tree.TableDef,
+) error {
+	var deferrability tree.ConstraintDeferrability
+	switch d := def.(type) {
+	case *tree.UniqueConstraintTableDef:
+		deferrability = d.Deferrable
+		if deferrability != tree.ConstraintNotDeferrable && !d.WithoutIndex {
+			return errors.WithHint(
+				pgerror.New(pgcode.FeatureNotSupported,
+					"unique constraints with an index cannot be marked DEFERRABLE"),
+				"use UNIQUE WITHOUT INDEX to create a deferrable unique constraint",
+			)
+		}
+	case *tree.ForeignKeyConstraintTableDef:
+		deferrability = d.Deferrable
+	}
+	if deferrability != tree.ConstraintNotDeferrable && !st.Version.IsActive(ctx, clusterversion.V24_3) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use deferrable constraints",
+			clusterversion.V24_3.Version())
+	}
+	return nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -1063,6 +1278,7 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrability:       tree.ConstraintDeferrabilityValue[d.Deferrable],
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -2050,7 +2266,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2199,6 +2416,9 @@ func NewTableDesc(
 
 	ckBuilder := schemaexpr.MakeCheckConstraintBuilder(ctx, n.Table, &desc, semaCtx)
 	for _, def := range n.Defs {
+		if err := checkDeferrableConstraintDef(ctx, st, def); err != nil {
+			return nil, err
+		}
 		switch d := def.(type) {
 		case *tree.ColumnTableDef:
 			if d.Unique.WithoutIndex {
@@ -2218,6 +2438,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
@@ -2348,7 +2575,7 @@ func newTableDesc(
 		return nil, err
 	}
 
-	newDefs, err := replaceLikeTableOpts(n, params)
+	newDefs, likeStorageParams, err := replaceLikeTableOpts(n, params)
 	if err != nil {
 		return nil, err
 	}
@@ -2359,6 +2586,19 @@ func newTableDesc(
 		// n.Defs.
 		n.Defs = newDefs
 	}
+	if len(likeStorageParams) > 0 {
+		n.StorageParams = append(
+			append(make(tree.StorageParams, 0, len(n.StorageParams)+len(likeStorageParams)), n.StorageParams...),
+			likeStorageParams...,
+		)
+	}
+
+	var parents []*tabledesc.Mutable
+	if len(n.Inherits) > 0 {
+		if n.Defs, parents, err = addInheritedTableDefs(n, params, db.GetID()); err != nil {
+			return nil, err
+		}
+	}
 
 	// Process any SERIAL columns to remove the SERIAL type, as required by
 	// NewTableDesc.
@@ -2416,6 +2656,14 @@ func newTableDesc(
 		}
 	}
 
+	// Record the inheritance in both the new table and its parents. The
+	// parents are written along with the other affected descriptors.
+	for _, parent := range parents {
+		ret.Inherits = append(ret.Inherits, parent.GetID())
+		parent.InheritedBy = append(parent.InheritedBy, ret.GetID())
+		affected[parent.GetID()] = parent
+	}
+
 	// Row level TTL tables require a scheduled job to be created as well.
 	if ret.HasRowLevelTTL() {
 		ttl := ret.GetRowLevelTTL()
@@ -2536,8 +2784,14 @@ func rowLevelTTLAutomaticColumnExpr(intervalExpr tree.Expr) tree.Expr {
 // node's TableDefs) by an equivalent set of TableDefs pulled from the
 // LikeTableDef's target table.
 // If no LikeTableDefs are found, the output tree.TableDefs will be nil.
-func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs, error) {
+// The storage parameters of the target tables that are included with
+// INCLUDING STORAGE and not specified explicitly in the input node are
+// returned as well.
+func replaceLikeTableOpts(
+	n *tree.CreateTable, params runParams,
+) (tree.TableDefs, tree.StorageParams, error) {
 	var newDefs tree.TableDefs
+	var storageParams tree.StorageParams
 	for i, def := range n.Defs {
 		d, ok := def.(*tree.LikeTableDef)
 		if !ok {
@@ -2553,16 +2807,27 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		}
 		_, td, err := params.p.ResolveMutableTableDescriptor(params.ctx, &d.Name, true, tree.ResolveRequireTableDesc)
 		if err != nil {
-			return nil, err
-		}
-		opts := tree.LikeTableOpt(0)
-		// Process ons / offs.
-		for _, opt := range d.Options {
-			if opt.Excluded {
-				opts &^= opt.Opt
-			} else {
-				opts |= opt.Opt
+			return nil, nil, err
+		}
+		opts := likeTableOpts(d)
+
+		// Copy the storage parameters, which include the row-level TTL
+		// settings. If the TTL is based on the automatic expiration column, that
+		// column is not copied below and is created anew by NewTableDesc.
+		skipTTLColumn := false
+		if opts.Has(tree.LikeTableOptStorage) {
+			for _, param := range td.GetStorageParams(false /* spaceBetweenEqual */) {
+				key, value, _ := strings.Cut(param, "=")
+				if n.StorageParams.GetVal(key) != nil || storageParams.GetVal(key) != nil {
+					continue
+				}
+				expr, err := parser.ParseExpr(value)
+				if err != nil {
+					return nil, nil, err
+				}
+				storageParams = append(storageParams, tree.StorageParam{Key: key, Value: expr})
 			}
+			skipTTLColumn = td.HasRowLevelTTL() && td.GetRowLevelTTL().HasDurationExpr()
 		}
 
 		// Copy defaults of implicitly created columns if they are needed by indexes.
@@ -2588,12 +2853,15 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 			c := &td.Columns[i]
 			implicit, err := isImplicitlyCreatedBySystem(td, c)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			if implicit {
 				// Don't add system-created implicit columns.
 				continue
 			}
+			if skipTTLColumn && c.Name == catpb.TTLDefaultExpirationColumnName {
+				continue
+			}
 			def := tree.ColumnTableDef{
 				Name:   tree.Name(c.Name),
 				Type:   c.Type,
@@ -2604,12 +2872,36 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 			} else {
 				def.Nullable.Nullability = tree.NotNull
 			}
-			if c.DefaultExpr != nil {
+			if c.GeneratedAsIdentityType != catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+				// The default expression of an identity column refers to the
+				// sequence owned by the column, so it is never copied. With
+				// INCLUDING IDENTITY, the column is recreated as an identity
+				// column with a new sequence using the same options.
+				if opts.Has(tree.LikeTableOptIdentity) {
+					def.GeneratedIdentity.IsGeneratedAsIdentity = true
+					if c.GeneratedAsIdentityType == catpb.GeneratedAsIdentityType_GENERATED_ALWAYS {
+						def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedAlways
+					} else {
+						def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedByDefault
+					}
+					if c.GeneratedAsIdentitySequenceOption != nil && *c.GeneratedAsIdentitySequenceOption != "" {
+						stmt, err := parser.ParseOne("CREATE SEQUENCE fake_seq " + *c.GeneratedAsIdentitySequenceOption)
+						if err != nil {
+							return nil, nil, errors.Wrap(err, "cannot parse sequence option")
+						}
+						createSeq, ok := stmt.AST.(*tree.CreateSequence)
+						if !ok {
+							return nil, nil, errors.AssertionFailedf("unexpected statement %T", stmt.AST)
+						}
+						def.GeneratedIdentity.SeqOptions = createSeq.Options
+					}
+				}
+			} else if c.DefaultExpr != nil {
 				_, shouldCopyColumnDefault := shouldCopyColumnDefaultSet[c.Name]
 				if opts.Has(tree.LikeTableOptDefaults) || shouldCopyColumnDefault {
 					def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2619,7 +2911,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 					def.Computed.Virtual = c.Virtual
 					def.Computed.Expr, err = parser.ParseExpr(*c.ComputeExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2627,7 +2919,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if opts.Has(tree.LikeTableOptDefaults) {
 					def.OnUpdateExpr.Expr, err = parser.ParseExpr(*c.OnUpdateExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2641,7 +2933,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				}
 				def.Expr, err = parser.ParseExpr(c.Expr)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				defs = append(defs, &def)
 			}
@@ -2655,7 +2947,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				}
 				colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				for i := range colNames {
 					def.Columns = append(def.Columns, tree.IndexElem{Column: tree.Name(colNames[i])})
@@ -2664,7 +2956,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if c.IsPartial() {
 					def.Predicate, err = parser.ParseExpr(c.Predicate)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2701,13 +2993,13 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 					}
 					col, err := catalog.MustFindColumnByID(td, idx.GetKeyColumnID(j))
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 					if col.IsExpressionIndexColumn() {
 						elem.Column = ""
 						elem.Expr, err = parser.ParseExpr(col.GetComputeExpr())
 						if err != nil {
-							return nil, err
+							return nil, nil, err
 						}
 					}
 					if idx.GetKeyColumnDirection(j) == catenumpb.IndexColumn_DESC {
@@ -2733,7 +3025,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if idx.IsPartial() {
 					indexDef.Predicate, err = parser.ParseExpr(idx.GetPredicate())
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 				defs = append(defs, def)
@@ -2741,7 +3033,406 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		}
 		newDefs = append(newDefs, defs...)
 	}
-	return newDefs, nil
+	return newDefs, storageParams, nil
+}
+
+// likeTableOpts returns the set of options enabled by the INCLUDING and
+// EXCLUDING clauses of a LikeTableDef, which are processed in order.
+func likeTableOpts(d *tree.LikeTableDef) tree.LikeTableOpt {
+	opts := tree.LikeTableOpt(0)
+	for _, opt := range d.Options {
+		if opt.Excluded {
+			opts &^= opt.Opt
+		} else {
+			opts |= opt.Opt
+		}
+	}
+	return opts
+}
+
+// copyLikeTableProperties copies the properties of the tables in the LIKE
+// clauses of a CREATE TABLE statement that are not stored in the table
+// descriptor: comments with INCLUDING COMMENTS, table statistics with
+// INCLUDING STATISTICS and zone configurations with INCLUDING STORAGE. The
+// elements of the new table are matched with those of the LIKE tables by name.
+// It must be called once the new table descriptor has been created.
+func (p *planner) copyLikeTableProperties(
+	ctx context.Context, defs tree.TableDefs, desc *tabledesc.Mutable,
+) error {
+	for _, def := range defs {
+		d, ok := def.(*tree.LikeTableDef)
+		if !ok {
+			continue
+		}
+		opts := likeTableOpts(d)
+		if !opts.Has(tree.LikeTableOptComments | tree.LikeTableOptStatistics | tree.LikeTableOptStorage) {
+			continue
+		}
+		_, td, err := p.ResolveMutableTableDescriptor(ctx, &d.Name, true /* required */, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		if opts.Has(tree.LikeTableOptComments) {
+			if err := p.copyLikeTableComments(ctx, td, desc); err != nil {
+				return err
+			}
+		}
+		if opts.Has(tree.LikeTableOptStatistics) {
+			if err := p.copyLikeTableStatistics(ctx, td, desc); err != nil {
+				return err
+			}
+		}
+		// The zone configurations of multi-region tables are derived from their
+		// locality, so they are not copied.
+		if opts.Has(tree.LikeTableOptStorage) && desc.LocalityConfig == nil {
+			if err := p.copyLikeTableZoneConfig(ctx, td, desc, opts.Has(tree.LikeTableOptIndexes)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyLikeTableComments copies the comments on the table, columns, indexes
+// and constraints of the source table to the new table. If several LIKE
+// tables have a table comment, the first one is used.
+func (p *planner) copyLikeTableComments(
+	ctx context.Context, src catalog.TableDescriptor, desc *tabledesc.Mutable,
+) error {
+	if _, ok := p.Descriptors().GetTableComment(desc.GetID()); !ok {
+		if cmt, ok := p.Descriptors().GetTableComment(src.GetID()); ok {
+			if err := p.updateComment(ctx, desc.GetID(), 0 /* subID */, catalogkeys.TableCommentType, cmt); err != nil {
+				return err
+			}
+		}
+	}
+	for _, srcCol := range src.PublicColumns() {
+		cmt, ok := p.Descriptors().GetColumnComment(src.GetID(), srcCol.GetPGAttributeNum())
+		if !ok {
+			continue
+		}
+		col := catalog.FindColumnByName(desc, srcCol.GetName())
+		if col == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, desc.GetID(), uint32(col.GetPGAttributeNum()), catalogkeys.ColumnCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	for _, srcIdx := range src.ActiveIndexes() {
+		cmt, ok := p.Descriptors().GetIndexComment(src.GetID(), srcIdx.GetID())
+		if !ok {
+			continue
+		}
+		idx := catalog.FindIndexByName(desc, srcIdx.GetName())
+		if idx == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, desc.GetID(), uint32(idx.GetID()), catalogkeys.IndexCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	for _, srcC := range src.AllConstraints() {
+		cmt, ok := p.Descriptors().GetConstraintComment(src.GetID(), srcC.GetConstraintID())
+		if !ok {
+			continue
+		}
+		c := catalog.FindConstraintByName(desc, srcC.GetName())
+		if c == nil {
+			continue
+		}
+		if err := p.updateComment(
+			ctx, desc.GetID(), uint32(c.GetConstraintID()), catalogkeys.ConstraintCommentType, cmt,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyLikeTableStatistics copies the full table statistics of the source
+// table to the new table. Statistics on columns that don't exist in the new
+// table are skipped, and so are partial statistics.
+func (p *planner) copyLikeTableStatistics(
+	ctx context.Context, src catalog.TableDescriptor, desc *tabledesc.Mutable,
+) error {
+	txn := p.InternalSQLTxn()
+	rows, err := txn.QueryBufferedEx(
+		ctx, "read-like-table-stats", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT name, "columnIDs", "createdAt", "rowCount", "distinctCount", "nullCount", histogram, "avgSize"
+		   FROM system.table_statistics
+		  WHERE "tableID" = $1 AND "partialPredicate" IS NULL`,
+		src.GetID(),
+	)
+	if err != nil {
+		return err
+	}
+StatsLoop:
+	for _, row := range rows {
+		columnIDs := tree.NewDArray(types.Int)
+		for _, d := range tree.MustBeDArray(row[1]).Array {
+			srcCol := catalog.FindColumnByID(src, descpb.ColumnID(tree.MustBeDInt(d)))
+			if srcCol == nil {
+				continue StatsLoop
+			}
+			col := catalog.FindColumnByName(desc, srcCol.GetName())
+			if col == nil {
+				continue StatsLoop
+			}
+			if err := columnIDs.Append(tree.NewDInt(tree.DInt(col.GetID()))); err != nil {
+				return err
+			}
+		}
+		if _, err := txn.Exec(
+			ctx, "insert-like-table-stats", txn.KV(),
+			`INSERT INTO system.table_statistics (
+					"tableID",
+					"name",
+					"columnIDs",
+					"createdAt",
+					"rowCount",
+					"distinctCount",
+					"nullCount",
+					histogram,
+					"avgSize"
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			desc.GetID(), row[0], columnIDs, row[2], row[3], row[4], row[5], row[6], row[7],
+		); err != nil {
+			return errors.Wrap(err, "failed to insert stats")
+		}
+	}
+	// Invalidate the local cache synchronously so that the next statement in
+	// the same session uses the copied statistics.
+	p.ExecCfg().TableStatsCache.InvalidateTableStats(ctx, desc.GetID())
+	return nil
+}
+
+// copyLikeTableZoneConfig copies the zone configuration of the source table
+// to the new table, unless the new table already has one. The zone
+// configurations of the indexes are copied along with the indexes themselves,
+// but those of partitions are not, since partitionings are not copied.
+func (p *planner) copyLikeTableZoneConfig(
+	ctx context.Context, src catalog.TableDescriptor, desc *tabledesc.Mutable, includeIndexes bool,
+) error {
+	srcZone, err := p.Descriptors().GetZoneConfig(ctx, p.txn, src.GetID())
+	if err != nil || srcZone == nil {
+		return err
+	}
+	existing, err := p.Descriptors().GetZoneConfig(ctx, p.txn, desc.GetID())
+	if err != nil || existing != nil {
+		return err
+	}
+	zone := protoutil.Clone(srcZone.ZoneConfigProto()).(*zonepb.ZoneConfig)
+	zone.Subzones = nil
+	zone.SubzoneSpans = nil
+	if includeIndexes {
+		for _, subzone := range srcZone.ZoneConfigProto().Subzones {
+			if subzone.PartitionName != "" {
+				continue
+			}
+			srcIdx, err := catalog.MustFindIndexByID(src, descpb.IndexID(subzone.IndexID))
+			if err != nil {
+				continue
+			}
+			idx := catalog.FindIndexByName(desc, srcIdx.GetName())
+			if idx == nil {
+				continue
+			}
+			subzone.IndexID = uint32(idx.GetID())
+			zone.SetSubzone(subzone)
+		}
+	}
+	if zone.IsSubzonePlaceholder() && len(zone.Subzones) == 0 {
+		return nil
+	}
+	_, err = writeZoneConfig(
+		ctx, p.InternalSQLTxn(), desc.GetID(), desc, zone,
+		nil /* expectedExistingRawBytes */, p.ExecCfg(), len(zone.Subzones) > 0, /* hasNewSubzones */
+		p.extendedEvalCtx.Tracing.KVTracingEnabled(),
+	)
+	return err
+}
+
+// addInheritedTableDefs resolves the parent tables of a CREATE TABLE ...
+// INHERITS statement and returns a copy of the statement's TableDefs with the
+// columns and check constraints of the parents prepended. As in Postgres,
+// columns with the same name are merged into one, as long as their types
+// match, and a column is NOT NULL if any of its definitions is. The resolved
+// parents are returned as well, so the caller can install back-references in
+// them.
+func addInheritedTableDefs(
+	n *tree.CreateTable, params runParams, dbID descpb.ID,
+) (tree.TableDefs, []*tabledesc.Mutable, error) {
+	if n.Persistence.IsTemporary() {
+		return nil, nil, unimplemented.NewWithIssue(
+			22456, "INHERITS is not supported for temporary tables",
+		)
+	}
+	parents := make([]*tabledesc.Mutable, 0, len(n.Inherits))
+	var inheritedCols []*tree.ColumnTableDef
+	var inheritedChecks []*tree.CheckConstraintTableDef
+	inheritedColTypes := make(map[tree.Name]*types.T)
+	for i := range n.Inherits {
+		tn := n.Inherits[i]
+		_, parent, err := params.p.ResolveMutableTableDescriptor(
+			params.ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := params.p.checkInheritanceParent(params.ctx, parent, dbID); err != nil {
+			return nil, nil, err
+		}
+		for _, other := range parents {
+			if other.GetID() == parent.GetID() {
+				return nil, nil, pgerror.Newf(pgcode.DuplicateTable,
+					"relation %q would be inherited from more than once", parent.GetName())
+			}
+		}
+		parents = append(parents, parent)
+
+		for j := range parent.Columns {
+			c := &parent.Columns[j]
+			implicit, err := isImplicitlyCreatedBySystem(parent, c)
+			if err != nil {
+				return nil, nil, err
+			}
+			if implicit {
+				continue
+			}
+			name := tree.Name(c.Name)
+			if typ, ok := inheritedColTypes[name]; ok {
+				if !typ.Identical(c.Type) {
+					return nil, nil, pgerror.Newf(pgcode.DatatypeMismatch,
+						"inherited column %q has a type conflict", c.Name)
+				}
+				params.p.BufferClientNotice(params.ctx,
+					pgnotice.Newf("merging multiple inherited definitions of column %q", c.Name))
+				if !c.Nullable {
+					for _, def := range inheritedCols {
+						if def.Name == name {
+							def.Nullable.Nullability = tree.NotNull
+						}
+					}
+				}
+				continue
+			}
+			inheritedColTypes[name] = c.Type
+			def := &tree.ColumnTableDef{
+				Name:   name,
+				Type:   c.Type,
+				Hidden: c.Hidden,
+			}
+			if c.Nullable {
+				def.Nullable.Nullability = tree.Null
+			} else {
+				def.Nullable.Nullability = tree.NotNull
+			}
+			if c.DefaultExpr != nil {
+				if def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr); err != nil {
+					return nil, nil, err
+				}
+			}
+			if c.ComputeExpr != nil {
+				def.Computed.Computed = true
+				def.Computed.Virtual = c.Virtual
+				if def.Computed.Expr, err = parser.ParseExpr(*c.ComputeExpr); err != nil {
+					return nil, nil, err
+				}
+			}
+			if c.OnUpdateExpr != nil {
+				if def.OnUpdateExpr.Expr, err = parser.ParseExpr(*c.OnUpdateExpr); err != nil {
+					return nil, nil, err
+				}
+			}
+			inheritedCols = append(inheritedCols, def)
+		}
+		for _, c := range parent.Checks {
+			if c.FromHashShardedColumn {
+				continue
+			}
+			def := &tree.CheckConstraintTableDef{Name: tree.Name(c.Name)}
+			if def.Expr, err = parser.ParseExpr(c.Expr); err != nil {
+				return nil, nil, err
+			}
+			duplicate := false
+			for _, other := range inheritedChecks {
+				if other.Name == def.Name {
+					if tree.Serialize(other.Expr) != tree.Serialize(def.Expr) {
+						return nil, nil, pgerror.Newf(pgcode.DuplicateObject,
+							"check constraint name %q appears multiple times but with different expressions",
+							c.Name)
+					}
+					duplicate = true
+				}
+			}
+			if !duplicate {
+				inheritedChecks = append(inheritedChecks, def)
+			}
+		}
+	}
+
+	// Merge the columns defined by the new table into the inherited ones.
+	newDefs := make(tree.TableDefs, 0, len(inheritedCols)+len(inheritedChecks)+len(n.Defs))
+	for _, def := range inheritedCols {
+		newDefs = append(newDefs, def)
+	}
+	mergedCols := make(map[tree.Name]struct{})
+	for _, def := range n.Defs {
+		switch d := def.(type) {
+		case *tree.ColumnTableDef:
+			inheritedType, ok := inheritedColTypes[d.Name]
+			if _, merged := mergedCols[d.Name]; !ok || merged {
+				// Columns specified more than once are rejected when the table
+				// descriptor is created.
+				newDefs = append(newDefs, d)
+				continue
+			}
+			mergedCols[d.Name] = struct{}{}
+			typ, err := tree.ResolveType(params.ctx, d.Type, params.p.semaCtx.GetTypeResolver())
+			if err != nil {
+				return nil, nil, err
+			}
+			if !typ.Identical(inheritedType) {
+				return nil, nil, pgerror.Newf(pgcode.DatatypeMismatch,
+					"column %q has a type conflict", d.Name)
+			}
+			params.p.BufferClientNotice(params.ctx,
+				pgnotice.Newf("merging column %q with inherited definition", d.Name))
+			for i, inherited := range newDefs[:len(inheritedCols)] {
+				inheritedDef := inherited.(*tree.ColumnTableDef)
+				if inheritedDef.Name != d.Name {
+					continue
+				}
+				merged := *d
+				if inheritedDef.Nullable.Nullability == tree.NotNull {
+					merged.Nullable.Nullability = tree.NotNull
+				}
+				if merged.DefaultExpr.Expr == nil {
+					merged.DefaultExpr = inheritedDef.DefaultExpr
+				}
+				newDefs[i] = &merged
+			}
+		case *tree.CheckConstraintTableDef:
+			for _, inherited := range inheritedChecks {
+				if d.Name != "" && d.Name == inherited.Name {
+					return nil, nil, pgerror.Newf(pgcode.DuplicateObject,
+						"constraint %q conflicts with inherited constraint", d.Name)
+				}
+			}
+			newDefs = append(newDefs, d)
+		default:
+			newDefs = append(newDefs, d)
+		}
+	}
+	for _, def := range inheritedChecks {
+		newDefs = append(newDefs, def)
+	}
+	return newDefs, parents, nil
 }
 
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
@@ -0,0 +1,454 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createTriggerNode{n: nil}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: CREATE on the table, and EXECUTE on the trigger function.
+//
+//	notes: postgres requires TRIGGER on the table.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptorEx(
+		ctx, n.TableName, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+			"must be owner of table %s or have CREATE privilege on table %s",
+			tree.Name(tableDesc.GetName()), tree.Name(tableDesc.GetName()))
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	if tableDesc.GetParentID() == keys.SystemDatabaseID {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"permission denied: %q is a system catalog", tableDesc.GetName())
+	}
+	return &createTriggerNode{n: n, tableDesc: tableDesc}, nil
+}
+
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+
+	trig, err := n.makeTriggerDescriptor(params)
+	if err != nil {
+		return err
+	}
+	fnDesc, err := n.resolveTriggerFunction(params)
+	if err != nil {
+		return err
+	}
+	trig.FuncID = fnDesc.GetID()
+
+	// Replace the existing trigger with the same name, if any.
+	tableDesc := n.tableDesc
+	var existing *descpb.TriggerDescriptor
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == trig.Name {
+			existing = &tableDesc.Triggers[i]
+			break
+		}
+	}
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", trig.Name, tableDesc.GetName())
+		}
+		if err := removeTriggerBackReference(params.ctx, params.p, tableDesc, existing); err != nil {
+			return err
+		}
+		trig.ID = existing.ID
+		*existing = trig
+	} else {
+		if tableDesc.NextTriggerID == 0 {
+			tableDesc.NextTriggerID = 1
+		}
+		trig.ID = tableDesc.NextTriggerID
+		tableDesc.NextTriggerID++
+		tableDesc.Triggers = append(tableDesc.Triggers, trig)
+	}
+
+	if err := fnDesc.AddTriggerReference(tableDesc.GetID(), trig.ID); err != nil {
+		return err
+	}
+	if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createTriggerNode) Close(ctx context.Context)           {}
+
+// makeTriggerDescriptor validates the CREATE TRIGGER statement and returns
+// the descriptor of the new trigger, without its ID and function.
+func (n *createTriggerNode) makeTriggerDescriptor(
+	params runParams,
+) (descpb.TriggerDescriptor, error) {
+	trig := descpb.TriggerDescriptor{
+		Name:       string(n.n.Name),
+		ForEachRow: n.n.ForEach == tree.TriggerForEachRow,
+		FuncArgs:   n.n.FuncArgs,
+		Enabled:    true,
+	}
+	switch n.n.ActionTime {
+	case tree.TriggerActionTimeBefore:
+		trig.ActionTime = catpb.TriggerActionTime_BEFORE
+	case tree.TriggerActionTimeAfter:
+		trig.ActionTime = catpb.TriggerActionTime_AFTER
+	case tree.TriggerActionTimeInsteadOf:
+		return trig, errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", n.tableDesc.GetName()),
+			"Tables cannot have INSTEAD OF triggers.",
+		)
+	}
+
+	// Validate the events.
+	var seen tree.TriggerEventType
+	hasColumnList := false
+	for _, ev := range n.n.Events {
+		if seen&ev.EventType != 0 {
+			return trig, pgerror.New(pgcode.Syntax, "duplicate trigger events specified")
+		}
+		seen |= ev.EventType
+		var evDesc descpb.TriggerDescriptor_Event
+		switch ev.EventType {
+		case tree.TriggerEventInsert:
+			evDesc.Type = catpb.TriggerEventType_INSERT_EVENT
+		case tree.TriggerEventUpdate:
+			evDesc.Type = catpb.TriggerEventType_UPDATE_EVENT
+		case tree.TriggerEventDelete:
+			evDesc.Type = catpb.TriggerEventType_DELETE_EVENT
+		case tree.TriggerEventTruncate:
+			return trig, unimplemented.NewWithIssue(126359, "TRUNCATE triggers are not supported")
+		default:
+			return trig, unimplemented.Newf("trigger-event", "%s triggers are not supported", tree.AsString(&ev.EventType))
+		}
+		for _, colName := range ev.Columns {
+			if _, err := catalog.MustFindPublicColumnByTreeName(n.tableDesc, colName); err != nil {
+				return trig, err
+			}
+			evDesc.ColumnNames = append(evDesc.ColumnNames, string(colName))
+			hasColumnList = true
+		}
+		trig.Events = append(trig.Events, evDesc)
+	}
+	hasEvent := func(typ tree.TriggerEventType) bool { return seen&typ != 0 }
+
+	// Validate the transition tables.
+	for _, transition := range n.n.Transitions {
+		if transition.IsRow {
+			return trig, errors.WithHint(
+				pgerror.New(pgcode.FeatureNotSupported,
+					"ROW variable naming in the REFERENCING clause is not supported"),
+				"Use OLD TABLE or NEW TABLE for naming transition tables.",
+			)
+		}
+		if n.n.ActionTime != tree.TriggerActionTimeAfter {
+			return trig, pgerror.New(pgcode.InvalidObjectDefinition,
+				"transition table name can only be specified for an AFTER trigger")
+		}
+		if hasColumnList {
+			return trig, pgerror.New(pgcode.FeatureNotSupported,
+				"transition tables cannot be specified for triggers with column lists")
+		}
+		if transition.IsNew {
+			if !hasEvent(tree.TriggerEventInsert) && !hasEvent(tree.TriggerEventUpdate) {
+				return trig, pgerror.New(pgcode.InvalidObjectDefinition,
+					"NEW TABLE can only be specified for an INSERT or UPDATE trigger")
+			}
+			if trig.NewTransitionAlias != "" {
+				return trig, pgerror.New(pgcode.Syntax, "NEW TABLE cannot be specified multiple times")
+			}
+			trig.NewTransitionAlias = string(transition.Name)
+		} else {
+			if !hasEvent(tree.TriggerEventDelete) && !hasEvent(tree.TriggerEventUpdate) {
+				return trig, pgerror.New(pgcode.InvalidObjectDefinition,
+					"OLD TABLE can only be specified for a DELETE or UPDATE trigger")
+			}
+			if trig.OldTransitionAlias != "" {
+				return trig, pgerror.New(pgcode.Syntax, "OLD TABLE cannot be specified multiple times")
+			}
+			trig.OldTransitionAlias = string(transition.Name)
+		}
+	}
+	if trig.NewTransitionAlias != "" && trig.NewTransitionAlias == trig.OldTransitionAlias {
+		return trig, pgerror.New(pgcode.Syntax, "OLD TABLE name and NEW TABLE name cannot be the same")
+	}
+
+	// Validate the WHEN condition.
+	if n.n.When != nil {
+		whenExpr, err := n.validateWhenExpr(params, hasEvent)
+		if err != nil {
+			return trig, err
+		}
+		trig.WhenExpr = whenExpr
+	}
+	return trig, nil
+}
+
+// validateWhenExpr type-checks the WHEN condition of the trigger and returns
+// its serialized form.
+func (n *createTriggerNode) validateWhenExpr(
+	params runParams, hasEvent func(tree.TriggerEventType) bool,
+) (string, error) {
+	if _, err := tree.SimpleVisit(n.n.When, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if _, ok := expr.(*tree.Subquery); ok {
+			return false, expr, pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use subquery in trigger WHEN condition")
+		}
+		return true, expr, nil
+	}); err != nil {
+		return "", err
+	}
+	recordType := triggerRecordType(n.tableDesc)
+	forEachRow := n.n.ForEach == tree.TriggerForEachRow
+	// Replace the references to NEW and OLD with typed NULLs so that the
+	// expression can be type-checked without a table.
+	replaced, err := replaceTriggerWhenRefs(n.n.When, func(isNew bool, col tree.Name) (tree.Expr, error) {
+		if !forEachRow {
+			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				"statement trigger's WHEN condition cannot reference column values")
+		}
+		if isNew && !hasEvent(tree.TriggerEventInsert) && !hasEvent(tree.TriggerEventUpdate) {
+			return nil, pgerror.New(pgcode.InvalidColumnReference,
+				"DELETE trigger's WHEN condition cannot reference NEW values")
+		}
+		if !isNew && !hasEvent(tree.TriggerEventDelete) && !hasEvent(tree.TriggerEventUpdate) {
+			return nil, pgerror.New(pgcode.InvalidColumnReference,
+				"INSERT trigger's WHEN condition cannot reference OLD values")
+		}
+		typ := recordType
+		if col != "" {
+			c, err := catalog.MustFindPublicColumnByTreeName(n.tableDesc, col)
+			if err != nil {
+				return nil, err
+			}
+			typ = c.GetType()
+		}
+		return &tree.CastExpr{Expr: tree.DNull, Type: typ, SyntaxMode: tree.CastShort}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := schemaexpr.SanitizeVarFreeExpr(
+		params.ctx, replaced, types.Bool, tree.TriggerWhenExpr, params.p.SemaCtx(),
+		volatility.Volatile, false, /* allowAssignmentCast */
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(n.n.When), nil
+}
+
+// resolveTriggerFunction resolves the trigger function, which must take no
+// arguments and return type trigger.
+func (n *createTriggerNode) resolveTriggerFunction(params runParams) (*funcdesc.Mutable, error) {
+	fnName, err := n.n.FuncName.UnresolvedName().ToRoutineName()
+	if err != nil {
+		return nil, err
+	}
+	ol, err := params.p.matchRoutine(
+		params.ctx, &tree.RoutineObj{FuncName: fnName, Params: tree.RoutineParams{}},
+		true /* required */, tree.UDFRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnName.Object())
+	}
+	fnDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !fnDesc.GetReturnType().Type.Identical(types.Trigger) {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnName.Object())
+	}
+	if fnDesc.GetParentID() != n.tableDesc.GetParentID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"trigger function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+// removeTriggerBackReference removes the back-reference from the trigger
+// function to the given trigger.
+func removeTriggerBackReference(
+	ctx context.Context, p *planner, tableDesc *tabledesc.Mutable, trig *descpb.TriggerDescriptor,
+) error {
+	fnDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, trig.FuncID)
+	if err != nil {
+		return err
+	}
+	fnDesc.RemoveTriggerReference(tableDesc.GetID(), trig.ID)
+	return p.writeFuncSchemaChange(ctx, fnDesc)
+}
+
+// triggerRecordType returns the type of the NEW and OLD records of triggers on
+// the given table, which is a tuple of the visible columns of the table.
+func triggerRecordType(tableDesc catalog.TableDescriptor) *types.T {
+	cols := tableDesc.VisibleColumns()
+	contents := make([]*types.T, len(cols))
+	labels := make([]string, len(cols))
+	for i, col := range cols {
+		contents[i] = col.GetType()
+		labels[i] = col.GetName()
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// replaceTriggerWhenRefs calls fn for each reference to NEW or OLD in the
+// WHEN condition of a trigger, and replaces the reference with the returned
+// expression. The column name is empty for references to the whole record.
+func replaceTriggerWhenRefs(
+	expr tree.Expr, fn func(isNew bool, col tree.Name) (tree.Expr, error),
+) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return false, v, nil
+		}
+		var rec, col tree.Name
+		switch {
+		case c.TableName == nil:
+			rec = c.ColumnName
+		case c.TableName.NumParts == 1:
+			rec, col = tree.Name(c.TableName.Parts[0]), c.ColumnName
+		default:
+			return false, v, nil
+		}
+		if rec != "new" && rec != "old" {
+			return false, v, nil
+		}
+		newExpr, err = fn(rec == "new", col)
+		return false, newExpr, err
+	})
+}
+
+// triggerReferencesColumn returns true if the given trigger fires on UPDATE OF
+// the column, or references it in its WHEN condition.
+func triggerReferencesColumn(trig *descpb.TriggerDescriptor, col tree.Name) (bool, error) {
+	for i := range trig.Events {
+		for _, name := range trig.Events[i].ColumnNames {
+			if name == string(col) {
+				return true, nil
+			}
+		}
+	}
+	if trig.WhenExpr == "" {
+		return false, nil
+	}
+	whenExpr, err := parser.ParseExpr(trig.WhenExpr)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	if _, err := replaceTriggerWhenRefs(whenExpr, func(_ bool, refCol tree.Name) (tree.Expr, error) {
+		found = found || refCol == col
+		return tree.DNull, nil
+	}); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// dropTriggersReferencingColumn drops the triggers which reference the given
+// column if the drop behavior is CASCADE, and returns an error otherwise.
+func dropTriggersReferencingColumn(
+	ctx context.Context,
+	p *planner,
+	tableDesc *tabledesc.Mutable,
+	col tree.Name,
+	behavior tree.DropBehavior,
+) error {
+	var triggers []descpb.TriggerDescriptor
+	for i := range tableDesc.Triggers {
+		trig := &tableDesc.Triggers[i]
+		referenced, err := triggerReferencesColumn(trig, col)
+		if err != nil {
+			return err
+		}
+		if !referenced {
+			triggers = append(triggers, *trig)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return sqlerrors.NewDependentBlocksOpError("drop", "column", string(col), "trigger", trig.Name)
+		}
+		if err := removeTriggerBackReference(ctx, p, tableDesc, trig); err != nil {
+			return err
+		}
+	}
+	tableDesc.Triggers = triggers
+	return nil
+}
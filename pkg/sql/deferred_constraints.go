@@ -0,0 +1,467 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// constraintCheckMode is the checking mode of a deferrable constraint, as set
+// with SET CONSTRAINTS.
+type constraintCheckMode int8
+
+const (
+	// constraintCheckModeUnset indicates that the mode of a constraint is
+	// determined by its INITIALLY DEFERRED or INITIALLY IMMEDIATE clause.
+	constraintCheckModeUnset constraintCheckMode = iota
+	constraintCheckModeImmediate
+	constraintCheckModeDeferred
+)
+
+// deferredConstraintKey identifies a constraint of a table.
+type deferredConstraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// pendingKeys contains the keys of the rows that violated a deferred
+// constraint when they were written.
+type pendingKeys struct {
+	keys []tree.Datums
+	// seen contains the string representations of keys, and is used to avoid
+	// validating the same key multiple times.
+	seen map[string]struct{}
+	// memUsage is the memory accounted for keys and seen.
+	memUsage int64
+}
+
+// pendingConstraint is a deferred constraint that must be validated before the
+// transaction commits.
+type pendingConstraint struct {
+	deferredConstraintKey
+	keys []tree.Datums
+}
+
+// deferredConstraints tracks the deferrable constraints of a SQL transaction.
+// Statements run the checks of the constraints that are deferred as usual, but
+// they record the keys of the violating rows as pending instead of failing.
+// Since a later statement of the transaction may resolve the violations, the
+// pending keys are validated again before the transaction commits, or when
+// their constraints are switched to immediate mode with SET CONSTRAINTS. The
+// rows that were not written by the transaction are never validated again.
+type deferredConstraints struct {
+	// all is the mode set with SET CONSTRAINTS ALL. It applies to the
+	// constraints that don't have a mode in named.
+	all constraintCheckMode
+
+	// named contains the modes set with SET CONSTRAINTS <name>. The value is
+	// true if the constraints with the given name are deferred.
+	named map[string]bool
+
+	// mu protects pending and acc from the checks of a statement, which may
+	// run in parallel.
+	mu syncutil.Mutex
+
+	// pending contains the keys that violated the deferred constraints when
+	// they were written by statements of the transaction.
+	pending map[deferredConstraintKey]*pendingKeys
+
+	// acc accounts for the memory used by the pending keys.
+	acc mon.BoundAccount
+}
+
+var _ eval.DeferredConstraints = &deferredConstraints{}
+
+// IsDeferred is part of the eval.DeferredConstraints interface.
+func (dc *deferredConstraints) IsDeferred(
+	tableID catid.DescID, name string, deferrability tree.ConstraintDeferrability,
+) bool {
+	if deferrability == tree.ConstraintNotDeferrable {
+		return false
+	}
+	if deferred, ok := dc.named[name]; ok {
+		return deferred
+	}
+	switch dc.all {
+	case constraintCheckModeImmediate:
+		return false
+	case constraintCheckModeDeferred:
+		return true
+	}
+	return deferrability == tree.ConstraintInitiallyDeferred
+}
+
+// Defer is part of the eval.DeferredConstraints interface.
+func (dc *deferredConstraints) Defer(
+	ctx context.Context, tableID catid.DescID, name string, keyVals tree.Datums,
+) error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.pending == nil {
+		dc.pending = make(map[deferredConstraintKey]*pendingKeys)
+	}
+	k := deferredConstraintKey{tableID: tableID, name: name}
+	pk := dc.pending[k]
+	if pk == nil {
+		pk = &pendingKeys{seen: make(map[string]struct{})}
+		dc.pending[k] = pk
+	}
+	str := tree.AsStringWithFlags(&keyVals, tree.FmtParsable)
+	if _, ok := pk.seen[str]; ok {
+		return nil
+	}
+	memUsage := memsize.MapEntryOverhead + memsize.String + int64(len(str)) +
+		memsize.DatumsOverhead + memsize.DatumOverhead*int64(len(keyVals))
+	for _, d := range keyVals {
+		memUsage += int64(d.Size())
+	}
+	if err := dc.acc.Grow(ctx, memUsage); err != nil {
+		return errors.Wrapf(err, "recording violation of deferred constraint %q", name)
+	}
+	pk.memUsage += memUsage
+	pk.seen[str] = struct{}{}
+	// The datums are not modified, but the slice may be reused by the check
+	// that found the violation.
+	pk.keys = append(pk.keys, append(tree.Datums(nil), keyVals...))
+	return nil
+}
+
+// setMode sets the checking mode of the given constraints, or of all
+// constraints if names is empty.
+func (dc *deferredConstraints) setMode(names tree.NameList, deferred bool) {
+	if len(names) == 0 {
+		dc.named = nil
+		dc.all = constraintCheckModeImmediate
+		if deferred {
+			dc.all = constraintCheckModeDeferred
+		}
+		return
+	}
+	if dc.named == nil {
+		dc.named = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		dc.named[string(name)] = deferred
+	}
+}
+
+// takePending removes the pending constraints with the given names, or all
+// the pending constraints if names is empty, and returns them in a
+// deterministic order.
+func (dc *deferredConstraints) takePending(
+	ctx context.Context, names tree.NameList,
+) []pendingConstraint {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	var res []pendingConstraint
+	for k, pk := range dc.pending {
+		if len(names) > 0 && !names.Contains(tree.Name(k.name)) {
+			continue
+		}
+		res = append(res, pendingConstraint{deferredConstraintKey: k, keys: pk.keys})
+		dc.acc.Shrink(ctx, pk.memUsage)
+		delete(dc.pending, k)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].tableID != res[j].tableID {
+			return res[i].tableID < res[j].tableID
+		}
+		return res[i].name < res[j].name
+	})
+	return res
+}
+
+// reset clears the state of the tracker at the end of a transaction.
+func (dc *deferredConstraints) reset(ctx context.Context) {
+	dc.all = constraintCheckModeUnset
+	dc.named = nil
+	dc.pending = nil
+	dc.acc.Clear(ctx)
+}
+
+// SetConstraints sets the checking mode of deferrable constraints in the
+// current transaction.
+// Privileges: None.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	dc := p.extendedEvalCtx.deferredConstraints
+	if p.extendedEvalCtx.TxnImplicit || dc == nil {
+		// This no-ops in postgres with a warning, so copy accordingly.
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return newZeroNode(nil /* columns */), nil
+	}
+	if !n.All {
+		if err := p.checkDeferrableConstraintNames(ctx, n.Names); err != nil {
+			return nil, err
+		}
+	}
+	dc.setMode(n.Names, n.Deferred)
+	if !n.Deferred {
+		// Constraints that are switched to immediate mode are validated right
+		// away, as in Postgres.
+		if err := p.validateDeferredConstraints(ctx, dc.takePending(ctx, n.Names)); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// checkDeferrableConstraintNames returns an error if any of the given names
+// does not refer to a deferrable constraint of a table in the current
+// database.
+func (p *planner) checkDeferrableConstraintNames(ctx context.Context, names tree.NameList) error {
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	inDB, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.Txn(), db)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(names))
+	if err := inDB.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tableDesc, err := catalog.AsTableDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		for _, c := range tableDesc.AllConstraints() {
+			if !names.Contains(tree.Name(c.GetName())) {
+				continue
+			}
+			found[c.GetName()] = found[c.GetName()] ||
+				constraintDeferrability(c) != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		deferrable, ok := found[string(name)]
+		if !ok {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", string(name))
+		}
+		if !deferrable {
+			return pgerror.Newf(pgcode.WrongObjectType,
+				"constraint %q is not deferrable", string(name))
+		}
+	}
+	return nil
+}
+
+// constraintDeferrability returns the deferrability of the given constraint.
+// Only foreign keys and unique constraints without an index can be
+// deferrable.
+func constraintDeferrability(c catalog.Constraint) semenumpb.ConstraintDeferrability {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrability
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		return uwi.UniqueWithoutIndexDesc().Deferrability
+	}
+	return semenumpb.ConstraintDeferrability_NOT_DEFERRABLE
+}
+
+// validateDeferredConstraints validates again the keys that violated the given
+// constraints when they were written by the statements of the current
+// transaction. Constraints that were dropped in the meantime are ignored.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, constraints []pendingConstraint,
+) error {
+	for _, pc := range constraints {
+		tableDesc, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).Get().Table(ctx, pc.tableID)
+		if err != nil {
+			return err
+		}
+		if tableDesc.Dropped() {
+			continue
+		}
+		c := catalog.FindConstraintByName(tableDesc, pc.name)
+		if c == nil {
+			continue
+		}
+		if fk := c.AsForeignKey(); fk != nil {
+			targetTable, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).Get().Table(
+				ctx, fk.GetReferencedTableID(),
+			)
+			if err != nil {
+				return err
+			}
+			if err := validateDeferredForeignKey(
+				ctx, p.InternalSQLTxn(), tableDesc, targetTable, fk.ForeignKeyDesc(), pc.keys,
+			); err != nil {
+				return err
+			}
+		} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+			if err := validateDeferredUniqueConstraint(
+				ctx, p.InternalSQLTxn(), tableDesc, uwi, pc.keys,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keyFilter returns a filter that matches the rows of the table with the
+// given alias whose columns are equal to the given key. The non-NULL values of
+// the key are passed as placeholders, and are appended to args. If nullsEqual
+// is false, the filter never matches a key with NULL values.
+func keyFilter(
+	alias string, colNames []string, keyVals tree.Datums, nullsEqual bool, args []interface{},
+) (filter string, _ []interface{}) {
+	conds := make([]string, len(colNames))
+	for i, n := range colNames {
+		col := fmt.Sprintf("%s.%s", alias, tree.NameString(n))
+		if keyVals[i] == tree.DNull {
+			if !nullsEqual {
+				return "false", args
+			}
+			conds[i] = fmt.Sprintf("%s IS NULL", col)
+			continue
+		}
+		args = append(args, keyVals[i])
+		conds[i] = fmt.Sprintf("%s = $%d", col, len(args))
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// validateDeferredForeignKey verifies that none of the given keys is
+// referenced by a row of the srcTable without having a match in the
+// targetTable. The keys are values of the foreign key columns that violated
+// the constraint when they were written: either values of new rows of the
+// srcTable, or values of the targetTable rows that were removed while still
+// being referenced.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateDeferredForeignKey(
+	ctx context.Context,
+	txn isql.Txn,
+	srcTable catalog.TableDescriptor,
+	targetTable catalog.TableDescriptor,
+	fk *descpb.ForeignKeyConstraint,
+	keys []tree.Datums,
+) error {
+	originColNames, err := catalog.ColumnNamesForIDs(srcTable, fk.OriginColumnIDs)
+	if err != nil {
+		return err
+	}
+	referencedColNames, err := catalog.ColumnNamesForIDs(targetTable, fk.ReferencedColumnIDs)
+	if err != nil {
+		return err
+	}
+	log.VEventf(ctx, 2, "validating %d keys of deferred FK %q", len(keys), fk.Name)
+	for _, keyVals := range keys {
+		// A key with NULL values can only violate a MATCH FULL constraint, and
+		// it never has a match in the referenced table.
+		srcFilter, args := keyFilter("src", originColNames, keyVals, true /* nullsEqual */, nil /* args */)
+		targetFilter, args := keyFilter("target", referencedColNames, keyVals, false /* nullsEqual */, args)
+		query := fmt.Sprintf(
+			`SELECT EXISTS (SELECT 1 FROM [%[1]d AS src]@{IGNORE_FOREIGN_KEYS} WHERE %[2]s)
+			    AND NOT EXISTS (SELECT 1 FROM [%[3]d AS target] WHERE %[4]s)`,
+			srcTable.GetID(),    // 1
+			srcFilter,           // 2
+			targetTable.GetID(), // 3
+			targetFilter,        // 4
+		)
+		row, err := txn.QueryRowEx(ctx, "validate deferred fk constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, args...)
+		if err != nil {
+			return err
+		}
+		if row != nil && tree.MustBeDBool(row[0]) {
+			return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
+				"foreign key violation: %q row %s has no match in %q",
+				srcTable.GetName(), formatValues(originColNames, keyVals), targetTable.GetName()), fk.Name)
+		}
+	}
+	return nil
+}
+
+// validateDeferredUniqueConstraint verifies that none of the given keys is
+// duplicated in the srcTable. The keys are values of the unique constraint
+// columns, in the order of their IDs, that violated the constraint when they
+// were written.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateDeferredUniqueConstraint(
+	ctx context.Context,
+	txn isql.Txn,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	keys []tree.Datums,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(srcTable, uc.CollectKeyColumnIDs().Ordered())
+	if err != nil {
+		return err
+	}
+	log.VEventf(ctx, 2, "validating %d keys of deferred unique constraint %q", len(keys), uc.GetName())
+	for _, keyVals := range keys {
+		filter, args := keyFilter("tbl", colNames, keyVals, false /* nullsEqual */, nil /* args */)
+		// Wrap the predicate in parentheses.
+		if pred := uc.GetPredicate(); pred != "" {
+			filter = fmt.Sprintf("%s AND (%s)", filter, pred)
+		}
+		query := fmt.Sprintf(
+			`SELECT count(*) > 1 FROM [%d AS tbl] WHERE %s`, srcTable.GetID(), filter,
+		)
+		row, err := txn.QueryRowEx(ctx, "validate deferred unique constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, args...)
+		if err != nil {
+			return err
+		}
+		if row != nil && tree.MustBeDBool(row[0]) {
+			valuesStr := make([]string, len(keyVals))
+			for i := range keyVals {
+				valuesStr[i] = keyVals[i].String()
+			}
+			return errors.WithDetail(
+				pgerror.WithConstraintName(
+					pgerror.Newf(
+						pgcode.UniqueViolation, "failed to validate unique constraint %q", uc.GetName(),
+					),
+					uc.GetName(),
+				),
+				fmt.Sprintf(
+					"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+				),
+			)
+		}
+	}
+	return nil
+}
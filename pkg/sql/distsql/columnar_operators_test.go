@@ -18,14 +18,20 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecagg"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecwindow"
+	"github.com/cockroachdb/cockroach/pkg/sql/colfetcher"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execagg"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
@@ -34,6 +40,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
@@ -134,6 +142,10 @@ func TestAggregateFuncToNumArguments(t *testing.T) {
 	fns := make([]execinfrapb.AggregatorSpec_Func, 0,
 		len(execinfrapb.AggregatorSpec_Func_name))
 	for fn := range execinfrapb.AggregatorSpec_Func_name {
+		if execinfrapb.AggregatorSpec_Func(fn) == execinfrapb.UserDefined {
+			// User-defined aggregates are not builtins.
+			continue
+		}
 		fns = append(fns, execinfrapb.AggregatorSpec_Func(fn))
 	}
 	sort.Slice(fns, func(i, j int) bool { return fns[i] < fns[j] })
@@ -1123,6 +1135,118 @@ func generateFilterExpr(
 	return execinfrapb.Expression{Expr: fmt.Sprintf("@%d %s @%d", colIdx+1, comparison, rightColIdx+1)}
 }
 
+func TestLookupJoinerAgainstProcessor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{
+		// The test uses the system codec to construct the fetch spec.
+		DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSecondaryTenantsButDoesntYet(MultiTenancyIssueNo),
+	})
+	defer s.Stopper().Stop(ctx)
+
+	rng, seed := randutil.NewTestRand()
+	nRuns := 3
+	nRows := 10
+	nCols := 3
+	maxNum := 5
+	intTyps := []*types.T{types.Int, types.Int, types.Int}
+
+	// Populate the lookup table with a random subset of all possible primary
+	// keys so that some of the lookups don't find any rows.
+	r := sqlutils.MakeSQLRunner(sqlDB)
+	r.Exec(t, "CREATE DATABASE test")
+	r.Exec(t, "CREATE TABLE test.t (a INT, b INT, c INT, PRIMARY KEY (a, b))")
+	for a := 0; a < maxNum; a++ {
+		for b := 0; b < maxNum; b++ {
+			if rng.Float64() < 0.3 {
+				continue
+			}
+			var c interface{}
+			if rng.Float64() >= nullProbability {
+				c = rng.Intn(maxNum)
+			}
+			r.Exec(t, "INSERT INTO test.t VALUES ($1, $2, $3)", a, b, c)
+		}
+	}
+	td := desctestutils.TestingGetPublicTableDescriptor(kvDB, keys.SystemSQLCodec, "test", "t")
+	var fetchSpec fetchpb.IndexFetchSpec
+	require.NoError(t, rowenc.InitIndexFetchSpec(
+		&fetchSpec, keys.SystemSQLCodec, td, td.GetPrimaryIndex(), []descpb.ColumnID{1, 2, 3},
+	))
+
+	for run := 0; run < nRuns; run++ {
+		for _, joinType := range []descpb.JoinType{
+			descpb.InnerJoin, descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin,
+		} {
+			for nLookupCols := 1; nLookupCols <= len(fetchSpec.KeyFullColumns()); nLookupCols++ {
+				for _, withOnExpr := range []bool{false, true} {
+					rows := randgen.MakeRandIntRowsInRange(rng, nRows, nCols, maxNum, nullProbability)
+					lookupCols := generateEqualityColumns(rng, nCols, nLookupCols)
+					var onExpr execinfrapb.Expression
+					if withOnExpr {
+						onExpr = generateFilterExpr(
+							rng, nCols, nLookupCols, append(intTyps, intTyps...),
+							false /* forceConstComparison */, false, /* forceSingleSide */
+						)
+					}
+					maintainOrdering := rng.Float64() < 0.5
+					// Only the inner and left outer joins that maintain the
+					// ordering can be the first join of paired joins.
+					outputContinuation := maintainOrdering && joinType.ShouldIncludeRightColsInOutput() &&
+						rng.Float64() < 0.5
+					jrSpec := &execinfrapb.JoinReaderSpec{
+						FetchSpec:                         fetchSpec,
+						LookupColumns:                     lookupCols,
+						OnExpr:                            onExpr,
+						Type:                              joinType,
+						MaintainOrdering:                  maintainOrdering,
+						OutputGroupContinuationForLeftRow: outputContinuation,
+					}
+					// Make sure that the vectorized engine plans the native
+					// lookup joiner rather than wrapping the joinReader.
+					require.NoError(t, colfetcher.CanPlanLookupJoin(jrSpec))
+					resultTypes := joinType.MakeOutputTypes(intTyps, intTyps)
+					if outputContinuation {
+						resultTypes = append(resultTypes, types.Bool)
+					}
+					pspec := &execinfrapb.ProcessorSpec{
+						Input:       []execinfrapb.InputSyncSpec{{ColumnTypes: intTyps}},
+						Core:        execinfrapb.ProcessorCoreUnion{JoinReader: jrSpec},
+						ResultTypes: resultTypes,
+					}
+					// The looked-up rows are buffered when maintaining the
+					// ordering of the inner and left outer joins, so
+					// randomly force that buffer to spill to disk.
+					forceDiskSpill := colfetcher.BuffersLookedUpRows(jrSpec) && rng.Float64() < 0.5
+					args := verifyColOperatorArgs{
+						anyOrder:       !maintainOrdering,
+						inputTypes:     [][]*types.T{intTyps},
+						inputs:         []rowenc.EncDatumRows{rows},
+						pspec:          pspec,
+						forceDiskSpill: forceDiskSpill,
+						// The spilling of the looked-up rows buffer isn't
+						// reported via the spilling callback.
+						forcedDiskSpillMightNotOccur: true,
+						rng:                          rng,
+						txn:                          kv.NewTxn(ctx, kvDB, s.NodeID()),
+					}
+					if err := verifyColOperator(t, args); err != nil {
+						fmt.Printf("--- join type = %s lookupCols = %v onExpr = %q maintainOrdering = %t"+
+							" outputContinuation = %t forceDiskSpill = %t seed = %d run = %d ---\n",
+							joinType.String(), lookupCols, onExpr.Expr, maintainOrdering,
+							outputContinuation, forceDiskSpill, seed, run)
+						prettyPrintTypes(intTyps, "input_table" /* tableName */)
+						prettyPrintInput(rows, intTyps, "input_table" /* tableName */)
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+	}
+}
+
 func TestWindowFunctionsAgainstProcessor(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/col/coldataext"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/colcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec"
@@ -68,6 +69,8 @@ type verifyColOperatorArgs struct {
 	numForcedRepartitions int
 	// rng (if set) will be used to randomize batch size.
 	rng *rand.Rand
+	// txn (if set) will be used by the processors that read from KV.
+	txn *kv.Txn
 }
 
 // verifyColOperator passes inputs through both the processor defined by pspec
@@ -104,6 +107,8 @@ func verifyColOperator(t *testing.T, args verifyColOperatorArgs) error {
 			Settings:    st,
 			TempStorage: tempEngine,
 		},
+		Txn:         args.txn,
+		NodeID:      base.TestingIDContainer,
 		DiskMonitor: diskMonitor,
 	}
 	flowCtx.Cfg.TestingKnobs.ForceDiskSpill = args.forceDiskSpill
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
@@ -450,6 +451,7 @@ var cannotDistributeRowLevelLockingErr = newQueryNotSupportedError(
 func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) bool {
 	switch n := node.(type) {
 	// Keep these cases alphabetized, please!
+	case *aggregateScanNode:
 	case *distinctNode:
 	case *exportNode:
 	case *filterNode:
@@ -520,6 +522,15 @@ func checkSupportForPlanNode(
 ) (distRecommendation, error) {
 	switch n := node.(type) {
 	// Keep these cases alphabetized, please!
+	case *aggregateScanNode:
+		if err := checkExprForDistSQL(n.filter, distSQLVisitor); err != nil {
+			return cannotDistribute, err
+		}
+		if n.scan.isFull {
+			return shouldDistribute, nil
+		}
+		return canDistribute, nil
+
 	case *createStatsNode:
 		if n.runAsJob {
 			return cannotDistribute, planNodeNotSupportedErr
@@ -739,6 +750,11 @@ func checkSupportForPlanNode(
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				return cannotDistribute, newQueryNotSupportedErrorf("window function %q cannot be executed with distsql", f.expr.Func.String())
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -922,9 +938,14 @@ type PlanningCtx struct {
 	// are being held onto throughout the whole flow lifecycle.
 	onFlowCleanup []func()
 
-	// This is true if plan is a simple insert that can be vectorized.
+	// This is true if plan is a simple insert that can be vectorized (see
+	// canVectorizeMutationOfTable).
 	isVectorInsert bool
 
+	// This is true if plan is an update, upsert, or delete that can be
+	// vectorized (see canVectorizeMutation).
+	isVectorMutation bool
+
 	// OverridePlannerMon, if set, will be used instead of the Planner.Mon() as
 	// the parent monitor for the DistSQL flow.
 	OverridePlannerMon *mon.BytesMonitor
@@ -2291,6 +2312,117 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	return nil
 }
 
+// aggregateScanFuncs maps the names of the aggregate functions supported by
+// aggregate scans to the corresponding partial aggregates computed by the KV
+// layer and to the functions used to combine them.
+var aggregateScanFuncs = map[string]struct {
+	partial kvpb.AggregateScanRequest_Aggregate_Func
+	final   execinfrapb.AggregatorSpec_Func
+}{
+	"count_rows": {partial: kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS, final: execinfrapb.SumInt},
+	"count":      {partial: kvpb.AggregateScanRequest_Aggregate_COUNT, final: execinfrapb.SumInt},
+	"sum":        {partial: kvpb.AggregateScanRequest_Aggregate_SUM, final: execinfrapb.Sum},
+	"min":        {partial: kvpb.AggregateScanRequest_Aggregate_MIN, final: execinfrapb.Min},
+	"max":        {partial: kvpb.AggregateScanRequest_Aggregate_MAX, final: execinfrapb.Max},
+}
+
+// createPlanForAggregateScan creates a physical plan for an aggregateScanNode.
+// An aggregate scanner is planned on each node that has spans that we are
+// reading, and if there are several of them, a final aggregation stage
+// combines their results on the gateway.
+func (dsp *DistSQLPlanner) createPlanForAggregateScan(
+	ctx context.Context, planCtx *PlanningCtx, n *aggregateScanNode,
+) (*PhysicalPlan, error) {
+	colIDs := make([]descpb.ColumnID, len(n.scan.cols))
+	for i := range n.scan.cols {
+		colIDs[i] = n.scan.cols[i].GetID()
+	}
+	spec := &execinfrapb.AggregateScannerSpec{
+		TableDescriptorModificationTime: n.scan.desc.GetModificationTime(),
+		BatchBytesLimit:                 dsp.distSQLSrv.TestingKnobs.TableReaderBatchBytesLimit,
+	}
+	if err := rowenc.InitIndexFetchSpec(
+		&spec.FetchSpec, planCtx.ExtendedEvalCtx.Codec, n.scan.desc, n.scan.index, colIDs,
+	); err != nil {
+		return nil, err
+	}
+	if n.filter != nil {
+		// The scanned columns are the fetched columns.
+		filter, exact := rowenc.MakeExactIndexFetchFilter(
+			&spec.FetchSpec, n.filter, func(varIdx int) (int, bool) { return varIdx, true },
+		)
+		if !exact {
+			return nil, errors.AssertionFailedf("aggregate scan filter cannot be evaluated by KV: %s", n.filter)
+		}
+		spec.FetchSpec.Filter = filter
+	}
+	resultTypes := make([]*types.T, len(n.aggregations))
+	finalAggs := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.aggregations))
+	spec.Aggregates = make([]kvpb.AggregateScanRequest_Aggregate, len(n.aggregations))
+	for i, agg := range n.aggregations {
+		fn, ok := aggregateScanFuncs[agg.FuncName]
+		if !ok || agg.Distinct || agg.Filter != tree.NoColumnIdx || len(agg.ArgCols) > 1 {
+			return nil, errors.AssertionFailedf("unsupported aggregate scan aggregation %s", agg.FuncName)
+		}
+		spec.Aggregates[i].Func = fn.partial
+		if len(agg.ArgCols) == 1 {
+			spec.Aggregates[i].FetchedColumnOrdinal = uint32(agg.ArgCols[0])
+		}
+		// The partial aggregates have the same types as the final ones.
+		resultTypes[i] = agg.ResultType
+		finalAggs[i] = execinfrapb.AggregatorSpec_Aggregation{
+			Func:   fn.final,
+			ColIdx: []uint32{uint32(i)},
+		}
+	}
+
+	var spanPartitions []SpanPartition
+	if planCtx.isLocal {
+		spanPartitions = []SpanPartition{{SQLInstanceID: dsp.gatewaySQLInstanceID, Spans: n.scan.spans}}
+	} else {
+		var err error
+		spanPartitions, _, err = dsp.partitionSpansEx(ctx, planCtx, n.scan.spans)
+		if err != nil {
+			return nil, err
+		}
+	}
+	corePlacement := make([]physicalplan.ProcessorCorePlacement, len(spanPartitions))
+	for i, sp := range spanPartitions {
+		as := spec
+		if i > 0 {
+			as = &execinfrapb.AggregateScannerSpec{}
+			*as = *spec
+		}
+		// Copy the spans so that the scanNode spans are not modified during
+		// execution (see planTableReaders).
+		as.Spans = make(roachpb.Spans, len(sp.Spans))
+		copy(as.Spans, sp.Spans)
+		corePlacement[i].SQLInstanceID = sp.SQLInstanceID
+		corePlacement[i].EstimatedRowCount = 1
+		corePlacement[i].Core.AggregateScanner = as
+	}
+
+	p := planCtx.NewPhysicalPlan()
+	p.TotalEstimatedScannedRows += n.scan.estimatedRowCount
+	p.AddNoInputStage(corePlacement, execinfrapb.PostProcessSpec{}, resultTypes, execinfrapb.Ordering{})
+	p.PlanToStreamColMap = identityMap(make([]int, len(resultTypes)), len(resultTypes))
+
+	if len(p.ResultRouters) > 1 {
+		// Combine the results of the aggregate scanners on the gateway.
+		p.AddSingleGroupStage(
+			ctx,
+			dsp.gatewaySQLInstanceID,
+			execinfrapb.ProcessorCoreUnion{Aggregator: &execinfrapb.AggregatorSpec{
+				Type:         execinfrapb.AggregatorSpec_SCALAR,
+				Aggregations: finalAggs,
+			}},
+			execinfrapb.PostProcessSpec{},
+			resultTypes,
+		)
+	}
+	return p, nil
+}
+
 // createPlanForRender takes a PhysicalPlan and updates it to produce results
 // corresponding to the render node. An evaluator stage is added if the render
 // node has any expressions which are not just simple column references.
@@ -2392,11 +2524,25 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		arguments := make([]tree.TypedExpr, len(fholder.arguments))
+		for j := range fholder.arguments {
+			arguments[j] = fholder.arguments[j]
+		}
+		if udAgg := fholder.userDefined; udAgg != nil {
+			// The initial state and the routines of a user-defined aggregate
+			// are passed as its arguments (see execagg.GetAggregateConstructor).
+			aggregations[i].Func = execinfrapb.UserDefined
+			arguments = append(arguments, udAgg.InitialState, udAgg.Transition)
+			if udAgg.Final != nil {
+				arguments = append(arguments, udAgg.Final)
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2405,11 +2551,11 @@ func (dsp *DistSQLPlanner) addAggregators(
 			col := uint32(p.PlanToStreamColMap[fholder.filterRenderIdx])
 			aggregations[i].FilterColIdx = &col
 		}
-		aggregations[i].Arguments = make([]execinfrapb.Expression, len(fholder.arguments))
-		argumentsColumnTypes[i] = make([]*types.T, len(fholder.arguments))
+		aggregations[i].Arguments = make([]execinfrapb.Expression, len(arguments))
+		argumentsColumnTypes[i] = make([]*types.T, len(arguments))
 		var ef physicalplan.ExprFactory
 		ef.Init(ctx, planCtx, nil /* indexVarMap */)
-		for j, argument := range fholder.arguments {
+		for j, argument := range arguments {
 			var err error
 			aggregations[i].Arguments[j], err = ef.Make(argument)
 			if err != nil {
@@ -3782,6 +3928,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 
 	switch n := node.(type) {
 	// Keep these cases alphabetized, please!
+	case *aggregateScanNode:
+		plan, err = dsp.createPlanForAggregateScan(ctx, planCtx, n)
+
 	case *createStatsNode:
 		if n.runAsJob {
 			plan, err = dsp.wrapPlan(ctx, planCtx, n, false /* allowPartialDistribution */)
@@ -3831,6 +3980,13 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			return nil, err
 		}
 
+	case *deleteNode:
+		if planCtx.isVectorMutation {
+			plan, err = dsp.createPlanForDelete(ctx, planCtx, n)
+		} else {
+			plan, err = dsp.wrapPlan(ctx, planCtx, n, false /* allowPartialDistribution */)
+		}
+
 	case *indexJoinNode:
 		plan, err = dsp.createPlanForIndexJoin(ctx, planCtx, n)
 
@@ -3883,7 +4039,8 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		}
 
 	case *rowCountNode:
-		if in, ok := n.source.(*insertNode); ok {
+		switch in := n.source.(type) {
+		case *insertNode:
 			// Skip over any renderNodes.
 			nod := in.source
 			for r, ok := nod.(*renderNode); ok; r, ok = r.source.plan.(*renderNode) {
@@ -3891,12 +4048,20 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			}
 			if v, ok := nod.(*valuesNode); ok {
 				if v.coldataBatch != nil {
-					planCtx.isVectorInsert = true
+					planCtx.isVectorInsert = dsp.canVectorizeMutationOfTable(planCtx, in.run.ti.tableDesc())
 				}
 			}
+		case *updateNode:
+			planCtx.isVectorMutation = in.run.regionLocalInfo.regionMustBeLocalColID == 0 &&
+				dsp.canVectorizeMutation(planCtx, in.run.tu.tableDesc(), in.columns)
+		case *upsertNode:
+			planCtx.isVectorMutation = in.run.tw.canaryOrdinal != -1 &&
+				dsp.canVectorizeMutation(planCtx, in.run.tw.tableDesc(), in.columns)
+		case *deleteNode:
+			planCtx.isVectorMutation = dsp.canVectorizeMutation(planCtx, in.run.td.tableDesc(), in.columns)
 		}
 
-		if planCtx.isVectorInsert {
+		if planCtx.isVectorInsert || planCtx.isVectorMutation {
 			plan, err = dsp.createPlanForRowCount(ctx, planCtx, n)
 		} else {
 			plan, err = dsp.wrapPlan(ctx, planCtx, n, false /* allowPartialDistribution */)
@@ -3927,6 +4092,20 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 	case *unaryNode:
 		plan, err = dsp.createPlanForUnary(planCtx, n)
 
+	case *updateNode:
+		if planCtx.isVectorMutation {
+			plan, err = dsp.createPlanForUpdate(ctx, planCtx, n)
+		} else {
+			plan, err = dsp.wrapPlan(ctx, planCtx, n, false /* allowPartialDistribution */)
+		}
+
+	case *upsertNode:
+		if planCtx.isVectorMutation {
+			plan, err = dsp.createPlanForUpsert(ctx, planCtx, n)
+		} else {
+			plan, err = dsp.wrapPlan(ctx, planCtx, n, false /* allowPartialDistribution */)
+		}
+
 	case *unionNode:
 		plan, err = dsp.createPlanForSetOp(ctx, planCtx, n)
 
@@ -5205,6 +5384,183 @@ func (dsp *DistSQLPlanner) createPlanForInsert(
 	return plan, nil
 }
 
+// vectorizedMutationsEnabled determines whether the UPDATE, UPSERT, and DELETE
+// statements that only return the number of affected rows are executed by the
+// vectorized engine.
+var vectorizedMutationsEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.vectorized_mutations.enabled",
+	"when true, eligible UPDATE, UPSERT, and DELETE statements are executed by the vectorized engine",
+	false,
+)
+
+// canVectorizeMutation returns whether the UPDATE, UPSERT or DELETE of the
+// given table whose rowCountNode is being planned can be executed by the
+// vectorized engine. In addition to the restrictions of
+// canVectorizeMutationOfTable, the vectorized operators don't support
+// RETURNING, tables that are undergoing schema changes, and the txn row count
+// guardrails, so such mutations are executed by the wrapped planNodes.
+func (dsp *DistSQLPlanner) canVectorizeMutation(
+	planCtx *PlanningCtx, desc catalog.TableDescriptor, returning colinfo.ResultColumns,
+) bool {
+	if planCtx.planner == nil || !vectorizedMutationsEnabled.Get(&dsp.st.SV) {
+		return false
+	}
+	sd := planCtx.ExtendedEvalCtx.SessionData()
+	if sd.VectorizeMode == sessiondatapb.VectorizeOff || sd.TxnRowsWrittenErr != 0 {
+		return false
+	}
+	if !dsp.canVectorizeMutationOfTable(planCtx, desc) {
+		return false
+	}
+	return len(returning) == 0 && len(desc.AllMutations()) == 0
+}
+
+// canVectorizeMutationOfTable returns whether a mutation of the given table
+// can be executed by the vectorized engine, which applies to every mutation
+// type. The vectorized operators don't support:
+//   - cascades, which include AFTER triggers;
+//   - post-query checks, which include the checks of deferred constraints;
+//   - the WITH CHECK expressions of row-level security policies;
+//   - tables with triggers;
+//   - temporary tables with ON COMMIT actions, which must be applied before
+//     the transaction commits.
+func (dsp *DistSQLPlanner) canVectorizeMutationOfTable(
+	planCtx *PlanningCtx, desc catalog.TableDescriptor,
+) bool {
+	if planCtx.planner != nil {
+		curPlan := &planCtx.planner.curPlan
+		if len(curPlan.cascades) > 0 || len(curPlan.checkPlans) > 0 {
+			return false
+		}
+	}
+	if desc.IsRowLevelSecurityEnabled() || len(desc.GetTriggers()) > 0 {
+		return false
+	}
+	return !desc.IsTemporary() || desc.GetOnCommit() == descpb.TableDescriptor_PRESERVE_ROWS
+}
+
+// addMutationStage adds the stage with the given mutation core to the plan of
+// the mutation's source. The mutation outputs a single row with the number of
+// affected rows.
+func addMutationStage(
+	ctx context.Context,
+	plan *PhysicalPlan,
+	source planNode,
+	core execinfrapb.ProcessorCoreUnion,
+) error {
+	// The mutation operators expect their input to have the columns of the
+	// source in order.
+	numCols := len(planColumns(source))
+	projection := make([]uint32, numCols)
+	for i := range projection {
+		outputCol := plan.PlanToStreamColMap[i]
+		if outputCol < 0 {
+			return errors.AssertionFailedf("column %d of the mutation source is not produced", i)
+		}
+		projection[i] = uint32(outputCol)
+	}
+	plan.EnsureSingleStreamOnGateway(ctx)
+	plan.AddProjection(projection, execinfrapb.Ordering{})
+	plan.AddNoGroupingStage(
+		core,
+		execinfrapb.PostProcessSpec{},
+		[]*types.T{types.Int},
+		execinfrapb.Ordering{},
+	)
+	return nil
+}
+
+func getColumnIDs(cols []catalog.Column) []descpb.ColumnID {
+	ids := make([]descpb.ColumnID, len(cols))
+	for i, c := range cols {
+		ids[i] = c.GetID()
+	}
+	return ids
+}
+
+func encodeCheckOrds(checkOrds checkSet) ([]byte, error) {
+	if checkOrds.Empty() {
+		return nil, nil
+	}
+	var buff bytes.Buffer
+	if err := checkOrds.Encode(&buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func (dsp *DistSQLPlanner) createPlanForUpdate(
+	ctx context.Context, planCtx *PlanningCtx, n *updateNode,
+) (*PhysicalPlan, error) {
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source)
+	if err != nil {
+		return nil, err
+	}
+	checkOrds, err := encodeCheckOrds(n.run.checkOrds)
+	if err != nil {
+		return nil, err
+	}
+	updateSpec := execinfrapb.UpdateSpec{
+		Table:           *n.run.tu.tableDesc().TableDesc(),
+		FetchColumnIDs:  getColumnIDs(n.run.tu.ru.FetchCols),
+		UpdateColumnIDs: getColumnIDs(n.run.tu.ru.UpdateCols),
+		CheckOrds:       checkOrds,
+	}
+	if err := addMutationStage(
+		ctx, plan, n.source, execinfrapb.ProcessorCoreUnion{Update: &updateSpec},
+	); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (dsp *DistSQLPlanner) createPlanForUpsert(
+	ctx context.Context, planCtx *PlanningCtx, n *upsertNode,
+) (*PhysicalPlan, error) {
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source)
+	if err != nil {
+		return nil, err
+	}
+	checkOrds, err := encodeCheckOrds(n.run.checkOrds)
+	if err != nil {
+		return nil, err
+	}
+	upsertSpec := execinfrapb.UpsertSpec{
+		Table:           *n.run.tw.tableDesc().TableDesc(),
+		InsertColumnIDs: getColumnIDs(n.run.insertCols),
+		FetchColumnIDs:  getColumnIDs(n.run.tw.fetchCols),
+		UpdateColumnIDs: getColumnIDs(n.run.tw.updateCols),
+		CanaryOrdinal:   int32(n.run.tw.canaryOrdinal),
+		CheckOrds:       checkOrds,
+	}
+	if err := addMutationStage(
+		ctx, plan, n.source, execinfrapb.ProcessorCoreUnion{Upsert: &upsertSpec},
+	); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (dsp *DistSQLPlanner) createPlanForDelete(
+	ctx context.Context, planCtx *PlanningCtx, n *deleteNode,
+) (*PhysicalPlan, error) {
+	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source)
+	if err != nil {
+		return nil, err
+	}
+	deleteSpec := execinfrapb.DeleteSpec{
+		Table:          *n.run.td.tableDesc().TableDesc(),
+		FetchColumnIDs: getColumnIDs(n.run.td.rd.FetchCols),
+	}
+	if err := addMutationStage(
+		ctx, plan, n.source, execinfrapb.ProcessorCoreUnion{Delete: &deleteSpec},
+	); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
 func (dsp *DistSQLPlanner) NodeDescStore() kvclient.NodeDescStore {
 	return dsp.nodeDescs
 }
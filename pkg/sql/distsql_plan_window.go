@@ -15,7 +15,9 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execagg"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
 )
@@ -28,6 +30,9 @@ func createWindowFnSpec(
 			return execinfrapb.WindowerSpec_WindowFn{}, nil, errors.Errorf("ColIdx out of range (%d)", argIdx)
 		}
 	}
+	if funcInProgress.userDefined != nil {
+		return createUserDefinedWindowFnSpec(ctx, planCtx, funcInProgress)
+	}
 	// Figure out which built-in to compute.
 	funcSpec, err := rowexec.CreateWindowerSpecFunc(funcInProgress.expr.Func.String())
 	if err != nil {
@@ -41,6 +46,49 @@ func createWindowFnSpec(
 	if err != nil {
 		return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
 	}
+	funcInProgressSpec, err := makeWindowFnSpec(ctx, planCtx, funcInProgress, funcSpec)
+	return funcInProgressSpec, outputType, err
+}
+
+// createUserDefinedWindowFnSpec creates the spec of a user-defined aggregate
+// used as a window function. Like for the aggregate, its initial state and
+// routines are passed as arguments (see execagg.GetAggregateConstructor).
+func createUserDefinedWindowFnSpec(
+	ctx context.Context, planCtx *PlanningCtx, funcInProgress *windowFuncHolder,
+) (execinfrapb.WindowerSpec_WindowFn, *types.T, error) {
+	udAgg := funcInProgress.userDefined
+	arguments := []tree.TypedExpr{udAgg.InitialState, udAgg.Transition}
+	outputType := udAgg.Transition.ResolvedType()
+	if udAgg.Final != nil {
+		arguments = append(arguments, udAgg.Final)
+		outputType = udAgg.Final.ResolvedType()
+	}
+	aggFunc := execinfrapb.UserDefined
+	funcSpec := execinfrapb.WindowerSpec_Func{AggregateFunc: &aggFunc}
+	funcInProgressSpec, err := makeWindowFnSpec(ctx, planCtx, funcInProgress, funcSpec)
+	if err != nil {
+		return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+	}
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	funcInProgressSpec.Arguments = make([]execinfrapb.Expression, len(arguments))
+	for i, argument := range arguments {
+		funcInProgressSpec.Arguments[i], err = ef.Make(argument)
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+		}
+	}
+	return funcInProgressSpec, outputType, nil
+}
+
+// makeWindowFnSpec creates the spec of the given window function which
+// computes funcSpec.
+func makeWindowFnSpec(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	funcInProgress *windowFuncHolder,
+	funcSpec execinfrapb.WindowerSpec_Func,
+) (execinfrapb.WindowerSpec_WindowFn, error) {
 	// Populating column ordering from ORDER BY clause of funcInProgress.
 	ordCols := make([]execinfrapb.Ordering_Column, 0, len(funcInProgress.columnOrdering))
 	for _, column := range funcInProgress.columnOrdering {
@@ -62,10 +110,10 @@ func createWindowFnSpec(
 		// funcInProgress has a custom window frame.
 		frameSpec := execinfrapb.WindowerSpec_Frame{}
 		if err := frameSpec.InitFromAST(ctx, funcInProgress.frame, planCtx.EvalContext()); err != nil {
-			return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+			return execinfrapb.WindowerSpec_WindowFn{}, err
 		}
 		funcInProgressSpec.Frame = &frameSpec
 	}
 
-	return funcInProgressSpec, outputType, nil
+	return funcInProgressSpec, nil
 }
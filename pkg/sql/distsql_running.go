@@ -2101,16 +2101,22 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// very end. We may want to make copies of the buffer nodes and clean up
 		// everything else.
 		buf := plan.cascades[i].Buffer
+		trig := plan.cascades[i].Trigger
 		var numBufferedRows int
 		if buf != nil {
 			numBufferedRows = buf.(*bufferNode).rows.rows.Len()
-			if numBufferedRows == 0 {
-				// No rows were actually modified.
+			if numBufferedRows == 0 && (trig == nil || trig.ForEachRow()) {
+				// No rows were actually modified. Statement-level triggers fire
+				// regardless.
 				continue
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		if trig != nil {
+			log.VEventf(ctx, 2, "executing AFTER trigger %s", trig.Name())
+		} else {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		}
 
 		// We place a sequence point before every cascade, so that each subsequent
 		// cascade can observe the writes by the previous step. However, The
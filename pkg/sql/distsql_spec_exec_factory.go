@@ -530,6 +530,12 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		i := len(groupCols) + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
+		if agg.UserDefined != nil {
+			return nil, unimplemented.New(
+				"distsql-spec-user-defined-aggregate",
+				"experimental opt-driven distsql planning: user-defined aggregate",
+			)
+		}
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
@@ -579,6 +585,17 @@ func (e *distSQLSpecExecFactory) ConstructGroupBy(
 	)
 }
 
+// ConstructAggregateScan is part of the exec.Factory interface.
+func (e *distSQLSpecExecFactory) ConstructAggregateScan(
+	table cat.Table,
+	index cat.Index,
+	params exec.ScanParams,
+	filter tree.TypedExpr,
+	aggregations []exec.AggInfo,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: aggregate scan")
+}
+
 func (e *distSQLSpecExecFactory) ConstructScalarGroupBy(
 	input exec.Node, aggregations []exec.AggInfo,
 ) (exec.Node, error) {
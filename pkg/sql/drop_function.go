@@ -77,6 +77,9 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if err := checkDropRoutineAggregate(n, mut); err != nil {
+			return nil, err
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -104,6 +107,22 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 	return dropNode, nil
 }
 
+// checkDropRoutineAggregate returns an error if DROP AGGREGATE targets a
+// function which is not an aggregate, or if DROP FUNCTION targets an
+// aggregate.
+func checkDropRoutineAggregate(n *tree.DropRoutine, fnDesc catalog.FunctionDescriptor) error {
+	switch {
+	case n.Aggregate && !fnDesc.IsAggregate():
+		return pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", fnDesc.GetName())
+	case !n.Aggregate && fnDesc.IsAggregate():
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", fnDesc.GetName()),
+			"Use DROP AGGREGATE to drop aggregate functions.",
+		)
+	}
+	return nil
+}
+
 func (n *dropFunctionNode) startExec(params runParams) error {
 	for _, fnMutable := range n.toDrop {
 		if err := params.p.dropFunctionImpl(params.ctx, fnMutable); err != nil {
@@ -74,6 +74,13 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
 
+	// Tables that inherit from a dropped table must be dropped along with it.
+	for _, toDel := range td {
+		if err := p.checkDropInheritedTable(ctx, toDel.desc, td, n.DropBehavior); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, toDel := range td {
 		droppedDesc := toDel.desc
 		for _, fk := range droppedDesc.InboundForeignKeys() {
@@ -257,6 +264,11 @@ func (p *planner) dropTableImpl(
 	}
 	tableDesc.InboundFKs = nil
 
+	// Remove references to and from the tables in the inheritance hierarchy.
+	if err := p.removeInheritanceReferences(ctx, tableDesc); err != nil {
+		return droppedViews, err
+	}
+
 	// Remove sequence dependencies.
 	for _, col := range tableDesc.PublicColumns() {
 		if err := p.removeSequenceDependencies(ctx, tableDesc, col); err != nil {
@@ -13,11 +13,90 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 )
 
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *tabledesc.Mutable
+}
+
+// Use to satisfy the linter.
+var _ planNode = &dropTriggerNode{n: nil}
+
 // DropTrigger drops a trigger.
+// Privileges: CREATE on the table.
+//
+//	notes: postgres requires ownership of the table.
 func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (ret planNode, err error) {
-	return nil, unimplemented.NewWithIssue(126359, "DROP TRIGGER")
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptorEx(
+		ctx, n.Table, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+			"must be owner of table %s or have CREATE privilege on table %s",
+			tree.Name(tableDesc.GetName()), tree.Name(tableDesc.GetName()))
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	return &dropTriggerNode{n: n, tableDesc: tableDesc}, nil
 }
+
+func (n *dropTriggerNode) ReadingOwnWrites() {}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	tableDesc := n.tableDesc
+	idx := -1
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.n.Trigger) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.n.Trigger, tableDesc.GetName())
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("trigger"))
+
+	if err := removeTriggerBackReference(
+		params.ctx, params.p, tableDesc, &tableDesc.Triggers[idx],
+	); err != nil {
+		return err
+	}
+	tableDesc.Triggers = append(tableDesc.Triggers[:idx], tableDesc.Triggers[idx+1:]...)
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*dropTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropTriggerNode) Close(ctx context.Context)           {}
@@ -84,6 +84,10 @@ func GetAggregateConstructor(
 	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
 	inputTypes []*types.T,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = getUserDefinedAggregateConstructor(aggInfo.Arguments)
+		return constructor, nil /* arguments */, outputType, err
+	}
 	argTypes := make([]*types.T, len(aggInfo.ColIdx)+len(aggInfo.Arguments))
 	for j, c := range aggInfo.ColIdx {
 		if c >= uint32(len(inputTypes)) {
@@ -113,6 +117,39 @@ func GetAggregateConstructor(
 	return
 }
 
+// getUserDefinedAggregateConstructor returns the constructor and the output
+// type of a user-defined aggregate. Its arguments are the initial state, the
+// transition routine, and optionally the final routine. Routines cannot be
+// serialized, so they are only available as local expressions.
+func getUserDefinedAggregateConstructor(
+	arguments []execinfrapb.Expression,
+) (AggregateConstructor, *types.T, error) {
+	if n := len(arguments); n != 2 && n != 3 {
+		return nil, nil, errors.AssertionFailedf(
+			"expected 2 or 3 arguments for a user-defined aggregate, found %d", n,
+		)
+	}
+	var udAgg tree.UserDefinedAggregate
+	var ok bool
+	if udAgg.InitialState, ok = arguments[0].LocalExpr.(tree.Datum); !ok {
+		return nil, nil, errors.AssertionFailedf("expected initial state of a user-defined aggregate to be a datum")
+	}
+	if udAgg.Transition, ok = arguments[1].LocalExpr.(*tree.RoutineExpr); !ok {
+		return nil, nil, errors.AssertionFailedf("expected transition function of a user-defined aggregate to be a routine")
+	}
+	outputType := udAgg.Transition.ResolvedType()
+	if len(arguments) == 3 {
+		if udAgg.Final, ok = arguments[2].LocalExpr.(*tree.RoutineExpr); !ok {
+			return nil, nil, errors.AssertionFailedf("expected final function of a user-defined aggregate to be a routine")
+		}
+		outputType = udAgg.Final.ResolvedType()
+	}
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(evalCtx, &udAgg)
+	}
+	return constructor, outputType, nil
+}
+
 // GetAggregateOutputType returns the output type for the given aggregate
 // function when applied on the given types.
 //
@@ -120,6 +157,14 @@ func GetAggregateConstructor(
 func GetAggregateOutputType(
 	fn execinfrapb.AggregatorSpec_Func, inputTypes []*types.T,
 ) (outputType *types.T, err error) {
+	if fn == execinfrapb.UserDefined {
+		// The last argument of a user-defined aggregate is the routine which
+		// produces its result (see getUserDefinedAggregateConstructor).
+		if len(inputTypes) == 0 {
+			return nil, errors.AssertionFailedf("user-defined aggregate has no arguments")
+		}
+		return inputTypes[len(inputTypes)-1], nil
+	}
 	_, outputType, err = getAggregateInfo(fn, inputTypes)
 	return outputType, err
 }
@@ -178,6 +223,20 @@ func GetWindowFunctionInfo(
 	)
 }
 
+// GetUserDefinedWindowFunctionInfo returns the windowFunc constructor and the
+// return type of a user-defined aggregate used as a window function. The
+// arguments are the same as the ones of the aggregate (see
+// GetAggregateConstructor).
+func GetUserDefinedWindowFunctionInfo(
+	arguments []execinfrapb.Expression,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	aggConstructor, returnType, err := getUserDefinedAggregateConstructor(arguments)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewAggregateWindowFunc(aggConstructor), returnType, nil
+}
+
 // NeedHashAggregator returns whether the given aggregator spec requires hash
 // aggregation.
 func NeedHashAggregator(aggSpec *execinfrapb.AggregatorSpec) (bool, error) {
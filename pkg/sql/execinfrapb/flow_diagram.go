@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -155,38 +157,66 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 	details = append(details, fmt.Sprintf("%s@%s", tr.FetchSpec.TableName, tr.FetchSpec.IndexName))
 	details = appendColumns(details, tr.FetchSpec.FetchedColumns)
 
-	if len(tr.Spans) > 0 {
-		// only show the first span
-		keyDirs := make([]encoding.Direction, len(tr.FetchSpec.KeyAndSuffixColumns))
-		for i := range keyDirs {
-			keyDirs[i] = encoding.Ascending
-			if tr.FetchSpec.KeyAndSuffixColumns[i].Direction == catenumpb.IndexColumn_DESC {
-				keyDirs[i] = encoding.Descending
-			}
-		}
+	details = appendSpans(details, &tr.FetchSpec, tr.Spans)
 
-		var spanStr strings.Builder
-		spanStr.WriteString("Spans: ")
-		spanStr.WriteString(catalogkeys.PrettySpan(keyDirs, tr.Spans[0], 2))
+	if tr.MaxTimestampAgeNanos != 0 {
+		details = append(details, fmt.Sprintf(
+			"Inconsistent scan (max ts age %s)", time.Duration(tr.MaxTimestampAgeNanos),
+		))
+	}
 
-		if len(tr.Spans) > 1 {
-			spanStr.WriteString(fmt.Sprintf(" and %d other", len(tr.Spans)-1))
-		}
+	return "TableReader", details
+}
 
-		if len(tr.Spans) > 2 {
-			spanStr.WriteString("s") // pluralize the 'other'
+// appendSpans appends a description of the first of the given spans of the
+// index described by the given fetch spec, if there are any.
+func appendSpans(
+	details []string, fetchSpec *fetchpb.IndexFetchSpec, spans []roachpb.Span,
+) []string {
+	if len(spans) == 0 {
+		return details
+	}
+	// only show the first span
+	keyDirs := make([]encoding.Direction, len(fetchSpec.KeyAndSuffixColumns))
+	for i := range keyDirs {
+		keyDirs[i] = encoding.Ascending
+		if fetchSpec.KeyAndSuffixColumns[i].Direction == catenumpb.IndexColumn_DESC {
+			keyDirs[i] = encoding.Descending
 		}
+	}
+
+	var spanStr strings.Builder
+	spanStr.WriteString("Spans: ")
+	spanStr.WriteString(catalogkeys.PrettySpan(keyDirs, spans[0], 2))
 
-		details = append(details, spanStr.String())
+	if len(spans) > 1 {
+		spanStr.WriteString(fmt.Sprintf(" and %d other", len(spans)-1))
 	}
 
-	if tr.MaxTimestampAgeNanos != 0 {
-		details = append(details, fmt.Sprintf(
-			"Inconsistent scan (max ts age %s)", time.Duration(tr.MaxTimestampAgeNanos),
-		))
+	if len(spans) > 2 {
+		spanStr.WriteString("s") // pluralize the 'other'
 	}
 
-	return "TableReader", details
+	return append(details, spanStr.String())
+}
+
+// summary implements the diagramCellType interface.
+func (as *AggregateScannerSpec) summary() (string, []string) {
+	details := make([]string, 0, len(as.Aggregates)+3)
+	details = append(details, fmt.Sprintf("%s@%s", as.FetchSpec.TableName, as.FetchSpec.IndexName))
+	details = appendColumns(details, as.FetchSpec.FetchedColumns)
+	details = appendSpans(details, &as.FetchSpec, as.Spans)
+	if n := len(as.FetchSpec.Filter); n > 0 {
+		details = append(details, fmt.Sprintf("Filter: %d predicate(s)", n))
+	}
+	for _, agg := range as.Aggregates {
+		if agg.Func == kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS {
+			details = append(details, agg.Func.String())
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s(@%d)", agg.Func, agg.FetchedColumnOrdinal+1))
+	}
+	return "AggregateScanner", details
 }
 
 // summary implements the diagramCellType interface.
@@ -709,6 +739,27 @@ func (i *InsertSpec) summary() (string, []string) {
 	}
 }
 
+// summary implements the diagramCellType interface.
+func (u *UpdateSpec) summary() (string, []string) {
+	return "Update", []string{
+		fmt.Sprintf("TableID: %d", u.Table.ID),
+	}
+}
+
+// summary implements the diagramCellType interface.
+func (u *UpsertSpec) summary() (string, []string) {
+	return "Upsert", []string{
+		fmt.Sprintf("TableID: %d", u.Table.ID),
+	}
+}
+
+// summary implements the diagramCellType interface.
+func (d *DeleteSpec) summary() (string, []string) {
+	return "Delete", []string{
+		fmt.Sprintf("TableID: %d", d.Table.ID),
+	}
+}
+
 // summary implements the diagramCellType interface.
 func (i *IngestStoppedSpec) summary() (string, []string) {
 	detail := fmt.Sprintf("job %d ingest stopped spans", i.JobID)
@@ -352,6 +352,20 @@ func (*DummyEvalPlanner) PLpgSQLFetchCursor(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// PLpgSQLExecuteDynamic is part of the Planner interface.
+func (*DummyEvalPlanner) PLpgSQLExecuteDynamic(
+	context.Context, string, tree.Datums, bool, bool,
+) (tree.Datums, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLOpenDynamicCursor is part of the Planner interface.
+func (*DummyEvalPlanner) PLpgSQLOpenDynamicCursor(
+	context.Context, tree.Name, string, tree.Datums,
+) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 func (p *DummyEvalPlanner) StartHistoryRetentionJob(
 	ctx context.Context, desc string, protectTS hlc.Timestamp, expiration time.Duration,
 ) (jobspb.JobID, error) {
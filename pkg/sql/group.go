@@ -82,6 +82,8 @@ type aggregateFuncHolder struct {
 	// distsqlBlocklist is set when this function cannot be evaluated in
 	// distributed fashion.
 	distsqlBlocklist bool
+	// userDefined is set if this is a user-defined aggregate function.
+	userDefined *tree.UserDefinedAggregate
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
@@ -59,6 +59,10 @@ type insertRun struct {
 
 	checkOrds checkSet
 
+	// checkPolicy is true if the last check column holds the result of the
+	// row-level security policies of the table.
+	checkPolicy bool
+
 	// insertCols are the columns being inserted into.
 	insertCols []catalog.Column
 
@@ -223,7 +227,7 @@ func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) erro
 		checkVals := rowVals[len(r.insertCols):]
 		if err := checkMutationInput(
 			params.ctx, params.p.EvalContext(), &params.p.semaCtx, params.p.SessionData(),
-			r.ti.tableDesc(), r.checkOrds, checkVals,
+			r.ti.tableDesc(), r.checkOrds, checkVals, r.checkPolicy,
 		); err != nil {
 			return err
 		}
@@ -412,6 +412,9 @@ func (ie *InternalExecutor) newConnExecutorWithTxn(
 			ex.extraTxnState.descCollection = ie.extraTxnState.descCollection
 			ex.extraTxnState.jobs = ie.extraTxnState.jobs
 			ex.extraTxnState.schemaChangerState = ie.extraTxnState.schemaChangerState
+			if ie.extraTxnState.tempTableCommitActions != nil {
+				ex.extraTxnState.tempTableCommitActions = ie.extraTxnState.tempTableCommitActions
+			}
 			ex.extraTxnState.shouldResetSyntheticDescriptors = shouldResetSyntheticDescriptors
 		}
 	}
@@ -1656,6 +1659,11 @@ func (icc *internalClientComm) Flush(pos CmdPos) error {
 	return nil
 }
 
+// BufferNotification is part of the ClientComm interface.
+func (icc *internalClientComm) BufferNotification(serverpb.Notification) error {
+	return nil
+}
+
 // CreateDescribeResult is part of the ClientComm interface.
 func (icc *internalClientComm) CreateDescribeResult(pos CmdPos) DescribeResult {
 	return icc.createRes(pos)
@@ -1691,6 +1699,13 @@ func (icc *internalClientComm) CreateCopyOutResult(cmd CopyOut, pos CmdPos) Copy
 	panic("unimplemented")
 }
 
+// CreateReplicationResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateReplicationResult(
+	cmd StartReplication, pos CmdPos,
+) ReplicationResult {
+	panic("unimplemented")
+}
+
 // CreateDrainResult is part of the ClientComm interface.
 func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
@@ -1749,6 +1764,11 @@ type extraTxnState struct {
 	jobs               *txnJobsCollection
 	schemaChangerState *SchemaChangerState
 
+	// tempTableCommitActions is shared with the caller so that the temporary
+	// tables used by internal statements get their ON COMMIT actions applied
+	// when the caller commits.
+	tempTableCommitActions *tempTableCommitActions
+
 	// regionsProvider is populated lazily.
 	regionsProvider *regions.Provider
 }
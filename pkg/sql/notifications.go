@@ -0,0 +1,654 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// maxNotificationChannelLength is the maximum length of a notification
+// channel name, matching NAMEDATALEN-1 in Postgres.
+const maxNotificationChannelLength = 63
+
+// maxNotificationPayloadLength is the maximum length of the payload of a
+// notification, as in Postgres.
+const maxNotificationPayloadLength = 7999
+
+// maxQueuedNotifications is the maximum number of notifications queued for
+// delivery to the client of a session. If a session receives more
+// notifications before the queue has been delivered, the notifications are
+// lost and the session is terminated (see errNotificationsLost).
+const maxQueuedNotifications = 1 << 16
+
+// maxOutgoingNotifications is the maximum number of notifications waiting to be
+// forwarded to the other nodes, including the notifications sent by open
+// transactions. NOTIFY fails once it is reached.
+const maxOutgoingNotifications = 1 << 16
+
+// notifyListenersTimeout bounds the time spent forwarding notifications to the
+// other nodes of the cluster.
+const notifyListenersTimeout = 10 * time.Second
+
+// notificationRegistry keeps track of the sessions of a node that listen on
+// notification channels, and delivers to them the notifications sent on these
+// channels by the transactions committed on any node of the cluster.
+//
+// When a transaction that sent notifications commits, they are delivered
+// synchronously to the listening sessions of its gateway node, and then
+// forwarded asynchronously to the other nodes through the NotifyListeners
+// endpoint of the status server. The notifications of all the transactions of
+// a node are forwarded by a single task, in commit order, so every listening
+// session receives the notifications sent from a node in the order in which
+// their transactions committed.
+//
+// Notifications are never dropped silently. Space in the outgoing buffer is
+// reserved when NOTIFY is executed, which fails if the buffer is full. The
+// notifications that cannot be forwarded to a node (e.g. because it is
+// unavailable) are lost. Every batch of forwarded notifications carries a
+// sequence number, so the node notices the gap when it receives the next
+// batch, and it then terminates all its listening sessions so that their
+// clients know they need to resynchronize. The same happens to a session whose
+// queue of notifications overflows.
+//
+// A node cannot tell whether it missed the notifications forwarded by another
+// node before it first heard from it since it started.
+type notificationRegistry struct {
+	stopper *stop.Stopper
+	// statusServer is used to forward notifications to the other nodes. It is
+	// nil in some tests, in which case notifications are only delivered
+	// locally.
+	statusServer serverpb.SQLStatusServer
+	// epoch is the time at which the registry was created. It identifies the
+	// sequence numbers of the notifications it forwards, which start over when
+	// the node restarts.
+	epoch int64
+
+	mu struct {
+		syncutil.RWMutex
+		// listeners maps every channel to the sessions listening on it.
+		listeners map[string]map[*notificationManager]struct{}
+	}
+
+	outgoing struct {
+		syncutil.Mutex
+		// notifications are waiting to be forwarded to the other nodes.
+		notifications []serverpb.Notification
+		// reserved is the number of notifications sent by open transactions,
+		// for which space is reserved in the outgoing buffer.
+		reserved int
+		// seq is the sequence number of the last batch of notifications that
+		// was forwarded, or dropped.
+		seq uint64
+		// forwarding is set while a task forwards the outgoing notifications.
+		forwarding bool
+	}
+
+	incoming struct {
+		syncutil.Mutex
+		// senders maps every node from which notifications were received to
+		// the last batch received from it.
+		senders map[roachpb.NodeID]notificationBatchID
+	}
+}
+
+// notificationBatchID identifies a batch of notifications forwarded by a node.
+type notificationBatchID struct {
+	epoch int64
+	seq   uint64
+}
+
+func newNotificationRegistry(
+	stopper *stop.Stopper, statusServer serverpb.SQLStatusServer,
+) *notificationRegistry {
+	r := &notificationRegistry{
+		stopper:      stopper,
+		statusServer: statusServer,
+		epoch:        timeutil.Now().UnixNano(),
+	}
+	r.mu.listeners = make(map[string]map[*notificationManager]struct{})
+	r.incoming.senders = make(map[roachpb.NodeID]notificationBatchID)
+	return r
+}
+
+func (r *notificationRegistry) listen(m *notificationManager, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*notificationManager]struct{})
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[m] = struct{}{}
+}
+
+func (r *notificationRegistry) unlisten(m *notificationManager, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.listeners[channel]
+	delete(listeners, m)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// deliver queues the given notifications for delivery to the sessions of
+// this node listening on their channels. If missed is set, this node could
+// not be sent some earlier notifications, so all its listening sessions are
+// terminated.
+func (r *notificationRegistry) deliver(
+	ctx context.Context, notifications []serverpb.Notification, missed bool,
+) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if missed {
+		log.Warningf(ctx, "terminating the sessions listening for notifications: "+
+			"some notifications could not be forwarded to this node")
+		for _, listeners := range r.mu.listeners {
+			for m := range listeners {
+				m.markLost(ctx)
+			}
+		}
+	}
+	for i := range notifications {
+		for m := range r.mu.listeners[notifications[i].Channel] {
+			m.enqueue(ctx, notifications[i])
+		}
+	}
+}
+
+// receive delivers the notifications forwarded by another node to the
+// sessions of this node listening on their channels. If the batch of
+// notifications does not follow the last one received from that node, this
+// node missed some notifications, so all its listening sessions are
+// terminated. Batches older than the last one received are ignored, since they
+// were already accounted for as missed.
+func (r *notificationRegistry) receive(
+	ctx context.Context,
+	sender roachpb.NodeID,
+	id notificationBatchID,
+	notifications []serverpb.Notification,
+) {
+	// The lock is held while delivering, so that the notifications are
+	// delivered in the order of their sequence numbers.
+	r.incoming.Lock()
+	defer r.incoming.Unlock()
+	last, ok := r.incoming.senders[sender]
+	var missed bool
+	switch {
+	case !ok:
+		// This is the first batch received from the sender.
+	case id.epoch < last.epoch || (id.epoch == last.epoch && id.seq <= last.seq):
+		// The batch was delayed, and was reported as missed when the
+		// following one was received.
+		return
+	case id.epoch > last.epoch:
+		// The sender restarted and numbers its batches from 1 again.
+		missed = id.seq != 1
+	default:
+		missed = id.seq != last.seq+1
+	}
+	r.incoming.senders[sender] = id
+	if missed {
+		log.Warningf(ctx, "some notifications forwarded by node %d were not received", sender)
+	}
+	r.deliver(ctx, notifications, missed)
+}
+
+// reserve reserves space in the outgoing buffer for a notification sent by an
+// open transaction. It returns an error if the buffer is full.
+func (r *notificationRegistry) reserve() error {
+	r.outgoing.Lock()
+	defer r.outgoing.Unlock()
+	if len(r.outgoing.notifications)+r.outgoing.reserved >= maxOutgoingNotifications {
+		return pgerror.New(pgcode.ProgramLimitExceeded,
+			"too many notifications in the NOTIFY queue")
+	}
+	r.outgoing.reserved++
+	return nil
+}
+
+// release releases the space reserved for n notifications sent by a
+// transaction that rolled back.
+func (r *notificationRegistry) release(n int) {
+	r.outgoing.Lock()
+	defer r.outgoing.Unlock()
+	r.outgoing.reserved -= n
+}
+
+// publish delivers the notifications sent by a committed transaction to the
+// listening sessions of this node, and forwards them to the other nodes. Space
+// must have been reserved for them in the outgoing buffer.
+func (r *notificationRegistry) publish(
+	ctx context.Context, notifications []serverpb.Notification,
+) {
+	r.deliver(ctx, notifications, false /* missed */)
+	r.outgoing.Lock()
+	defer r.outgoing.Unlock()
+	r.outgoing.reserved -= len(notifications)
+	if r.statusServer == nil {
+		return
+	}
+	r.outgoing.notifications = append(r.outgoing.notifications, notifications...)
+	if r.outgoing.forwarding {
+		return
+	}
+	// The forwarding task must not be interrupted when the session that
+	// committed the transaction goes away.
+	if err := r.stopper.RunAsyncTask(
+		context.WithoutCancel(ctx), "forward-notifications", r.forward,
+	); err != nil {
+		log.Warningf(ctx, "unable to forward %d notifications to other nodes: %v",
+			len(r.outgoing.notifications), err)
+		// Skipping a sequence number tells the other nodes that they missed
+		// these notifications.
+		r.outgoing.notifications = nil
+		r.outgoing.seq++
+		return
+	}
+	r.outgoing.forwarding = true
+}
+
+// forward sends the outgoing notifications to the other nodes until there are
+// none left.
+func (r *notificationRegistry) forward(ctx context.Context) {
+	for {
+		r.outgoing.Lock()
+		notifications := r.outgoing.notifications
+		r.outgoing.notifications = nil
+		if len(notifications) == 0 {
+			r.outgoing.forwarding = false
+			r.outgoing.Unlock()
+			return
+		}
+		r.outgoing.seq++
+		req := &serverpb.NotifyListenersRequest{
+			Notifications: notifications,
+			SenderEpoch:   r.epoch,
+			Seq:           r.outgoing.seq,
+		}
+		r.outgoing.Unlock()
+
+		var resp *serverpb.NotifyListenersResponse
+		err := timeutil.RunWithTimeout(
+			ctx, "forward notifications", notifyListenersTimeout,
+			func(ctx context.Context) (err error) {
+				resp, err = r.statusServer.NotifyListeners(ctx, req)
+				return err
+			},
+		)
+		// The nodes that did not receive the notifications notice it when they
+		// receive the next ones.
+		if err != nil {
+			log.Warningf(ctx, "unable to forward %d notifications to other nodes: %v",
+				len(notifications), err)
+		} else if len(resp.FailedNodeIDs) > 0 {
+			log.Warningf(ctx, "unable to forward %d notifications to nodes %v",
+				len(notifications), resp.FailedNodeIDs)
+		}
+	}
+}
+
+// notificationManager implements eval.Notifications for a session.
+//
+// As in Postgres, LISTEN, UNLISTEN and NOTIFY take effect when the transaction
+// that executed them commits, and have no effect if it rolls back. Rolling
+// back to a savepoint undoes the ones executed since the savepoint.
+//
+// The notifications received by the session are queued until the connExecutor
+// delivers them to the client, which it only does outside of transactions.
+type notificationManager struct {
+	registry *notificationRegistry
+	// pid identifies the session in the notifications it sends.
+	pid uint32
+	// wakeup is called when a notification is added to an empty queue. It
+	// schedules the delivery of the queue to the client if the session is idle.
+	wakeup func(ctx context.Context)
+
+	// channels is the set of channels the session listens on.
+	channels map[string]struct{}
+
+	// txn contains the effects of the current transaction.
+	txn struct {
+		// ops are the LISTEN and UNLISTEN operations, in order.
+		ops []listenOp
+		// notifications are the notifications sent by the transaction, without
+		// duplicates.
+		notifications []serverpb.Notification
+		sent          map[notificationKey]struct{}
+	}
+
+	mu struct {
+		syncutil.Mutex
+		// queue contains the notifications not yet delivered to the client.
+		queue []serverpb.Notification
+		// lost is set once the session missed some notifications, either
+		// because its queue overflowed or because they could not be forwarded
+		// to this node. The session is then terminated.
+		lost bool
+	}
+}
+
+var _ eval.Notifications = &notificationManager{}
+
+// listenOp is a LISTEN or UNLISTEN operation.
+type listenOp struct {
+	channel  string
+	unlisten bool
+	// all is set for UNLISTEN *.
+	all bool
+}
+
+// notificationKey identifies duplicate notifications sent by a transaction.
+type notificationKey struct {
+	channel, payload string
+}
+
+func newNotificationManager(
+	registry *notificationRegistry, pid uint32, wakeup func(ctx context.Context),
+) *notificationManager {
+	return &notificationManager{
+		registry: registry,
+		pid:      pid,
+		wakeup:   wakeup,
+	}
+}
+
+// Listen is part of the eval.Notifications interface.
+func (m *notificationManager) Listen(_ context.Context, channel string) {
+	m.txn.ops = append(m.txn.ops, listenOp{channel: channel})
+}
+
+// Unlisten is part of the eval.Notifications interface.
+func (m *notificationManager) Unlisten(_ context.Context, channel string) {
+	m.txn.ops = append(m.txn.ops, listenOp{channel: channel, unlisten: true})
+}
+
+// UnlistenAll is part of the eval.Notifications interface.
+func (m *notificationManager) UnlistenAll(_ context.Context) {
+	m.txn.ops = append(m.txn.ops, listenOp{unlisten: true, all: true})
+}
+
+// Notify is part of the eval.Notifications interface.
+func (m *notificationManager) Notify(_ context.Context, channel, payload string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxNotificationChannelLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) > maxNotificationPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	key := notificationKey{channel: channel, payload: payload}
+	if _, ok := m.txn.sent[key]; ok {
+		return nil
+	}
+	if err := m.registry.reserve(); err != nil {
+		return err
+	}
+	if m.txn.sent == nil {
+		m.txn.sent = make(map[notificationKey]struct{})
+	}
+	m.txn.sent[key] = struct{}{}
+	m.txn.notifications = append(m.txn.notifications, serverpb.Notification{
+		Channel: channel,
+		Payload: payload,
+		PID:     m.pid,
+	})
+	return nil
+}
+
+// ListeningChannels is part of the eval.Notifications interface.
+func (m *notificationManager) ListeningChannels() []string {
+	channels := make([]string, 0, len(m.channels))
+	for channel := range m.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// commit applies the effects of the current transaction, which just committed.
+// The LISTEN and UNLISTEN operations are applied first, so the session
+// receives the notifications it sent on the channels it started listening on.
+func (m *notificationManager) commit(ctx context.Context) {
+	for _, op := range m.txn.ops {
+		switch {
+		case op.all:
+			for channel := range m.channels {
+				m.registry.unlisten(m, channel)
+			}
+			m.channels = nil
+		case op.unlisten:
+			if _, ok := m.channels[op.channel]; ok {
+				m.registry.unlisten(m, op.channel)
+				delete(m.channels, op.channel)
+			}
+		default:
+			if _, ok := m.channels[op.channel]; !ok {
+				if m.channels == nil {
+					m.channels = make(map[string]struct{})
+				}
+				m.channels[op.channel] = struct{}{}
+				m.registry.listen(m, op.channel)
+			}
+		}
+	}
+	notifications := m.txn.notifications
+	m.resetTxn()
+	if len(notifications) > 0 {
+		m.registry.publish(ctx, notifications)
+	}
+}
+
+// notificationSavepoint records the effects of the current transaction when a
+// savepoint is created.
+type notificationSavepoint struct {
+	numOps, numNotifications int
+}
+
+// savepoint returns the state to restore when rolling back to a savepoint
+// created now.
+func (m *notificationManager) savepoint() notificationSavepoint {
+	return notificationSavepoint{
+		numOps:           len(m.txn.ops),
+		numNotifications: len(m.txn.notifications),
+	}
+}
+
+// rollbackToSavepoint discards the effects of the current transaction since
+// the given savepoint was created. It is also used to rewind the transaction
+// when it is retried automatically, in which case the effects may already have
+// been discarded by a rollback to an earlier savepoint.
+func (m *notificationManager) rollbackToSavepoint(sp notificationSavepoint) {
+	m.txn.ops = m.txn.ops[:min(sp.numOps, len(m.txn.ops))]
+	discarded := m.txn.notifications[min(sp.numNotifications, len(m.txn.notifications)):]
+	for _, n := range discarded {
+		delete(m.txn.sent, notificationKey{channel: n.Channel, payload: n.Payload})
+	}
+	m.registry.release(len(discarded))
+	m.txn.notifications = m.txn.notifications[:len(m.txn.notifications)-len(discarded)]
+}
+
+// rollback discards the effects of the current transaction.
+func (m *notificationManager) rollback() {
+	m.registry.release(len(m.txn.notifications))
+	m.resetTxn()
+}
+
+func (m *notificationManager) resetTxn() {
+	m.txn.ops = nil
+	m.txn.notifications = nil
+	m.txn.sent = nil
+}
+
+// close stops listening on all channels. It is called when the session ends.
+func (m *notificationManager) close() {
+	m.rollback()
+	for channel := range m.channels {
+		m.registry.unlisten(m, channel)
+	}
+	m.channels = nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mu.queue = nil
+}
+
+// enqueue queues a notification received by the session for delivery to the
+// client.
+func (m *notificationManager) enqueue(ctx context.Context, n serverpb.Notification) {
+	m.mu.Lock()
+	if m.mu.lost {
+		// The session will be terminated, so there is no point in queueing.
+		m.mu.Unlock()
+		return
+	}
+	if len(m.mu.queue) >= maxQueuedNotifications {
+		m.mu.Unlock()
+		log.Warningf(ctx, "terminating session with PID %d: "+
+			"%d notifications are already queued", m.pid, maxQueuedNotifications)
+		m.markLost(ctx)
+		return
+	}
+	wasEmpty := len(m.mu.queue) == 0
+	m.mu.queue = append(m.mu.queue, n)
+	m.mu.Unlock()
+	if wasEmpty {
+		m.wakeup(ctx)
+	}
+}
+
+// markLost records that the session missed some notifications, and schedules
+// its termination.
+func (m *notificationManager) markLost(ctx context.Context) {
+	m.mu.Lock()
+	wasLost := m.mu.lost
+	m.mu.lost = true
+	m.mu.queue = nil
+	m.mu.Unlock()
+	if !wasLost {
+		m.wakeup(ctx)
+	}
+}
+
+// lost returns true if the session missed some notifications.
+func (m *notificationManager) lost() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mu.lost
+}
+
+// takeQueued returns the notifications queued for delivery to the client and
+// empties the queue.
+func (m *notificationManager) takeQueued() []serverpb.Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	queue := m.mu.queue
+	m.mu.queue = nil
+	return queue
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	return &listenNode{listenOp: listenOp{channel: string(n.ChannelName)}}, nil
+}
+
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	if n.Star {
+		return &listenNode{listenOp: listenOp{unlisten: true, all: true}}, nil
+	}
+	if n.ChannelName.NumParts > 1 {
+		return nil, pgerror.Newf(pgcode.Syntax, "invalid channel name: %s", n.ChannelName)
+	}
+	return &listenNode{
+		listenOp: listenOp{channel: n.ChannelName.Object(), unlisten: true},
+	}, nil
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	var payload string
+	if n.Payload != nil {
+		payload = *n.Payload
+	}
+	return &notifyNode{channel: string(n.ChannelName), payload: payload}, nil
+}
+
+type listenNode struct {
+	listenOp
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	notifications := params.EvalContext().Notifications
+	if notifications == nil {
+		return errNotificationsNotSupported
+	}
+	switch {
+	case n.all:
+		notifications.UnlistenAll(params.ctx)
+	case n.unlisten:
+		notifications.Unlisten(params.ctx, n.channel)
+	default:
+		notifications.Listen(params.ctx, n.channel)
+	}
+	return nil
+}
+
+func (*listenNode) Next(runParams) (bool, error) { return false, nil }
+func (*listenNode) Values() tree.Datums          { return nil }
+func (*listenNode) Close(context.Context)        {}
+
+type notifyNode struct {
+	channel, payload string
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	notifications := params.EvalContext().Notifications
+	if notifications == nil {
+		return errNotificationsNotSupported
+	}
+	return notifications.Notify(params.ctx, n.channel, n.payload)
+}
+
+func (*notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (*notifyNode) Values() tree.Datums          { return nil }
+func (*notifyNode) Close(context.Context)        {}
+
+var errNotificationsNotSupported = pgerror.New(pgcode.FeatureNotSupported,
+	"LISTEN, UNLISTEN and NOTIFY are not supported in this context")
+
+// errNotificationsLost is sent to the client of a session that missed some
+// notifications before the session is terminated. Clients handle
+// pgcode.AdminShutdown by reconnecting, after which they need to resynchronize
+// the state they maintain using notifications.
+var errNotificationsLost = errors.WithHint(
+	pgerror.New(pgcode.AdminShutdown,
+		"terminating connection because some notifications could not be delivered"),
+	"Reconnect and listen again. The notifications sent in the meantime are lost.",
+)
@@ -0,0 +1,132 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationsOutgoingLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newNotificationRegistry(nil /* stopper */, nil /* statusServer */)
+	m := newNotificationManager(r, 1 /* pid */, func(context.Context) {})
+
+	for i := 0; i < maxOutgoingNotifications; i++ {
+		require.NoError(t, m.Notify(ctx, "c", fmt.Sprint(i)))
+	}
+	// Duplicate notifications don't take any space.
+	require.NoError(t, m.Notify(ctx, "c", "0"))
+	err := m.Notify(ctx, "c", "overflow")
+	require.Equal(t, pgcode.ProgramLimitExceeded, pgerror.GetPGCode(err))
+
+	// Rolling back releases the reserved space.
+	m.rollback()
+	require.NoError(t, m.Notify(ctx, "c", "after rollback"))
+
+	// So does committing, once the notifications are published.
+	m.commit(ctx)
+	require.Zero(t, r.outgoing.reserved)
+}
+
+func TestNotificationsLost(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newNotificationRegistry(nil /* stopper */, nil /* statusServer */)
+	var wakeups int
+	listen := func(pid uint32, channel string) *notificationManager {
+		m := newNotificationManager(r, pid, func(context.Context) { wakeups++ })
+		m.Listen(ctx, channel)
+		m.commit(ctx)
+		return m
+	}
+
+	t.Run("queue overflow", func(t *testing.T) {
+		wakeups = 0
+		m := listen(1, "overflow")
+		defer m.close()
+		n := serverpb.Notification{Channel: "overflow"}
+		for i := 0; i < maxQueuedNotifications; i++ {
+			r.deliver(ctx, []serverpb.Notification{n}, false /* missed */)
+		}
+		require.False(t, m.lost())
+		require.Equal(t, 1, wakeups)
+
+		r.deliver(ctx, []serverpb.Notification{n}, false /* missed */)
+		require.True(t, m.lost())
+		require.Equal(t, 2, wakeups)
+		require.Empty(t, m.takeQueued())
+
+		// The session is only woken up once more.
+		r.deliver(ctx, []serverpb.Notification{n}, false /* missed */)
+		require.Equal(t, 2, wakeups)
+	})
+
+	t.Run("missed", func(t *testing.T) {
+		wakeups = 0
+		listening := listen(2, "a")
+		defer listening.close()
+		idle := newNotificationManager(r, 3, func(context.Context) { wakeups++ })
+		defer idle.close()
+
+		// All the listening sessions are terminated, whichever channel they
+		// listen on.
+		r.deliver(ctx, []serverpb.Notification{{Channel: "b"}}, true /* missed */)
+		require.True(t, listening.lost())
+		require.False(t, idle.lost())
+		require.Equal(t, 1, wakeups)
+	})
+
+	t.Run("sequence gap", func(t *testing.T) {
+		const sender = roachpb.NodeID(2)
+		receive := func(epoch int64, seq uint64) {
+			r.receive(ctx, sender, notificationBatchID{epoch: epoch, seq: seq},
+				[]serverpb.Notification{{Channel: "gap"}})
+		}
+		m := listen(4, "gap")
+		defer m.close()
+
+		// Consecutive batches are delivered.
+		receive(1, 5)
+		receive(1, 6)
+		require.False(t, m.lost())
+		require.Len(t, m.takeQueued(), 2)
+
+		// So are the batches of a sender that restarted, starting at 1.
+		receive(2, 1)
+		require.False(t, m.lost())
+		require.Len(t, m.takeQueued(), 1)
+
+		// Delayed batches are ignored.
+		receive(1, 7)
+		receive(2, 1)
+		require.False(t, m.lost())
+		require.Empty(t, m.takeQueued())
+
+		// A gap terminates the session.
+		receive(2, 3)
+		require.True(t, m.lost())
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Policy is an interface to a row-level security policy on a table, exposing
+// only the information needed by the query optimizer.
+type Policy interface {
+	// Name is the name of the policy.
+	Name() tree.Name
+
+	// IsRestrictive returns true if the policy is RESTRICTIVE, and false if it
+	// is PERMISSIVE. A row is visible only if it satisfies at least one
+	// permissive policy and all restrictive policies that apply.
+	IsRestrictive() bool
+
+	// Command returns the command to which the policy applies.
+	Command() catpb.PolicyCommand
+
+	// RoleCount returns the number of roles to which the policy applies.
+	RoleCount() int
+
+	// Role returns the ith role to which the policy applies, where
+	// i < RoleCount.
+	Role(i int) username.SQLUsername
+
+	// UsingExpr returns the SQL text of the USING expression of the policy, or
+	// the empty string if there is none.
+	UsingExpr() string
+
+	// WithCheckExpr returns the SQL text of the WITH CHECK expression of the
+	// policy, or the empty string if there is none.
+	WithCheckExpr() string
+}
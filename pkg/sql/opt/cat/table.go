@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -180,6 +181,36 @@ type Table interface {
 	// IsHypothetical returns true if this is a hypothetical table (used when
 	// searching for index recommendations).
 	IsHypothetical() bool
+
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if row-level security also applies
+	// to the owner of the table.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where i < PolicyCount.
+	Policy(i int) Policy
+
+	// TriggerCount returns the number of triggers defined on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// ordered by name, which is the order in which they fire.
+	Trigger(i int) Trigger
+
+	// InheritedByCount returns the number of tables that inherit from this
+	// table (see CREATE TABLE ... INHERITS).
+	InheritedByCount() int
+
+	// InheritedBy returns the StableID of the ith table that inherits from
+	// this table, where i < InheritedByCount.
+	InheritedBy(i int) StableID
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -328,6 +359,11 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checks of the constraint can be deferred
+	// until the end of the transaction. The existing data of a deferrable
+	// constraint is not guaranteed to satisfy it until the transaction commits.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -375,6 +411,24 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrability returns whether the checks of the constraint can be deferred
+	// until the end of the transaction. Only constraints that are not enforced by
+	// an index can be deferrable.
+	Deferrability() tree.ConstraintDeferrability
+
+	// IsExclusion is true if this is an exclusion constraint, which is never
+	// enforced by a unique index; its checks use an existing index of the
+	// table to find conflicting rows instead. Two rows conflict if, for every
+	// column i of the constraint, their values compare true with
+	// ExclusionOperator(i). Since the operators are not all equality, an
+	// exclusion constraint does not imply that its columns form a key.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator used to compare the values of the
+	// ith column of an exclusion constraint. It is either treecmp.EQ or
+	// treecmp.Overlaps.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -0,0 +1,68 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// Trigger is an interface to a trigger on a table, exposing only the
+// information needed by the query optimizer.
+type Trigger interface {
+	// Name is the name of the trigger. It is unique within the table.
+	Name() tree.Name
+
+	// ActionTime returns whether the trigger fires before or after the
+	// operation on the table.
+	ActionTime() tree.TriggerActionTime
+
+	// EventCount returns the number of events which fire the trigger.
+	EventCount() int
+
+	// Event returns the ith event which fires the trigger, where
+	// i < EventCount.
+	Event(i int) TriggerEvent
+
+	// NewTransitionAlias returns the name of the transition table with the new
+	// values of the modified rows, or the empty string if there is none.
+	NewTransitionAlias() tree.Name
+
+	// OldTransitionAlias returns the name of the transition table with the old
+	// values of the modified rows, or the empty string if there is none.
+	OldTransitionAlias() tree.Name
+
+	// ForEachRow returns true if the trigger fires once for each modified row,
+	// and false if it fires once per statement.
+	ForEachRow() bool
+
+	// WhenExpr returns the SQL text of the WHEN condition of the trigger, or
+	// the empty string if there is none. It refers to the columns of the
+	// table as NEW.col and OLD.col.
+	WhenExpr() string
+
+	// FuncID returns the ID of the trigger function.
+	FuncID() StableID
+
+	// FuncArgs returns the arguments which are passed to the trigger function
+	// as TG_ARGV.
+	FuncArgs() []string
+
+	// Enabled returns false if the trigger has been disabled.
+	Enabled() bool
+}
+
+// TriggerEvent is a mutation which fires a trigger.
+type TriggerEvent struct {
+	// EventType is the kind of mutation.
+	EventType tree.TriggerEventType
+
+	// Columns is set for UPDATE OF triggers, which only fire if one of the
+	// columns is the target of the UPDATE.
+	Columns tree.NameList
+}
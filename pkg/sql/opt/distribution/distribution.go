@@ -50,6 +50,14 @@ func CanProvide(
 			provided.FromIndexScan(ctx, evalCtx, tabMeta, t.Index, t.Constraint)
 		}
 
+	case *memo.AggregateScanExpr:
+		tabMeta := t.Memo().Metadata().TableMeta(t.Table)
+		if t.Distribution.Regions != nil {
+			provided = t.Distribution
+		} else {
+			provided.FromIndexScan(ctx, evalCtx, tabMeta, t.Index, t.Constraint)
+		}
+
 	case *memo.LookupJoinExpr:
 		if t.LocalityOptimized {
 			provided.FromLocality(evalCtx.Locality)
@@ -78,7 +86,7 @@ func BuildChildRequired(
 	case *memo.LocalityOptimizedSearchExpr:
 		return physical.Distribution{}
 
-	case *memo.ScanExpr:
+	case *memo.ScanExpr, *memo.AggregateScanExpr:
 		return physical.Distribution{}
 	}
 
@@ -112,6 +120,14 @@ func BuildProvided(
 			provided.FromIndexScan(ctx, evalCtx, tabMeta, t.Index, t.Constraint)
 		}
 
+	case *memo.AggregateScanExpr:
+		tabMeta := t.Memo().Metadata().TableMeta(t.Table)
+		if t.Distribution.Regions != nil {
+			provided = t.Distribution
+		} else {
+			provided.FromIndexScan(ctx, evalCtx, tabMeta, t.Index, t.Constraint)
+		}
+
 	default:
 		// TODO(msirek): Clarify the distinction between a distribution which can
 		//               provide any required distribution and one which can provide
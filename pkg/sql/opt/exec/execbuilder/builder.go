@@ -111,6 +111,12 @@ type Builder struct {
 	// subqueries for statements inside a UDF.
 	planLazySubqueries bool
 
+	// allowRoutineOuterWithRefs is true if the statements inside routines built
+	// by this builder may refer to With expressions of the enclosing plan. It is
+	// set when planning AFTER triggers, since the trigger function can read the
+	// buffered mutation input through its transition tables.
+	allowRoutineOuterWithRefs bool
+
 	// tailCalls is used when building the last body statement of a routine. It
 	// identifies nested routines that are in tail-call position. This information
 	// is used to determine whether tail-call optimization is applicable.
@@ -154,6 +154,7 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
 	return exec.Cascade{
 		FKConstraint: cascade.FKConstraint,
+		Trigger:      cascade.Trigger,
 		Buffer:       cb.mutationBuffer,
 		PlanFn: func(
 			ctx context.Context,
@@ -303,6 +304,8 @@ func (cb *cascadeBuilder) planCascade(
 	if bufferRef != nil {
 		// Set up the With binding.
 		eb.addBuiltWithExpr(cascadeInputWithID, bufferColMap, bufferRef)
+		// Trigger functions read the transition tables from the binding.
+		eb.allowRoutineOuterWithRefs = cascade.Trigger != nil
 	}
 	plan, err := eb.Build()
 	if err != nil {
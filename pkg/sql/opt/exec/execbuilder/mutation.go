@@ -23,6 +23,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
@@ -135,6 +137,11 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	// Inserts only have cascading queries if the table has AFTER triggers.
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	return ep, outputCols, nil
 }
 
@@ -153,11 +160,28 @@ func (b *Builder) tryBuildFastPathInsert(
 	if !b.allowInsertFastPath {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// AFTER triggers are planned as cascades, which the fast path does not
+	// support.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 	// If there are unique checks required, there must be the same number of fast
 	// path unique checks.
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// The fast path fails on any violation found by the checks, so it cannot be
+	// used if any of them is deferred until the end of the transaction.
+	for i := range ins.UniqueChecks {
+		if b.isUniqueCheckDeferred(&ins.UniqueChecks[i]) {
+			return execPlan{}, colOrdMap{}, false, nil
+		}
+	}
+	for i := range ins.FKChecks {
+		if b.isFKCheckDeferred(&ins.FKChecks[i]) {
+			return execPlan{}, colOrdMap{}, false, nil
+		}
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -801,6 +825,7 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
+		deferViolation := b.deferUniqueCheck(c)
 		// Construct the query that returns uniqueness violations.
 		query, queryCols, err := b.buildRelational(c.Check)
 		if err != nil {
@@ -816,6 +841,9 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferViolation != nil {
+				return deferViolation(keyVals)
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -833,6 +861,7 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
+		deferViolation := b.deferFKCheck(c)
 		// Construct the query that returns FK violations.
 		query, queryCols, err := b.buildRelational(c.Check)
 		if err != nil {
@@ -848,6 +877,9 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferViolation != nil {
+				return deferViolation(keyVals)
+			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -859,12 +891,106 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// deferredConstraints returns the tracker of the deferred constraints of the
+// current transaction, or nil if constraint checks cannot be deferred.
+// Statements in implicit transactions always run their checks immediately,
+// since there is no later point at which the checks could be performed.
+func (b *Builder) deferredConstraints() eval.DeferredConstraints {
+	if b.evalCtx == nil || b.evalCtx.TxnImplicit {
+		return nil
+	}
+	return b.evalCtx.DeferredConstraints
+}
+
+// isUniqueCheckDeferred returns true if the violations found by the given
+// uniqueness check must not cause the current statement to fail because its
+// constraint is deferred until the end of the transaction.
+func (b *Builder) isUniqueCheckDeferred(c *memo.UniqueChecksItem) bool {
+	dc := b.deferredConstraints()
+	if dc == nil {
+		return false
+	}
+	tab := b.mem.Metadata().Table(c.Table)
+	uc := tab.Unique(c.CheckOrdinal)
+	return dc.IsDeferred(catid.DescID(tab.ID()), uc.Name(), uc.Deferrability())
+}
+
+// deferUniqueCheck returns nil if the given uniqueness check is not deferred.
+// Otherwise, it returns a function that records the key of a violating row for
+// validation at the end of the transaction.
+func (b *Builder) deferUniqueCheck(c *memo.UniqueChecksItem) func(keyVals tree.Datums) error {
+	if !b.isUniqueCheckDeferred(c) {
+		return nil
+	}
+	tab := b.mem.Metadata().Table(c.Table)
+	return b.deferViolation(catid.DescID(tab.ID()), tab.Unique(c.CheckOrdinal).Name())
+}
+
+// fkCheckConstraint returns the foreign key constraint verified by the given
+// check.
+func (b *Builder) fkCheckConstraint(c *memo.FKChecksItem) cat.ForeignKeyConstraint {
+	md := b.mem.Metadata()
+	if c.FKOutbound {
+		return md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal)
+	}
+	return md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+}
+
+// isFKCheckDeferred returns true if the violations found by the given foreign
+// key check must not cause the current statement to fail because its
+// constraint is deferred until the end of the transaction.
+func (b *Builder) isFKCheckDeferred(c *memo.FKChecksItem) bool {
+	dc := b.deferredConstraints()
+	if dc == nil {
+		return false
+	}
+	fk := b.fkCheckConstraint(c)
+	if !c.FKOutbound {
+		// As in Postgres, the checks of a RESTRICT action are never deferred.
+		action := fk.UpdateReferenceAction()
+		if c.OpName == "delete" {
+			action = fk.DeleteReferenceAction()
+		}
+		if action == tree.Restrict {
+			return false
+		}
+	}
+	return dc.IsDeferred(catid.DescID(fk.OriginTableID()), fk.Name(), fk.Deferrability())
+}
+
+// deferFKCheck returns nil if the given foreign key check is not deferred.
+// Otherwise, it returns a function that records the key of a violating row for
+// validation at the end of the transaction.
+func (b *Builder) deferFKCheck(c *memo.FKChecksItem) func(keyVals tree.Datums) error {
+	if !b.isFKCheckDeferred(c) {
+		return nil
+	}
+	fk := b.fkCheckConstraint(c)
+	return b.deferViolation(catid.DescID(fk.OriginTableID()), fk.Name())
+}
+
+// deferViolation returns a function that records the key of a row violating
+// the given deferred constraint. The violation may still be resolved by a
+// later statement of the transaction, so the key is validated again before the
+// transaction commits.
+func (b *Builder) deferViolation(
+	tableID catid.DescID, name string,
+) func(keyVals tree.Datums) error {
+	ctx, dc := b.ctx, b.evalCtx.DeferredConstraints
+	return func(keyVals tree.Datums) error {
+		return dc.Defer(ctx, tableID, name, keyVals)
+	}
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
 func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	if uc.IsExclusion() {
+		return mkExclusionCheckErr(md, c, keyVals)
+	}
 	constraintName := uc.Name()
 	var msg, details bytes.Buffer
 
@@ -901,6 +1027,48 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing a violation
+// of an exclusion constraint. The keyVals are the values that correspond to
+// the cat.UniqueConstraint columns.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	uc := tabMeta.Table.Unique(c.CheckOrdinal)
+	constraintName := uc.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k, r)=(2, [1,5)) conflicts with an existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	details.WriteString("Key (")
+	for i := 0; i < uc.ColumnCount(); i++ {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		col := tabMeta.Table.Column(uc.ColumnOrdinal(tabMeta.Table, i))
+		details.WriteString(string(col.ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+
+	details.WriteString(") conflicts with an existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkUniqueCheckErrWithoutColNames is a simpler version of mkUniqueCheckErr that
 // omits column names from the error details.
 func mkUniqueCheckErrWithoutColNames(
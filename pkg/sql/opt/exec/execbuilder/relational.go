@@ -200,6 +200,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (_ execPlan, outputCols colOrd
 	case *memo.PlaceholderScanExpr:
 		ep, outputCols, err = b.buildPlaceholderScan(t)
 
+	case *memo.AggregateScanExpr:
+		ep, outputCols, err = b.buildAggregateScan(t)
+
 	case *memo.SelectExpr:
 		ep, outputCols, err = b.buildSelect(t)
 
@@ -943,6 +946,103 @@ func (b *Builder) buildPlaceholderScan(
 	return res, outputCols, nil
 }
 
+func (b *Builder) buildAggregateScan(
+	scan *memo.AggregateScanExpr,
+) (_ execPlan, outputCols colOrdMap, err error) {
+	md := b.mem.Metadata()
+	tab := md.Table(scan.Table)
+	idx := tab.Index(scan.Index)
+
+	isUnfiltered := scan.IsUnfiltered(md)
+	if scan.Flags.NoFullScan && isUnfiltered {
+		return execPlan{}, colOrdMap{}, fmt.Errorf("could not produce a query plan conforming to the NO_FULL_SCAN hint")
+	}
+	b.IndexesUsed = util.CombineUnique(b.IndexesUsed, []string{fmt.Sprintf("%d@%d", tab.ID(), idx.ID())})
+
+	// The AggregateScan is in the same group as the ScalarGroupBy it replaces,
+	// so the statistics of the scanned rows are those of the ScalarGroupBy
+	// input.
+	inputProps := scan.FirstExpr().(*memo.ScalarGroupByExpr).Input.Relational()
+	stats := inputProps.Statistics()
+	if isUnfiltered {
+		large := !stats.Available || stats.RowCount > b.evalCtx.SessionData().LargeFullScanRows
+		if scan.Index == cat.PrimaryIndex {
+			b.flags.Set(exec.PlanFlagContainsFullTableScan)
+			if large {
+				b.flags.Set(exec.PlanFlagContainsLargeFullTableScan)
+			}
+		} else {
+			b.flags.Set(exec.PlanFlagContainsFullIndexScan)
+			if large {
+				b.flags.Set(exec.PlanFlagContainsLargeFullIndexScan)
+			}
+		}
+		if stats.Available && stats.RowCount > b.MaxFullScanRows {
+			b.MaxFullScanRows = stats.RowCount
+		}
+	}
+	b.ScanCounts[exec.ScanCount]++
+	if stats.Available {
+		b.TotalScanRows += stats.RowCount
+		b.ScanCounts[exec.ScanWithStatsCount]++
+	}
+
+	params, scanCols, err := b.scanParams(tab, &scan.ScanPrivate, inputProps, physical.MinRequired)
+	// The scan column map is only used for the lifetime of this function, so
+	// free the map afterward.
+	defer b.colOrdsAlloc.Free(scanCols)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	// The aggregates are computed over all the rows of the scan, so it cannot
+	// be limited (not even by the txn_rows_read_err guardrail).
+	params.HardLimit = 0
+	params.SoftLimit = 0
+	params.Parallelize = false
+
+	var filter tree.TypedExpr
+	if len(scan.Filters) > 0 {
+		filter, err = b.buildScalarWithMap(scanCols, &scan.Filters)
+		if err != nil {
+			return execPlan{}, colOrdMap{}, err
+		}
+	}
+
+	aggInfos := make([]exec.AggInfo, len(scan.Aggregations))
+	outputCols = b.colOrdsAlloc.Alloc()
+	for i := range scan.Aggregations {
+		item := &scan.Aggregations[i]
+		name, overload := memo.FindAggregateOverload(item.Agg)
+		var argCols []exec.NodeColumnOrdinal
+		if item.Agg.ChildCount() > 0 {
+			variable, ok := item.Agg.Child(0).(*memo.VariableExpr)
+			if !ok {
+				return execPlan{}, colOrdMap{}, errors.AssertionFailedf("only VariableOp args supported")
+			}
+			ord, err := getNodeColumnOrdinal(scanCols, variable.Col)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			argCols = []exec.NodeColumnOrdinal{ord}
+		}
+		aggInfos[i] = exec.AggInfo{
+			FuncName:         name,
+			ResultType:       item.Agg.DataType(),
+			ArgCols:          argCols,
+			Filter:           tree.NoColumnIdx,
+			DistsqlBlocklist: overload.DistsqlBlocklist,
+		}
+		outputCols.Set(item.Col, i)
+	}
+
+	var res execPlan
+	res.root, err = b.factory.ConstructAggregateScan(tab, idx, params, filter, aggInfos)
+	if err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+	return res, outputCols, nil
+}
+
 func (b *Builder) buildSelect(sel *memo.SelectExpr) (_ execPlan, outputCols colOrdMap, err error) {
 	input, inputCols, err := b.buildRelational(sel.Input)
 	if err != nil {
@@ -1660,6 +1760,35 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
+		if udAgg, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			// The arguments of a user-defined aggregate are always variables.
+			for _, arg := range udAgg.Args {
+				variable, ok := arg.(*memo.VariableExpr)
+				if !ok {
+					return execPlan{}, colOrdMap{}, errors.AssertionFailedf("only VariableOp args supported")
+				}
+				ord, err := getNodeColumnOrdinal(inputCols, variable.Col)
+				if err != nil {
+					return execPlan{}, colOrdMap{}, err
+				}
+				argCols = append(argCols, ord)
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:   udAgg.Name,
+				Distinct:   distinct,
+				ResultType: item.Agg.DataType(),
+				ArgCols:    argCols[:len(argCols):len(argCols)],
+				Filter:     filterOrd,
+				// The routines of a user-defined aggregate can only be
+				// evaluated on the gateway.
+				DistsqlBlocklist: true,
+				UserDefined:      b.buildUserDefinedAgg(udAgg),
+			}
+			outputCols.Set(item.Col, len(groupingColIdx)+i)
+			argCols = argCols[len(argCols):]
+			continue
+		}
+
 		name, overload := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -3425,6 +3554,8 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		false, /* blockStart */
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
+		false, /* ownsResultBuffer */
+		false, /* addsToResultBuffer */
 	)
 
 	var ep execPlan
@@ -3609,20 +3740,39 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefined []*tree.UserDefinedAggregate
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var fnArgs memo.ScalarListExpr
+		if udAgg, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			if userDefined == nil {
+				userDefined = make([]*tree.UserDefinedAggregate, len(w.Windows))
+			}
+			userDefined[i] = b.buildUserDefinedAgg(udAgg)
+			name = udAgg.Name
+			props = &tree.FunctionProperties{}
+			fnArgs = udAgg.Args
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			fnArgs = make(memo.ScalarListExpr, fn.ChildCount())
+			for j := range fnArgs {
+				fnArgs[j] = fn.Child(j).(opt.ScalarExpr)
+			}
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, len(fnArgs))
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, len(fnArgs))
+		for j := range fnArgs {
+			col := fnArgs[j].(*memo.VariableExpr).Col
 			indexedVar, err := b.indexedVar(&ctx, b.mem.Metadata(), col)
 			if err != nil {
 				return execPlan{}, colOrdMap{}, err
@@ -3660,9 +3810,17 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapBuiltinFunction(name)
-		if err != nil {
-			return execPlan{}, colOrdMap{}, err
+		var wrappedFn tree.ResolvableFunctionReference
+		var typ *types.T
+		if userDefined != nil && userDefined[i] != nil {
+			wrappedFn.FunctionReference = &tree.ResolvedFunctionDefinition{Name: name}
+			typ = fn.DataType()
+		} else {
+			wrappedFn, err = b.wrapBuiltinFunction(name)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			typ = overload.FixedReturnType()
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
 			wrappedFn,
@@ -3670,7 +3828,7 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			typ,
 			props,
 			overload,
 		)
@@ -3709,13 +3867,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	}
 	var ep execPlan
 	ep.root, err = b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   sqlOrdering,
+		Cols:        resultCols,
+		Exprs:       exprs,
+		OutputIdxs:  outputIdxs,
+		ArgIdxs:     argIdxs,
+		FilterIdxs:  filterIdxs,
+		UserDefined: userDefined,
+		Partition:   partitionIdxs,
+		Ordering:    sqlOrdering,
 	})
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
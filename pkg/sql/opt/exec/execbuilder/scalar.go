@@ -715,6 +715,8 @@ func (b *Builder) buildExistsSubquery(
 				false, /* blockStart */
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				false, /* ownsResultBuffer */
+				false, /* addsToResultBuffer */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -836,6 +838,8 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* ownsResultBuffer */
+			false, /* addsToResultBuffer */
 		), nil
 	}
 
@@ -856,6 +860,7 @@ func (b *Builder) buildSubquery(
 			eb.withExprs = withExprs
 			eb.disableTelemetry = true
 			eb.planLazySubqueries = true
+			eb.allowRoutineOuterWithRefs = b.allowRoutineOuterWithRefs
 			ePlan, _, err := eb.buildRelational(input)
 			if err != nil {
 				return err
@@ -896,6 +901,8 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* ownsResultBuffer */
+			false, /* addsToResultBuffer */
 		), nil
 	}
 
@@ -973,6 +980,11 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 			"expected more than one body statement for a routine that opens a cursor",
 		))
 	}
+	if udf.Def.AddsToResultBuffer && len(udf.Def.Body) <= 1 {
+		panic(errors.AssertionFailedf(
+			"expected more than one body statement for a routine that adds to a result buffer",
+		))
+	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
@@ -981,8 +993,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.Body,
 		udf.Def.BodyProps,
 		udf.Def.BodyStmts,
-		false, /* allowOuterWithRefs */
-		nil,   /* wrapRootExpr */
+		b.allowRoutineOuterWithRefs,
+		nil, /* wrapRootExpr */
 	)
 
 	// Enable stepping for volatile functions so that statements within the UDF
@@ -1008,6 +1020,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.BlockStart,
 		blockState,
 		udf.Def.CursorDeclaration,
+		udf.Def.OwnsResultBuffer,
+		udf.Def.AddsToResultBuffer,
 	), nil
 }
 
@@ -1064,11 +1078,68 @@ func (b *Builder) initRoutineExceptionHandler(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* ownsResultBuffer */
+			false, /* addsToResultBuffer */
 		)
 	}
 	blockState.ExceptionHandler = exceptionHandler
 }
 
+// buildUserDefinedAgg builds the routines that implement a user-defined
+// aggregate function.
+func (b *Builder) buildUserDefinedAgg(agg *memo.UserDefinedAggExpr) *tree.UserDefinedAggregate {
+	udAgg := &tree.UserDefinedAggregate{
+		Transition:   b.buildUserDefinedAggRoutine(agg.Transition),
+		InitialState: agg.InitialState,
+	}
+	if agg.Final != nil {
+		udAgg.Final = b.buildUserDefinedAggRoutine(agg.Final)
+	}
+	return udAgg
+}
+
+// buildUserDefinedAggRoutine builds a routine with no arguments for the
+// transition or final function of a user-defined aggregate. The actual
+// arguments are supplied each time the aggregate invokes the routine.
+func (b *Builder) buildUserDefinedAggRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.flags.Set(exec.PlanFlagContainsMutation)
+			break
+		}
+	}
+	blockState := def.BlockState
+	if blockState != nil {
+		blockState.VariableCount = len(def.Params)
+		b.initRoutineExceptionHandler(blockState, def.ExceptionBlock)
+	}
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		def.BodyStmts,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		def.MultiColDataSource,
+		def.SetReturning,
+		false, /* tailCall */
+		false, /* procedure */
+		def.BlockStart,
+		blockState,
+		def.CursorDeclaration,
+		def.OwnsResultBuffer,
+		def.AddsToResultBuffer,
+	)
+}
+
 type wrapRootExprFn func(f *norm.Factory, e memo.RelExpr) opt.Expr
 
 // buildRoutinePlanGenerator returns a tree.RoutinePlanFn that can plan the
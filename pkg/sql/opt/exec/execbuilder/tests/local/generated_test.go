@@ -181,6 +181,13 @@ func TestExecBuild_enums(
 	runExecBuildLogicTest(t, "enums")
 }
 
+func TestExecBuild_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "exclusion_constraints")
+}
+
 func TestExecBuild_execute_internally_builtin(
 	t *testing.T,
 ) {
@@ -121,6 +121,22 @@ func emitInternal(
 	}
 
 	for _, cascade := range plan.Cascades {
+		if cascade.Trigger != nil {
+			// AFTER triggers are planned like cascades, but their plans only
+			// invoke the trigger function, so they cannot recurse.
+			ob.EnterMetaNode("after-trigger")
+			ob.Attr("trigger", string(cascade.Trigger.Name()))
+			const createPlanIfMissing = true
+			triggerPlan, err := cascade.GetExplainPlan(ctx, createPlanIfMissing)
+			if err != nil {
+				return err
+			}
+			if err := emitInternal(ctx, triggerPlan.(*Plan), ob, spanFormatFn, visitedFKsByCascades); err != nil {
+				return err
+			}
+			ob.LeaveNode()
+			continue
+		}
 		ob.EnterMetaNode("fk-cascade")
 		ob.Attr("fk", cascade.FKConstraint.Name())
 		// Here we do want to allow creation of the plans for the cascades to be
@@ -324,6 +340,7 @@ func (e *emitter) nodeName(n *Node) (name string, _ error) {
 }
 
 var nodeNames = [...]string{
+	aggregateScanOp:        "aggregate scan",
 	alterRangeRelocateOp:   "relocate range",
 	alterTableRelocateOp:   "relocate table",
 	alterTableSplitOp:      "split",
@@ -646,6 +663,19 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		}
 		e.emitLockingPolicy(a.Params.Locking)
 
+	case aggregateScanOp:
+		a := n.args.(*aggregateScanArgs)
+		e.emitTableAndIndex("table", a.Table, a.Index, "" /* suffix */)
+		e.emitSpans("spans", a.Table, a.Index, a.Params)
+		scanCols := tableColumns(a.Table, a.Params.NeededCols)
+		if a.Filter != nil {
+			ob.Expr("filter", a.Filter, scanCols)
+		}
+		e.emitGroupByAttributes(
+			scanCols,
+			a.Aggregations, nil /* groupCols */, nil /* groupColOrdering */, true, /* isScalar */
+		)
+
 	case valuesOp:
 		a := n.args.(*valuesArgs)
 		// Don't emit anything for the "norows" and "emptyrow" cases.
@@ -628,6 +628,38 @@ func (u *unknownTable) IsHypothetical() bool {
 	return false
 }
 
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+func (u *unknownTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) InheritedByCount() int {
+	return 0
+}
+
+func (u *unknownTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
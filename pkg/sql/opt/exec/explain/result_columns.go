@@ -71,6 +71,12 @@ func getResultColumns(
 		a := args.(*indexJoinArgs)
 		return tableColumns(a.Table, a.TableCols), nil
 
+	case aggregateScanOp:
+		a := args.(*aggregateScanArgs)
+		return groupByColumns(
+			tableColumns(a.Table, a.Params.NeededCols), nil /* groupCols */, a.Aggregations,
+		), nil
+
 	case valuesOp:
 		return args.(*valuesArgs).Columns, nil
 
@@ -216,6 +216,9 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is a user-defined aggregate function.
+	UserDefined *tree.UserDefinedAggregate
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -240,6 +243,10 @@ type WindowInfo struct {
 	// FilterIdxs is the list of column indices to use as filters.
 	FilterIdxs []int
 
+	// UserDefined is the list of user-defined aggregates, in the same order as
+	// Exprs. The entry is nil if the function is a builtin.
+	UserDefined []*tree.UserDefinedAggregate
+
 	// Partition is the set of input columns to partition on.
 	Partition []NodeColumnOrdinal
 
@@ -279,6 +286,10 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 type Cascade struct {
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is set instead of FKConstraint if the cascading query fires an
+	// AFTER trigger. Statement-level triggers run even if the buffer is empty.
+	Trigger cat.Trigger
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
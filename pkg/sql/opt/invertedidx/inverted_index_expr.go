@@ -46,6 +46,9 @@ func NewDatumsToInvertedExpr(
 	if !geoConfig.IsEmpty() {
 		return NewGeoDatumsToInvertedExpr(ctx, evalCtx, colTypes, expr, geoConfig)
 	}
+	if isRangeInvertedExpr(expr) {
+		return NewRangeDatumsToInvertedExpr(evalCtx, colTypes, expr)
+	}
 
 	return NewJSONOrArrayDatumsToInvertedExpr(ctx, evalCtx, colTypes, expr)
 }
@@ -146,6 +149,12 @@ func TryFilterInvertedIndex(
 				index:           index,
 				computedColumns: computedColumns,
 			}
+		case types.RangeFamily:
+			filterPlanner = &rangeFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+			}
 		default:
 			return nil, nil, nil, nil, false
 		}
@@ -476,6 +485,13 @@ func TryJoinInvertedIndex(
 			inputCols:   inputCols,
 			getSpanExpr: getSpanExprForGeometryIndex,
 		}
+	} else if isRangeIndex(factory.Metadata(), tabID, index) {
+		joinPlanner = &rangeJoinPlanner{
+			factory:   factory,
+			tabID:     tabID,
+			index:     index,
+			inputCols: inputCols,
+		}
 	} else {
 		joinPlanner = &jsonOrArrayJoinPlanner{
 			factory:   factory,
@@ -0,0 +1,329 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type rangeJoinPlanner struct {
+	factory   *norm.Factory
+	tabID     opt.TableID
+	index     cat.Index
+	inputCols opt.ColSet
+}
+
+var _ invertedJoinPlanner = &rangeJoinPlanner{}
+
+// extractInvertedJoinConditionFromLeaf is part of the invertedJoinPlanner
+// interface.
+func (r *rangeJoinPlanner) extractInvertedJoinConditionFromLeaf(
+	_ context.Context, expr opt.ScalarExpr,
+) opt.ScalarExpr {
+	// Only the overlap operator can be used for joins. An empty range is
+	// contained by every range, so a join on @> or <@ would need to know
+	// whether the input value is empty before the spans could be used.
+	t, ok := expr.(*memo.OverlapsExpr)
+	if !ok {
+		return nil
+	}
+	var val opt.ScalarExpr
+	commuteArgs := false
+	if isIndexColumn(r.tabID, r.index, t.Left, nil /* computedColumns */) {
+		val = t.Right
+	} else if isIndexColumn(r.tabID, r.index, t.Right, nil /* computedColumns */) {
+		// Overlaps is commutative, so the arguments can be swapped so that the
+		// indexed column is on the left.
+		val, commuteArgs = t.Left, true
+	} else {
+		return nil
+	}
+	if val.DataType().Family() != types.RangeFamily {
+		return nil
+	}
+	// The non-indexed argument should either come from the input or be a
+	// constant.
+	var p props.Shared
+	memo.BuildSharedProps(val, &p, r.factory.EvalContext())
+	if !p.OuterCols.Empty() {
+		if !p.OuterCols.SubsetOf(r.inputCols) {
+			return nil
+		}
+	} else if !memo.CanExtractConstDatum(val) {
+		return nil
+	}
+	if commuteArgs {
+		return r.factory.ConstructOverlaps(t.Right, t.Left)
+	}
+	return expr
+}
+
+// getInvertedExprForRangeIndexForOverlaps gets an inverted.Expression that
+// constrains a range index to the ranges that may overlap the given range.
+// It returns nil if the given range is NULL or empty, since it does not
+// overlap any range.
+func getInvertedExprForRangeIndexForOverlaps(d tree.Datum) (inverted.Expression, error) {
+	if d == tree.DNull {
+		return nil, nil
+	}
+	rng, ok := tree.UnwrapDOidWrapper(d).(*tree.DRange)
+	if !ok {
+		return nil, errors.AssertionFailedf("expected a range, found %s", d.ResolvedType())
+	}
+	if rng.Empty {
+		return nil, nil
+	}
+	return rowenc.EncodeOverlappingRangeInvertedIndexSpans(rng)
+}
+
+type rangeInvertedExpr struct {
+	tree.ComparisonExpr
+
+	nonIndexParam tree.TypedExpr
+
+	// spanExpr is the result of evaluating the comparison expression
+	// represented by this rangeInvertedExpr if the non-indexed argument is a
+	// constant. It is nil otherwise.
+	spanExpr *inverted.SpanExpression
+}
+
+var _ tree.TypedExpr = &rangeInvertedExpr{}
+
+// rangeDatumsToInvertedExpr implements invertedexpr.DatumsToInvertedExpr for
+// range columns.
+type rangeDatumsToInvertedExpr struct {
+	evalCtx      *eval.Context
+	colTypes     []*types.T
+	invertedExpr tree.TypedExpr
+
+	row   rowenc.EncDatumRow
+	alloc tree.DatumAlloc
+}
+
+var _ invertedexpr.DatumsToInvertedExpr = &rangeDatumsToInvertedExpr{}
+var _ eval.IndexedVarContainer = &rangeDatumsToInvertedExpr{}
+
+// IndexedVarEval is part of the eval.IndexedVarContainer interface.
+func (g *rangeDatumsToInvertedExpr) IndexedVarEval(idx int) (tree.Datum, error) {
+	err := g.row[idx].EnsureDecoded(g.colTypes[idx], &g.alloc)
+	if err != nil {
+		return nil, err
+	}
+	return g.row[idx].Datum, nil
+}
+
+// IndexedVarResolvedType is part of the IndexedVarContainer interface.
+func (g *rangeDatumsToInvertedExpr) IndexedVarResolvedType(idx int) *types.T {
+	return g.colTypes[idx]
+}
+
+// NewRangeDatumsToInvertedExpr returns a new rangeDatumsToInvertedExpr.
+func NewRangeDatumsToInvertedExpr(
+	evalCtx *eval.Context, colTypes []*types.T, expr tree.TypedExpr,
+) (invertedexpr.DatumsToInvertedExpr, error) {
+	g := &rangeDatumsToInvertedExpr{
+		evalCtx:  evalCtx,
+		colTypes: colTypes,
+	}
+
+	getInvertedExprLeaf := func(expr tree.TypedExpr) (tree.TypedExpr, error) {
+		t, ok := expr.(*tree.ComparisonExpr)
+		if !ok || t.Operator.Symbol != treecmp.Overlaps {
+			return nil, fmt.Errorf("%s cannot be index-accelerated", expr)
+		}
+		// We know that the non-index param is the second param.
+		nonIndexParam := t.TypedRight()
+
+		// If possible, get the span expression now so we don't need to
+		// recompute it for every row.
+		var spanExpr *inverted.SpanExpression
+		if d, ok := nonIndexParam.(tree.Datum); ok {
+			invertedExpr, err := getInvertedExprForRangeIndexForOverlaps(d)
+			if err != nil {
+				return nil, err
+			}
+			spanExpr, _ = invertedExpr.(*inverted.SpanExpression)
+		}
+		return &rangeInvertedExpr{
+			ComparisonExpr: *t,
+			nonIndexParam:  nonIndexParam,
+			spanExpr:       spanExpr,
+		}, nil
+	}
+
+	var err error
+	g.invertedExpr, err = getInvertedExpr(expr, getInvertedExprLeaf)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Convert implements the invertedexpr.DatumsToInvertedExpr interface.
+func (g *rangeDatumsToInvertedExpr) Convert(
+	ctx context.Context, datums rowenc.EncDatumRow,
+) (*inverted.SpanExpressionProto, interface{}, error) {
+	g.row = datums
+	g.evalCtx.PushIVarContainer(g)
+	defer g.evalCtx.PopIVarContainer()
+
+	evalInvertedExprLeaf := func(expr tree.TypedExpr) (inverted.Expression, error) {
+		t, ok := expr.(*rangeInvertedExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression %v", expr)
+		}
+		if t.spanExpr != nil {
+			// We call Copy so the caller can modify the returned expression.
+			return t.spanExpr.Copy(), nil
+		}
+		d, err := eval.Expr(ctx, g.evalCtx, t.nonIndexParam)
+		if err != nil {
+			return nil, err
+		}
+		return getInvertedExprForRangeIndexForOverlaps(d)
+	}
+
+	invertedExpr, err := evalInvertedExpr(g.invertedExpr, evalInvertedExprLeaf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if invertedExpr == nil {
+		return nil, nil, nil
+	}
+
+	spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to construct span expression")
+	}
+
+	return spanExpr.ToProto(), nil, nil
+}
+
+// CanPreFilter implements the invertedexpr.DatumsToInvertedExpr interface.
+func (g *rangeDatumsToInvertedExpr) CanPreFilter() bool {
+	return false
+}
+
+// PreFilter implements the invertedexpr.DatumsToInvertedExpr interface.
+func (g *rangeDatumsToInvertedExpr) PreFilter(
+	enc inverted.EncVal, preFilters []interface{}, result []bool,
+) (bool, error) {
+	return false, errors.AssertionFailedf("PreFilter called on rangeDatumsToInvertedExpr")
+}
+
+// isRangeInvertedExpr returns true if the leaves of the given inverted
+// expression compare range columns.
+func isRangeInvertedExpr(expr tree.TypedExpr) bool {
+	switch t := expr.(type) {
+	case *tree.AndExpr:
+		return isRangeInvertedExpr(t.TypedLeft())
+	case *tree.OrExpr:
+		return isRangeInvertedExpr(t.TypedLeft())
+	case *tree.ComparisonExpr:
+		return t.TypedLeft().ResolvedType().Family() == types.RangeFamily
+	}
+	return false
+}
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	_ context.Context, _ *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var indexCol, constantVal opt.ScalarExpr
+	switch e := expr.(type) {
+	case *memo.OverlapsExpr:
+		// Overlaps is commutative.
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			indexCol, constantVal = e.Left, e.Right
+		} else if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			indexCol, constantVal = e.Right, e.Left
+		}
+	case *memo.ContainsExpr:
+		// A range which contains a non-empty range or element also overlaps it.
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			indexCol, constantVal = e.Left, e.Right
+		}
+	case *memo.ContainedByExpr:
+		if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			indexCol, constantVal = e.Right, e.Left
+		}
+	}
+	if constantVal == nil {
+		// Can only accelerate the above expressions with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	d := tree.UnwrapDOidWrapper(memo.ExtractConstDatum(constantVal))
+	rng, ok := d.(*tree.DRange)
+	if !ok {
+		// The constant is an element of the range type. Search for the ranges
+		// overlapping the range which only contains that element.
+		bound := tree.RangeBound{Val: d, Inclusive: true}
+		var err error
+		if rng, err = tree.NewDRange(indexCol.DataType(), bound, bound); err != nil {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+	}
+	if rng.Empty || !rng.ResolvedType().Identical(indexCol.DataType()) {
+		// Every range contains the empty range, so a filter on an empty range
+		// cannot be accelerated.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	var err error
+	invertedExpr, err = rowenc.EncodeOverlappingRangeInvertedIndexSpans(rng)
+	if err != nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// The spans of a range inverted index are never tight, so the original
+	// filter must be applied after the inverted index scan.
+	remainingFilters = expr
+
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
+
+// isRangeIndex returns true if the inverted column of the given
+// index is a range.
+func isRangeIndex(md *opt.Metadata, tabID opt.TableID, index cat.Index) bool {
+	col := index.InvertedColumn().InvertedSourceColumnOrdinal()
+	return md.Table(tabID).Column(col).DatumType().Family() == types.RangeFamily
+}
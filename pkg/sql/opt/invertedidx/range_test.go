@@ -0,0 +1,129 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func TestTryJoinRangeIndex(t *testing.T) {
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+
+	// Create the input table.
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t1 (r1 INT4RANGE, r11 INT4RANGE, i1 INT)",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the indexed table.
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t2 (r2 INT4RANGE, i2 INT, INVERTED INDEX (r2))",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn1 := tree.NewUnqualifiedTableName("t1")
+	tn2 := tree.NewUnqualifiedTableName("t2")
+	tab1 := md.AddTable(tc.Table(tn1), tn1)
+	tab2 := md.AddTable(tc.Table(tn2), tn2)
+	rangeOrd := 1
+
+	testCases := []struct {
+		filters      string
+		invertedExpr string
+	}{
+		{
+			filters:      "r2 && r1",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			// Indexed column can be on either side of &&.
+			filters:      "r1 && r2",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			// The other argument can be computed from the input.
+			filters:      "r2 && range_merge(r1, r11)",
+			invertedExpr: "r2 && range_merge(r1, r11)",
+		},
+		{
+			// Containment cannot be used for joins, since every range contains
+			// the empty range.
+			filters:      "r2 @> r1",
+			invertedExpr: "",
+		},
+		{
+			filters:      "r2 && r1 AND i1 = i2",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			filters:      "r2 && r1 OR r2 && r11",
+			invertedExpr: "r2 && r1 OR r2 && r11",
+		},
+		{
+			filters:      "r2 && r1 OR i1 = i2",
+			invertedExpr: "",
+		},
+		{
+			// At least one column from the input is required.
+			filters:      "r2 && '[1,5)'::int4range",
+			invertedExpr: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		var inputCols opt.ColSet
+		for i, n := 0, md.Table(tab1).ColumnCount(); i < n; i++ {
+			inputCols.Add(tab1.ColumnID(i))
+		}
+
+		actInvertedExpr := invertedidx.TryJoinInvertedIndex(
+			context.Background(), &f, filters, tab2, md.Table(tab2).Index(rangeOrd), inputCols,
+		)
+
+		if actInvertedExpr == nil {
+			if tc.invertedExpr != "" {
+				t.Fatalf("expected %s, got <nil>", tc.invertedExpr)
+			}
+			continue
+		}
+
+		if tc.invertedExpr == "" {
+			t.Fatalf("expected <nil>, got %v", actInvertedExpr)
+		}
+
+		expInvertedExpr := testutils.BuildScalar(t, &f, &semaCtx, evalCtx, tc.invertedExpr)
+		if actInvertedExpr.String() != expInvertedExpr.String() {
+			t.Errorf("expected %v, got %v", expInvertedExpr, actInvertedExpr)
+		}
+	}
+}
@@ -89,6 +89,22 @@ func (m *Memo) CheckExpr(e opt.Expr) {
 			}
 		}
 
+	case *AggregateScanExpr:
+		if t.HardLimit.IsSet() || t.Locking.IsLocking() || t.InvertedConstraint != nil {
+			panic(errors.AssertionFailedf("aggregate scan with a limit, locking or inverted constraint"))
+		}
+		checkFilters(t.Filters)
+		referencedCols := t.Filters.OuterCols()
+		for i := range t.Aggregations {
+			referencedCols.UnionWith(t.Aggregations[i].ScalarProps().OuterCols)
+		}
+		if !referencedCols.SubsetOf(t.Cols) {
+			panic(errors.AssertionFailedf(
+				"aggregate scan references columns that are not scanned: %v",
+				referencedCols.Difference(t.Cols),
+			))
+		}
+
 	case *ProjectExpr:
 		if !t.Passthrough.SubsetOf(t.Input.Relational().OutputCols) {
 			panic(errors.AssertionFailedf(
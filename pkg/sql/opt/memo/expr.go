@@ -760,6 +760,18 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// OwnsResultBuffer is true for a set-returning PL/pgSQL routine. The rows
+	// produced by RETURN NEXT and RETURN QUERY statements within the routine are
+	// accumulated in a buffer, which becomes the result of the routine once its
+	// execution is complete.
+	OwnsResultBuffer bool
+
+	// AddsToResultBuffer indicates that the rows produced by the *first* body
+	// statement should be added to the result buffer of the set-returning
+	// PL/pgSQL routine that (transitively) invoked this routine. If it is set,
+	// there will be at least two body statements.
+	AddsToResultBuffer bool
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -1337,9 +1349,16 @@ type FKCascades []FKCascade
 
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
+//
+// AFTER triggers are planned in the same way as cascades; in that case
+// FKConstraint is nil and Trigger is set.
 type FKCascade struct {
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is the AFTER trigger that is fired by this cascading query. It is
+	// nil for foreign key cascades.
+	Trigger cat.Trigger
+
 	// Builder is an object that can be used as the "optbuilder" for the cascading
 	// query.
 	Builder CascadeBuilder
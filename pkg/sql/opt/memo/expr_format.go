@@ -277,8 +277,8 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		FormatPrivate(f, e.Private(), required)
 		f.Buffer.WriteByte(')')
 
-	case *ScanExpr, *PlaceholderScanExpr, *IndexJoinExpr, *ShowTraceForSessionExpr,
-		*InsertExpr, *UpdateExpr, *UpsertExpr, *DeleteExpr, *LockExpr, *SequenceSelectExpr,
+	case *ScanExpr, *PlaceholderScanExpr, *AggregateScanExpr, *IndexJoinExpr,
+		*ShowTraceForSessionExpr, *InsertExpr, *UpdateExpr, *UpsertExpr, *DeleteExpr, *LockExpr, *SequenceSelectExpr,
 		*WindowExpr, *OpaqueRelExpr, *OpaqueMutationExpr, *OpaqueDDLExpr,
 		*AlterTableSplitExpr, *AlterTableUnsplitExpr, *AlterTableUnsplitAllExpr,
 		*AlterTableRelocateExpr, *AlterRangeRelocateExpr, *ControlJobsExpr, *CancelQueriesExpr,
@@ -426,7 +426,7 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Childf("internal-ordering: %s", private.Ordering)
 		}
 
-	case *ScanExpr, *PlaceholderScanExpr:
+	case *ScanExpr, *PlaceholderScanExpr, *AggregateScanExpr:
 		private := t.Private().(*ScanPrivate)
 		if t.Op() == opt.ScanOp && private.IsCanonical() {
 			// For the canonical scan, show the expressions attached to the TableMeta.
@@ -978,6 +978,10 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 				if i == 0 && def.CursorDeclaration != nil {
 					// The first statement is opening a cursor.
 					stmtNode = n.Child("open-cursor")
+				} else if i == 0 && def.AddsToResultBuffer {
+					// The first statement is adding rows to the result of a
+					// set-returning routine.
+					stmtNode = n.Child("return-next")
 				}
 				prevTailCalls := f.tailCalls
 				if i == len(def.Body)-1 {
@@ -1555,6 +1559,10 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
+			if trig := p.FKCascades[i].Trigger; trig != nil {
+				c.Childf("trigger %s", string(trig.Name()))
+				continue
+			}
 			c.Child(p.FKCascades[i].FKConstraint.Name())
 		}
 	}
@@ -1805,6 +1813,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
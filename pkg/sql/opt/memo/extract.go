@@ -135,13 +135,7 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
-	for i, n := 0, e.ChildCount(); i < n; i++ {
-		if variable, ok := e.Child(i).(*VariableExpr); ok {
-			res.Add(variable.Col)
-		}
-	}
-
-	return res
+	return AddAggInputColumns(res, e)
 }
 
 // AddAggInputColumns adds the set of columns the aggregate depands on to the
@@ -163,8 +157,16 @@ func AddAggInputColumns(cols opt.ColSet, e opt.ScalarExpr) opt.ColSet {
 	}
 
 	for i, n := 0, e.ChildCount(); i < n; i++ {
-		if variable, ok := e.Child(i).(*VariableExpr); ok {
-			cols.Add(variable.Col)
+		switch t := e.Child(i).(type) {
+		case *VariableExpr:
+			cols.Add(t.Col)
+		case *ScalarListExpr:
+			// The arguments of user-defined aggregates are in a list.
+			for _, arg := range *t {
+				if variable, ok := arg.(*VariableExpr); ok {
+					cols.Add(variable.Col)
+				}
+			}
 		}
 	}
 
@@ -1344,6 +1344,9 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
+	if l.OwnsResultBuffer != r.OwnsResultBuffer || l.AddsToResultBuffer != r.AddsToResultBuffer {
+		return false
+	}
 	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
 }
 
@@ -211,6 +211,51 @@ func (b *logicalPropsBuilder) buildPlaceholderScanProps(
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (b *logicalPropsBuilder) buildAggregateScanProps(
+	scan *AggregateScanExpr, rel *props.Relational,
+) {
+	BuildSharedProps(scan, &rel.Shared, b.evalCtx)
+
+	// Output Columns
+	// --------------
+	// Output columns are the columns of the aggregate projection list.
+	for i := range scan.Aggregations {
+		rel.OutputCols.Add(scan.Aggregations[i].Col)
+	}
+
+	// Not Null Columns
+	// ----------------
+	// Some aggregates never return NULL. The others return NULL when there are
+	// no input rows.
+	for i := range scan.Aggregations {
+		item := &scan.Aggregations[i]
+		if opt.AggregateIsNeverNull(item.Agg.Op()) {
+			rel.NotNullCols.Add(item.Col)
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were derived by BuildSharedProps; remove the scanned
+	// columns.
+	rel.OuterCols.DifferenceWith(scan.Cols)
+
+	// Functional Dependencies
+	// -----------------------
+	rel.FuncDeps.MakeMax1Row(rel.OutputCols)
+
+	// Cardinality
+	// -----------
+	// AggregateScan returns exactly one row.
+	rel.Cardinality = props.OneCardinality
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildAggregateScan(rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildSequenceSelectProps(
 	seq *SequenceSelectExpr, rel *props.Relational,
 ) {
@@ -1748,6 +1793,13 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.Add(t.Transition.Volatility)
+		if t.Final != nil {
+			shared.VolatilitySet.Add(t.Final.Volatility)
+		}
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1933,9 +1985,15 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
-		if !unique.Validated() {
-			// This unique constraint has not been validated, so we cannot use it
-			// as a key.
+		if !unique.Validated() || unique.Deferrability() != tree.ConstraintNotDeferrable {
+			// This unique constraint has not been validated, or its checks may be
+			// postponed until commit, so we cannot use it as a key.
+			continue
+		}
+
+		if unique.IsExclusion() {
+			// Exclusion constraints only prevent conflicts between rows according
+			// to their operators, so their columns do not necessarily form a key.
 			continue
 		}
 
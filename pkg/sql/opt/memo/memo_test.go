@@ -507,6 +507,12 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerUsePolymorphicParameterFix = false
 	notStale()
 
+	// Stale optimizer_use_aggregate_scans.
+	evalCtx.SessionData().OptimizerUseAggregateScans = true
+	stale()
+	evalCtx.SessionData().OptimizerUseAggregateScans = false
+	notStale()
+
 	// User no longer has access to view.
 	catalog.View(tree.NewTableNameWithSchema("t", catconstants.PublicSchemaName, "abcview")).Revoked = true
 	_, err = o.Memo().IsStale(ctx, &evalCtx, catalog)
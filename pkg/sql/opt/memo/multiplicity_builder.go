@@ -513,8 +513,9 @@ func checkForeignKeyCase(
 		leftBaseTable := md.Table(leftTableID)
 		for i, cnt := 0, leftBaseTable.OutboundForeignKeyCount(); i < cnt; i++ {
 			fk := leftBaseTable.OutboundForeignKey(i)
-			if !fk.Validated() {
-				// The data is not guaranteed to follow the foreign key constraint.
+			if !fk.Validated() || fk.Deferrability() != tree.ConstraintNotDeferrable {
+				// The data is not guaranteed to follow the foreign key constraint. The
+				// checks of a deferrable constraint may be postponed until commit.
 				continue
 			}
 			if rightTableIDs == nil {
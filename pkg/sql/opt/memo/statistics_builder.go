@@ -509,6 +509,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.SequenceSelectOp:
 		return sb.colStatSequenceSelect(colSet, e.(*SequenceSelectExpr))
 
+	case opt.AggregateScanOp:
+		return sb.colStatAggregateScan(colSet, e.(*AggregateScanExpr))
+
 	case opt.ExplainOp, opt.ShowTraceForSessionOp,
 		opt.OpaqueRelOp, opt.OpaqueMutationOp, opt.OpaqueDDLOp, opt.RecursiveCTEOp,
 		opt.AlterTableSplitOp, opt.AlterTableUnsplitOp,
@@ -2924,6 +2927,33 @@ func (sb *statisticsBuilder) colStatSequenceSelect(
 	return colStat
 }
 
+// +----------------+
+// | Aggregate Scan |
+// +----------------+
+
+func (sb *statisticsBuilder) buildAggregateScan(relProps *props.Relational) {
+	s := relProps.Statistics()
+	s.Available = true
+	s.RowCount = 1
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatAggregateScan(
+	colSet opt.ColSet, scan *AggregateScanExpr,
+) *props.ColumnStatistic {
+	s := scan.Relational().Statistics()
+
+	colStat, _ := s.ColStats.Add(colSet)
+	colStat.DistinctCount = 1
+	if colSet.SubsetOf(scan.Relational().NotNullCols) {
+		colStat.NullCount = 0
+	} else {
+		colStat.NullCount = s.RowCount * UnknownNullCountRatio
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +---------+
 // | Unknown |
 // +---------+
@@ -214,6 +214,8 @@ func init() {
 	typingFuncMap[opt.MergeStatementStatsOp] = typeAsFirstArg
 	typingFuncMap[opt.MergeTransactionStatsOp] = typeAsFirstArg
 
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
+
 	// Modifiers for aggregations pass through their argument.
 	typingFuncMap[opt.AggDistinctOp] = typeAsFirstArg
 	typingFuncMap[opt.AggFilterOp] = typeAsFirstArg
@@ -392,6 +394,11 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeUserDefinedAgg returns the type of a UserDefinedAggExpr operator.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Typ
+}
+
 // typeTxnControl returns the type of a TxnControlExpr operator
 func typeTxnControl(e opt.ScalarExpr) *types.T {
 	return e.(*TxnControlExpr).Def.Typ
@@ -132,6 +132,11 @@ func (c *CustomFuncs) IsAdditiveType(typ *types.T) bool {
 	return types.IsAdditiveType(typ)
 }
 
+// IsDomainType returns true if the given type is a domain.
+func (c *CustomFuncs) IsDomainType(typ *types.T) bool {
+	return typ.IsDomain()
+}
+
 // IsConstJSON returns true if the given ScalarExpr is a ConstExpr that wraps a
 // DJSON datum.
 func (c *CustomFuncs) IsConstJSON(expr opt.ScalarExpr) bool {
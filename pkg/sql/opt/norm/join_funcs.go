@@ -706,8 +706,9 @@ func (c *CustomFuncs) ForeignKeyConstraintFilters(
 
 		for i := 0; i < fkChildTable.OutboundForeignKeyCount(); i++ {
 			fk := fkChildTable.OutboundForeignKey(i)
-			if !fk.Validated() {
-				// The data is not guaranteed to follow the foreign key constraint.
+			if !fk.Validated() || fk.Deferrability() != tree.ConstraintNotDeferrable {
+				// The data is not guaranteed to follow the foreign key constraint. The
+				// checks of a deferrable constraint may be postponed until commit.
 				continue
 			}
 			if parentTable.ID() != fk.ReferencedTableID() {
@@ -146,6 +146,16 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Triggers can read the old value of any column of a modified row, so none
+	// of the FetchCols can be pruned.
+	if op != opt.InsertOp && tabMeta.Table.TriggerCount() > 0 {
+		for ord, col := range private.FetchCols {
+			if col != 0 {
+				cols.Add(tabMeta.MetaID.ColumnID(ord))
+			}
+		}
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
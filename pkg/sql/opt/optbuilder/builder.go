@@ -153,10 +153,29 @@ type Builder struct {
 	// insideDataSource is true when we are processing a data source.
 	insideDataSource bool
 
+	// onlyDataSource is true when the data source being processed was
+	// qualified with ONLY, in which case the rows of the tables that inherit
+	// from it are not included.
+	onlyDataSource bool
+
 	// insideNestedPLpgSQLCall is true when we are processing a nested PLpgSQL
 	// CALL statement.
 	insideNestedPLpgSQLCall bool
 
+	// insideTriggerFunction is true when we are processing the body of a
+	// trigger function, or the WHEN condition of a trigger. In this context,
+	// fields of the NEW and OLD records can be referenced as "new.field".
+	insideTriggerFunction bool
+
+	// triggerTransitionTables maps from the names of the transition tables of
+	// the trigger whose function is being built (if any) to their sources. See
+	// resolveTransitionTable.
+	triggerTransitionTables map[string]*cteSource
+
+	// firingTriggers contains the triggers whose functions are currently being
+	// built. It is used to detect recursive triggers.
+	firingTriggers map[firingTrigger]struct{}
+
 	// If set, we are collecting view dependencies in schemaDeps. This can only
 	// happen inside view/function definitions.
 	//
@@ -326,8 +345,8 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
-			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable,
+			*tree.CreateView, *tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateRoutine:
 			panic(pgerror.Newf(
@@ -346,6 +365,7 @@ func (b *Builder) buildStmt(
 			if !activeVersion.IsActive(clusterversion.V24_1) {
 				panic(unimplemented.Newf("stored procedures", "%s usage inside a routine definition is not supported until version 24.1", stmt.StatementTag()))
 			}
+		case *tree.DoBlock:
 		default:
 			panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition", stmt.StatementTag()))
 		}
@@ -373,6 +393,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -382,12 +407,12 @@ func (b *Builder) buildStmt(
 	case *tree.CreateRoutine:
 		return b.buildCreateFunction(stmt, inScope)
 
-	case *tree.CreateTrigger:
-		return b.buildCreateTrigger(stmt, inScope)
-
 	case *tree.Call:
 		return b.buildProcedure(stmt, inScope)
 
+	case *tree.DoBlock:
+		return b.buildDo(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -165,7 +165,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// When multiple OUT parameters are present, parameter names become the
 	// labels in the output RECORD type.
 	var outParamNames []string
-	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam bool
+	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam, sawVariadicParam bool
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -224,6 +224,28 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 				))
 			}
 		}
+		if sawVariadicParam {
+			// Only OUT parameters of functions can follow the VARIADIC parameter.
+			if param.Class != tree.RoutineParamOut {
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter"))
+			}
+			if cf.IsProcedure {
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last parameter"))
+			}
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be an array"))
+			}
+			if param.DefaultVal != nil || sawDefaultExpr {
+				panic(unimplemented.NewWithIssue(88947,
+					"DEFAULT values in variadic routines are not yet supported"))
+			}
+			sawVariadicParam = true
+		}
 		if param.DefaultVal != nil && param.Class == tree.RoutineParamOut {
 			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
 				"only input parameters can have default values"))
@@ -310,7 +332,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// CREATE correctly.
 		funcReturnType = outParamType
 		cf.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: cf.ReturnType != nil && cf.ReturnType.SetOf,
 		}
 	} else if funcReturnType == nil {
 		if cf.IsProcedure {
@@ -411,16 +434,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			afterBuildStmt()
 		}
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType != nil && cf.ReturnType.SetOf {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-		if funcReturnType.Identical(types.Trigger) {
-			panic(unimplemented.NewWithIssue(126356, "trigger functions are not yet supported"))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsqlparser.Parse(funcBodyStr)
 		if err != nil {
@@ -439,13 +452,21 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 		}
 
+		if funcReturnType.Identical(types.Trigger) {
+			// The types of the NEW and OLD records of a trigger function are only
+			// known once the function is bound to a table, so the body is built
+			// each time the trigger fires rather than here.
+			formatFuncBodyStmt(fmtCtx, stmt.AST, language, false /* newLine */)
+			break
+		}
+
 		// We need to disable stable function folding because we want to catch the
 		// volatility of stable functions. If folded, we only get a scalar and lose
 		// the volatility.
 		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
 			plBuilder := newPLpgSQLBuilder(
 				b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
-				routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
+				routineParams, funcReturnType, cf.IsProcedure, cf.ReturnType.SetOf, nil, /* outScope */
 			)
 			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		})
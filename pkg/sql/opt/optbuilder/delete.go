@@ -82,6 +82,8 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 
 	var mb mutationBuilder
 	mb.init(b, "delete", tab, alias)
+	mb.initRowLevelSecurity()
+	mb.fireStatementTriggers = true
 
 	// Build the input expression that selects the rows that will be deleted:
 	//
@@ -92,6 +94,9 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	// All columns from the delete table will be projected.
 	mb.buildInputForDelete(inScope, del.Table, del.Where, del.Using, del.Limit, del.OrderBy)
 
+	// Fire any statement-level BEFORE DELETE triggers.
+	mb.buildBeforeStatementTriggers(tree.TriggerEventDelete)
+
 	// Build the final delete statement, including any returned expressions.
 	if resultsNeeded(del.Returning) {
 		mb.buildDelete(del.Returning.(*tree.ReturningExprs))
@@ -105,8 +110,14 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 // buildDelete constructs a Delete operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
+	// Fire any row-level BEFORE DELETE triggers, which may skip the deletion of
+	// some rows.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventDelete)
+
 	mb.buildFKChecksAndCascadesForDelete()
 
+	mb.buildAfterTriggers(tree.TriggerEventDelete)
+
 	// Project partial index DEL boolean columns.
 	mb.projectPartialIndexDelCols()
 
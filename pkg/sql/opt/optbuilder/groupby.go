@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -100,6 +101,13 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// nullCols contains the columns for the GROUP BY expressions that are not
+	// part of the grouping set being built, when the query has ROLLUP, CUBE or
+	// GROUPING SETS. These expressions are NULL in the output of the
+	// aggregation. nullCols are included in groupStrs, but they are not
+	// grouping columns and are not part of aggInScope.
+	nullCols []*scopeColumn
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -130,14 +138,29 @@ func (g *groupby) hasNonCommutativeAggregates() bool {
 // columns.
 func (g *groupby) groupingCols() []scopeColumn {
 	// Grouping cols are always clustered at the end of the column list.
-	return g.aggInScope.cols[len(g.aggInScope.cols)-len(g.groupStrs):]
+	return g.aggInScope.cols[len(g.aggInScope.cols)-g.numGroupingCols():]
 }
 
 // getAggregateArgCols returns the columns in the aggInScope corresponding to
 // arguments to aggregate functions. If the aggregate has a filter, the column
 // corresponding to the filter's input will immediately follow the arguments.
 func (g *groupby) aggregateArgCols() []scopeColumn {
-	return g.aggInScope.cols[:len(g.aggInScope.cols)-len(g.groupStrs)]
+	return g.aggInScope.cols[:len(g.aggInScope.cols)-g.numGroupingCols()]
+}
+
+// numGroupingCols returns the number of grouping columns in the aggInScope.
+func (g *groupby) numGroupingCols() int {
+	return len(g.groupStrs) - len(g.nullCols)
+}
+
+// isNullCol returns true if the given column is one of the nullCols.
+func (g *groupby) isNullCol(id opt.ColumnID) bool {
+	for _, col := range g.nullCols {
+		if col.id == id {
+			return true
+		}
+	}
+	return false
 }
 
 // getAggregateResultCols returns the columns in the aggOutScope corresponding
@@ -243,6 +266,12 @@ func (a aggregateInfo) isOrderedSetAggregate() bool {
 	}
 }
 
+// isUserDefined returns true if the aggregate is a user-defined aggregate
+// function.
+func (a aggregateInfo) isUserDefined() bool {
+	return isUserDefinedAggregate(&a.def)
+}
+
 // isOrderingSensitive returns true if the given aggregate operator is
 // ordering sensitive. That is, it can give different results based on the order
 // values are fed to it.
@@ -250,6 +279,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if a.isUserDefined() {
+		// The transition function of a user-defined aggregate may depend on
+		// the order of its input.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
@@ -325,17 +359,30 @@ func (b *Builder) constructGroupBy(
 // buildGroupingColumns builds the grouping columns and adds them to the
 // groupby scopes that will be used to build the aggregation expression.
 // Returns the slice of grouping columns.
-func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope, fromScope *scope) {
+//
+// If gs is not nil, the SELECT clause is one of the branches of a query with
+// ROLLUP, CUBE or GROUPING SETS, and the GROUP BY expressions of the query that
+// are not part of the branch's grouping set are built as NULL columns.
+func (b *Builder) buildGroupingColumns(
+	sel *tree.SelectClause, gs *groupingSet, projectionsScope, fromScope *scope,
+) {
 	if fromScope.groupby == nil {
 		fromScope.initGrouping()
 	}
 	g := fromScope.groupby
 
 	// The "from" columns are visible to any grouping expressions.
-	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	var nulls tree.Exprs
+	if gs != nil {
+		nulls = gs.nulls
+	}
+	b.buildGroupingList(sel.GroupBy, nulls, sel.Exprs, projectionsScope, fromScope)
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
+	for _, col := range g.nullCols {
+		g.aggOutScope.appendColumn(col)
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -385,7 +432,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.isUserDefined() {
+			aggCols[i].scalar = b.constructUserDefinedAggregate(agg.FuncExpr, &agg.def, args)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -436,6 +487,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		aggCols,
 		g.aggInScope.ordering,
 	)
+	g.aggOutScope.expr = b.constructNullGroupingCols(g.aggOutScope.expr, g)
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -493,7 +545,11 @@ func (b *Builder) buildHaving(having tree.TypedExpr, fromScope *scope) opt.Scala
 //
 // fromScope The scope for the input to the aggregation (the FROM clause).
 func (b *Builder) buildGroupingList(
-	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope *scope, fromScope *scope,
+	groupBy tree.GroupBy,
+	nulls tree.Exprs,
+	selects tree.SelectExprs,
+	projectionsScope *scope,
+	fromScope *scope,
 ) {
 	g := fromScope.groupby
 	g.groupStrs = make(groupByStrSet, len(groupBy))
@@ -511,7 +567,10 @@ func (b *Builder) buildGroupingList(
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope, false /* null */)
+	}
+	for _, e := range nulls {
+		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope, true /* null */)
 	}
 	g.buildingGroupingCols = false
 }
@@ -536,8 +595,15 @@ func (b *Builder) buildGroupingList(
 // aggInScope       The scope that will contain the grouping expressions as well
 //
 //	as the aggregate function arguments.
+//
+// null             If true, the expression is not part of the grouping set
+//
+//	being built, and it is added to nullCols rather than to the aggInScope.
 func (b *Builder) buildGrouping(
-	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
+	groupBy tree.Expr,
+	selects tree.SelectExprs,
+	projectionsScope, fromScope, aggInScope *scope,
+	null bool,
 ) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
@@ -640,6 +706,17 @@ func (b *Builder) buildGrouping(
 			continue
 		}
 
+		if null {
+			// The expression is projected as NULL on top of the aggregation; see
+			// constructNullGroupingCols.
+			col := &scopeColumn{name: scopeColName(tree.Name(alias)), typ: e.ResolvedType(), expr: e}
+			b.populateSynthesizedColumn(col, b.factory.ConstructNull(col.typ))
+			g := fromScope.groupby
+			g.nullCols = append(g.nullCols, col)
+			g.groupStrs[exprStr] = col
+			continue
+		}
+
 		// Save a representation of the GROUP BY expression for validation of the
 		// SELECT and HAVING expressions. This enables queries such as:
 		//   SELECT x+y FROM t GROUP BY x+y
@@ -713,7 +790,11 @@ func (b *Builder) buildAggregateFunction(
 	defer func() { b.subquery = subq }()
 
 	for i, pexpr := range f.Exprs {
-		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
+		texpr := pexpr.(tree.TypedExpr)
+		if info.isUserDefined() {
+			texpr = castToParamType(texpr, def.Overload, i)
+		}
+		info.args[i] = b.buildAggArg(texpr, &info, tempScope, fromScope)
 	}
 
 	// If we have a filter, add it to tempScope after all the arguments. We'll
@@ -804,6 +885,82 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	}
 }
 
+// constructUserDefinedAggregate constructs a UserDefinedAgg expression for an
+// invocation of a user-defined aggregate function with the given arguments.
+// The transition and final functions of the aggregate are built as routines.
+func (b *Builder) constructUserDefinedAggregate(
+	f *tree.FuncExpr, def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := def.Overload
+	udAgg := o.UserDefinedAggregate
+	// Like in Postgres, only the execution privilege on the aggregate is
+	// checked. The privileges on the transition and final functions are
+	// checked when the aggregate is created.
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+	invocationTypes := make([]*types.T, len(args))
+	for i := range args {
+		invocationTypes[i] = args[i].DataType()
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o, invocationTypes, f.Func.ReferenceByName)
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+
+	// The transition function takes the state followed by the arguments of the
+	// aggregate, and the final function takes only the state.
+	transitionTypes := make([]*types.T, 0, len(args)+1)
+	transitionTypes = append(transitionTypes, udAgg.StateType)
+	transitionTypes = append(transitionTypes, o.Types.Types()...)
+	private := &memo.UserDefinedAggPrivate{
+		Name:         def.Name,
+		Typ:          f.ResolvedType(),
+		Transition:   b.buildUserDefinedAggregateRoutine(udAgg.TransitionFunc, transitionTypes),
+		InitialState: tree.DNull,
+	}
+	if udAgg.FinalFunc != 0 {
+		private.Final = b.buildUserDefinedAggregateRoutine(
+			udAgg.FinalFunc, []*types.T{udAgg.StateType},
+		)
+	}
+	if udAgg.InitialCondition != nil {
+		d, _, err := tree.ParseAndRequireString(udAgg.StateType, *udAgg.InitialCondition, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		private.InitialState = d
+	}
+	return b.factory.ConstructUserDefinedAgg(args, private)
+}
+
+// buildUserDefinedAggregateRoutine builds the definition of the transition or
+// final function of a user-defined aggregate, which has the given OID and
+// parameter types. The arguments are supplied by the aggregate during
+// execution.
+func (b *Builder) buildUserDefinedAggregateRoutine(
+	funcOID oid.Oid, paramTypes []*types.T,
+) *memo.UDFDefinition {
+	ref := tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}}
+	fnDef, err := ref.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	o := fnDef.Overloads[0].Overload
+	exprs := make(tree.TypedExprs, len(paramTypes))
+	for i, typ := range paramTypes {
+		exprs[i] = tree.NewTypedCastExpr(tree.DNull, typ)
+	}
+	f := tree.NewTypedFuncExpr(
+		ref, 0 /* aggQualifier */, exprs, nil /* filter */, nil, /* windowDef */
+		o.FixedReturnType(), &o.FunctionProperties, o,
+	)
+	_, udfDef := b.buildRoutineDefinition(
+		f, fnDef, b.allocScope(), nil /* outScope */, nil, /* colRefs */
+	)
+	return udfDef
+}
+
 func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
 	switch name {
 	case "array_agg":
@@ -909,6 +1066,24 @@ func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
 
+// isUserDefinedAggregate returns true if the given function is a user-defined
+// aggregate function.
+func isUserDefinedAggregate(def *memo.FunctionPrivate) bool {
+	return def.Overload != nil && def.Overload.UserDefinedAggregate != nil
+}
+
+// castToParamType casts the given argument of a user-defined aggregate to the
+// type of the corresponding parameter, if necessary. Unlike builtin
+// aggregates, the transition function of a user-defined aggregate can be
+// invoked with arguments of a different type than its parameters.
+func castToParamType(arg tree.TypedExpr, o *tree.Overload, i int) tree.TypedExpr {
+	paramTyp := o.Types.GetAt(i)
+	if arg.ResolvedType().Identical(paramTyp) {
+		return arg
+	}
+	return tree.NewTypedCastExpr(arg, paramTyp)
+}
+
 func isGenerator(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.GeneratorClass)
 }
@@ -968,6 +1143,10 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints do not guarantee that their columns are unique.
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
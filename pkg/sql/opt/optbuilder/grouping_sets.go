@@ -0,0 +1,294 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to. It matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. It matches the
+// limit in Postgres.
+const maxCubeElements = 12
+
+// groupingSet is one of the grouping sets of a query with ROLLUP, CUBE or
+// GROUPING SETS in its GROUP BY clause.
+type groupingSet struct {
+	// exprs are the grouping expressions in the set.
+	exprs tree.GroupBy
+
+	// nulls are the grouping expressions of the query which are not in the
+	// set. They are NULL in the rows produced for the set.
+	nulls tree.Exprs
+}
+
+// hasGroupingSets returns true if the GROUP BY clause contains ROLLUP, CUBE or
+// GROUPING SETS.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds a SELECT clause with ROLLUP, CUBE or GROUPING SETS
+// in its GROUP BY clause. The GROUP BY clause is expanded into a list of
+// grouping sets, and the SELECT clause is built once per grouping set, with
+// a GROUP BY on the expressions of that set. The results are combined with
+// UNION ALL. For example:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY a, b
+//	UNION ALL
+//	SELECT a, NULL, sum(c) FROM t GROUP BY a
+//	UNION ALL
+//	SELECT NULL, NULL, sum(c) FROM t
+//
+// Within each branch, references to grouping expressions that are not in the
+// grouping set of the branch are built as NULL (see buildGrouping), and
+// GROUPING() expressions are built as constants (see buildGroupingExpr).
+//
+// See Builder.buildStmt for a description of the remaining input and return
+// values.
+func (b *Builder) buildGroupingSets(
+	sel *tree.SelectClause, lockCtx lockingContext, desiredTypes []*types.T, inScope *scope,
+) (outScope *scope) {
+	if sel.DistinctOn != nil {
+		panic(unimplemented.NewWithIssue(46280, "DISTINCT ON with grouping sets"))
+	}
+	if containsWindowFunction(sel.Exprs) {
+		panic(unimplemented.NewWithIssue(46280, "window functions with grouping sets"))
+	}
+
+	sets := expandGroupingSets(sel.GroupBy)
+	for i := range sets {
+		branch := *sel
+		branch.GroupBy = sets[i].exprs
+		branch.Distinct = false
+		branchScope := b.buildSelectClause(
+			&branch, nil /* orderBy */, lockCtx, desiredTypes, inScope, &sets[i],
+		)
+		if outScope == nil {
+			outScope = branchScope
+			// Propagate the types of the first branch to the remaining branches,
+			// if we didn't already have desired types.
+			if len(desiredTypes) == 0 {
+				desiredTypes = outScope.makeColumnTypes()
+			}
+			continue
+		}
+		outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, branchScope)
+	}
+
+	if sel.Distinct {
+		outScope.expr = b.constructDistinct(outScope)
+	}
+	return outScope
+}
+
+// expandGroupingSets expands a GROUP BY clause with ROLLUP, CUBE or GROUPING
+// SETS into the list of grouping sets that it represents. Multiple items in
+// the GROUP BY clause are combined by taking the cross product of their
+// grouping sets, as in Postgres.
+func expandGroupingSets(groupBy tree.GroupBy) []groupingSet {
+	sets := [][]tree.Expr{nil}
+	for _, e := range groupBy {
+		itemSets := groupingSetsForItem(e)
+		product := make([][]tree.Expr, 0, len(sets)*len(itemSets))
+		for _, s := range sets {
+			for _, t := range itemSets {
+				set := make([]tree.Expr, 0, len(s)+len(t))
+				set = append(set, s...)
+				set = append(set, t...)
+				product = append(product, set)
+			}
+		}
+		if len(product) > maxGroupingSets {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"too many grouping sets present (maximum %d)", maxGroupingSets))
+		}
+		sets = product
+	}
+
+	// Determine all the grouping expressions of the query. Each of them that is
+	// not in a grouping set is NULL in the rows produced for that set.
+	var all tree.Exprs
+	allStrs := make(map[string]struct{})
+	setStrs := make([]map[string]struct{}, len(sets))
+	for i, s := range sets {
+		setStrs[i] = make(map[string]struct{})
+		for _, e := range flattenGroupingExprs(s, nil /* res */) {
+			str := e.String()
+			setStrs[i][str] = struct{}{}
+			if _, ok := allStrs[str]; !ok {
+				allStrs[str] = struct{}{}
+				all = append(all, e)
+			}
+		}
+	}
+
+	res := make([]groupingSet, len(sets))
+	for i, s := range sets {
+		res[i].exprs = s
+		for _, e := range all {
+			if _, ok := setStrs[i][e.String()]; !ok {
+				res[i].nulls = append(res[i].nulls, e)
+			}
+		}
+	}
+	return res
+}
+
+// groupingSetsForItem returns the grouping sets for a single item of a GROUP BY
+// clause.
+func groupingSetsForItem(e tree.Expr) [][]tree.Expr {
+	gs, ok := e.(*tree.GroupingSet)
+	if !ok {
+		return [][]tree.Expr{{e}}
+	}
+	var sets [][]tree.Expr
+	switch gs.Type {
+	case tree.Rollup:
+		// ROLLUP (a, b, c) is equivalent to
+		// GROUPING SETS ((a, b, c), (a, b), (a), ()).
+		for i := len(gs.Exprs); i >= 0; i-- {
+			sets = append(sets, gs.Exprs[:i:i])
+		}
+
+	case tree.Cube:
+		// CUBE (a, b) is equivalent to GROUPING SETS ((a, b), (a), (b), ()).
+		n := len(gs.Exprs)
+		if n > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set []tree.Expr
+			for i := range gs.Exprs {
+				if mask&(1<<(n-1-i)) != 0 {
+					set = append(set, gs.Exprs[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+
+	case tree.GroupingSets:
+		for _, e := range gs.Exprs {
+			sets = append(sets, groupingSetsForItem(e)...)
+		}
+	}
+	return sets
+}
+
+// flattenGroupingExprs appends the individual grouping expressions in exprs to
+// res, stripping parentheses and extracting the members of tuples.
+func flattenGroupingExprs(exprs []tree.Expr, res tree.Exprs) tree.Exprs {
+	for _, e := range exprs {
+		e = tree.StripParens(e)
+		if t, ok := e.(*tree.Tuple); ok {
+			res = flattenGroupingExprs(t.Exprs, res)
+			continue
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+// containsWindowFunction returns true if any of the given select expressions
+// contains a window function, outside of subqueries.
+func containsWindowFunction(exprs tree.SelectExprs) bool {
+	var v windowFunctionFinder
+	for i := range exprs {
+		tree.WalkExprConst(&v, exprs[i].Expr)
+		if v.found {
+			return true
+		}
+	}
+	return false
+}
+
+// windowFunctionFinder is a tree.Visitor that finds window functions.
+type windowFunctionFinder struct {
+	found bool
+}
+
+var _ tree.Visitor = &windowFunctionFinder{}
+
+// VisitPre is part of the Visitor interface.
+func (v *windowFunctionFinder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	switch t := expr.(type) {
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.found = true
+		}
+	case *tree.Subquery:
+		return false, expr
+	}
+	return !v.found, expr
+}
+
+// VisitPost is part of the Visitor interface.
+func (v *windowFunctionFinder) VisitPost(expr tree.Expr) tree.Expr {
+	return expr
+}
+
+// buildGroupingExpr builds a GROUPING(...) expression. The arguments must be
+// grouping expressions of the enclosing query level. The result is a constant
+// bit mask, in which each bit is set if the corresponding argument is not part
+// of the grouping set that is being built.
+func (b *Builder) buildGroupingExpr(t *tree.GroupingExpr, inScope *scope) opt.ScalarExpr {
+	if !inScope.inGroupingContext() || inScope.inAgg || inScope.groupby.buildingGroupingCols {
+		panic(errInvalidGroupingArgs)
+	}
+	g := inScope.groupby
+	var mask int64
+	for _, e := range t.Exprs {
+		col, ok := g.groupStrs[symbolicExprStr(e.(tree.TypedExpr))]
+		if !ok {
+			panic(errInvalidGroupingArgs)
+		}
+		mask <<= 1
+		if g.isNullCol(col.id) {
+			mask |= 1
+		}
+	}
+	return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(mask)), types.Int)
+}
+
+var errInvalidGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
+// constructNullGroupingCols wraps the given aggregation in a projection of the
+// nullCols of g, if there are any.
+func (b *Builder) constructNullGroupingCols(input memo.RelExpr, g *groupby) memo.RelExpr {
+	if len(g.nullCols) == 0 {
+		return input
+	}
+	projections := make(memo.ProjectionsExpr, len(g.nullCols))
+	for i, col := range g.nullCols {
+		projections[i] = b.factory.ConstructProjectionsItem(col.scalar, col.id)
+	}
+	return b.factory.ConstructProject(input, projections, input.Relational().OutputCols)
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
@@ -232,6 +233,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.init(b, "insert", tab, alias)
 	}
+	mb.initRowLevelSecurity()
+	if tab.TriggerCount() > 0 && ins.OnConflict != nil && !ins.OnConflict.DoNothing {
+		panic(unimplemented.NewWithIssue(126359,
+			"UPSERT and INSERT ... ON CONFLICT DO UPDATE are not supported on tables with triggers"))
+	}
+	mb.fireStatementTriggers = true
 
 	// Compute target columns in two cases:
 	//
@@ -291,6 +298,9 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	// See mutationBuilder.buildCheckInputScan.
 	mb.insertExpr = mb.outScope.expr
 
+	// Fire any statement-level BEFORE INSERT triggers.
+	mb.buildBeforeStatementTriggers(tree.TriggerEventInsert)
+
 	var returning *tree.ReturningExprs
 	if resultsNeeded(ins.Returning) {
 		returning = ins.Returning.(*tree.ReturningExprs)
@@ -376,6 +386,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //     values specified for them.
 //  4. Each update value is the same as the corresponding insert value.
 //  5. There are no inbound foreign keys containing non-key columns.
+//  6. Row-level security policies do not apply to the statement.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -387,6 +398,12 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	// The row-level security policies that apply to a row depend on whether it
+	// already exists.
+	if mb.applyRowLevelSecurity {
+		return true
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -600,6 +617,13 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 	//
 	//   INSERT INTO <table> (...) VALUES (...)
 	//
+	mb.outScope = mb.b.buildStmt(inputRows, mb.desiredTypesForInsert(), inScope)
+	mb.addInputColsForInsert()
+}
+
+// desiredTypesForInsert returns the desired types of the columns of the input
+// expression of an Insert operator.
+func (mb *mutationBuilder) desiredTypesForInsert() []*types.T {
 	var desiredTypes []*types.T
 	if len(mb.targetColList) != 0 {
 		desiredTypes = make([]*types.T, len(mb.targetColList))
@@ -614,9 +638,12 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 			}
 		}
 	}
+	return desiredTypes
+}
 
-	mb.outScope = mb.b.buildStmt(inputRows, desiredTypes, inScope)
-
+// addInputColsForInsert maps the columns of the input expression of an Insert
+// operator, which has been built in mb.outScope, to the target columns.
+func (mb *mutationBuilder) addInputColsForInsert() {
 	if len(mb.targetColList) != 0 {
 		// Target columns already exist, so ensure that the number of input
 		// columns exactly matches the number of target columns.
@@ -680,6 +707,10 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Fire any row-level BEFORE INSERT triggers, which may modify the inserted
+	// values. Computed columns are computed from the modified values.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -697,6 +728,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Add the row-level security policy check column to the input.
+	mb.addRowLevelSecurityCheckCol(catpb.PolicyCommand_INSERT)
+
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
 
@@ -704,6 +738,8 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
@@ -913,6 +949,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Add the row-level security policy check column to the input.
+	mb.addUpsertRowLevelSecurityCheckCol()
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -0,0 +1,734 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// mergeDupErrText is the error text used when a target row is matched by more
+// than one source row that would modify it.
+const mergeDupErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. The join of the source
+// and target tables is built once and buffered in a With binding, along with
+// an action column that contains the 1-based ordinal of the WHEN clause that
+// applies to each row of the join (or 0 if none does):
+//
+//	MERGE INTO t USING s ON t.k = s.k
+//	WHEN MATCHED AND s.del THEN DELETE
+//	WHEN MATCHED THEN UPDATE SET v = s.v
+//	WHEN NOT MATCHED THEN INSERT VALUES (s.k, s.v)
+//
+// is planned similarly to:
+//
+//	WITH
+//	  src AS MATERIALIZED (
+//	    SELECT s.*, t.k AS pk, CASE
+//	      WHEN t.k IS NOT NULL THEN CASE WHEN s.del THEN 1 ELSE 2 END
+//	      ELSE 3
+//	    END AS action
+//	    FROM s LEFT JOIN t ON t.k = s.k
+//	  ),
+//	  upd AS (
+//	    UPDATE t SET v = src.v
+//	    FROM (
+//	      SELECT * FROM (
+//	        SELECT DISTINCT ON (pk) * FROM src WHERE action IN (1, 2)
+//	      ) WHERE action = 2
+//	    ) AS src
+//	    WHERE t.k = src.pk
+//	  ),
+//	  del AS (
+//	    DELETE FROM t USING (
+//	      SELECT * FROM (
+//	        SELECT DISTINCT ON (pk) * FROM src WHERE action IN (1, 2)
+//	      ) WHERE action = 1
+//	    ) AS src
+//	    WHERE t.k = src.pk
+//	  ),
+//	  ins AS (
+//	    INSERT INTO t SELECT src.k, src.v FROM src WHERE action = 3
+//	  )
+//	SELECT count(*) FROM (TABLE upd UNION ALL TABLE del UNION ALL TABLE ins)
+//
+// Each mutation is fed from a scan of the same binding, so the matched and not
+// matched decisions are consistent across the mutations even if the source or
+// the WHEN conditions are volatile, and every row of the join is processed by
+// at most one mutation. The UPDATE and DELETE mutations re-read the target
+// rows by primary key so that their fetch columns are columns of the target
+// table.
+//
+// The matched rows that are modified by either an UPDATE or a DELETE action
+// are passed through an EnsureDistinctOn on the primary key of the target
+// table, which raises a cardinality violation if the same target row is
+// matched by more than one source row that would modify it. Since the update
+// and delete inputs are built identically up to that point, a row that is both
+// updated and deleted is detected as well.
+//
+// If the statement has a RETURNING clause, the returned rows of each mutation
+// are combined with UNION ALL. Otherwise, the rows are counted to produce the
+// number of rows affected. RETURNING expressions can only reference columns of
+// the target table.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	telemetry.Inc(sqltelemetry.MergeUseCounter)
+
+	// Validate the WHEN clauses and determine which privileges are needed.
+	var hasUpdate, hasDelete, hasInsert bool
+	var seenUnconditionalMatched, seenUnconditionalNotMatched bool
+	for _, w := range merge.Whens {
+		seenUnconditional := &seenUnconditionalNotMatched
+		if w.Matched {
+			seenUnconditional = &seenUnconditionalMatched
+		}
+		if *seenUnconditional {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"unreachable WHEN clause specified after unconditional WHEN clause"))
+		}
+		if w.Cond == nil {
+			*seenUnconditional = true
+		}
+		switch w.Action {
+		case tree.MergeActionUpdate:
+			hasUpdate = true
+		case tree.MergeActionDelete:
+			hasDelete = true
+		case tree.MergeActionInsert:
+			hasInsert = true
+		}
+	}
+
+	// Find which table we're working on, check the permissions. Existing values
+	// are always read, so the SELECT privilege is required.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot merge into view \"%s\"", tab.Name(),
+		))
+	}
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	if tab.TriggerCount() > 0 {
+		panic(unimplemented.NewWithIssue(126359, "MERGE is not supported on tables with triggers"))
+	}
+
+	if sourceName := mergeSourceName(merge.Source); sourceName != "" && sourceName == alias.ObjectName {
+		panic(pgerror.Newf(
+			pgcode.DuplicateAlias,
+			"source name %q specified more than once (missing AS clause)",
+			tree.ErrString(&sourceName),
+		))
+	}
+
+	// The results of each mutation must be buffered so that they can be
+	// combined. If the number of affected rows is needed rather than the
+	// RETURNING expressions, each mutation returns zero columns per row.
+	returning := &tree.ReturningExprs{}
+	if resultsNeeded(merge.Returning) {
+		returning = merge.Returning.(*tree.ReturningExprs)
+	}
+
+	if !hasUpdate && !hasDelete && !hasInsert {
+		// Every WHEN clause is DO NOTHING, so no rows are modified.
+		return b.buildMergeNoop(merge, returning, inScope)
+	}
+
+	src := b.buildMergeSource(merge, tab, alias, hasInsert, inScope)
+
+	// Each mutation is built as a separate statement of the statement tree and
+	// checked against the other mutations of the same table in the query. The
+	// mutations of a MERGE statement never modify the same row, so they are
+	// siblings that don't conflict with each other.
+	var branches []*scope
+	buildBranch := func(typ mutationType, build func() *scope) {
+		b.stmtTree.Push()
+		defer b.stmtTree.Pop()
+		b.checkMultipleMutations(tab, typ)
+		branches = append(branches, build())
+	}
+
+	if hasUpdate || hasDelete {
+		// Determine the actions that modify matched rows.
+		var modified, updated, deleted []int
+		for i, w := range merge.Whens {
+			switch w.Action {
+			case tree.MergeActionUpdate:
+				modified = append(modified, i+1)
+				updated = append(updated, i+1)
+			case tree.MergeActionDelete:
+				modified = append(modified, i+1)
+				deleted = append(deleted, i+1)
+			}
+		}
+
+		if hasUpdate {
+			buildBranch(generalMutation, func() *scope {
+				var mb mutationBuilder
+				mb.init(b, "merge", tab, alias)
+				mb.initRowLevelSecurity()
+				actionCol := mb.buildInputForMerge(
+					inScope, merge.Table, src, modified, updated, catpb.PolicyCommand_UPDATE,
+				)
+				exprs := mb.mergeUpdateExprs(merge.Whens, actionCol)
+				mb.addTargetColsForUpdate(exprs)
+				mb.addUpdateCols(exprs)
+				mb.buildUpdate(returning)
+				return mb.outScope
+			})
+		}
+
+		if hasDelete {
+			buildBranch(generalMutation, func() *scope {
+				var mb mutationBuilder
+				mb.init(b, "merge", tab, alias)
+				mb.initRowLevelSecurity()
+				mb.buildInputForMerge(
+					inScope, merge.Table, src, modified, deleted, catpb.PolicyCommand_DELETE,
+				)
+				mb.buildDelete(returning)
+				return mb.outScope
+			})
+		}
+	}
+
+	for i, w := range merge.Whens {
+		if w.Action != tree.MergeActionInsert {
+			continue
+		}
+		buildBranch(simpleInsert, func() *scope {
+			var mb mutationBuilder
+			mb.init(b, "merge", tab, alias)
+			mb.initRowLevelSecurity()
+			mb.buildInputForMergeInsert(inScope, src, w, i+1)
+			mb.addSynthesizedColsForInsert()
+			mb.insertExpr = mb.outScope.expr
+			mb.buildInsert(returning)
+			return mb.outScope
+		})
+	}
+
+	// Buffer each mutation in a With binding built at the root of the query,
+	// in the same way as a data-modifying CTE. This guarantees that each
+	// mutation is executed exactly once.
+	for i, branch := range branches {
+		cte := b.addMergeBinding(branch, merge, tree.CTEMaterializeDefault)
+		branches[i] = b.buildMergeWithScan(cte, branch.cols, inScope)
+	}
+	outScope = branches[0]
+	for _, branch := range branches[1:] {
+		outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, branch)
+	}
+
+	if !resultsNeeded(merge.Returning) {
+		outScope = b.buildMergeRowCount(outScope, inScope)
+	}
+	return outScope
+}
+
+// mergeSource describes the With binding that buffers the join of the source
+// and target tables of a MERGE statement.
+type mergeSource struct {
+	cte *cteSource
+	// sourceCols are the columns of the source table.
+	sourceCols []scopeColumn
+	// pkCols are the primary key columns of the target table, which are NULL
+	// for the rows of the source table without a match.
+	pkCols []scopeColumn
+	// actionCol contains the 1-based ordinal of the WHEN clause that applies to
+	// each row, or 0 if none does.
+	actionCol scopeColumn
+}
+
+// buildMergeSource builds the join of the source and target tables of a MERGE
+// statement, projects the action column, and buffers the result in a With
+// binding. If there are no WHEN NOT MATCHED ... THEN INSERT clauses, the rows
+// of the source table without a match are not needed, so an inner join is
+// built instead of a left join.
+func (b *Builder) buildMergeSource(
+	merge *tree.Merge, tab cat.Table, alias tree.TableName, hasInsert bool, inScope *scope,
+) *mergeSource {
+	var indexFlags *tree.IndexFlags
+	if t, ok := merge.Table.(*tree.AliasedTableExpr); ok && t.IndexFlags != nil {
+		indexFlags = t.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+	targetScope := b.buildScan(
+		b.addTable(tab, &alias),
+		tableOrdinals(tab, columnKinds{
+			includeMutations: false,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+	sourceScope := b.buildFromTables(tree.TableExprs{merge.Source}, noLocking, inScope)
+
+	// Check that the same table name is not used multiple times.
+	b.validateJoinTableNames(targetScope, sourceScope)
+
+	joinScope := inScope.push()
+	joinScope.appendColumnsFromScope(sourceScope)
+	joinScope.appendColumnsFromScope(targetScope)
+
+	// The ON and WHEN conditions should reject aggregates, generators, etc.
+	scalarProps := &b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	b.semaCtx.Properties.Require("MERGE", tree.RejectSpecial)
+
+	on := b.buildScalar(
+		joinScope.resolveAndRequireType(merge.On, types.Bool), joinScope, nil, nil, nil,
+	)
+	joinType := descpb.InnerJoin
+	if hasInsert {
+		joinType = descpb.LeftOuterJoin
+	}
+	joinScope.expr = b.constructJoin(
+		joinType, sourceScope.expr, targetScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(on)},
+		memo.EmptyJoinPrivate, false, /* isLateral */
+	)
+
+	// Find the primary key columns of the target table.
+	src := &mergeSource{}
+	primaryIndex := tab.Index(cat.PrimaryIndex)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		ord := primaryIndex.Column(i).Ordinal()
+		for j := range targetScope.cols {
+			if targetScope.cols[j].tableOrdinal == ord {
+				src.pkCols = append(src.pkCols, targetScope.cols[j])
+				break
+			}
+		}
+	}
+
+	// Build the action column. The primary key columns are never NULL in the
+	// target table, so a row of the join has a match if they are not NULL:
+	//
+	//	CASE
+	//	  WHEN <pk> IS NOT NULL THEN
+	//	    CASE WHEN <cond_1> THEN 1 WHEN <cond_2> THEN 2 ... ELSE 0 END
+	//	  ELSE
+	//	    CASE WHEN <cond_3> THEN 3 ... ELSE 0 END
+	//	END
+	//
+	// The first WHEN clause of each kind whose condition holds is applied.
+	matched := &tree.CaseExpr{Else: tree.NewDInt(0)}
+	notMatched := &tree.CaseExpr{Else: tree.NewDInt(0)}
+	for i, w := range merge.Whens {
+		c := notMatched
+		if w.Matched {
+			c = matched
+		}
+		var cond tree.Expr = tree.DBoolTrue
+		if w.Cond != nil {
+			cond = w.Cond
+		}
+		c.Whens = append(c.Whens, &tree.When{Cond: cond, Val: tree.NewDInt(tree.DInt(i + 1))})
+	}
+	var matchedAction, notMatchedAction tree.Expr = matched, notMatched
+	if len(matched.Whens) == 0 {
+		matchedAction = matched.Else
+	}
+	if len(notMatched.Whens) == 0 {
+		notMatchedAction = notMatched.Else
+	}
+	action := &tree.CaseExpr{
+		Whens: []*tree.When{{Cond: &tree.IsNotNullExpr{Expr: &src.pkCols[0]}, Val: matchedAction}},
+		Else:  notMatchedAction,
+	}
+	projectionsScope := joinScope.replace()
+	projectionsScope.appendColumnsFromScope(joinScope)
+	texpr := joinScope.resolveAndRequireType(action, types.Int)
+	actionCol := projectionsScope.addColumn(scopeColName("").WithMetadataName("merge_action"), texpr)
+	b.buildScalar(texpr, joinScope, projectionsScope, actionCol, nil)
+	b.constructProjectForScope(joinScope, projectionsScope)
+
+	// Always materialize the join, so that all mutations see the same rows even
+	// if the source or the WHEN conditions are volatile.
+	src.cte = b.addMergeBinding(projectionsScope, merge, tree.CTEMaterializeAlways)
+	src.sourceCols = projectionsScope.cols[:len(sourceScope.cols)]
+	src.actionCol = *actionCol
+	return src
+}
+
+// buildMergeSourceScan returns a scope that scans the With binding of the
+// given MERGE source and selects the rows whose action is one of the given
+// actions. The scope contains the columns of the source table, which can be
+// referenced by their names, followed by the anonymous primary key columns of
+// the target table and the anonymous action column, which are also returned.
+func (b *Builder) buildMergeSourceScan(
+	src *mergeSource, actions []int, inScope *scope,
+) (outScope *scope, pkCols []scopeColumn, actionCol *scopeColumn) {
+	cols := make([]scopeColumn, 0, len(src.sourceCols)+len(src.pkCols)+1)
+	cols = append(cols, src.sourceCols...)
+	for _, col := range src.pkCols {
+		col.name.Anonymize()
+		cols = append(cols, col)
+	}
+	cols = append(cols, src.actionCol)
+	outScope = b.buildMergeWithScan(src.cte, cols, inScope)
+
+	pkCols = outScope.cols[len(src.sourceCols) : len(src.sourceCols)+len(src.pkCols)]
+	actionCol = &outScope.cols[len(outScope.cols)-1]
+	outScope.expr = b.factory.ConstructSelect(
+		outScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(b.constructMergeActionIn(actionCol.id, actions))},
+	)
+	return outScope, pkCols, actionCol
+}
+
+// constructMergeActionIn returns a scalar expression that is true if the given
+// action column is one of the given actions.
+func (b *Builder) constructMergeActionIn(actionCol opt.ColumnID, actions []int) opt.ScalarExpr {
+	elems := make(memo.ScalarListExpr, len(actions))
+	elemTypes := make([]*types.T, len(actions))
+	for i, a := range actions {
+		elems[i] = b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(a)), types.Int)
+		elemTypes[i] = types.Int
+	}
+	return b.factory.ConstructIn(
+		b.factory.ConstructVariable(actionCol),
+		b.factory.ConstructTuple(elems, types.MakeTuple(elemTypes)),
+	)
+}
+
+// addMergeBinding adds the expression of the given scope as a With binding
+// that is built at the root of the query.
+func (b *Builder) addMergeBinding(
+	s *scope, merge *tree.Merge, mtr tree.CTEMaterializeClause,
+) *cteSource {
+	id := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(id, s.expr)
+	cte := &cteSource{
+		name:         tree.AliasClause{},
+		cols:         s.makePresentationWithHiddenCols(),
+		originalExpr: merge,
+		expr:         s.expr,
+		id:           id,
+		mtr:          mtr,
+	}
+	b.addCTE(cte)
+	return cte
+}
+
+// buildMergeWithScan returns a scope that scans the given columns of the given
+// With binding. The columns of the returned scope are copies of the given
+// columns with new IDs.
+func (b *Builder) buildMergeWithScan(
+	cte *cteSource, cols []scopeColumn, inScope *scope,
+) (outScope *scope) {
+	md := b.factory.Metadata()
+	inCols := make(opt.ColList, len(cols))
+	outCols := make(opt.ColList, len(cols))
+	outScope = inScope.push()
+	for i, col := range cols {
+		c := md.ColumnMeta(col.id)
+		inCols[i] = col.id
+		outCols[i] = md.AddColumn(c.Alias, c.Type)
+		col.scalar = nil
+		col.id = outCols[i]
+		outScope.cols = append(outScope.cols, col)
+	}
+	outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    cte.id,
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      md.NextUniqueID(),
+		Mtr:     cte.mtr,
+	})
+	return outScope
+}
+
+// buildMergeRowCount wraps the given expression, which produces one row for
+// each modified row, with an aggregation that counts the rows. The single
+// resulting row is used as the number of rows affected by the statement.
+func (b *Builder) buildMergeRowCount(input *scope, inScope *scope) (outScope *scope) {
+	outScope = inScope.push()
+	col := b.synthesizeColumn(outScope, scopeColName("count"), types.Int, nil /* expr */, nil /* scalar */)
+	outScope.expr = b.factory.ConstructScalarGroupBy(
+		input.expr,
+		memo.AggregationsExpr{b.factory.ConstructAggregationsItem(
+			b.factory.ConstructCountRows(), col.id,
+		)},
+		memo.EmptyGroupingPrivate,
+	)
+	return outScope
+}
+
+// buildMergeNoop builds the output of a MERGE statement in which every WHEN
+// clause is DO NOTHING. Such a statement does not modify any rows, but the
+// RETURNING expressions must still be type-checked against the target table.
+func (b *Builder) buildMergeNoop(
+	merge *tree.Merge, returning *tree.ReturningExprs, inScope *scope,
+) (outScope *scope) {
+	if !resultsNeeded(merge.Returning) {
+		emptyScope := inScope.push()
+		emptyScope.expr = b.factory.ConstructValues(memo.EmptyScalarListExpr, &memo.ValuesPrivate{
+			Cols: opt.ColList{},
+			ID:   b.factory.Metadata().NextUniqueID(),
+		})
+		return b.buildMergeRowCount(emptyScope, inScope)
+	}
+	sel := &tree.Select{Select: &tree.SelectClause{
+		Exprs: tree.SelectExprs(*returning),
+		From:  tree.From{Tables: tree.TableExprs{merge.Table}},
+		Where: tree.NewWhere(tree.AstWhere, tree.DBoolFalse),
+	}}
+	return b.buildStmt(sel, nil /* desiredTypes */, inScope)
+}
+
+// buildInputForMerge constructs the input of the Update or Delete operator
+// built for the WHEN MATCHED clauses of a MERGE statement, similar to this:
+//
+//	SELECT <cols>
+//	FROM <target>
+//	JOIN (
+//	  SELECT * FROM (
+//	    SELECT DISTINCT ON (pk) * FROM <src> WHERE action IN (<modified>)
+//	  ) WHERE action IN (<actions>)
+//	) AS src ON <target-pk> = src.pk
+//
+// The DISTINCT ON raises an error if a target row is matched by more than one
+// source row. All columns from the target table are added to fetchColList. If
+// row-level security applies, the target rows are filtered according to the
+// policies for the given command. The action column of the input is returned.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope,
+	texpr tree.TableExpr,
+	src *mergeSource,
+	modified, actions []int,
+	cmd catpb.PolicyCommand,
+) (actionCol *scopeColumn) {
+	var indexFlags *tree.IndexFlags
+	if t, ok := texpr.(*tree.AliasedTableExpr); ok && t.IndexFlags != nil {
+		indexFlags = t.IndexFlags
+	}
+
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	//       reason other than as "fetch columns". See buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	// Only modify the rows that are visible according to the row-level
+	// security policies of the table.
+	mb.addRowLevelSecurityFilter(cmd)
+
+	// Ensure there is at most one row for every row in the target table that
+	// is modified.
+	rowsScope, pkCols, actionCol := mb.b.buildMergeSourceScan(src, modified, inScope)
+	var pkColSet opt.ColSet
+	for i := range pkCols {
+		pkColSet.Add(pkCols[i].id)
+	}
+	rowsScope = mb.b.buildDistinctOn(
+		pkColSet, rowsScope, false /* nullsAreDistinct */, mergeDupErrText,
+	)
+
+	// Select the rows processed by this action.
+	if len(actions) < len(modified) {
+		rowsScope.expr = mb.b.factory.ConstructSelect(
+			rowsScope.expr,
+			memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(
+				mb.b.constructMergeActionIn(actionCol.id, actions),
+			)},
+		)
+	}
+
+	// Join the rows with the target table on the primary key. We create a new
+	// scope so that fetchScope is not modified. It will be used later to build
+	// partial index predicate expressions, and we do not want ambiguities with
+	// column names in the source.
+	on := make(memo.FiltersExpr, len(pkCols))
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i := range pkCols {
+		fetchCol := mb.fetchColIDs[primaryIndex.Column(i).Ordinal()]
+		on[i] = mb.b.factory.ConstructFiltersItem(mb.b.factory.ConstructEq(
+			mb.b.factory.ConstructVariable(fetchCol), mb.b.factory.ConstructVariable(pkCols[i].id),
+		))
+	}
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	mb.outScope.appendColumnsFromScope(rowsScope)
+	mb.outScope.expr = mb.b.factory.ConstructInnerJoin(
+		mb.fetchScope.expr, rowsScope.expr, on, memo.EmptyJoinPrivate,
+	)
+	return actionCol
+}
+
+// mergeUpdateExprs returns the SET expressions of the Update operator built
+// for the WHEN MATCHED ... THEN UPDATE clauses of a MERGE statement. If there
+// is a single UPDATE clause, its SET expressions are used as-is. Otherwise,
+// each updated column is assigned a CASE expression that selects the value of
+// the clause that applies to the row according to the given action column:
+//
+//	SET a = CASE WHEN action = 1 THEN <a1> WHEN action = 2 THEN <a2> ELSE a END
+func (mb *mutationBuilder) mergeUpdateExprs(
+	whens tree.MergeWhenClauses, actionCol *scopeColumn,
+) tree.UpdateExprs {
+	var updates []int
+	for i, w := range whens {
+		if w.Action == tree.MergeActionUpdate {
+			updates = append(updates, i)
+		}
+	}
+	if len(updates) == 1 {
+		return whens[updates[0]].Exprs
+	}
+
+	var names tree.NameList
+	cases := make(map[tree.Name]*tree.CaseExpr)
+	for _, i := range updates {
+		isAction := &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.EQ),
+			Left:     actionCol,
+			Right:    tree.NewDInt(tree.DInt(i + 1)),
+		}
+		for _, set := range whens[i].Exprs {
+			var exprs tree.Exprs
+			if set.Tuple {
+				t, ok := set.Expr.(*tree.Tuple)
+				if !ok {
+					panic(unimplemented.Newf("merge multiple-column update",
+						"multiple-column UPDATE in MERGE with more than one UPDATE clause must be a ROW() expression; not supported: %T", set.Expr))
+				}
+				if len(set.Names) != len(t.Exprs) {
+					panic(pgerror.Newf(pgcode.Syntax,
+						"number of columns (%d) does not match number of values (%d)",
+						len(set.Names), len(t.Exprs)))
+				}
+				exprs = t.Exprs
+			} else {
+				exprs = tree.Exprs{set.Expr}
+			}
+			for j, name := range set.Names {
+				if _, ok := exprs[j].(tree.DefaultVal); ok {
+					panic(unimplemented.New("merge update default",
+						"DEFAULT in MERGE with more than one UPDATE clause"))
+				}
+				c, ok := cases[name]
+				if !ok {
+					c = &tree.CaseExpr{Else: tree.NewColumnItem(&mb.alias, name)}
+					cases[name] = c
+					names = append(names, name)
+				}
+				c.Whens = append(c.Whens, &tree.When{Cond: isAction, Val: exprs[j]})
+			}
+		}
+	}
+
+	exprs := make(tree.UpdateExprs, len(names))
+	for i, name := range names {
+		exprs[i] = &tree.UpdateExpr{Names: tree.NameList{name}, Expr: cases[name]}
+	}
+	return exprs
+}
+
+// buildInputForMergeInsert constructs the input of the Insert operator built
+// for a WHEN NOT MATCHED ... THEN INSERT clause of a MERGE statement, similar
+// to this:
+//
+//	SELECT <values> FROM <src> WHERE action = <action>
+//
+// The target columns and insert columns are set up in the same way as for an
+// INSERT statement.
+func (mb *mutationBuilder) buildInputForMergeInsert(
+	inScope *scope, src *mergeSource, when *tree.MergeWhenClause, action int,
+) {
+	values := when.Values
+	if len(when.Columns) != 0 {
+		mb.addTargetNamedColsForInsert(when.Columns)
+		mb.checkNumCols(len(mb.targetColList), len(values))
+	} else if len(values) > 0 {
+		mb.addTargetTableColsForInsert(len(values))
+	}
+
+	// The values should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE INSERT", tree.RejectSpecial)
+
+	// Only the columns of the source table can be referenced by the values.
+	rowsScope, _, _ := mb.b.buildMergeSourceScan(src, []int{action}, inScope)
+	desiredTypes := mb.desiredTypesForInsert()
+	projectionsScope := rowsScope.replace()
+	for i, val := range values {
+		// Replace any DEFAULT expressions with the default value expression of
+		// the corresponding column.
+		if _, ok := val.(tree.DefaultVal); ok {
+			val = mb.parseDefaultExpr(mb.targetColList[i])
+		}
+		texpr := rowsScope.resolveType(val, desiredTypes[i])
+		col := projectionsScope.addColumn(scopeColName(""), texpr)
+		mb.b.buildScalar(texpr, rowsScope, projectionsScope, col, nil)
+	}
+	mb.b.constructProjectForScope(rowsScope, projectionsScope)
+	mb.outScope = projectionsScope
+	mb.addInputColsForInsert()
+}
+
+// mergeSourceName returns the name by which the columns of the given MERGE
+// source can be qualified, or the empty string if it cannot be determined from
+// the syntax.
+func mergeSourceName(source tree.TableExpr) tree.Name {
+	if t, ok := source.(*tree.AliasedTableExpr); ok {
+		if t.As.Alias != "" {
+			return t.As.Alias
+		}
+		source = t.Expr
+	}
+	if tn, ok := source.(*tree.TableName); ok {
+		return tn.ObjectName
+	}
+	return ""
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -118,7 +119,7 @@ type mutationBuilder struct {
 	// checkColIDs lists the input column IDs storing the boolean results of
 	// evaluating check constraint expressions defined on the target table. Its
 	// length is always equal to the number of check constraints on the table
-	// (see opt.Table.CheckCount).
+	// (see opt.Table.CheckCount), plus one if applyRowLevelSecurity is true.
 	checkColIDs opt.OptionalColList
 
 	// partialIndexPutColIDs lists the input column IDs storing the boolean
@@ -194,6 +195,11 @@ type mutationBuilder struct {
 	// checks.
 	withID opt.WithID
 
+	// fireStatementTriggers is true if the statement-level triggers on the
+	// table should fire. This is false for the mutations of foreign key
+	// cascades, which only fire row-level triggers.
+	fireStatementTriggers bool
+
 	// extraAccessibleCols stores all the columns that are available to the
 	// mutation that are not part of the target table. This is useful for
 	// UPDATE ... FROM queries and DELETE ... USING queries, as the columns
@@ -214,6 +220,10 @@ type mutationBuilder struct {
 	// inputForInsertExpr stores the result of outscope.expr from the most
 	// recent call to buildInputForInsert.
 	inputForInsertExpr memo.RelExpr
+
+	// applyRowLevelSecurity is true if the row-level security policies of the
+	// target table apply to the mutation; see initRowLevelSecurity.
+	applyRowLevelSecurity bool
 }
 
 func (mb *mutationBuilder) init(b *Builder, opName string, tab cat.Table, alias tree.TableName) {
@@ -318,6 +328,10 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only update the rows that are visible according to the row-level
+	// security policies of the table.
+	mb.addRowLevelSecurityFilter(catpb.PolicyCommand_UPDATE)
+
 	// If there is a FROM clause present, we must join all the tables
 	// together with the table being updated.
 	fromClausePresent := len(from) > 0
@@ -429,6 +443,10 @@ func (mb *mutationBuilder) buildInputForDelete(
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
+	// Only delete the rows that are visible according to the row-level
+	// security policies of the table.
+	mb.addRowLevelSecurityFilter(catpb.PolicyCommand_DELETE)
+
 	// USING
 	usingClausePresent := len(using) > 0
 	if usingClausePresent {
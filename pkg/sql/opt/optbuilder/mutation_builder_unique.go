@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -210,8 +211,13 @@ type uniqueCheckHelper struct {
 	// UniqueConstraint.
 	uniqueOrdinals intsets.Fast
 
+	// overlapOrdinals are the ordinals in uniqueOrdinals of the columns of an
+	// exclusion constraint that are compared with the overlap operator rather
+	// than with equality. It is empty for other unique constraints.
+	overlapOrdinals intsets.Fast
+
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not compared with equality by the constraint.
 	primaryKeyOrdinals intsets.Fast
 
 	// The scope and column ordinals of the scan that will serve as the right
@@ -233,10 +239,18 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	var uniqueOrds, overlapOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if h.unique.IsExclusion() && h.unique.ExclusionOperator(i) != treecmp.EQ {
+			overlapOrds.Add(ord)
+		}
 	}
+	// Only the columns compared with equality guarantee that two conflicting
+	// rows have the same values, so only they can be used to determine that a
+	// check is not needed.
+	eqOrds := uniqueOrds.Difference(overlapOrds)
 
 	// Find the primary key columns that are not part of the unique constraint.
 	// If there aren't any, we don't need a check.
@@ -246,7 +260,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -254,6 +268,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	}
 
 	h.uniqueOrdinals = uniqueOrds
+	h.overlapOrdinals = overlapOrds
 	h.primaryKeyOrdinals = primaryOrds
 
 	for tabOrd, ok := h.uniqueOrdinals.Next(0); ok; tabOrd, ok = h.uniqueOrdinals.Next(tabOrd + 1) {
@@ -268,6 +283,9 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		// If one of the columns is a UUID (or UUID casted to STRING or BYTES) set
 		// to gen_random_uuid() and we don't require uniqueness checks for
 		// gen_random_uuid(), unique check not needed.
+		if h.overlapOrdinals.Contains(tabOrd) {
+			continue
+		}
 		switch mb.md.ColumnMeta(colID).Type.Family() {
 		case types.UuidFamily, types.StringFamily, types.BytesFamily:
 			if columnIsGenRandomUUID(mb.outScope.expr, colID) {
@@ -307,7 +325,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	eqOrds.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -445,6 +463,9 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// The columns of an exclusion constraint that are compared with the
+	// overlap operator use (new_c && existing_c) instead.
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -457,12 +478,15 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+		newVal := f.ConstructVariable(uniqueCheckScope.cols[i].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[i].id)
+		var cmp opt.ScalarExpr
+		if h.overlapOrdinals.Contains(i) {
+			cmp = f.ConstructOverlaps(newVal, existingVal)
+		} else {
+			cmp = f.ConstructEq(newVal, existingVal)
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cmp))
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -481,8 +505,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		scanExpr, foundScan = possibleScan.(*memo.ScanExpr)
 
 		// Fast path is disabled if this check is for a UNIQUE WITHOUT INDEX with a
-		// partial index predicate.
-		if foundScan && !isPartial {
+		// partial index predicate, or for an exclusion constraint.
+		if foundScan && !isPartial && !h.unique.IsExclusion() {
 			scanFilters = h.buildFiltersForFastPathCheck(uniqueCheckExpr, uniqueCheckCols, scanExpr)
 		}
 	}
@@ -537,8 +561,17 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+	if h.unique.IsExclusion() {
+		// The columns of an exclusion constraint are not necessarily ordered by
+		// their ordinals, so follow the order of the constraint instead.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			keyCols = append(keyCols, uniqueCheckScope.cols[ord].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, uniqueCheckScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -25,6 +25,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -174,8 +176,13 @@ type plpgsqlBuilder struct {
 	// building their body statements.
 	outScope *scope
 
+	// forLoopCursors is the set of hidden cursor variables that are used to
+	// implement FOR loops over the results of a query. See buildForLoopFetch.
+	forLoopCursors map[ast.Variable]struct{}
+
 	routineName  string
 	isProcedure  bool
+	setReturning bool
 	identCounter int
 }
 
@@ -192,18 +199,19 @@ func newPLpgSQLBuilder(
 	colRefs *opt.ColSet,
 	routineParams []routineParam,
 	returnType *types.T,
-	isProcedure bool,
+	isProcedure, setReturning bool,
 	outScope *scope,
 ) *plpgsqlBuilder {
 	const initialBlocksCap = 2
 	b := &plpgsqlBuilder{
-		ob:          ob,
-		colRefs:     colRefs,
-		returnType:  returnType,
-		blocks:      make([]plBlock, 0, initialBlocksCap),
-		routineName: routineName,
-		isProcedure: isProcedure,
-		outScope:    outScope,
+		ob:           ob,
+		colRefs:      colRefs,
+		returnType:   returnType,
+		blocks:       make([]plBlock, 0, initialBlocksCap),
+		routineName:  routineName,
+		isProcedure:  isProcedure,
+		setReturning: setReturning,
+		outScope:     outScope,
 	}
 	// Build the initial block for the routine parameters, which are considered
 	// PL/pgSQL variables.
@@ -447,9 +455,16 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 		case *ast.Return:
 			// If the routine has OUT-parameters or a VOID return type, the RETURN
 			// statement must have no expression. Otherwise, the RETURN statement must
-			// have a non-empty expression.
+			// have a non-empty expression. The result of a set-returning routine is
+			// built by RETURN NEXT and RETURN QUERY statements, so in that case RETURN
+			// only ends execution of the routine, and must have no expression.
 			expr := t.Expr
-			if b.hasOutParam() {
+			if b.setReturning {
+				if expr != nil {
+					panic(returnWithSetofErr)
+				}
+				expr = tree.DNull
+			} else if b.hasOutParam() {
 				if expr != nil {
 					panic(returnWithOUTParameterErr)
 				}
@@ -479,8 +494,14 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
-			// name as the variable being assigned.
-			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			// name as the variable being assigned. Assignment to a single field of
+			// a composite-typed variable rebuilds the variable with that field
+			// replaced.
+			val := t.Value
+			if t.Indirection != "" {
+				val = b.buildFieldAssignExpr(t.Var, t.Indirection, t.Value)
+			}
+			s = b.addPLpgSQLAssign(s, t.Var, val)
 			if b.hasExceptionHandler() {
 				// If exception handling is required, we have to start a new
 				// continuation after each variable assignment. This ensures that in the
@@ -605,6 +626,268 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			return b.buildPLpgSQLStatements(b.prependStmt(loop, stmts[i+1:]), s)
 
+		case *ast.ForInt:
+			// An integer FOR loop is handled by a rewrite into a LOOP within a
+			// hidden block, which declares the loop variable as well as variables
+			// for the loop bounds and step:
+			//
+			//   FOR [var] IN [REVERSE] [lower]..[upper] BY [step] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   DECLARE
+			//     [var] INT;
+			//     _counter INT := [lower];
+			//     _upper INT := [upper];
+			//     _step INT := [step];
+			//   BEGIN
+			//     LOOP
+			//       IF _counter > _upper THEN
+			//         EXIT;
+			//       END IF;
+			//       [var] := _counter;
+			//       _counter := _counter + _step;
+			//       [body];
+			//     END LOOP;
+			//   END;
+			//
+			// The counter is advanced before the body statements are executed so that
+			// CONTINUE statements within the body do not skip the increment. For a
+			// REVERSE loop, the comparison and the increment are inverted.
+			//
+			// TODO(117508): the loop variable should shadow any variable with the same
+			// name from an enclosing block.
+			counter := ast.Variable(b.makeIdentifier("_for_counter"))
+			upper := ast.Variable(b.makeIdentifier("_for_upper"))
+			step := ast.Variable(b.makeIdentifier("_for_step"))
+			var stepExpr ast.Expr = tree.NewDInt(1)
+			if t.Step != nil {
+				stepExpr = t.Step
+			}
+			cmpOp, incOp := treecmp.GT, treebin.Plus
+			if t.Reverse {
+				cmpOp, incOp = treecmp.LT, treebin.Minus
+			}
+			prologue := []ast.Statement{
+				b.makeRaiseIf(b.makeIsNull(counter), pgcode.NullValueNotAllowed,
+					"lower bound of FOR loop cannot be null"),
+				b.makeRaiseIf(b.makeIsNull(upper), pgcode.NullValueNotAllowed,
+					"upper bound of FOR loop cannot be null"),
+			}
+			if t.Step != nil {
+				prologue = append(prologue,
+					b.makeRaiseIf(b.makeIsNull(step), pgcode.NullValueNotAllowed,
+						"BY value of FOR loop cannot be null"),
+					b.makeRaiseIf(
+						&tree.ComparisonExpr{
+							Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+							Left:     b.makeVarRef(step),
+							Right:    tree.NewDInt(0),
+						},
+						pgcode.InvalidParameterValue,
+						"BY value of FOR loop must be greater than zero",
+					),
+				)
+			}
+			loop := &ast.Loop{
+				Label: t.Label,
+				Body: b.prependStmts([]ast.Statement{
+					&ast.If{
+						Condition: &tree.ComparisonExpr{
+							Operator: treecmp.MakeComparisonOperator(cmpOp),
+							Left:     b.makeVarRef(counter),
+							Right:    b.makeVarRef(upper),
+						},
+						ThenBody: []ast.Statement{&ast.Exit{}},
+					},
+					&ast.Assignment{Var: t.Var, Value: b.makeVarRef(counter)},
+					&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+						Operator: treebin.MakeBinaryOperator(incOp),
+						Left:     b.makeVarRef(counter),
+						Right:    b.makeVarRef(step),
+					}},
+				}, t.Body),
+			}
+			block := &ast.Block{
+				Decls: []ast.Statement{
+					&ast.Declaration{Var: t.Var, Typ: types.Int},
+					&ast.Declaration{Var: counter, Typ: types.Int, Expr: t.Lower},
+					&ast.Declaration{Var: upper, Typ: types.Int, Expr: t.Upper},
+					&ast.Declaration{Var: step, Typ: types.Int, Expr: stepExpr},
+				},
+				Body: append(prologue, loop),
+			}
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ForEachArray:
+			// A FOREACH loop is handled by a rewrite into an integer loop over the
+			// subscripts of the array, similar to the integer FOR loop above:
+			//
+			//   FOREACH [var] IN ARRAY [expr] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   DECLARE
+			//     _arr [array type] := [expr];
+			//     _counter INT := array_lower(_arr, 1);
+			//     _upper INT := array_upper(_arr, 1);
+			//   BEGIN
+			//     LOOP
+			//       IF _counter IS NULL OR _counter > _upper THEN
+			//         EXIT;
+			//       END IF;
+			//       [var] := _arr[_counter];
+			//       _counter := _counter + 1;
+			//       [body];
+			//     END LOOP;
+			//   END;
+			//
+			// Note that the bounds of an empty array are NULL.
+			b.resolveVariableForAssign(t.Var)
+			arrTyp := b.resolveExprType(t.Expr, s)
+			if arrTyp.Family() != types.ArrayFamily {
+				panic(pgerror.Newf(pgcode.DatatypeMismatch,
+					"FOREACH expression must yield an array, not type %s", arrTyp.Name(),
+				))
+			}
+			arr := ast.Variable(b.makeIdentifier("_foreach_array"))
+			counter := ast.Variable(b.makeIdentifier("_foreach_counter"))
+			upper := ast.Variable(b.makeIdentifier("_foreach_upper"))
+			arrayBound := func(fnName string) ast.Expr {
+				return &tree.FuncExpr{
+					Func:  tree.WrapFunction(fnName),
+					Exprs: tree.Exprs{b.makeVarRef(arr), tree.NewDInt(1)},
+				}
+			}
+			loop := &ast.Loop{
+				Label: t.Label,
+				Body: b.prependStmts([]ast.Statement{
+					&ast.If{
+						Condition: &tree.OrExpr{
+							Left: b.makeIsNull(counter),
+							Right: &tree.ComparisonExpr{
+								Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+								Left:     b.makeVarRef(counter),
+								Right:    b.makeVarRef(upper),
+							},
+						},
+						ThenBody: []ast.Statement{&ast.Exit{}},
+					},
+					&ast.Assignment{Var: t.Var, Value: &tree.IndirectionExpr{
+						Expr:        b.makeVarRef(arr),
+						Indirection: tree.ArraySubscripts{{Begin: b.makeVarRef(counter)}},
+					}},
+					&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+						Operator: treebin.MakeBinaryOperator(treebin.Plus),
+						Left:     b.makeVarRef(counter),
+						Right:    tree.NewDInt(1),
+					}},
+				}, t.Body),
+			}
+			block := &ast.Block{
+				Decls: []ast.Statement{
+					&ast.Declaration{Var: arr, Typ: arrTyp, Expr: t.Expr},
+					&ast.Declaration{Var: counter, Typ: types.Int, Expr: arrayBound("array_lower")},
+					&ast.Declaration{Var: upper, Typ: types.Int, Expr: arrayBound("array_upper")},
+				},
+				Body: []ast.Statement{
+					b.makeRaiseIf(b.makeIsNull(arr), pgcode.NullValueNotAllowed,
+						"FOREACH expression must not be null"),
+					loop,
+				},
+			}
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ForSelect:
+			// A FOR loop over the results of a query is handled by a rewrite into a
+			// LOOP that fetches each row from a hidden cursor:
+			//
+			//   FOR [target] IN [query] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   DECLARE
+			//     _cursor REFCURSOR;
+			//   BEGIN
+			//     OPEN _cursor FOR [query];
+			//     LOOP
+			//       FETCH _cursor INTO [target];
+			//       [body];
+			//     END LOOP;
+			//     CLOSE _cursor;
+			//   END;
+			//
+			// Unlike an ordinary FETCH, the FETCH within the loop exits the loop once
+			// the cursor is exhausted. See buildForLoopFetch for details.
+			//
+			// Note that a RETURN or EXIT statement that leaves the loop early does
+			// not close the cursor; it is closed when the transaction ends.
+			b.checkDuplicateTargets(t.Target, "FOR")
+			cursor := ast.Variable(b.makeIdentifier("_for_cursor"))
+			if b.forLoopCursors == nil {
+				b.forLoopCursors = make(map[ast.Variable]struct{})
+			}
+			b.forLoopCursors[cursor] = struct{}{}
+			fetch := &ast.Fetch{
+				Cursor: tree.CursorStmt{Name: tree.Name(cursor), FetchType: tree.FetchNormal, Count: 1},
+				Target: t.Target,
+			}
+			block := &ast.Block{
+				Decls: []ast.Statement{&ast.Declaration{Var: cursor, Typ: types.RefCursor}},
+				Body: []ast.Statement{
+					&ast.Open{CurVar: cursor, Query: t.Query},
+					&ast.Loop{Label: t.Label, Body: b.prependStmt(fetch, t.Body)},
+					&ast.Close{CurVar: cursor},
+				},
+			}
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ReturnNext:
+			// RETURN NEXT adds a row to the result of a set-returning routine, and
+			// then continues execution. It is handled by a continuation routine with
+			// two body statements: the first projects the row, which is added to the
+			// result of the routine during execution, and the second executes the
+			// remaining PL/pgSQL statements.
+			if !b.setReturning {
+				panic(returnNextNonSetofErr)
+			}
+			expr := t.Expr
+			if b.hasOutParam() {
+				if expr != nil {
+					panic(returnNextWithOUTParameterErr)
+				}
+				expr = b.makeReturnForOutParams()
+			}
+			if expr == nil {
+				panic(emptyReturnNextErr)
+			}
+			nextCon := b.makeContinuation("_stmt_return_next")
+			nextCon.def.Volatility = volatility.Volatile
+			nextCon.def.AddsToResultBuffer = true
+			nextScalar := b.buildPLpgSQLExpr(expr, b.returnType, nextCon.s)
+			nextColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_next"))
+			nextScope := nextCon.s.push()
+			b.ob.synthesizeColumn(nextScope, nextColName, b.returnType, nil /* expr */, nextScalar)
+			b.ob.constructProjectForScope(nextCon.s, nextScope)
+			b.appendBodyStmt(&nextCon, nextScope)
+			b.appendPlpgSQLStmts(&nextCon, stmts[i+1:])
+			return b.callContinuation(&nextCon, s)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY is handled similarly to RETURN NEXT, except that the first
+			// body statement of the continuation is the query, which can add any
+			// number of rows to the result of the routine.
+			if !b.setReturning {
+				panic(returnQueryNonSetofErr)
+			}
+			queryCon := b.makeContinuation("_stmt_return_query")
+			queryCon.def.Volatility = volatility.Volatile
+			queryCon.def.AddsToResultBuffer = true
+			queryScope := b.ob.buildStmtAtRootWithScope(t.Query, nil /* desiredTypes */, queryCon.s)
+			b.appendBodyStmt(&queryCon, b.projectReturnQueryResult(queryScope))
+			b.appendPlpgSQLStmts(&queryCon, stmts[i+1:])
+			return b.callContinuation(&queryCon, s)
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -789,20 +1072,32 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
 				))
 			}
-			// Initialize the routine with the information needed to pipe the first
-			// body statement into a cursor.
-			query := b.resolveOpenQuery(t)
-			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
-			fmtCtx.FormatNode(query)
-			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
-				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
-				CursorSQL:  fmtCtx.CloseAndGetString(),
-			}
-			openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
-			if openScope.expr.Relational().CanMutate {
-				// Cursors with mutations are invalid.
-				panic(cursorMutationErr)
+			var openScope *scope
+			if query := b.resolveOpenQuery(t); query != nil {
+				// Initialize the routine with the information needed to pipe the first
+				// body statement into a cursor.
+				fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+				fmtCtx.FormatNode(query)
+				openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+					NameArgIdx: source.(*scopeColumn).getParamOrd(),
+					Scroll:     t.Scroll,
+					CursorSQL:  fmtCtx.CloseAndGetString(),
+				}
+				openScope = b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
+				if openScope.expr.Relational().CanMutate {
+					// Cursors with mutations are invalid.
+					panic(cursorMutationErr)
+				}
+				if _, ok := b.forLoopCursors[t.CurVar]; ok {
+					// The cursor for a FOR loop over a query has an extra column that is
+					// used to detect when the cursor is exhausted.
+					openScope = b.addForLoopFoundCol(openScope)
+				}
+			} else {
+				// The query for OPEN ... FOR EXECUTE is only known at execution time,
+				// so the cursor is opened by the crdb_internal.plpgsql_open_dynamic
+				// builtin function.
+				openScope = b.projectOpenDynamicCall(openCon.s, t, source.(*scopeColumn))
 			}
 			b.appendBodyStmt(&openCon, openScope)
 			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
@@ -859,6 +1154,10 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			return b.callContinuation(&closeCon, s)
 
 		case *ast.Fetch:
+			if _, ok := b.forLoopCursors[ast.Variable(t.Cursor.Name)]; ok {
+				// This FETCH statement was added for a FOR loop over a query.
+				return b.buildForLoopFetch(t, stmts[i+1:], s)
+			}
 			// FETCH and MOVE statements are used to shift the position of a SQL
 			// cursor and (for FETCH statements) retrieve a row from the cursor and
 			// assign it to one or more PLpgSQL variables. MOVE statements have no
@@ -1018,6 +1317,54 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.appendBodyStmt(&callCon, intoScope)
 			return b.callContinuation(&callCon, s)
 
+		case *ast.DynamicExecute:
+			// EXECUTE statements run a SQL string that is only known at execution
+			// time, so it cannot be built here. Instead, the string is passed to the
+			// crdb_internal.plpgsql_execute builtin function, which parses, plans,
+			// and executes it each time it is called. If there is an INTO target,
+			// the builtin function returns a tuple with the first row of the result,
+			// which is assigned to the target variables similar to FETCH.
+			b.checkDuplicateTargets(t.Target, "INTO")
+			strict := t.Strict || b.ob.evalCtx.SessionData().PLpgSQLUseStrictInto
+			execCon := b.makeContinuation("_stmt_dyn_exec")
+			execCon.def.Volatility = volatility.Volatile
+			var typs []*types.T
+			if t.Target != nil {
+				typs = b.getFetchTargetTypes(t.Target)
+			}
+			execScope := b.projectDynamicExecuteCall(execCon.s, t, strict, typs)
+			if t.Target == nil {
+				// When there is no INTO target, the statement is only executed for its
+				// side effects.
+				b.appendBodyStmt(&execCon, execScope)
+				b.appendPlpgSQLStmts(&execCon, stmts[i+1:])
+				return b.callContinuation(&execCon, s)
+			}
+			if b.targetIsRecordVar(t.Target) {
+				// Handle a single record-type variable (see projectRecordVar for
+				// details).
+				execScope = b.projectRecordVar(execScope, t.Target[0])
+			}
+			intoScope := b.projectTupleAsIntoTarget(execScope, t.Target)
+
+			// Add a barrier in case the projected variables are never referenced
+			// again, to prevent column-pruning rules from removing the EXECUTE.
+			b.addBarrier(intoScope)
+
+			// Call a continuation for the remaining PLpgSQL statements from the newly
+			// built statement that has updated variables.
+			retCon := b.makeContinuation("_stmt_dyn_exec_ret")
+			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
+			intoScope = b.callContinuation(&retCon, intoScope)
+			b.appendBodyStmt(&execCon, intoScope)
+			return b.callContinuation(&execCon, s)
+
+		case *ast.Perform:
+			// PERFORM executes a SELECT statement and discards the result. This is
+			// the same as a SQL statement without an INTO target.
+			execStmt := &ast.Execute{SqlStmt: t.Query}
+			return b.buildPLpgSQLStatements(b.prependStmt(execStmt, stmts[i+1:]), s)
+
 		default:
 			panic(errors.WithDetailf(unsupportedPLStmtErr,
 				"%s is not yet supported", stmt.PlpgSQLStatementTag(),
@@ -1031,7 +1378,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 }
 
 // resolveOpenQuery finds and validates the query that is bound to cursor for
-// the given OPEN statement.
+// the given OPEN statement. It returns nil for OPEN ... FOR EXECUTE.
 func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 	// Search the blocks in reverse order to ensure that more recent declarations
 	// are encountered first.
@@ -1046,13 +1393,17 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 		}
 	}
 	stmt := open.Query
-	if stmt != nil && boundStmt != nil {
+	if (stmt != nil || open.DynamicQuery != nil) && boundStmt != nil {
 		// A bound cursor cannot be opened with "OPEN FOR" syntax.
 		panic(errors.WithHintf(
 			pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
 			"cannot specify a query during OPEN for bound cursor \"%s\"", open.CurVar,
 		))
 	}
+	if open.DynamicQuery != nil {
+		// The query for OPEN ... FOR EXECUTE is only known at execution time.
+		return nil
+	}
 	if stmt == nil && boundStmt == nil {
 		// The query was not specified either during cursor declaration or in the
 		// open statement.
@@ -1128,6 +1479,47 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, va
 	return assignScope
 }
 
+// buildFieldAssignExpr returns an expression that reconstructs the given
+// composite-typed variable with the given field replaced by val, as in
+// "rec.field := val".
+func (b *plpgsqlBuilder) buildFieldAssignExpr(
+	ident ast.Variable, field tree.Name, val ast.Expr,
+) ast.Expr {
+	typ := b.resolveVariableForAssign(ident)
+	if typ.Family() != types.TupleFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"cannot assign to field \"%s\" of variable \"%s\" because its type %s is not a composite type",
+			field, ident, typ.SQLStringForError(),
+		))
+	}
+	labels := typ.TupleLabels()
+	exprs := make(tree.Exprs, len(typ.TupleContents()))
+	found := false
+	for i := range exprs {
+		if i < len(labels) && labels[i] == string(field) {
+			exprs[i] = val
+			found = true
+			continue
+		}
+		var colName tree.Name
+		if i < len(labels) {
+			colName = tree.Name(labels[i])
+		}
+		exprs[i] = &tree.ColumnAccessExpr{
+			Expr:     &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{string(ident)}},
+			ColName:  colName,
+			ByIndex:  colName == "",
+			ColIndex: i,
+		}
+	}
+	if !found {
+		panic(pgerror.Newf(pgcode.UndefinedColumn,
+			"record \"%s\" has no field \"%s\"", ident, field,
+		))
+	}
+	return &tree.Tuple{Exprs: exprs, Labels: labels}
+}
+
 // buildInto handles the mapping from the columns of a SQL statement to the
 // variables in an INTO target.
 func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *scope {
@@ -1486,11 +1878,11 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
-	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
-		// Routines with OUT-parameters and VOID return types need not explicitly
-		// specify a RETURN statement.
+	if b.setReturning || b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
+		// Set-returning routines, as well as routines with OUT-parameters and VOID
+		// return types need not explicitly specify a RETURN statement.
 		var returnExpr tree.Expr = tree.DNull
-		if b.hasOutParam() {
+		if b.hasOutParam() && !b.setReturning {
 			returnExpr = b.makeReturnForOutParams()
 		}
 		returnScope := inScope.push()
@@ -1593,42 +1985,61 @@ func (b *plpgsqlBuilder) addOneRowCheck(s *scope) {
 // buildFetch projects a call to the crdb_internal.plpgsql_fetch builtin
 // function, which handles cursors for the PLpgSQL FETCH and MOVE statements.
 func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
+	// For a FETCH statement, we have to pass the expected result types.
+	var typs []*types.T
+	if !fetch.IsMove {
+		typs = b.getFetchTargetTypes(fetch.Target)
+	}
+	fetchScope := b.projectFetchCall(s, &fetch.Cursor, typs)
+	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) {
+		// Handle a single record-type variable (see projectRecordVar for details).
+		fetchScope = b.projectRecordVar(fetchScope, fetch.Target[0])
+	}
+	return fetchScope
+}
+
+// getFetchTargetTypes returns the types of the columns that are assigned to
+// the given FETCH target.
+func (b *plpgsqlBuilder) getFetchTargetTypes(target []ast.Variable) []*types.T {
+	if b.targetIsRecordVar(target) {
+		// If the target is a single record-type variable, the columns of the
+		// FETCH are assigned as its *elements*, rather than directly to the
+		// variable.
+		return b.resolveVariableForAssign(target[0]).TupleContents()
+	}
+	typs := make([]*types.T, len(target))
+	for i := range target {
+		typs[i] = b.resolveVariableForAssign(target[i])
+	}
+	return typs
+}
+
+// projectFetchCall projects a single tuple column with the result of calling
+// crdb_internal.plpgsql_fetch on the given cursor. The tuple has an element for
+// each of the given types.
+func (b *plpgsqlBuilder) projectFetchCall(
+	s *scope, cursor *tree.CursorStmt, typs []*types.T,
+) *scope {
 	const fetchFnName = "crdb_internal.plpgsql_fetch"
 	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
 	if len(overloads) != 1 {
 		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
 	}
-	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, fetch.Cursor.Name)
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, cursor.Name)
 	if err != nil {
 		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", fetch.Cursor.Name))
+			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", cursor.Name))
 		}
 		panic(err)
 	}
 	if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
 		panic(pgerror.Newf(pgcode.DatatypeMismatch,
-			"variable \"%s\" must be of type cursor or refcursor", fetch.Cursor.Name,
+			"variable \"%s\" must be of type cursor or refcursor", cursor.Name,
 		))
 	}
 	makeConst := func(val tree.Datum, typ *types.T) opt.ScalarExpr {
 		return b.ob.factory.ConstructConstVal(val, typ)
 	}
-	// For a FETCH statement, we have to pass the expected result types.
-	var typs []*types.T
-	if !fetch.IsMove {
-		if b.targetIsRecordVar(fetch.Target) {
-			// If the target is a single record-type variable, the columns of the
-			// FETCH are assigned as its *elements*, rather than directly to the
-			// variable.
-			typs = b.resolveVariableForAssign(fetch.Target[0]).TupleContents()
-		} else {
-			typs = make([]*types.T, len(fetch.Target))
-			for i := range fetch.Target {
-				typ := b.resolveVariableForAssign(fetch.Target[i])
-				typs[i] = typ
-			}
-		}
-	}
 	returnType := types.MakeTuple(typs)
 	elems := make(memo.ScalarListExpr, len(typs))
 	for i := range elems {
@@ -1644,8 +2055,8 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	fetchCall := b.ob.factory.ConstructFunction(
 		memo.ScalarListExpr{
 			b.ob.factory.ConstructVariable(source.(*scopeColumn).id),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.FetchType)), types.Int),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.Count)), types.Int),
+			makeConst(tree.NewDInt(tree.DInt(cursor.FetchType)), types.Int),
+			makeConst(tree.NewDInt(tree.DInt(cursor.Count)), types.Int),
 			b.ob.factory.ConstructTuple(elems, returnType),
 		},
 		&memo.FunctionPrivate{
@@ -1660,13 +2071,239 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	fetchScope := s.push()
 	b.ob.synthesizeColumn(fetchScope, fetchColName, returnType, nil /* expr */, fetchCall)
 	b.ob.constructProjectForScope(s, fetchScope)
-	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) {
-		// Handle a single record-type variable (see projectRecordVar for details).
-		fetchScope = b.projectRecordVar(fetchScope, fetch.Target[0])
-	}
 	return fetchScope
 }
 
+// projectDynamicExecuteCall projects a single tuple column with the result of
+// calling crdb_internal.plpgsql_execute for the given EXECUTE statement. The
+// tuple has an element for each of the given types.
+func (b *plpgsqlBuilder) projectDynamicExecuteCall(
+	s *scope, execute *ast.DynamicExecute, strict bool, typs []*types.T,
+) *scope {
+	const execFnName = "crdb_internal.plpgsql_execute"
+	props, overloads := builtinsregistry.GetBuiltinProperties(execFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", execFnName))
+	}
+	returnType := types.MakeTuple(typs)
+	elems := make(memo.ScalarListExpr, len(typs))
+	for i := range elems {
+		elems[i] = b.ob.factory.ConstructConstVal(tree.DNull, typs[i])
+	}
+
+	// The arguments are:
+	//   1. The SQL string to execute.
+	//   2. A tuple with the values of the USING parameters.
+	//   3. Whether the statement has an INTO target.
+	//   4. Whether the INTO target is STRICT.
+	//   5. The types of the columns to return (can be empty).
+	execCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.buildPLpgSQLExpr(execute.Query, types.String, s),
+			b.buildDynamicParams(execute.Params, s),
+			b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(execute.Target != nil)), types.Bool),
+			b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(strict)), types.Bool),
+			b.ob.factory.ConstructTuple(elems, returnType),
+		},
+		&memo.FunctionPrivate{
+			Name:       execFnName,
+			Typ:        returnType,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	b.addBarrierIfVolatile(s, execCall)
+	execColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_dyn_exec"))
+	execScope := s.push()
+	b.ob.synthesizeColumn(execScope, execColName, returnType, nil /* expr */, execCall)
+	b.ob.constructProjectForScope(s, execScope)
+	return execScope
+}
+
+// projectOpenDynamicCall projects a call to crdb_internal.plpgsql_open_dynamic,
+// which opens a cursor for the given OPEN ... FOR EXECUTE statement. The name
+// of the cursor is supplied by the given variable.
+func (b *plpgsqlBuilder) projectOpenDynamicCall(
+	s *scope, open *ast.Open, nameCol *scopeColumn,
+) *scope {
+	const openFnName = "crdb_internal.plpgsql_open_dynamic"
+	props, overloads := builtinsregistry.GetBuiltinProperties(openFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", openFnName))
+	}
+	openCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructVariable(nameCol.id),
+			b.buildPLpgSQLExpr(open.DynamicQuery, types.String, s),
+			b.buildDynamicParams(open.Params, s),
+		},
+		&memo.FunctionPrivate{
+			Name:       openFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	openColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_open"))
+	openScope := s.push()
+	b.ob.synthesizeColumn(openScope, openColName, types.Int, nil /* expr */, openCall)
+	b.ob.constructProjectForScope(s, openScope)
+	return openScope
+}
+
+// buildDynamicParams builds a tuple with the values of the USING parameters of
+// a dynamic EXECUTE or OPEN ... FOR EXECUTE statement. Each parameter keeps its
+// own type, which is used to type the corresponding placeholder in the query.
+func (b *plpgsqlBuilder) buildDynamicParams(params []ast.Expr, s *scope) opt.ScalarExpr {
+	elems := make(memo.ScalarListExpr, len(params))
+	typs := make([]*types.T, len(params))
+	for i := range params {
+		expr, _ := tree.WalkExpr(s, params[i])
+		typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		elems[i] = b.ob.buildScalar(typedExpr, s, nil, nil, b.colRefs)
+		typs[i] = typedExpr.ResolvedType()
+	}
+	return b.ob.factory.ConstructTuple(elems, types.MakeTuple(typs))
+}
+
+// addForLoopFoundCol adds a leading BOOL column that is always true to the
+// query of a cursor that is opened for a FOR loop over the query's results.
+// When the cursor is exhausted, FETCH pads its result with NULL values, so a
+// NULL value for the column indicates that there are no more rows.
+//
+// The column comes first so that it is not affected when the number of target
+// variables for the loop differs from the number of columns in the query.
+func (b *plpgsqlBuilder) addForLoopFoundCol(inScope *scope) *scope {
+	foundScope := inScope.push()
+	foundColName := scopeColName("").WithMetadataName(b.makeIdentifier("for_loop_found"))
+	b.ob.synthesizeColumn(foundScope, foundColName, types.Bool, nil /* expr */, memo.TrueSingleton)
+	foundScope.appendColumnsFromScope(inScope)
+	foundScope.copyOrdering(inScope)
+	b.ob.constructProjectForScope(inScope, foundScope)
+	return foundScope
+}
+
+// buildForLoopFetch builds the FETCH statement that begins each iteration of a
+// FOR loop over the results of a query. It fetches the next row from the
+// cursor, which has an extra leading column (see addForLoopFoundCol), and
+// assigns it to the loop's target variables. If a row was found, execution
+// continues with the given statements from the loop body. Otherwise, the loop
+// exit continuation is called.
+func (b *plpgsqlBuilder) buildForLoopFetch(
+	fetch *ast.Fetch, stmts []ast.Statement, s *scope,
+) *scope {
+	fetchCon := b.makeContinuation("_stmt_fetch")
+	fetchCon.def.Volatility = volatility.Volatile
+	typs := b.getFetchTargetTypes(fetch.Target)
+	fetchTyps := make([]*types.T, 0, len(typs)+1)
+	fetchTyps = append(fetchTyps, types.Bool)
+	fetchTyps = append(fetchTyps, typs...)
+	fetchScope := b.projectFetchCall(fetchCon.s, &fetch.Cursor, fetchTyps)
+
+	// Project the target variables, as well as a column that indicates whether a
+	// row was found.
+	tupleCol := fetchScope.cols[0].id
+	tupleElem := func(idx int) opt.ScalarExpr {
+		return b.ob.factory.ConstructColumnAccess(
+			b.ob.factory.ConstructVariable(tupleCol), memo.TupleOrdinal(idx),
+		)
+	}
+	intoScope := fetchScope.push()
+	if b.targetIsRecordVar(fetch.Target) {
+		typ := b.resolveVariableForAssign(fetch.Target[0])
+		elems := make(memo.ScalarListExpr, len(typs))
+		for j := range elems {
+			elems[j] = tupleElem(j + 1)
+		}
+		tuple := b.ob.factory.ConstructTuple(elems, typ)
+		b.ob.synthesizeColumn(intoScope, scopeColName(fetch.Target[0]), typ, nil /* expr */, tuple)
+	} else {
+		for j := range fetch.Target {
+			scalar := b.coerceType(tupleElem(j+1), typs[j])
+			b.ob.synthesizeColumn(intoScope, scopeColName(fetch.Target[j]), typs[j], nil /* expr */, scalar)
+		}
+	}
+	foundColName := scopeColName("").WithMetadataName(b.makeIdentifier("for_loop_found"))
+	found := b.ob.factory.ConstructIsNot(tupleElem(0), b.ob.factory.ConstructNull(types.Bool))
+	foundCol := b.ob.synthesizeColumn(intoScope, foundColName, types.Bool, nil /* expr */, found)
+	b.ob.constructProjectForScope(fetchScope, intoScope)
+
+	// Add a barrier in case the projected variables are never referenced
+	// again, to prevent column-pruning rules from removing the FETCH.
+	b.addBarrier(intoScope)
+
+	// Either continue with the rest of the loop body, or exit the loop.
+	bodyCon := b.makeContinuation("_stmt_fetch_ret")
+	b.appendPlpgSQLStmts(&bodyCon, stmts)
+	exitCon := b.getContinuation(continuationLoopExit, unspecifiedLabel)
+	if exitCon == nil {
+		panic(errors.AssertionFailedf("expected a loop exit continuation for FOR loop"))
+	}
+	bodyCall := b.ob.factory.ConstructUDFCall(
+		b.makeContinuationArgs(&bodyCon, intoScope), &memo.UDFCallPrivate{Def: bodyCon.def},
+	)
+	exitCall := b.ob.factory.ConstructUDFCall(
+		b.makeContinuationArgs(exitCon, intoScope), &memo.UDFCallPrivate{Def: exitCon.def},
+	)
+	scalar := b.ob.factory.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructWhen(b.ob.factory.ConstructVariable(foundCol.id), bodyCall),
+		},
+		exitCall,
+	)
+	b.addBarrierIfVolatile(intoScope, scalar)
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_for"))
+	returnScope := intoScope.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, scalar)
+	b.ob.constructProjectForScope(intoScope, returnScope)
+
+	// Add the built statement to the FETCH continuation.
+	b.appendBodyStmt(&fetchCon, returnScope)
+	return b.callContinuation(&fetchCon, s)
+}
+
+// projectReturnQueryResult projects the columns of a RETURN QUERY statement's
+// query as a single column with the return type of the set-returning routine.
+// If necessary, the columns are combined into a tuple.
+func (b *plpgsqlBuilder) projectReturnQueryResult(queryScope *scope) *scope {
+	cols := queryScope.makePresentation()
+	var expected []*types.T
+	if b.returnType.Family() == types.TupleFamily {
+		expected = b.returnType.TupleContents()
+		if len(cols) == 1 && queryScope.getColumn(cols[0].ID).typ.Family() == types.TupleFamily {
+			// The query returns a single composite-typed column.
+			expected = []*types.T{b.returnType}
+		}
+	} else {
+		expected = []*types.T{b.returnType}
+	}
+	if len(cols) != len(expected) {
+		panic(errors.WithDetailf(
+			pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+			"Number of returned columns (%d) does not match expected column count (%d).",
+			len(cols), len(expected),
+		))
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	for j := range cols {
+		elems[j] = b.coerceType(b.ob.factory.ConstructVariable(cols[j].ID), expected[j])
+	}
+	scalar := elems[0]
+	if len(expected) != 1 || !expected[0].Identical(b.returnType) {
+		scalar = b.ob.factory.ConstructTuple(elems, b.returnType)
+	}
+	resultColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	resultScope := queryScope.push()
+	b.ob.synthesizeColumn(resultScope, resultColName, b.returnType, nil /* expr */, scalar)
+	resultScope.copyOrdering(queryScope)
+	b.ob.constructProjectForScope(queryScope, resultScope)
+	return resultScope
+}
+
 // targetIsSingleCompositeVar returns true if the given INTO target is a single
 // RECORD-type variable.
 func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
@@ -1977,6 +2614,46 @@ func (b *plpgsqlBuilder) prependStmt(stmt ast.Statement, stmts []ast.Statement)
 	return append(newStmts, stmts...)
 }
 
+func (b *plpgsqlBuilder) prependStmts(prefix, stmts []ast.Statement) []ast.Statement {
+	newStmts := make([]ast.Statement, 0, len(prefix)+len(stmts))
+	newStmts = append(newStmts, prefix...)
+	return append(newStmts, stmts...)
+}
+
+// makeVarRef returns an expression that references the given PL/pgSQL
+// variable. It is used when rewriting a statement into simpler statements.
+func (b *plpgsqlBuilder) makeVarRef(name ast.Variable) ast.Expr {
+	return tree.NewUnresolvedName(string(name))
+}
+
+// makeIsNull returns an expression that checks whether the given PL/pgSQL
+// variable is NULL.
+func (b *plpgsqlBuilder) makeIsNull(name ast.Variable) ast.Expr {
+	return &tree.IsNullExpr{Expr: b.makeVarRef(name)}
+}
+
+// makeRaiseIf returns a statement that throws an error with the given code and
+// message if the given condition is true.
+func (b *plpgsqlBuilder) makeRaiseIf(cond ast.Expr, code pgcode.Code, msg string) ast.Statement {
+	return &ast.If{
+		Condition: cond,
+		ThenBody: []ast.Statement{
+			&ast.Raise{LogLevel: "EXCEPTION", Code: code.String(), Message: msg},
+		},
+	}
+}
+
+// resolveExprType type-checks the given expression in the given scope, and
+// returns its type.
+func (b *plpgsqlBuilder) resolveExprType(expr ast.Expr, s *scope) *types.T {
+	expr, _ = tree.WalkExpr(s, expr)
+	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+	if err != nil {
+		panic(err)
+	}
+	return typedExpr.ResolvedType()
+}
+
 func (b *plpgsqlBuilder) ensureScopeHasExpr(s *scope) {
 	if s.expr == nil {
 		s.expr = b.ob.factory.ConstructNoColsRow()
@@ -2196,35 +2873,48 @@ func (r *recordTypeVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, re
 			return t, false
 		}
 	case *ast.Return:
-		desired := types.Any
-		if r.typ != nil && r.typ.Family() != types.UnknownFamily {
-			desired = r.typ
+		if t.Expr != nil {
+			r.visitReturnExpr(t.Expr)
 		}
-		expr, _ := tree.WalkExpr(r.s, t.Expr)
-		typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
-		if err != nil {
-			panic(err)
-		}
-		typ := typedExpr.ResolvedType()
-		switch typ.Family() {
-		case types.UnknownFamily, types.TupleFamily:
-		default:
-			panic(nonCompositeErr)
-		}
-		if r.typ == nil || r.typ.Family() == types.UnknownFamily {
-			r.typ = typ
-			return stmt, false
-		}
-		if typ.Family() == types.UnknownFamily {
-			return stmt, false
-		}
-		if !typ.Identical(r.typ) {
-			panic(recordReturnErr)
+	case *ast.ReturnNext:
+		// The rows of a set-returning routine are supplied by RETURN NEXT.
+		if t.Expr != nil {
+			r.visitReturnExpr(t.Expr)
 		}
 	}
 	return stmt, true
 }
 
+// visitReturnExpr type-checks an expression that is returned by the routine,
+// and updates the inferred type.
+func (r *recordTypeVisitor) visitReturnExpr(returnExpr ast.Expr) {
+	desired := types.Any
+	if r.typ != nil && r.typ.Family() != types.UnknownFamily {
+		desired = r.typ
+	}
+	expr, _ := tree.WalkExpr(r.s, returnExpr)
+	typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
+	if err != nil {
+		panic(err)
+	}
+	typ := typedExpr.ResolvedType()
+	switch typ.Family() {
+	case types.UnknownFamily, types.TupleFamily:
+	default:
+		panic(nonCompositeErr)
+	}
+	if r.typ == nil || r.typ.Family() == types.UnknownFamily {
+		r.typ = typ
+		return
+	}
+	if typ.Family() == types.UnknownFamily {
+		return
+	}
+	if !typ.Identical(r.typ) {
+		panic(recordReturnErr)
+	}
+}
+
 // transactionControlVisitor is used to check for COMMIT or ROLLBACK statements
 // for a PL/pgSQL stored procedure, so that stable folding can be disabled.
 type transactionControlVisitor struct {
@@ -2299,6 +2989,22 @@ var (
 	)
 	returnWithVoidParameterProcedureErr = pgerror.New(pgcode.Syntax,
 		"RETURN cannot have a parameter in a procedure")
+	returnWithSetofErr = errors.WithHint(
+		pgerror.New(pgcode.DatatypeMismatch, "RETURN cannot have a parameter in function returning set"),
+		"Use RETURN NEXT or RETURN QUERY.",
+	)
+	returnNextNonSetofErr = pgerror.New(pgcode.Syntax,
+		"cannot use RETURN NEXT in a non-SETOF function",
+	)
+	returnQueryNonSetofErr = pgerror.New(pgcode.Syntax,
+		"cannot use RETURN QUERY in a non-SETOF function",
+	)
+	returnNextWithOUTParameterErr = pgerror.New(pgcode.DatatypeMismatch,
+		"RETURN NEXT cannot have a parameter in function with OUT parameters",
+	)
+	emptyReturnNextErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT must have a parameter",
+	)
 	emptyReturnErr = pgerror.New(pgcode.Syntax,
 		"missing expression at or near \"RETURN;\"",
 	)
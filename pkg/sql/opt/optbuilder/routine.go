@@ -24,8 +24,8 @@ import (
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -146,6 +146,92 @@ func (b *Builder) buildProcedure(c *tree.Call, inScope *scope) *scope {
 	return outScope
 }
 
+// doBlockRoutineName is the name given to the ephemeral procedure that is
+// built for the anonymous code block of a DO statement. It matches the name
+// used by Postgres.
+const doBlockRoutineName = "inline_code_block"
+
+// buildDo builds a set of memo groups that represents the execution of an
+// anonymous code block. The code block is built as a PL/pgSQL procedure with no
+// parameters, which is invoked in the same way as a CALL statement. Since the
+// code block is built with the current user's catalog, it runs with the
+// caller's privileges.
+func (b *Builder) buildDo(do *tree.DoBlock, inScope *scope) *scope {
+	// Disable memo reuse, since the body of the code block is not tracked as a
+	// dependency of the statement.
+	b.DisableMemoReuse = true
+	outScope := inScope.push()
+
+	// Parse the code block.
+	stmt, err := plpgsql.Parse(do.Code)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the code block in its own scope; like a routine body, it cannot
+	// refer to anything from the outer expression. Do not track any of the
+	// objects referenced by the code block as schema dependencies.
+	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine, insideTriggerFunction bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+		b.insideSQLRoutine = insideSQLRoutine
+		b.insideTriggerFunction = insideTriggerFunction
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine, b.insideTriggerFunction)
+	nested := b.insideUDF
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+	b.insideSQLRoutine = false
+	b.insideTriggerFunction = false
+
+	bodyScope := b.allocScope()
+	plBuilder := newPLpgSQLBuilder(
+		b, doBlockRoutineName, stmt.AST.Label, nil /* colRefs */, nil /* routineParams */, types.Void,
+		true /* isProcedure */, false /* setReturning */, outScope,
+	)
+	var stmtScope *scope
+	buildBody := func() {
+		stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, nil /* routineParams */)
+	}
+	if nested {
+		// A DO statement within a routine is treated as a nested procedure call.
+		b.withinNestedPLpgSQLCall(buildBody)
+	} else {
+		buildBody()
+	}
+
+	// The code block does not return a result, so add a LIMIT 1 to the last
+	// statement in the same way as for a VOID procedure.
+	physProps := stmtScope.makePhysicalProps()
+	b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+	physProps.Ordering = props.OrderingChoice{}
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+	routine := b.factory.ConstructUDFCall(nil /* args */, &memo.UDFCallPrivate{
+		Def: &memo.UDFDefinition{
+			Name:              doBlockRoutineName,
+			Typ:               types.Void,
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+			RoutineType:       tree.ProcedureRoutine,
+			RoutineLang:       tree.RoutineLangPLpgSQL,
+			Body:              []memo.RelExpr{stmtScope.expr},
+			BodyProps:         []*physical.Required{physProps},
+			BodyStmts:         bodyStmts,
+		},
+	})
+	routine = b.finishBuildScalar(nil /* texpr */, routine, inScope,
+		nil /* outScope */, nil /* outCol */)
+
+	// Build a call expression with no output columns.
+	callPrivate := &memo.CallPrivate{Columns: outScope.colList()}
+	outScope.expr = b.factory.ConstructCall(routine, callPrivate)
+	return outScope
+}
+
 // resolveProcedureDefinition type-checks and resolves the given procedure
 // reference, and checks its privileges.
 func (b *Builder) resolveProcedureDefinition(
@@ -210,6 +296,19 @@ func (b *Builder) buildRoutine(
 	inScope, outScope *scope,
 	colRefs *opt.ColSet,
 ) opt.ScalarExpr {
+	args, udfDef := b.buildRoutineDefinition(f, def, inScope, outScope, colRefs)
+	return b.factory.ConstructUDFCall(args, &memo.UDFCallPrivate{Def: udfDef})
+}
+
+// buildRoutineDefinition builds the definition of a user-defined function or
+// procedure, and the expressions for the arguments of its invocation. See
+// buildRoutine.
+func (b *Builder) buildRoutineDefinition(
+	f *tree.FuncExpr,
+	def *tree.ResolvedFunctionDefinition,
+	inScope, outScope *scope,
+	colRefs *opt.ColSet,
+) (memo.ScalarListExpr, *memo.UDFDefinition) {
 	o := f.ResolvedOverload()
 	isProc := o.Type == tree.ProcedureRoutine
 	invocationTypes := make([]*types.T, len(f.Exprs))
@@ -282,8 +381,7 @@ func (b *Builder) buildRoutine(
 		// Add all input parameters to the scope.
 		paramTypes, ok := o.Types.(tree.ParamTypes)
 		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+			panic(errors.AssertionFailedf("unexpected parameter list %T for routine", o.Types))
 		}
 		if len(paramTypes) != len(args) {
 			panic(errors.AssertionFailedf(
@@ -347,14 +445,16 @@ func (b *Builder) buildRoutine(
 	// for the schema changer we only need depth 1. Also keep track of when
 	// we have are executing inside a UDF, and whether the routine is used as a
 	// data source (this could be nested, so we need to track the previous state).
-	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine bool) {
+	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine, insideTriggerFunction bool) {
 		b.trackSchemaDeps = trackSchemaDeps
 		b.insideUDF = insideUDF
 		b.insideDataSource = insideDataSource
 		b.insideSQLRoutine = insideSQLRoutine
-	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine)
+		b.insideTriggerFunction = insideTriggerFunction
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine, b.insideTriggerFunction)
 	oldInsideDataSource := b.insideDataSource
 	b.insideDataSource = false
+	b.insideTriggerFunction = false
 	b.trackSchemaDeps = false
 	b.insideUDF = true
 	b.insideSQLRoutine = o.Language == tree.RoutineLangSQL
@@ -431,7 +531,8 @@ func (b *Builder) buildRoutine(
 		var expr memo.RelExpr
 		var physProps *physical.Required
 		plBuilder := newPLpgSQLBuilder(
-			b, def.Name, stmt.AST.Label, colRefs, routineParams, f.ResolvedType(), isProc, outScope,
+			b, def.Name, stmt.AST.Label, colRefs, routineParams, f.ResolvedType(), isProc,
+			isSetReturning, outScope,
 		)
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		expr, physProps = b.finishBuildLastStmt(
@@ -447,26 +548,21 @@ func (b *Builder) buildRoutine(
 	}
 
 	multiColDataSource := len(f.ResolvedType().TupleContents()) > 0 && oldInsideDataSource
-	routine := b.factory.ConstructUDFCall(
-		args,
-		&memo.UDFCallPrivate{
-			Def: &memo.UDFDefinition{
-				Name:               def.Name,
-				Typ:                f.ResolvedType(),
-				Volatility:         o.Volatility,
-				SetReturning:       isSetReturning,
-				CalledOnNullInput:  o.CalledOnNullInput,
-				MultiColDataSource: multiColDataSource,
-				RoutineType:        o.Type,
-				RoutineLang:        o.Language,
-				Body:               body,
-				BodyProps:          bodyProps,
-				BodyStmts:          bodyStmts,
-				Params:             params,
-			},
-		},
-	)
-	return routine
+	return args, &memo.UDFDefinition{
+		Name:               def.Name,
+		Typ:                f.ResolvedType(),
+		Volatility:         o.Volatility,
+		SetReturning:       isSetReturning,
+		CalledOnNullInput:  o.CalledOnNullInput,
+		MultiColDataSource: multiColDataSource,
+		OwnsResultBuffer:   isSetReturning && o.Language == tree.RoutineLangPLpgSQL,
+		RoutineType:        o.Type,
+		RoutineLang:        o.Language,
+		Body:               body,
+		BodyProps:          bodyProps,
+		BodyStmts:          bodyStmts,
+		Params:             params,
+	}
 }
 
 // finishBuildLastStmt manages the columns returned by the last statement of a
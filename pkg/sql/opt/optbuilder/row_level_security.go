@@ -0,0 +1,261 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// shouldApplyRowLevelSecurity returns true if the row-level security policies
+// of the given table apply to the current user. Policies do not apply to
+// admins, to users with the BYPASSRLS role option, or to the owner of the
+// table unless row-level security is forced.
+func (b *Builder) shouldApplyRowLevelSecurity(tab cat.Table) bool {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return false
+	}
+	// The plan depends on the current user, so it cannot be reused.
+	b.DisableMemoReuse = true
+	if isAdmin, err := b.catalog.HasAdminRole(b.ctx); err != nil {
+		panic(err)
+	} else if isAdmin {
+		return false
+	}
+	if bypass, err := b.catalog.HasRoleOption(b.ctx, roleoption.BYPASSRLS); err != nil {
+		panic(err)
+	} else if bypass {
+		return false
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		if isOwner, err := b.catalog.HasOwnership(b.ctx, tab); err != nil {
+			panic(err)
+		} else if isOwner {
+			return false
+		}
+	}
+	return true
+}
+
+// policyApplies returns true if the policy applies to the given command for
+// the current user.
+func (b *Builder) policyApplies(p cat.Policy, cmd catpb.PolicyCommand) bool {
+	if p.Command() != catpb.PolicyCommand_ALL && p.Command() != cmd {
+		return false
+	}
+	for i, n := 0, p.RoleCount(); i < n; i++ {
+		isMember, err := b.catalog.IsMemberOfRole(b.ctx, p.Role(i))
+		if err != nil {
+			panic(err)
+		}
+		if isMember {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRowLevelSecurityExpr returns the expression that rows must satisfy
+// according to the policies of the table that apply to the given command.
+// The USING expressions of the policies are used, unless withCheck is true, in
+// which case the WITH CHECK expressions are used, falling back to the USING
+// expressions for policies without a WITH CHECK expression.
+//
+// The expression is the disjunction of the permissive policies, in conjunction
+// with all the restrictive policies. If no permissive policy applies, no rows
+// satisfy the expression.
+func (b *Builder) buildRowLevelSecurityExpr(
+	tab cat.Table, cmd catpb.PolicyCommand, withCheck bool,
+) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		p := tab.Policy(i)
+		if !b.policyApplies(p, cmd) {
+			continue
+		}
+		exprStr := p.UsingExpr()
+		if withCheck && p.WithCheckExpr() != "" {
+			exprStr = p.WithCheckExpr()
+		}
+		if exprStr == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		if p.IsRestrictive() {
+			if restrictive == nil {
+				restrictive = expr
+			} else {
+				restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+			}
+		} else {
+			if permissive == nil {
+				permissive = expr
+			} else {
+				permissive = &tree.OrExpr{Left: permissive, Right: expr}
+			}
+		}
+	}
+	if permissive == nil {
+		return tree.DBoolFalse
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return &tree.AndExpr{Left: permissive, Right: restrictive}
+}
+
+// addRowLevelSecurityFilter filters the rows of the given table scan according
+// to the USING expressions of the policies that apply to the given command, if
+// row-level security applies to the table.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, cmd catpb.PolicyCommand, scanScope *scope,
+) {
+	if b.shouldApplyRowLevelSecurity(tab) {
+		b.buildRowLevelSecurityFilter(tab, cmd, scanScope)
+	}
+}
+
+// buildRowLevelSecurityFilter filters the rows of the given table scan
+// according to the USING expressions of the policies that apply to the given
+// command.
+func (b *Builder) buildRowLevelSecurityFilter(
+	tab cat.Table, cmd catpb.PolicyCommand, scanScope *scope,
+) {
+	filter := b.resolveAndBuildScalar(
+		b.buildRowLevelSecurityExpr(tab, cmd, false /* withCheck */),
+		types.Bool,
+		exprKindWhere,
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+		scanScope,
+	)
+	scanScope.expr = b.factory.ConstructSelect(
+		scanScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	)
+}
+
+// initRowLevelSecurity determines whether the row-level security policies of
+// the target table apply to the mutation. If they do, an extra entry is
+// reserved at the end of checkColIDs for the column that holds the result of
+// the policies' WITH CHECK expressions; see addRowLevelSecurityCheckCol.
+//
+// Policies do not apply to the mutations performed by foreign key cascades, so
+// this is only called for the mutation statements issued by the user.
+func (mb *mutationBuilder) initRowLevelSecurity() {
+	if !mb.b.shouldApplyRowLevelSecurity(mb.tab) {
+		return
+	}
+	mb.applyRowLevelSecurity = true
+	mb.checkColIDs = make(opt.OptionalColList, mb.tab.CheckCount()+1)
+}
+
+// addRowLevelSecurityFilter filters the rows fetched by an UPDATE or DELETE
+// according to the USING expressions of the policies that apply to the given
+// command.
+func (mb *mutationBuilder) addRowLevelSecurityFilter(cmd catpb.PolicyCommand) {
+	if mb.applyRowLevelSecurity {
+		mb.b.buildRowLevelSecurityFilter(mb.tab, cmd, mb.fetchScope)
+	}
+}
+
+// addRowLevelSecurityCheckCol synthesizes a boolean output column that is true
+// if the new row satisfies the WITH CHECK expressions of the policies that
+// apply to the given command. The column is stored after the check constraint
+// columns in checkColIDs; the mutation operator reports a policy violation
+// error if its value is false.
+func (mb *mutationBuilder) addRowLevelSecurityCheckCol(cmd catpb.PolicyCommand) {
+	if !mb.applyRowLevelSecurity {
+		return
+	}
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	// A NULL result is treated as a violation, unlike for check constraints.
+	expr := &tree.CoalesceExpr{
+		Name:  "COALESCE",
+		Exprs: tree.Exprs{mb.b.buildRowLevelSecurityExpr(mb.tab, cmd, true /* withCheck */), tree.DBoolFalse},
+	}
+	texpr := mb.outScope.resolveAndRequireType(expr, types.Bool)
+
+	// Use an anonymous name because the column cannot be referenced in other
+	// expressions.
+	colName := scopeColName("").WithMetadataName("policy_check")
+	scopeCol := projectionsScope.addColumn(colName, texpr)
+	mb.b.buildScalar(texpr, mb.outScope, projectionsScope, scopeCol, nil /* colRefs */)
+	mb.checkColIDs[mb.tab.CheckCount()] = scopeCol.id
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// addUpsertRowLevelSecurityCheckCol synthesizes the policy check column for an
+// UPSERT or INSERT ... ON CONFLICT DO UPDATE. A row that does not conflict with
+// an existing row must satisfy the WITH CHECK expressions of the INSERT
+// policies. Otherwise, the existing row must satisfy the USING expressions of
+// the UPDATE policies, and the updated row must satisfy their WITH CHECK
+// expressions. Unlike an UPDATE, an existing row that is not visible to the
+// UPDATE policies is not skipped, since the row could then be neither inserted
+// nor updated; it is reported as a policy violation instead.
+func (mb *mutationBuilder) addUpsertRowLevelSecurityCheckCol() {
+	if !mb.applyRowLevelSecurity {
+		return
+	}
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	// A NULL result is treated as a violation, unlike for check constraints.
+	buildExpr := func(cmd catpb.PolicyCommand, withCheck bool, inScope *scope) opt.ScalarExpr {
+		expr := &tree.CoalesceExpr{
+			Name:  "COALESCE",
+			Exprs: tree.Exprs{mb.b.buildRowLevelSecurityExpr(mb.tab, cmd, withCheck), tree.DBoolFalse},
+		}
+		texpr := inScope.resolveAndRequireType(expr, types.Bool)
+		return mb.b.buildScalar(texpr, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	}
+	insertCheck := buildExpr(catpb.PolicyCommand_INSERT, true /* withCheck */, mb.outScope)
+	updateUsing := buildExpr(catpb.PolicyCommand_UPDATE, false /* withCheck */, mb.fetchScope)
+	updateCheck := buildExpr(catpb.PolicyCommand_UPDATE, true /* withCheck */, mb.outScope)
+
+	// The canary column is NULL if the row does not conflict with an existing
+	// row, in which case it is inserted.
+	caseExpr := mb.b.factory.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{
+			mb.b.factory.ConstructWhen(
+				mb.b.factory.ConstructIs(
+					mb.b.factory.ConstructVariable(mb.canaryColID),
+					memo.NullSingleton,
+				),
+				insertCheck,
+			),
+		},
+		mb.b.factory.ConstructAnd(updateUsing, updateCheck),
+	)
+
+	// Use an anonymous name because the column cannot be referenced in other
+	// expressions.
+	colName := scopeColName("").WithMetadataName("policy_check")
+	scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, types.Bool, nil /* expr */, caseExpr)
+	mb.checkColIDs[mb.tab.CheckCount()] = scopeCol.id
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
@@ -1007,6 +1007,27 @@ func makeUntypedTuple(labels []string, texprs []tree.TypedExpr) *tree.Tuple {
 	return &tree.Tuple{Exprs: exprs, Labels: labels}
 }
 
+// resolveTriggerRecordField attempts to resolve a column item of the form
+// "rec.field" as an access of a field of a record-typed column named "rec". It
+// returns nil if the column item cannot be resolved this way. This is only
+// allowed when building a trigger function or trigger WHEN condition, where
+// the fields of the NEW and OLD records are referenced in this way.
+func (s *scope) resolveTriggerRecordField(t *tree.ColumnItem) tree.Expr {
+	if !s.builder.insideTriggerFunction || t.TableName == nil || t.TableName.NumParts != 1 {
+		return nil
+	}
+	recItem := tree.ColumnItem{ColumnName: tree.Name(t.TableName.Parts[0])}
+	colI, err := colinfo.ResolveColumnItem(s.builder.ctx, s, &recItem)
+	if err != nil {
+		return nil
+	}
+	col := colI.(*scopeColumn)
+	if col.typ.Family() != types.TupleFamily {
+		return nil
+	}
+	return &tree.ColumnAccessExpr{Expr: col, ColName: t.ColumnName}
+}
+
 // VisitPre is part of the Visitor interface.
 //
 // NB: This code is adapted from sql/select_name_resolution.go and
@@ -1042,6 +1063,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			// It may be a reference to a field of the NEW or OLD record within a
+			// trigger function, e.g. NEW.x.
+			if fieldAccess := s.resolveTriggerRecordField(t); fieldAccess != nil {
+				return false, fieldAccess
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -52,15 +52,17 @@ import (
 func (b *Builder) buildDataSource(
 	texpr tree.TableExpr, indexFlags *tree.IndexFlags, lockCtx lockingContext, inScope *scope,
 ) (outScope *scope) {
-	defer func(prevAtRoot bool, prevInsideDataSource bool) {
+	defer func(prevAtRoot bool, prevInsideDataSource bool, prevOnlyDataSource bool) {
 		inScope.atRoot = prevAtRoot
 		b.insideDataSource = prevInsideDataSource
-	}(inScope.atRoot, b.insideDataSource)
+		b.onlyDataSource = prevOnlyDataSource
+	}(inScope.atRoot, b.insideDataSource, b.onlyDataSource)
 	inScope.atRoot = false
 	b.insideDataSource = true
 	// NB: The case statements are sorted lexicographically.
 	switch source := (texpr).(type) {
 	case *tree.AliasedTableExpr:
+		b.onlyDataSource = source.Only
 		if source.IndexFlags != nil {
 			telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 			telemetry.Inc(sqltelemetry.IndexHintSelectUseCounter)
@@ -108,8 +110,13 @@ func (b *Builder) buildDataSource(
 	case *tree.TableName:
 		tn := source
 
-		// CTEs take precedence over other data sources.
-		if cte := inScope.resolveCTE(tn); cte != nil {
+		// CTEs take precedence over other data sources, followed by the
+		// transition tables of a trigger.
+		cte := inScope.resolveCTE(tn)
+		if cte == nil {
+			cte = b.resolveTransitionTable(tn)
+		}
+		if cte != nil {
 			lockCtx.locking.ignoreLockingForCTE()
 			outScope = inScope.push()
 			inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
@@ -183,7 +190,7 @@ func (b *Builder) buildDataSource(
 					locking = nil
 				}
 			}
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -193,6 +200,11 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			b.addRowLevelSecurityFilter(t, catpb.PolicyCommand_SELECT, outScope)
+			if t.InheritedByCount() > 0 && !b.onlyDataSource {
+				outScope = b.buildInheritedScans(t, &resName, lockCtx, outScope, inScope)
+			}
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -309,6 +321,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			outScope = b.buildScanFromTableRef(t, source, indexFlags, lockCtx.locking, inScope)
+			b.addRowLevelSecurityFilter(t, catpb.PolicyCommand_SELECT, outScope)
 		case cat.View:
 			if source.Columns != nil {
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
@@ -567,6 +580,108 @@ func errorOnInvalidMultiregionDB(
 	}
 }
 
+// buildInheritedScans extends the scan of the given table with the rows of the
+// tables that inherit from it, recursively. The result is a UNION ALL of the
+// visible columns of the table and the same-named columns of each inheriting
+// table. This matches Postgres, where a query against a parent table includes
+// the rows of its children unless the table is qualified with ONLY.
+//
+// Access to the inheriting tables is governed by the privileges on the parent
+// table, as in Postgres.
+func (b *Builder) buildInheritedScans(
+	tab cat.Table, tn *tree.TableName, lockCtx lockingContext, tabScope, inScope *scope,
+) (outScope *scope) {
+	if lockCtx.locking.isSet() {
+		panic(errors.WithHint(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported on a table that is inherited by other tables",
+			lockCtx.locking.get().Strength),
+			"Use ONLY to lock the rows of the parent table alone.",
+		))
+	}
+	outScope = tabScope
+	for i, n := 0, tab.InheritedByCount(); i < n; i++ {
+		id := tab.InheritedBy(i)
+		var flags cat.Flags
+		if b.insideViewDef || b.insideFuncDef {
+			flags.AvoidDescriptorCaches = true
+		}
+		ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, flags, id)
+		if err != nil {
+			panic(err)
+		}
+		child, ok := ds.(cat.Table)
+		if !ok {
+			panic(errors.AssertionFailedf("inheriting data source %d is not a table", id))
+		}
+		b.factory.Metadata().AddDependency(opt.DepByID(id), child, 0 /* priv */)
+
+		childName := tree.MakeUnqualifiedTableName(child.Name())
+		childScope := b.buildScan(
+			b.addTable(child, &childName),
+			tableOrdinals(child, columnKinds{
+				includeMutations: false,
+				includeSystem:    false,
+				includeInverted:  false,
+			}),
+			nil /* indexFlags */, noRowLocking, inScope,
+			false, /* disableNotVisibleIndex */
+		)
+		if child.InheritedByCount() > 0 {
+			childScope = b.buildInheritedScans(child, &childName, lockCtx, childScope, inScope)
+		}
+
+		// Project the columns of the inheriting table in the order of the
+		// visible columns of the parent.
+		projectionsScope := childScope.replace()
+		for j := range outScope.cols {
+			col := &outScope.cols[j]
+			if col.visibility != visible {
+				continue
+			}
+			found := false
+			for k := range childScope.cols {
+				if childScope.cols[k].name.MatchesReferenceName(col.name.ReferenceName()) {
+					projectionsScope.cols = append(projectionsScope.cols, childScope.cols[k])
+					projectionsScope.cols[len(projectionsScope.cols)-1].visibility = visible
+					found = true
+					break
+				}
+			}
+			if !found {
+				// A column added to the parent is added to the inheriting table by
+				// a separate schema change, which may not be done yet. Until then,
+				// the column is NULL for the rows of the inheriting table.
+				if !hasMutationColumn(child, col.name.ReferenceName()) {
+					panic(errors.AssertionFailedf(
+						"inheriting table %s does not have column %s", child.Name(), col.name.ReferenceName(),
+					))
+				}
+				nullCol := b.synthesizeColumn(
+					projectionsScope, col.name, col.typ, nil /* expr */, b.factory.ConstructNull(col.typ),
+				)
+				nullCol.visibility = visible
+			}
+		}
+		b.constructProjectForScope(childScope, projectionsScope)
+		outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, projectionsScope)
+	}
+	for i := range outScope.cols {
+		outScope.cols[i].table = *tn
+	}
+	return outScope
+}
+
+// hasMutationColumn returns whether the given table has a column with the given
+// name which is being added or dropped.
+func hasMutationColumn(tab cat.Table, name tree.Name) bool {
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		if col := tab.Column(i); col.IsMutation() && col.ColName() == name {
+			return true
+		}
+	}
+	return false
+}
+
 // buildScan builds a memo group for a ScanOp expression on the given table. If
 // the ordinals list contains any VirtualComputed columns, a ProjectOp is built
 // on top.
@@ -1093,7 +1208,9 @@ func (b *Builder) buildSelectStmt(
 		return b.buildSelect(stmt.Select, lockCtx, desiredTypes, inScope)
 
 	case *tree.SelectClause:
-		return b.buildSelectClause(stmt, nil /* orderBy */, lockCtx, desiredTypes, inScope)
+		return b.buildSelectClause(
+			stmt, nil /* orderBy */, lockCtx, desiredTypes, inScope, nil, /* gs */
+		)
 
 	case *tree.UnionClause:
 		return b.buildUnionClause(stmt, desiredTypes, inScope)
@@ -1209,7 +1326,7 @@ func (b *Builder) buildSelectStmtWithoutParens(
 			"%T in buildSelectStmtWithoutParens", wrapped))
 
 	case *tree.SelectClause:
-		outScope = b.buildSelectClause(t, orderBy, lockCtx, desiredTypes, inScope)
+		outScope = b.buildSelectClause(t, orderBy, lockCtx, desiredTypes, inScope, nil /* gs */)
 
 	case *tree.UnionClause:
 		b.rejectIfLocking(lockCtx.locking, "UNION/INTERSECT/EXCEPT")
@@ -1264,6 +1381,10 @@ func (b *Builder) buildSelectStmtWithoutParens(
 // results using columns from the FROM/GROUP BY clause and/or from the
 // projection list.
 //
+// If the GROUP BY clause contains ROLLUP, CUBE or GROUPING SETS, the select
+// clause is built by buildGroupingSets, which calls back into
+// buildSelectClause for each grouping set gs.
+//
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelectClause(
@@ -1272,7 +1393,13 @@ func (b *Builder) buildSelectClause(
 	lockCtx lockingContext,
 	desiredTypes []*types.T,
 	inScope *scope,
+	gs *groupingSet,
 ) (outScope *scope) {
+	if gs == nil && hasGroupingSets(sel.GroupBy) {
+		// ORDER BY is applied to the output columns by the caller.
+		return b.buildGroupingSets(sel, lockCtx, desiredTypes, inScope)
+	}
+
 	if sel.Where != nil {
 		lockCtx.safeUpdate = true
 	}
@@ -1299,12 +1426,14 @@ func (b *Builder) buildSelectClause(
 	lockScope := b.analyzeLockArgs(lockCtx, fromScope, projectionsScope)
 
 	var having opt.ScalarExpr
-	needsAgg := b.needsAggregation(sel, fromScope)
+	// Every grouping set is built as an aggregation, even if its set of
+	// grouping expressions is empty.
+	needsAgg := gs != nil || b.needsAggregation(sel, fromScope)
 	if needsAgg {
 		// Grouping columns must be built before building the projection list so
 		// we can check that any column references that appear in the SELECT list
 		// outside of aggregate functions are present in the grouping list.
-		b.buildGroupingColumns(sel, projectionsScope, fromScope)
+		b.buildGroupingColumns(sel, gs, projectionsScope, fromScope)
 		having = b.buildHaving(havingExpr, fromScope)
 	}
 
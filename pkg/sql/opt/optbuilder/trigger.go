@@ -0,0 +1,694 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// This file contains methods that fire the triggers defined on the target
+// table of a mutation.
+//
+// -- BEFORE triggers --
+//
+// Row-level BEFORE triggers are built inline into the mutation input. The NEW
+// and OLD records are projected as tuple columns, and each trigger function is
+// invoked in turn with the record returned by the previous one. Rows for which
+// a trigger function returns NULL are filtered out. Finally, the fields of the
+// NEW record are projected as the new values of the table columns:
+//
+//	insert t
+//	 └── project (a_new := new.a, b_new := new.b)
+//	      └── select (new_trig IS NOT NULL)
+//	           └── barrier
+//	                └── project (new_trig := trig_fn(new, old, ...))
+//	                     └── project (new := (a, b), old := NULL)
+//	                          └── <input>
+//
+// Statement-level BEFORE triggers are invoked once by an uncorrelated subquery
+// which is evaluated before the mutation.
+//
+// -- AFTER triggers --
+//
+// AFTER triggers are planned in the same way as foreign key cascades (see
+// mutation_builder_fk.go): they are "potential" future queries that read the
+// buffered mutation input and invoke the trigger function for each row, or
+// once for the statement. These queries are built by afterTriggerBuilder once
+// the mutation has run.
+
+// triggerRecordCols returns the type of the NEW and OLD records of triggers on
+// the given table, which is a tuple of the visible columns of the table. It
+// also returns the table ordinals of the columns, in the same order as the
+// fields of the record.
+func triggerRecordCols(tab cat.Table) (*types.T, []int) {
+	var contents []*types.T
+	var labels []string
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() != cat.Ordinary || col.Visibility() != cat.Visible {
+			continue
+		}
+		contents = append(contents, col.DatumType())
+		labels = append(labels, string(col.ColName()))
+		ords = append(ords, i)
+	}
+	return types.MakeLabeledTuple(contents, labels), ords
+}
+
+// triggersToFire returns the enabled triggers on the target table with the
+// given action time and level which fire for the given event.
+func (mb *mutationBuilder) triggersToFire(
+	actionTime tree.TriggerActionTime, forEachRow bool, event tree.TriggerEventType,
+) []cat.Trigger {
+	var triggers []cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trigger := mb.tab.Trigger(i)
+		if !trigger.Enabled() || trigger.ActionTime() != actionTime ||
+			trigger.ForEachRow() != forEachRow {
+			continue
+		}
+		for j := 0; j < trigger.EventCount(); j++ {
+			if ev := trigger.Event(j); ev.EventType == event && mb.triggerEventColumnsTargeted(ev) {
+				triggers = append(triggers, trigger)
+				break
+			}
+		}
+	}
+	return triggers
+}
+
+// triggerEventColumnsTargeted returns true if the event has no column list, or
+// if one of the columns is a target of the mutation (as in UPDATE OF col).
+func (mb *mutationBuilder) triggerEventColumnsTargeted(ev cat.TriggerEvent) bool {
+	if len(ev.Columns) == 0 {
+		return true
+	}
+	for _, name := range ev.Columns {
+		if ord := findPublicTableColumnByName(mb.tab, name); ord != -1 &&
+			mb.targetColSet.Contains(mb.tabID.ColumnID(ord)) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRowLevelBeforeTriggers adds the invocations of the row-level BEFORE
+// triggers on the target table to the mutation input. For INSERT and UPDATE,
+// the values returned by the trigger functions replace the insert or update
+// columns. Rows for which a trigger function returns NULL are skipped.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(event tree.TriggerEventType) {
+	triggers := mb.triggersToFire(tree.TriggerActionTimeBefore, true /* forEachRow */, event)
+	if len(triggers) == 0 {
+		return
+	}
+	b := mb.b
+	f := b.factory
+	recordType, ords := triggerRecordCols(mb.tab)
+
+	// makeRecord returns a tuple with the given column for each field of the
+	// record, or NULL if there are no such columns.
+	makeRecord := func(colIDs opt.OptionalColList, fallback opt.OptionalColList) opt.ScalarExpr {
+		if colIDs == nil {
+			return f.ConstructNull(recordType)
+		}
+		elems := make(memo.ScalarListExpr, len(ords))
+		for i, ord := range ords {
+			colID := colIDs[ord]
+			if colID == 0 && fallback != nil {
+				colID = fallback[ord]
+			}
+			if colID == 0 {
+				// This is a computed column, which is not computed until after the
+				// BEFORE triggers have fired.
+				elems[i] = f.ConstructNull(recordType.TupleContents()[i])
+				continue
+			}
+			elems[i] = f.ConstructVariable(colID)
+		}
+		return f.ConstructTuple(elems, recordType)
+	}
+	var newRec, oldRec opt.ScalarExpr
+	switch event {
+	case tree.TriggerEventInsert:
+		newRec, oldRec = makeRecord(mb.insertColIDs, nil), makeRecord(nil, nil)
+	case tree.TriggerEventUpdate:
+		newRec, oldRec = makeRecord(mb.updateColIDs, mb.fetchColIDs), makeRecord(mb.fetchColIDs, nil)
+	case tree.TriggerEventDelete:
+		newRec, oldRec = makeRecord(nil, nil), makeRecord(mb.fetchColIDs, nil)
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger event %s", tree.AsString(&event)))
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	newCol := b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("new"), recordType, nil /* expr */, newRec,
+	)
+	oldCol := b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("old"), recordType, nil /* expr */, oldRec,
+	)
+	b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	newID, oldID := newCol.id, oldCol.id
+
+	for _, trigger := range triggers {
+		// A trigger which returns NULL skips the operation for the row. For
+		// DELETE, the record returned by the trigger is otherwise ignored.
+		call := b.buildTriggerFunctionCall(
+			mb.tab, trigger, event, f.ConstructVariable(newID), f.ConstructVariable(oldID), nil, /* transitionTables */
+		)
+		passthrough := newID
+		if event == tree.TriggerEventDelete {
+			passthrough = oldID
+		}
+		if trigger.WhenExpr() != "" {
+			cond := b.buildTriggerWhenExpr(trigger, recordType, newID, oldID)
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+				f.ConstructVariable(passthrough),
+			)
+		}
+		resScope := mb.outScope.replace()
+		resScope.appendColumnsFromScope(mb.outScope)
+		resCol := b.synthesizeColumn(
+			resScope,
+			scopeColName("").WithMetadataName(string(trigger.Name())),
+			recordType,
+			nil, /* expr */
+			call,
+		)
+		b.constructProjectForScope(mb.outScope, resScope)
+		mb.outScope = resScope
+
+		// Prevent the trigger function from being reordered or eliminated.
+		mb.outScope.expr = f.ConstructBarrier(mb.outScope.expr)
+		mb.outScope.expr = f.ConstructSelect(
+			mb.outScope.expr,
+			memo.FiltersExpr{f.ConstructFiltersItem(
+				f.ConstructIsNot(f.ConstructVariable(resCol.id), f.ConstructNull(recordType)),
+			)},
+		)
+		if event != tree.TriggerEventDelete {
+			newID = resCol.id
+		}
+	}
+	if event == tree.TriggerEventDelete {
+		return
+	}
+
+	// Project the fields of the final NEW record as the new values of the table
+	// columns.
+	projectionsScope = mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, ord := range ords {
+		tabCol := mb.tab.Column(ord)
+		if tabCol.IsComputed() {
+			continue
+		}
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(
+			string(tabCol.ColName()) + "_new",
+		)
+		col := b.synthesizeColumn(
+			projectionsScope, colName, tabCol.DatumType(), nil, /* expr */
+			f.ConstructColumnAccess(f.ConstructVariable(newID), memo.TupleOrdinal(i)),
+		)
+		if event == tree.TriggerEventInsert {
+			mb.insertColIDs[ord] = col.id
+		} else {
+			mb.updateColIDs[ord] = col.id
+		}
+	}
+	b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// buildBeforeStatementTriggers adds the invocations of the statement-level
+// BEFORE triggers on the target table. Each trigger function is invoked by an
+// uncorrelated subquery, which is evaluated once before the mutation.
+func (mb *mutationBuilder) buildBeforeStatementTriggers(event tree.TriggerEventType) {
+	if !mb.fireStatementTriggers {
+		return
+	}
+	triggers := mb.triggersToFire(tree.TriggerActionTimeBefore, false /* forEachRow */, event)
+	if len(triggers) == 0 {
+		return
+	}
+	b := mb.b
+	f := b.factory
+	if b.insideUDF {
+		// Subqueries within routines are evaluated lazily, so they cannot be
+		// used to fire a statement-level trigger.
+		panic(unimplemented.NewWithIssue(126359,
+			"statement-level BEFORE triggers are not supported for mutations within routines"))
+	}
+	recordType, _ := triggerRecordCols(mb.tab)
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for _, trigger := range triggers {
+		call := b.buildTriggerFunctionCall(
+			mb.tab, trigger, event, f.ConstructNull(recordType), f.ConstructNull(recordType),
+			nil, /* transitionTables */
+		)
+		callCol := f.Metadata().AddColumn(string(trigger.Name()), recordType)
+		subquery := f.ConstructSubquery(
+			f.ConstructProject(
+				f.ConstructNoColsRow(),
+				memo.ProjectionsExpr{f.ConstructProjectionsItem(call, callCol)},
+				opt.ColSet{},
+			),
+			&memo.SubqueryPrivate{},
+		)
+		b.synthesizeColumn(
+			projectionsScope, scopeColName("").WithMetadataName(string(trigger.Name())),
+			recordType, nil /* expr */, subquery,
+		)
+	}
+	b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	mb.outScope.expr = f.ConstructBarrier(mb.outScope.expr)
+}
+
+// buildAfterTriggers adds the AFTER triggers on the target table to the
+// cascading queries of the mutation. Row-level triggers fire before
+// statement-level triggers.
+func (mb *mutationBuilder) buildAfterTriggers(event tree.TriggerEventType) {
+	_, ords := triggerRecordCols(mb.tab)
+	recordCols := func(colIDs opt.OptionalColList, fallback opt.OptionalColList) opt.ColList {
+		cols := make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			cols[i] = colIDs[ord]
+			if cols[i] == 0 && fallback != nil {
+				cols[i] = fallback[ord]
+			}
+			if cols[i] == 0 {
+				panic(errors.AssertionFailedf("expected column %d to be in the mutation input", ord))
+			}
+		}
+		return cols
+	}
+	addTrigger := func(trigger cat.Trigger) {
+		var oldValues, newValues opt.ColList
+		needsInput := trigger.ForEachRow() ||
+			trigger.NewTransitionAlias() != "" || trigger.OldTransitionAlias() != ""
+		if needsInput {
+			mb.ensureWithID()
+			switch event {
+			case tree.TriggerEventInsert:
+				newValues = recordCols(mb.insertColIDs, nil)
+			case tree.TriggerEventUpdate:
+				oldValues = recordCols(mb.fetchColIDs, nil)
+				newValues = recordCols(mb.updateColIDs, mb.fetchColIDs)
+			case tree.TriggerEventDelete:
+				oldValues = recordCols(mb.fetchColIDs, nil)
+			}
+		}
+		var withID opt.WithID
+		if needsInput {
+			withID = mb.withID
+		}
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			Trigger:   trigger,
+			Builder:   newAfterTriggerBuilder(mb.tab, trigger, event),
+			WithID:    withID,
+			OldValues: oldValues,
+			NewValues: newValues,
+		})
+	}
+	for _, trigger := range mb.triggersToFire(tree.TriggerActionTimeAfter, true /* forEachRow */, event) {
+		addTrigger(trigger)
+	}
+	if mb.fireStatementTriggers {
+		for _, trigger := range mb.triggersToFire(tree.TriggerActionTimeAfter, false /* forEachRow */, event) {
+			addTrigger(trigger)
+		}
+	}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder implementation for AFTER
+// triggers.
+//
+// For a row-level trigger, it builds a query that invokes the trigger function
+// once for each row of the buffered mutation input, equivalent to:
+//
+//	SELECT trig_fn(new, old, ...) FROM mutation_input WHERE <when>
+//
+// For a statement-level trigger, the trigger function is invoked exactly once.
+// The query never returns any rows.
+type afterTriggerBuilder struct {
+	tab     cat.Table
+	trigger cat.Trigger
+	event   tree.TriggerEventType
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+func newAfterTriggerBuilder(
+	tab cat.Table, trigger cat.Trigger, event tree.TriggerEventType,
+) *afterTriggerBuilder {
+	return &afterTriggerBuilder{tab: tab, trigger: trigger, event: event}
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		f := b.factory
+		md := f.Metadata()
+		recordType, _ := triggerRecordCols(tb.tab)
+
+		var transitionTables map[string]*cteSource
+		if binding != 0 {
+			// Construct a dummy operator as the binding.
+			md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+				Props: bindingProps,
+			}))
+			addTransitionTable := func(alias tree.Name, cols opt.ColList) {
+				if alias == "" {
+					return
+				}
+				if transitionTables == nil {
+					transitionTables = make(map[string]*cteSource)
+				}
+				cte := &cteSource{
+					id:   binding,
+					name: tree.AliasClause{Alias: alias},
+					cols: make(physical.Presentation, len(cols)),
+				}
+				for i, col := range cols {
+					cte.cols[i] = opt.AliasedColumn{Alias: recordType.TupleLabels()[i], ID: col}
+				}
+				transitionTables[string(alias)] = cte
+			}
+			addTransitionTable(tb.trigger.NewTransitionAlias(), newValues)
+			addTransitionTable(tb.trigger.OldTransitionAlias(), oldValues)
+		}
+
+		var input memo.RelExpr
+		var call opt.ScalarExpr
+		if tb.trigger.ForEachRow() {
+			// Scan the buffered mutation input.
+			var inCols, outCols opt.ColList
+			outColByInCol := make(map[opt.ColumnID]opt.ColumnID)
+			mapCols := func(cols opt.ColList) opt.ColList {
+				if cols == nil {
+					return nil
+				}
+				mapped := make(opt.ColList, len(cols))
+				for i, col := range cols {
+					if _, ok := outColByInCol[col]; !ok {
+						c := md.ColumnMeta(col)
+						inCols = append(inCols, col)
+						outColByInCol[col] = md.AddColumn(c.Alias, c.Type)
+						outCols = append(outCols, outColByInCol[col])
+					}
+					mapped[i] = outColByInCol[col]
+				}
+				return mapped
+			}
+			mappedNew, mappedOld := mapCols(newValues), mapCols(oldValues)
+			input = f.ConstructWithScan(&memo.WithScanPrivate{
+				With:    binding,
+				InCols:  inCols,
+				OutCols: outCols,
+				ID:      md.NextUniqueID(),
+			})
+			makeRecord := func(cols opt.ColList) opt.ScalarExpr {
+				if cols == nil {
+					return f.ConstructNull(recordType)
+				}
+				elems := make(memo.ScalarListExpr, len(cols))
+				for i, col := range cols {
+					elems[i] = f.ConstructVariable(col)
+				}
+				return f.ConstructTuple(elems, recordType)
+			}
+
+			// Project the NEW and OLD records.
+			newCol := md.AddColumn("new", recordType)
+			oldCol := md.AddColumn("old", recordType)
+			input = f.ConstructProject(
+				input,
+				memo.ProjectionsExpr{
+					f.ConstructProjectionsItem(makeRecord(mappedNew), newCol),
+					f.ConstructProjectionsItem(makeRecord(mappedOld), oldCol),
+				},
+				opt.ColSet{},
+			)
+			if tb.trigger.WhenExpr() != "" {
+				cond := b.buildTriggerWhenExpr(tb.trigger, recordType, newCol, oldCol)
+				input = f.ConstructSelect(input, memo.FiltersExpr{f.ConstructFiltersItem(cond)})
+			}
+			call = b.buildTriggerFunctionCall(
+				tb.tab, tb.trigger, tb.event, f.ConstructVariable(newCol), f.ConstructVariable(oldCol),
+				transitionTables,
+			)
+		} else {
+			input = f.ConstructNoColsRow()
+			call = b.buildTriggerFunctionCall(
+				tb.tab, tb.trigger, tb.event, f.ConstructNull(recordType), f.ConstructNull(recordType),
+				transitionTables,
+			)
+		}
+		callCol := md.AddColumn(string(tb.trigger.Name()), recordType)
+		out := f.ConstructProject(
+			input,
+			memo.ProjectionsExpr{f.ConstructProjectionsItem(call, callCol)},
+			opt.ColSet{},
+		)
+
+		// The result of an AFTER trigger is ignored. Filter out all rows, but only
+		// after the trigger function has been invoked.
+		out = f.ConstructBarrier(out)
+		return f.ConstructSelect(out, memo.FiltersExpr{f.ConstructFiltersItem(memo.FalseSingleton)})
+	})
+}
+
+// buildTriggerWhenExpr builds the WHEN condition of a row-level trigger, given
+// the columns which hold the NEW and OLD records.
+func (b *Builder) buildTriggerWhenExpr(
+	trigger cat.Trigger, recordType *types.T, newCol, oldCol opt.ColumnID,
+) opt.ScalarExpr {
+	expr, err := parser.ParseExpr(trigger.WhenExpr())
+	if err != nil {
+		panic(err)
+	}
+	defer func(insideTriggerFunction bool) {
+		b.insideTriggerFunction = insideTriggerFunction
+	}(b.insideTriggerFunction)
+	b.insideTriggerFunction = true
+
+	whenScope := b.allocScope()
+	whenScope.cols = []scopeColumn{
+		{name: scopeColName("new"), typ: recordType, id: newCol},
+		{name: scopeColName("old"), typ: recordType, id: oldCol},
+	}
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, whenScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// buildTriggerFunctionCall builds an invocation of the function of the given
+// trigger on the given table. The NEW and OLD records are passed to the
+// function, along with the other special trigger variables such as TG_OP.
+// The given transition tables can be referenced by name within the function.
+//
+// Unlike other routines, the body of a trigger function is built each time the
+// trigger fires, since the types of the NEW and OLD records depend on the table.
+func (b *Builder) buildTriggerFunctionCall(
+	tab cat.Table,
+	trigger cat.Trigger,
+	event tree.TriggerEventType,
+	newRec, oldRec opt.ScalarExpr,
+	transitionTables map[string]*cteSource,
+) opt.ScalarExpr {
+	f := b.factory
+	funcOID := catid.FuncIDToOID(catid.DescID(trigger.FuncID()))
+	funcName, o, err := b.catalog.ResolveFunctionByOID(b.ctx, funcOID)
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"trigger functions must be written in PL/pgSQL"))
+	}
+	f.Metadata().AddUserDefinedFunction(o, nil /* invocationTypes */, nil /* name */)
+
+	// A trigger which directly or indirectly fires itself would cause the
+	// trigger function to be built recursively.
+	key := firingTrigger{tab: tab.ID(), name: trigger.Name()}
+	if _, ok := b.firingTriggers[key]; ok {
+		panic(unimplemented.NewWithIssue(126359, "recursive triggers are not supported"))
+	}
+	if b.firingTriggers == nil {
+		b.firingTriggers = make(map[firingTrigger]struct{})
+	}
+	b.firingTriggers[key] = struct{}{}
+	defer delete(b.firingTriggers, key)
+
+	tabName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	level := "STATEMENT"
+	if trigger.ForEachRow() {
+		level = "ROW"
+	}
+	actionTime := trigger.ActionTime()
+	argv := tree.NewDArray(types.String)
+	for _, arg := range trigger.FuncArgs() {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+
+	// Build the arguments for the trigger variables, and add a parameter for
+	// each of them to the scope of the function body.
+	recordType, _ := triggerRecordCols(tab)
+	type triggerVar struct {
+		name ast.Variable
+		typ  *types.T
+		arg  opt.ScalarExpr
+	}
+	vars := []triggerVar{
+		{name: "new", typ: recordType, arg: newRec},
+		{name: "old", typ: recordType, arg: oldRec},
+		{name: "tg_name", typ: types.Name, arg: f.ConstructConstVal(tree.NewDName(string(trigger.Name())), types.Name)},
+		{name: "tg_when", typ: types.String, arg: f.ConstructConstVal(tree.NewDString(tree.AsString(&actionTime)), types.String)},
+		{name: "tg_level", typ: types.String, arg: f.ConstructConstVal(tree.NewDString(level), types.String)},
+		{name: "tg_op", typ: types.String, arg: f.ConstructConstVal(tree.NewDString(tree.AsString(&event)), types.String)},
+		{name: "tg_relid", typ: types.Oid, arg: f.ConstructConstVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid)},
+		{name: "tg_relname", typ: types.Name, arg: f.ConstructConstVal(tree.NewDName(string(tab.Name())), types.Name)},
+		{name: "tg_table_name", typ: types.Name, arg: f.ConstructConstVal(tree.NewDName(string(tab.Name())), types.Name)},
+		{name: "tg_table_schema", typ: types.Name, arg: f.ConstructConstVal(tree.NewDName(tabName.Schema()), types.Name)},
+		{name: "tg_nargs", typ: types.Int, arg: f.ConstructConstVal(tree.NewDInt(tree.DInt(len(trigger.FuncArgs()))), types.Int)},
+		{name: "tg_argv", typ: types.StringArray, arg: f.ConstructConstVal(argv, types.StringArray)},
+	}
+	args := make(memo.ScalarListExpr, len(vars))
+	params := make(opt.ColList, len(vars))
+	routineParams := make([]routineParam, len(vars))
+	bodyScope := b.allocScope()
+	for i := range vars {
+		args[i] = vars[i].arg
+		col := b.synthesizeColumn(
+			bodyScope, funcParamColName(vars[i].name, i), vars[i].typ, nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		params[i] = col.id
+		routineParams[i] = routineParam{name: vars[i].name, typ: vars[i].typ, class: tree.RoutineParamIn}
+	}
+
+	defer func(
+		trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine, insideTriggerFunction bool,
+		triggerTransitionTables map[string]*cteSource,
+	) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+		b.insideSQLRoutine = insideSQLRoutine
+		b.insideTriggerFunction = insideTriggerFunction
+		b.triggerTransitionTables = triggerTransitionTables
+	}(
+		b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine, b.insideTriggerFunction,
+		b.triggerTransitionTables,
+	)
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+	b.insideSQLRoutine = false
+	b.insideTriggerFunction = true
+	b.triggerTransitionTables = transitionTables
+
+	// Build the function body.
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	plBuilder := newPLpgSQLBuilder(
+		b, funcName.Object(), stmt.AST.Label, nil /* colRefs */, routineParams, recordType,
+		false /* isProcedure */, false /* setReturning */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+	physProps := stmtScope.makePhysicalProps()
+	b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+	physProps.Ordering = props.OrderingChoice{}
+	body, bodyProps := b.maybeAddRoutineAssignmentCasts(
+		physProps.Presentation, bodyScope, recordType, stmtScope.expr, physProps,
+		false, /* insideDataSource */
+	)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+
+	return f.ConstructUDFCall(args, &memo.UDFCallPrivate{
+		Def: &memo.UDFDefinition{
+			Name: funcName.Object(),
+			Typ:  recordType,
+			// Trigger functions are invoked for their side effects, so they must
+			// not be eliminated or reordered.
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+			RoutineType:       tree.UDFRoutine,
+			RoutineLang:       tree.RoutineLangPLpgSQL,
+			Body:              []memo.RelExpr{body},
+			BodyProps:         []*physical.Required{bodyProps},
+			BodyStmts:         bodyStmts,
+			Params:            params,
+		},
+	})
+}
+
+// firingTrigger identifies a trigger which is currently being built, in order
+// to detect recursive triggers.
+type firingTrigger struct {
+	tab  cat.StableID
+	name tree.Name
+}
+
+// resolveTransitionTable returns the transition table with the given name if
+// the current expression is within a trigger function with such a transition
+// table, or nil otherwise.
+func (b *Builder) resolveTransitionTable(tn *tree.TableName) *cteSource {
+	if b.triggerTransitionTables == nil || tn.ExplicitSchema || tn.ExplicitCatalog {
+		return nil
+	}
+	return b.triggerTransitionTables[string(tn.ObjectName)]
+}
@@ -143,7 +143,12 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if isUserDefinedAggregate(&w.def) {
+			fn = b.constructUserDefinedAggregate(w.FuncExpr, &w.def, argLists[i])
+		} else {
+			fn = b.constructWindowFn(w.def.Name, argLists[i])
+		}
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -262,6 +267,11 @@ func (b *Builder) buildAggregationAsWindow(
 	// Build the arguments, partitions and orderings for each aggregate.
 	for i, agg := range g.aggs {
 		argExprs := getTypedExprs(agg.Exprs)
+		if agg.isUserDefined() {
+			for j := range argExprs {
+				argExprs[j] = castToParamType(argExprs[j], agg.def.Overload, j)
+			}
+		}
 
 		// Build the appropriate arguments.
 		argLists[i] = b.buildWindowArgs(argExprs, i, agg.def.Name, fromScope, g.aggInScope)
@@ -288,7 +298,12 @@ func (b *Builder) buildAggregationAsWindow(
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if agg.isUserDefined() {
+			fn = b.constructUserDefinedAggregate(agg.FuncExpr, &agg.def, argLists[i])
+		} else {
+			fn = b.constructAggregate(agg.def.Name, argLists[i])
+		}
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -318,6 +333,7 @@ func (b *Builder) buildAggregationAsWindow(
 	// instead of each group. To rectify this, we must 'squash' the values down by
 	// wrapping it with a GroupBy or ScalarGroupBy.
 	g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, g.aggOutScope)
+	g.aggOutScope.expr = b.constructNullGroupingCols(g.aggOutScope.expr, g)
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -338,6 +354,12 @@ func (b *Builder) buildAggregationAsWindow(
 // not do that projection.
 func (b *Builder) getTypedWindowArgs(w *windowInfo) []tree.TypedExpr {
 	argExprs := getTypedExprs(w.Exprs)
+	if isUserDefinedAggregate(&w.def) {
+		for i := range argExprs {
+			argExprs[i] = castToParamType(argExprs[i], w.def.Overload, i)
+		}
+		return argExprs
+	}
 
 	switch w.def.Name {
 	// The second argument of {lead,lag} is 1 by default, and the third argument
@@ -88,12 +88,16 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 	var outParamTypes []*types.T
 	var outParamNames []string
 	var defaultExprs []tree.Expr
+	var variadic bool
 	for i := range c.Params {
 		param := &c.Params[i]
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
 		if err != nil {
 			panic(err)
 		}
+		if param.Class == tree.RoutineParamVariadic {
+			variadic = true
+		}
 		if tree.IsInParamClass(param.Class) {
 			signatureTypes = append(signatureTypes, tree.ParamType{
 				Name: string(param.Name),
@@ -180,6 +184,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 		OutParamOrdinals:  outParamOrdinals,
 		OutParamTypes:     outParams,
 		DefaultExprs:      defaultExprs,
+		Variadic:          variadic,
 	}
 	overload.ReturnsRecordType = !c.IsProcedure && retType.Identical(types.AnyTuple)
 	if c.ReturnType != nil && c.ReturnType.SetOf {
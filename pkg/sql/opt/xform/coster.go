@@ -540,6 +540,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 	case opt.ScanOp:
 		cost = c.computeScanCost(candidate.(*memo.ScanExpr), required)
 
+	case opt.AggregateScanOp:
+		cost = c.computeAggregateScanCost(candidate.(*memo.AggregateScanExpr))
+
 	case opt.SelectOp:
 		cost = c.computeSelectCost(candidate.(*memo.SelectExpr), required)
 
@@ -872,6 +875,55 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 	return cost
 }
 
+// computeAggregateScanCost returns the cost of an AggregateScan. The rows are
+// scanned and aggregated in the KV layer, so unlike for a Scan, there is no
+// per-row cost of emitting the rows; only the partial aggregates are returned.
+func (c *coster) computeAggregateScanCost(scan *memo.AggregateScanExpr) memo.Cost {
+	if scan.Flags.ForceIndex && scan.Flags.Index != scan.Index || scan.Flags.ForceZigzag {
+		return hugeCost
+	}
+	isUnfiltered := scan.IsUnfiltered(c.mem.Metadata())
+	if scan.Flags.NoFullScan && (isUnfiltered || (scan.Flags.ForceIndex && scan.IsFullIndexScan())) {
+		return hugeCost
+	}
+
+	// AggregateScan is only generated as an alternative to a ScalarGroupBy, so
+	// the number of aggregated rows is the row count of the input of the
+	// ScalarGroupBy in the same group.
+	var rowCount float64
+	if grp, ok := scan.FirstExpr().(*memo.ScalarGroupByExpr); ok {
+		rowCount = grp.Input.Relational().Statistics().RowCount
+	}
+	if isUnfiltered && c.evalCtx != nil && c.evalCtx.SessionData().DisallowFullTableScans {
+		if rowCount > c.evalCtx.SessionData().LargeFullScanRows {
+			return hugeCost
+		}
+	}
+
+	numSpans := 1
+	if scan.Constraint != nil {
+		numSpans = scan.Constraint.Spans.Count()
+	}
+	baseCost := memo.Cost(numSpans * randIOCostFactor)
+	if isUnfiltered {
+		rowCount += fullScanRowCountPenalty
+	}
+
+	// Each filter and aggregate function is evaluated on each row in the KV
+	// layer.
+	perRowCost := seqIOCostFactor + memo.Cost(len(scan.Filters)+len(scan.Aggregations))*cpuCostFactor
+	cost := baseCost + memo.Cost(rowCount)*perRowCost
+
+	var regionsAccessed physical.Distribution
+	if scan.Distribution.Regions != nil {
+		regionsAccessed = scan.Distribution
+	} else {
+		tabMeta := scan.Memo().Metadata().TableMeta(scan.Table)
+		regionsAccessed.FromIndexScan(c.ctx, c.evalCtx, tabMeta, scan.Index, scan.Constraint)
+	}
+	return cost + c.distributionCost(regionsAccessed)
+}
+
 func distributionIsLocal(regionsAccessed physical.Distribution, evalCtx *eval.Context) bool {
 	if len(regionsAccessed.Regions) == 1 {
 		var localDist physical.Distribution
@@ -11,12 +11,14 @@
 package xform
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/ordering"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
@@ -611,3 +613,153 @@ func (c *CustomFuncs) GenerateLimitedGroupByScans(
 		grp.Memo().AddLimitToGroup(&memo.LimitExpr{Limit: limit, Ordering: requiredOrdering, Input: input}, grp)
 	})
 }
+
+// CanUseAggregateScan returns true if the given aggregations over the given
+// scan can be computed by an AggregateScan, i.e. if the scan can be performed
+// by the KV layer and every aggregate function can be computed from partial
+// aggregates on each range. Only COUNT(*), and COUNT, SUM, MIN and MAX of a
+// scanned column with a type the KV layer can evaluate are supported.
+//
+// The AggregateScan request is only sent once all the nodes of the cluster
+// support it.
+func (c *CustomFuncs) CanUseAggregateScan(
+	scanPrivate *memo.ScanPrivate, aggs memo.AggregationsExpr,
+) bool {
+	if !c.e.evalCtx.SessionData().OptimizerUseAggregateScans {
+		return false
+	}
+	activeVersion := c.e.evalCtx.Settings.Version.ActiveVersion(c.e.ctx)
+	if !activeVersion.IsActive(clusterversion.V24_3_AggregateScans) {
+		return false
+	}
+	md := c.e.mem.Metadata()
+	tab := md.Table(scanPrivate.Table)
+	if tab.IsVirtualTable() || tab.Index(scanPrivate.Index).IsInverted() {
+		return false
+	}
+	if scanPrivate.InvertedConstraint != nil || scanPrivate.HardLimit.IsSet() ||
+		scanPrivate.Locking.IsLocking() || scanPrivate.LocalityOptimized {
+		return false
+	}
+	if len(aggs) == 0 {
+		return false
+	}
+	for i := range aggs {
+		switch aggs[i].Agg.Op() {
+		case opt.CountRowsOp:
+			continue
+		case opt.CountOp, opt.MinOp, opt.MaxOp, opt.SumOp:
+		default:
+			return false
+		}
+		typ, ok := c.aggregateScanColumnType(scanPrivate, aggs[i].Agg.Child(0))
+		if !ok {
+			return false
+		}
+		if aggs[i].Agg.Op() == opt.SumOp {
+			switch typ.Family() {
+			case types.IntFamily, types.FloatFamily, types.DecimalFamily:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CanPushFiltersIntoAggregateScan returns true if all the given filters can be
+// evaluated by the KV layer as part of an AggregateScan over the given scan.
+// Each filter must compare a scanned column with a constant of the same type
+// (see rowenc.MakeExactIndexFetchFilter).
+func (c *CustomFuncs) CanPushFiltersIntoAggregateScan(
+	scanPrivate *memo.ScanPrivate, filters memo.FiltersExpr,
+) bool {
+	for i := range filters {
+		if !c.canPushFilterIntoAggregateScan(scanPrivate, filters[i].Condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CustomFuncs) canPushFilterIntoAggregateScan(
+	scanPrivate *memo.ScanPrivate, cond opt.ScalarExpr,
+) bool {
+	// isConst returns true if the given expression is a non-NULL constant with
+	// the given type.
+	isConst := func(e opt.ScalarExpr, typ *types.T) bool {
+		return opt.IsConstValueOp(e) && e.Op() != opt.NullOp && e.DataType().Equivalent(typ)
+	}
+	switch cond.Op() {
+	case opt.EqOp, opt.NeOp, opt.LtOp, opt.LeOp, opt.GtOp, opt.GeOp:
+		left, right := cond.Child(0).(opt.ScalarExpr), cond.Child(1).(opt.ScalarExpr)
+		if typ, ok := c.aggregateScanColumnType(scanPrivate, left); ok {
+			return isConst(right, typ)
+		}
+		if typ, ok := c.aggregateScanColumnType(scanPrivate, right); ok {
+			return isConst(left, typ)
+		}
+
+	case opt.IsOp, opt.IsNotOp:
+		_, ok := c.aggregateScanColumnType(scanPrivate, cond.Child(0))
+		return ok && cond.Child(1).Op() == opt.NullOp
+
+	case opt.InOp:
+		typ, ok := c.aggregateScanColumnType(scanPrivate, cond.Child(0))
+		if !ok {
+			return false
+		}
+		tuple, ok := cond.Child(1).(*memo.TupleExpr)
+		if !ok {
+			return false
+		}
+		hasConst := false
+		for _, elem := range tuple.Elems {
+			if elem.Op() == opt.NullOp {
+				continue
+			}
+			if !isConst(elem, typ) {
+				return false
+			}
+			hasConst = true
+		}
+		return hasConst
+	}
+	return false
+}
+
+// aggregateScanColumnType returns the type of the column referenced by the
+// given expression, if it is a variable referencing a non-system column of the
+// given scan with a type the KV layer can evaluate.
+func (c *CustomFuncs) aggregateScanColumnType(
+	scanPrivate *memo.ScanPrivate, e opt.Expr,
+) (_ *types.T, ok bool) {
+	v, ok := e.(*memo.VariableExpr)
+	if !ok || !scanPrivate.Cols.Contains(v.Col) {
+		return nil, false
+	}
+	tab := c.e.mem.Metadata().Table(scanPrivate.Table)
+	if tab.Column(scanPrivate.Table.ColumnOrdinal(v.Col)).Kind() == cat.System {
+		return nil, false
+	}
+	if !rowenc.IndexFetchFilterSupportsType(v.Typ) {
+		return nil, false
+	}
+	return v.Typ, true
+}
+
+// MakeAggregateScanPrivate returns a copy of the given ScanPrivate for an
+// AggregateScan with the given aggregations and filters. Only the columns
+// referenced by the aggregations and filters are scanned.
+func (c *CustomFuncs) MakeAggregateScanPrivate(
+	scanPrivate *memo.ScanPrivate, aggs memo.AggregationsExpr, filters memo.FiltersExpr,
+) *memo.ScanPrivate {
+	var cols opt.ColSet
+	for i := range aggs {
+		cols.UnionWith(aggs[i].ScalarProps().OuterCols)
+	}
+	cols.UnionWith(filters.OuterCols())
+	newScanPrivate := *scanPrivate
+	newScanPrivate.Cols = scanPrivate.Cols.Intersection(cols)
+	return &newScanPrivate
+}
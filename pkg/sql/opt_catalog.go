@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
@@ -444,6 +445,29 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	user := oc.planner.User()
+	if role.IsPublicRole() || role == user {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -771,6 +795,10 @@ type optTable struct {
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
+
+	// triggers are the inlined wrappers for the table's triggers, ordered by
+	// name.
+	triggers []optTrigger
 }
 
 var _ cat.Table = &optTable{}
@@ -827,6 +855,14 @@ func newOptTable(
 		// written to the primary index and all secondary indexes.
 		if !col.IsVirtual() || pkCols.Contains(col.GetID()) {
 			cd := col.ColumnDesc()
+			defaultExpr := cd.DefaultExpr
+			if defaultExpr == nil && col.GetType().IsDomain() {
+				// Columns of a domain type without a default expression of their
+				// own use the default expression of the domain, if any.
+				if md := col.GetType().TypeMeta.DomainData; md != nil && md.DefaultExpr != "" {
+					defaultExpr = &md.DefaultExpr
+				}
+			}
 			ot.columns[col.Ordinal()].Init(
 				col.Ordinal(),
 				cat.StableID(col.GetID()),
@@ -835,7 +871,7 @@ func newOptTable(
 				col.GetType(),
 				col.IsNullable(),
 				visibility,
-				cd.DefaultExpr,
+				defaultExpr,
 				cd.ComputeExpr,
 				cd.OnUpdateExpr,
 				mapGeneratedAsIdentityType(col.GetGeneratedAsIdentityType()),
@@ -902,12 +938,27 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:          u.GetName(),
+			table:         ot.ID(),
+			columns:       u.CollectKeyColumnIDs().Ordered(),
+			predicate:     u.GetPredicate(),
+			withoutIndex:  true,
+			validity:      u.GetConstraintValidity(),
+			deferrability: tree.ConstraintDeferrabilityType[u.UniqueWithoutIndexDesc().Deferrability],
+		}
+		if u.IsExclusion() {
+			// The operators of an exclusion constraint correspond to its columns
+			// in the order in which they were specified.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			uc.exclusionOps = make([]treecmp.ComparisonOperatorSymbol, len(uc.columns))
+			for j, op := range u.ExclusionOperators() {
+				if op == "&&" {
+					uc.exclusionOps[j] = treecmp.Overlaps
+				} else {
+					uc.exclusionOps[j] = treecmp.EQ
+				}
+			}
 		}
 	}
 
@@ -1012,6 +1063,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrabilityType[fk.ForeignKeyDesc().Deferrability],
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1025,6 +1077,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrabilityType[fk.ForeignKeyDesc().Deferrability],
 		})
 	}
 
@@ -1079,6 +1132,17 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Triggers fire in alphabetical order of their names.
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]optTrigger, len(triggers))
+		for i := range triggers {
+			ot.triggers[i] = optTrigger{desc: &triggers[i]}
+		}
+		sort.Slice(ot.triggers, func(i, j int) bool {
+			return ot.triggers[i].desc.Name < ot.triggers[j].desc.Name
+		})
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1424,6 +1488,46 @@ func (ot *optTable) IsRefreshViewRequired() bool {
 	return ot.desc.IsRefreshViewRequired()
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.desc.GetPolicies())
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return &optPolicy{desc: &ot.desc.GetPolicies()[i]}
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optTable) InheritedByCount() int {
+	return len(ot.desc.GetInheritedBy())
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optTable) InheritedBy(i int) cat.StableID {
+	return cat.StableID(ot.desc.GetInheritedBy()[i])
+}
+
 // optIndex is a wrapper around catalog.Index that caches some
 // commonly accessed information and keeps a reference to the table wrapper.
 type optIndex struct {
@@ -1740,6 +1844,131 @@ func (op *optPartition) PartitionByListPrefixes() []tree.Datums {
 	return op.datums
 }
 
+// optPolicy is a wrapper around descpb.PolicyDescriptor that implements
+// cat.Policy.
+type optPolicy struct {
+	desc *descpb.PolicyDescriptor
+}
+
+var _ cat.Policy = &optPolicy{}
+
+// Name is part of the cat.Policy interface.
+func (op *optPolicy) Name() tree.Name {
+	return tree.Name(op.desc.Name)
+}
+
+// IsRestrictive is part of the cat.Policy interface.
+func (op *optPolicy) IsRestrictive() bool {
+	return op.desc.Type == catpb.PolicyType_RESTRICTIVE
+}
+
+// Command is part of the cat.Policy interface.
+func (op *optPolicy) Command() catpb.PolicyCommand {
+	return op.desc.Command
+}
+
+// RoleCount is part of the cat.Policy interface.
+func (op *optPolicy) RoleCount() int {
+	return len(op.desc.RoleNames)
+}
+
+// Role is part of the cat.Policy interface.
+func (op *optPolicy) Role(i int) username.SQLUsername {
+	return username.MakeSQLUsernameFromPreNormalizedString(op.desc.RoleNames[i])
+}
+
+// UsingExpr is part of the cat.Policy interface.
+func (op *optPolicy) UsingExpr() string {
+	return op.desc.UsingExpr
+}
+
+// WithCheckExpr is part of the cat.Policy interface.
+func (op *optPolicy) WithCheckExpr() string {
+	return op.desc.WithCheckExpr
+}
+
+// optTrigger is a wrapper around descpb.TriggerDescriptor that implements
+// cat.Trigger.
+type optTrigger struct {
+	desc *descpb.TriggerDescriptor
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (ot *optTrigger) Name() tree.Name {
+	return tree.Name(ot.desc.Name)
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (ot *optTrigger) ActionTime() tree.TriggerActionTime {
+	if ot.desc.ActionTime == catpb.TriggerActionTime_BEFORE {
+		return tree.TriggerActionTimeBefore
+	}
+	return tree.TriggerActionTimeAfter
+}
+
+// EventCount is part of the cat.Trigger interface.
+func (ot *optTrigger) EventCount() int {
+	return len(ot.desc.Events)
+}
+
+// Event is part of the cat.Trigger interface.
+func (ot *optTrigger) Event(i int) cat.TriggerEvent {
+	e := ot.desc.Events[i]
+	var ev cat.TriggerEvent
+	switch e.Type {
+	case catpb.TriggerEventType_INSERT_EVENT:
+		ev.EventType = tree.TriggerEventInsert
+	case catpb.TriggerEventType_UPDATE_EVENT:
+		ev.EventType = tree.TriggerEventUpdate
+	case catpb.TriggerEventType_DELETE_EVENT:
+		ev.EventType = tree.TriggerEventDelete
+	}
+	if len(e.ColumnNames) > 0 {
+		ev.Columns = make(tree.NameList, len(e.ColumnNames))
+		for j, name := range e.ColumnNames {
+			ev.Columns[j] = tree.Name(name)
+		}
+	}
+	return ev
+}
+
+// NewTransitionAlias is part of the cat.Trigger interface.
+func (ot *optTrigger) NewTransitionAlias() tree.Name {
+	return tree.Name(ot.desc.NewTransitionAlias)
+}
+
+// OldTransitionAlias is part of the cat.Trigger interface.
+func (ot *optTrigger) OldTransitionAlias() tree.Name {
+	return tree.Name(ot.desc.OldTransitionAlias)
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (ot *optTrigger) ForEachRow() bool {
+	return ot.desc.ForEachRow
+}
+
+// WhenExpr is part of the cat.Trigger interface.
+func (ot *optTrigger) WhenExpr() string {
+	return ot.desc.WhenExpr
+}
+
+// FuncID is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncID() cat.StableID {
+	return cat.StableID(ot.desc.FuncID)
+}
+
+// FuncArgs is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncArgs() []string {
+	return ot.desc.FuncArgs
+}
+
+// Enabled is part of the cat.Trigger interface.
+func (ot *optTrigger) Enabled() bool {
+	return ot.desc.Enabled
+}
+
 // optCheckConstraint implements cat.CheckConstraint. See that interface
 // for more information on the fields.
 type optCheckConstraint struct {
@@ -1955,8 +2184,13 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
+
+	// exclusionOps is non-empty for exclusion constraints, and contains the
+	// operator for each column in columns.
+	exclusionOps []treecmp.ComparisonOperatorSymbol
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -2014,6 +2248,21 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return len(u.exclusionOps) > 0
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	return u.exclusionOps[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2027,10 +2276,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity      descpb.ConstraintValidity
+	match         tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2102,6 +2352,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2449,6 +2704,46 @@ func (ot *optVirtualTable) IsHypothetical() bool {
 	return false
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no inheriting tables"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
@@ -490,6 +490,32 @@ func (ef *execFactory) ConstructScalarGroupBy(
 	return n, nil
 }
 
+// ConstructAggregateScan is part of the exec.Factory interface.
+func (ef *execFactory) ConstructAggregateScan(
+	table cat.Table,
+	index cat.Index,
+	params exec.ScanParams,
+	filter tree.TypedExpr,
+	aggregations []exec.AggInfo,
+) (exec.Node, error) {
+	scan, err := ef.ConstructScan(table, index, params, nil /* reqOrdering */)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := scan.(*scanNode)
+	if !ok {
+		// The scan was constructed as a zeroNode because its constraint is a
+		// contradiction, so there is no need to scan anything.
+		return ef.ConstructScalarGroupBy(scan, aggregations)
+	}
+	return &aggregateScanNode{
+		scan:         s,
+		filter:       filter,
+		aggregations: aggregations,
+		columns:      getResultColumnsForGroupBy(s.resultColumns, nil /* groupCols */, aggregations),
+	}, nil
+}
+
 // ConstructGroupBy is part of the exec.Factory interface.
 func (ef *execFactory) ConstructGroupBy(
 	input exec.Node,
@@ -543,6 +569,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1146,6 +1173,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefined != nil {
+			p.funcs[i].userDefined = wi.UserDefined[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
@@ -1345,6 +1375,11 @@ func (ef *execFactory) ConstructInsert(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if ef.planner.registerTempTableCommitAction(tabDesc) {
+		// The ON COMMIT action of the temporary table is applied before the
+		// transaction commits, so the mutation cannot commit it.
+		autoCommit = false
+	}
 	cols := makeColList(table, insertColOrdSet)
 
 	// Create the table inserter, which does the bulk of the work.
@@ -1369,9 +1404,10 @@ func (ef *execFactory) ConstructInsert(
 	*ins = insertNode{
 		source: input.(planNode),
 		run: insertRun{
-			ti:         tableInserter{ri: ri},
-			checkOrds:  checkOrdSet,
-			insertCols: ri.InsertCols,
+			ti:          tableInserter{ri: ri},
+			checkOrds:   checkOrdSet,
+			checkPolicy: checksRowLevelSecurity(table, checkOrdSet),
+			insertCols:  ri.InsertCols,
 		},
 	}
 
@@ -1417,6 +1453,11 @@ func (ef *execFactory) ConstructInsertFastPath(
 	// Derive insert table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if ef.planner.registerTempTableCommitAction(tabDesc) {
+		// The ON COMMIT action of the temporary table is applied before the
+		// transaction commits, so the mutation cannot commit it.
+		autoCommit = false
+	}
 	cols := makeColList(table, insertColOrdSet)
 
 	// Create the table inserter, which does the bulk of the work.
@@ -1442,9 +1483,10 @@ func (ef *execFactory) ConstructInsertFastPath(
 		input: rows,
 		run: insertFastPathRun{
 			insertRun: insertRun{
-				ti:         tableInserter{ri: ri},
-				checkOrds:  checkOrdSet,
-				insertCols: ri.InsertCols,
+				ti:          tableInserter{ri: ri},
+				checkOrds:   checkOrdSet,
+				checkPolicy: checksRowLevelSecurity(table, checkOrdSet),
+				insertCols:  ri.InsertCols,
 			},
 		},
 	}
@@ -1517,6 +1559,11 @@ func (ef *execFactory) ConstructUpdate(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if ef.planner.registerTempTableCommitAction(tabDesc) {
+		// The ON COMMIT action of the temporary table is applied before the
+		// transaction commits, so the mutation cannot commit it.
+		autoCommit = false
+	}
 	fetchCols := makeColList(table, fetchColOrdSet)
 
 	// Add each column to update as a sourceSlot. The CBO only uses scalarSlot,
@@ -1549,6 +1596,7 @@ func (ef *execFactory) ConstructUpdate(
 		run: updateRun{
 			tu:             tableUpdater{ru: ru},
 			checkOrds:      checks,
+			checkPolicy:    checksRowLevelSecurity(table, checks),
 			numPassthrough: len(passthrough),
 		},
 	}
@@ -1607,6 +1655,11 @@ func (ef *execFactory) ConstructUpsert(
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
+	if ef.planner.registerTempTableCommitAction(tabDesc) {
+		// The ON COMMIT action of the temporary table is applied before the
+		// transaction commits, so the mutation cannot commit it.
+		autoCommit = false
+	}
 	insertCols := makeColList(table, insertColOrdSet)
 	fetchCols := makeColList(table, fetchColOrdSet)
 	updateCols := makeColList(table, updateColOrdSet)
@@ -1651,8 +1704,9 @@ func (ef *execFactory) ConstructUpsert(
 	*ups = upsertNode{
 		source: input.(planNode),
 		run: upsertRun{
-			checkOrds:  checks,
-			insertCols: ri.InsertCols,
+			checkOrds:   checks,
+			checkPolicy: checksRowLevelSecurity(table, checks),
+			insertCols:  ri.InsertCols,
 			tw: optTableUpserter{
 				ri:            ri,
 				canaryOrdinal: int(canaryCol),
@@ -2224,6 +2278,13 @@ func makeColList(table cat.Table, cols exec.TableColumnOrdinalSet) []catalog.Col
 	return ret
 }
 
+// checksRowLevelSecurity returns true if the given check ordinals include the
+// column that holds the result of the row-level security policies of the
+// table, which the optimizer places after the check constraints.
+func checksRowLevelSecurity(table cat.Table, checks exec.CheckOrdinalSet) bool {
+	return table.IsRowLevelSecurityEnabled() && checks.Contains(table.CheckCount())
+}
+
 // makePublicToReturnColumnIndexMapping returns a map from the ordinals
 // of the table's public columns to ordinals in the returnColDescs slice.
 //
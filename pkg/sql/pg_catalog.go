@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -640,6 +641,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				return err
 			}
 
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
 				return err
@@ -654,7 +659,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(bypassRLS),            // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -765,6 +770,8 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		}
 		implicitTypOID := typedesc.TableIDToImplicitTypeOID(table.GetID())
 		namespaceOid := schemaOid(sc.GetID())
+		relRowSecurity := tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityEnabled()))
+		relForceRowSecurity := tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityForced()))
 		if err := addRow(
 			tableOid(table.GetID()),        // oid
 			tree.NewDName(table.GetName()), // relname
@@ -795,12 +802,12 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
-			tree.DNull,                 // relforcerowsecurity
+			relForceRowSecurity,        // relforcerowsecurity
 			tree.DNull,                 // relispartition
 			tree.DNull,                 // relispopulated
 			tree.NewDString(replIdent), // relreplident
 			tree.DNull,                 // relrewrite
-			tree.DNull,                 // relrowsecurity
+			relRowSecurity,             // relrowsecurity
 			tree.DNull,                 // relpartbound
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relminmxid
@@ -914,7 +921,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -1059,13 +1065,27 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
-			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
-			if err != nil {
-				return err
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				elems, err := formatExclusionConstraintElems(table, uwoi)
+				if err != nil {
+					return err
+				}
+				f.WriteString("EXCLUDE (")
+				f.WriteString(elems)
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
+				if err != nil {
+					return err
+				}
+				f.WriteString(strings.Join(colNames, ", "))
 			}
-			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			if d := uwoi.UniqueWithoutIndexDesc().Deferrability; d != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+				f.WriteByte(' ')
+				f.WriteString(tree.ConstraintDeferrabilityType[d].String())
+			}
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1095,14 +1115,17 @@ func populateTableConstraints(
 			}
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
+		deferrability := constraintDeferrability(c)
+		condeferrable := tree.MakeDBool(deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE)
+		condeferred := tree.MakeDBool(deferrability == semenumpb.ConstraintDeferrability_INITIALLY_DEFERRED)
 
 		if err := addRow(
 			conoid,                   // oid
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2175,13 +2198,37 @@ https://www.postgresql.org/docs/9.5/catalog-pg-language.html`,
 }
 
 var pgCatalogLocksTable = virtualSchemaTable{
-	comment: `locks held by active processes (empty - feature does not exist)
+	comment: `locks held by active processes (only advisory locks are shown)
 https://www.postgresql.org/docs/9.6/view-pg-locks.html`,
 	schema: vtable.PGCatalogLocks,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		advisoryDatum := tree.NewDString("advisory")
+		exclusiveLockDatum := tree.NewDString("ExclusiveLock")
+		shareLockDatum := tree.NewDString("ShareLock")
+		return p.forEachAdvisoryLock(ctx, func(l advisoryLockRow) error {
+			mode := shareLockDatum
+			if l.exclusive {
+				mode = exclusiveLockDatum
+			}
+			return addRow(
+				advisoryDatum,                           // locktype
+				tree.NewDOid(oid.Oid(l.key.DatabaseID)), // database
+				tree.DNull,                              // relation
+				tree.DNull,                              // page
+				tree.DNull,                              // tuple
+				tree.DNull,                              // virtualxid
+				tree.DNull,                              // transactionid
+				tree.NewDOid(oid.Oid(l.key.ClassID)),    // classid
+				tree.NewDOid(oid.Oid(l.key.ObjID)),      // objid
+				tree.NewDInt(tree.DInt(l.key.ObjSubID)), // objsubid
+				tree.DNull,                              // virtualtransaction
+				tree.NewDInt(tree.DInt(l.pid)),          // pid
+				mode,                                    // mode
+				tree.DBoolTrue,                          // granted
+				tree.DBoolFalse,                         // fastpath
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogMatViewsTable = virtualSchemaTable{
@@ -2636,6 +2683,8 @@ func addPgProcUDFRow(
 	kind := proKindFunction
 	if fnDesc.IsProcedure() {
 		kind = proKindProcedure
+	} else if fnDesc.IsAggregate() {
+		kind = proKindAggregate
 	}
 
 	lang := languageInternalOid
@@ -2651,6 +2700,7 @@ func addPgProcUDFRow(
 	var foundAnyArgNames bool
 	var nArgs, nArgDefaults int
 	var argDefaultsBuilder strings.Builder
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
@@ -2689,6 +2739,8 @@ func addPgProcUDFRow(
 			argMode = proArgModeInOut
 		case tree.RoutineParamVariadic:
 			argMode = proArgModeVariadic
+			// provariadic is the element type of the VARIADIC array parameter.
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
 		default:
 			return errors.AssertionFailedf("unknown parameter class %d", class)
 		}
@@ -2724,7 +2776,7 @@ func addPgProcUDFRow(
 		lang,            // prolang
 		tree.DNull,      // procost
 		tree.DNull,      // prorows
-		oidZero,         // provariadic // TODO(88947): this might need an adjustment.
+		variadicType,    // provariadic
 		tree.DNull,      // prosupport
 		kind,            // prokind
 		tree.DBoolFalse, // prosecdef
@@ -2742,11 +2794,11 @@ func addPgProcUDFRow(
 		argNames,                                        // proargnames
 		argDefaults,                                     // proargdefaults
 		tree.DNull,                                      // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
-		tree.DNull,                                      // probin
-		tree.DNull,                                      // prosqlbody
-		tree.DNull,                                      // proconfig
-		tree.DNull,                                      // proacl
+		tree.NewDString(fnDesc.GetFunctionBody()), // prosrc
+		tree.DNull, // probin
+		tree.DNull, // prosqlbody
+		tree.DNull, // proconfig
+		tree.DNull, // proacl
 	)
 }
 
@@ -2934,6 +2986,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -2952,7 +3008,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                             // rolconnlimit
 					passwdStarString,                      // rolpassword
 					rolValidUntil,                         // rolvaliduntil
-					tree.DBoolFalse,                       // rolbypassrls
+					tree.MakeDBool(bypassRLS),             // rolbypassrls
 					settings,                              // rolconfig
 				)
 			})
@@ -3263,9 +3319,7 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3287,7 +3341,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3393,6 +3446,9 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
+	case types.RangeFamily:
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	case types.VoidFamily:
 		// void does not have an array type.
 	case types.TriggerFamily:
@@ -3403,6 +3459,26 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typTypmod := negOneVal
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		// Domains use the I/O functions of their base type, which is described by
+		// the family-specific cases above.
+		base := typ.DomainBaseType()
+		typType = typTypeDomain
+		typElem = oidZero
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		typBaseType = tree.NewDOid(base.Oid())
+		typTypmod = tree.NewDInt(tree.DInt(base.TypeModifier()))
+		if md := typ.TypeMeta.DomainData; md != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(md.NotNull))
+			if md.DefaultExpr != "" {
+				typDefault = tree.NewDString(md.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3432,13 +3508,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
-		negOneVal,       // typtypmod
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
+		typTypmod,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3741,6 +3817,10 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -3752,7 +3832,7 @@ https://www.postgresql.org/docs/9.5/view-pg-user.html`,
 					tree.MakeDBool(isSuper || createDB),  // usecreatedb
 					tree.MakeDBool(isRoot || isSuper),    // usesuper
 					tree.DBoolFalse,                      // userepl
-					tree.DBoolFalse,                      // usebypassrls
+					tree.MakeDBool(bypassRLS),            // usebypassrls
 					passwdStarString,                     // passwd
 					validUntil,                           // valuntil
 					settings,                             // useconfig
@@ -3863,6 +3943,10 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
 				return err
@@ -3874,7 +3958,7 @@ https://www.postgresql.org/docs/13/view-pg-shadow.html`,
 				tree.MakeDBool(isRoot || createDB),   // usecreatedb
 				tree.MakeDBool(isRoot || isSuper),    // usesuper
 				tree.DBoolFalse,                      // userepl
-				tree.DBoolFalse,                      // usebypassrls
+				tree.MakeDBool(bypassRLS),            // usebypassrls
 				passwdStarString,                     // passwd
 				rolValidUntil,                        // valuntil
 				settings,                             // useconfig
@@ -4046,12 +4130,48 @@ var pgCatalogStatioAllSequencesTable = virtualSchemaTable{
 }
 
 var pgCatalogPoliciesTable = virtualSchemaTable{
-	comment: "pg_policies was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPolicies,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `row-level security policies
+https://www.postgresql.org/docs/13/view-pg-policies.html`,
+	schema: vtable.PgCatalogPolicies,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have policies */
+			func(ctx context.Context, db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range table.GetPolicies() {
+					policy := &table.GetPolicies()[i]
+					permissive := "PERMISSIVE"
+					if policy.Type == catpb.PolicyType_RESTRICTIVE {
+						permissive = "RESTRICTIVE"
+					}
+					roles := tree.NewDArray(types.Name)
+					for _, role := range policy.RoleNames {
+						if err := roles.Append(tree.NewDName(role)); err != nil {
+							return err
+						}
+					}
+					qual, err := formatPolicyExpr(ctx, p, table, policy.UsingExpr)
+					if err != nil {
+						return err
+					}
+					withCheck, err := formatPolicyExpr(ctx, p, table, policy.WithCheckExpr)
+					if err != nil {
+						return err
+					}
+					if err := addRow(
+						tree.NewDName(sc.GetName()),              // schemaname
+						tree.NewDName(table.GetName()),           // tablename
+						tree.NewDName(policy.Name),               // policyname
+						tree.NewDString(permissive),              // permissive
+						roles,                                    // roles
+						tree.NewDString(policy.Command.String()), // cmd
+						qual,                                     // qual
+						withCheck,                                // with_check
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatsExtTable = virtualSchemaTable{
@@ -4190,12 +4310,85 @@ var pgCatalogStatProgressBasebackupTable = virtualSchemaTable{
 }
 
 var pgCatalogPolicyTable = virtualSchemaTable{
-	comment: "pg_policy was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPolicy,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `row-level security policies
+https://www.postgresql.org/docs/13/catalog-pg-policy.html`,
+	schema: vtable.PgCatalogPolicy,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables do not have policies */
+			func(ctx context.Context, db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range table.GetPolicies() {
+					policy := &table.GetPolicies()[i]
+					permissive := tree.MakeDBool(policy.Type != catpb.PolicyType_RESTRICTIVE)
+					roles := tree.NewDArray(types.Oid)
+					for _, role := range policy.RoleNames {
+						// The public role is represented by OID 0.
+						roleOid := oidZero
+						if role != username.PublicRole {
+							roleOid = h.UserOid(username.MakeSQLUsernameFromPreNormalizedString(role))
+						}
+						if err := roles.Append(roleOid); err != nil {
+							return err
+						}
+					}
+					qual, err := formatPolicyExpr(ctx, p, table, policy.UsingExpr)
+					if err != nil {
+						return err
+					}
+					withCheck, err := formatPolicyExpr(ctx, p, table, policy.WithCheckExpr)
+					if err != nil {
+						return err
+					}
+					if err := addRow(
+						h.PolicyOid(table.GetID(), policy.ID), // oid
+						tree.NewDName(policy.Name),            // polname
+						tableOid(table.GetID()),               // polrelid
+						policyCommandChar(policy.Command),     // polcmd
+						permissive,                            // polpermissive
+						roles,                                 // polroles
+						qual,                                  // polqual
+						withCheck,                             // polwithcheck
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
+}
+
+// policyCommandChar returns the character used to represent the given policy
+// command in pg_policy.polcmd.
+func policyCommandChar(cmd catpb.PolicyCommand) tree.Datum {
+	switch cmd {
+	case catpb.PolicyCommand_SELECT:
+		return tree.NewDString("r")
+	case catpb.PolicyCommand_INSERT:
+		return tree.NewDString("a")
+	case catpb.PolicyCommand_UPDATE:
+		return tree.NewDString("w")
+	case catpb.PolicyCommand_DELETE:
+		return tree.NewDString("d")
+	default:
+		return tree.NewDString("*")
+	}
+}
+
+// formatPolicyExpr formats the given policy expression for display, returning
+// NULL if the policy has no such expression.
+func formatPolicyExpr(
+	ctx context.Context, p *planner, table catalog.TableDescriptor, expr string,
+) (tree.Datum, error) {
+	if expr == "" {
+		return tree.DNull, nil
+	}
+	displayExpr, err := schemaexpr.FormatExprForDisplay(
+		ctx, table, expr, p.EvalContext(), &p.semaCtx, p.SessionData(), tree.FmtPGCatalog,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDString(displayExpr), nil
 }
 
 var pgCatalogStatArchiverTable = virtualSchemaTable{
@@ -4226,12 +4419,35 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/16/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if dbContext == nil || checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings) != nil {
+			return nil
+		}
+		pubs, err := getPublications(ctx, p.InternalSQLTxn(), dbContext.GetID(), nil /* names */)
+		if err != nil {
+			return err
+		}
+		h := makeOidHasher()
+		for _, pub := range pubs {
+			if err := addRow(
+				h.PublicationOid(dbContext.GetID(), pub.name),                         // oid
+				tree.NewDName(pub.name),                                               // pubname
+				h.UserOid(username.MakeSQLUsernameFromPreNormalizedString(pub.owner)), // pubowner
+				tree.MakeDBool(tree.DBool(pub.allTables)),                             // puballtables
+				tree.DBoolTrue,  // pubinsert
+				tree.DBoolTrue,  // pubupdate
+				tree.DBoolTrue,  // pubdelete
+				tree.DBoolFalse, // pubtruncate
+				tree.DBoolFalse, // pubviaroot
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4386,12 +4602,38 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	comment: `tables in publications for logical replication
+https://www.postgresql.org/docs/16/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if dbContext == nil || checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings) != nil {
+			return nil
+		}
+		pubs, err := getPublications(ctx, p.InternalSQLTxn(), dbContext.GetID(), nil /* names */)
+		if err != nil {
+			return err
+		}
+		for _, pub := range pubs {
+			tables, err := getPublishedTables(ctx, p.Descriptors(), p.Txn(), dbContext, pub)
+			if err != nil {
+				return err
+			}
+			for _, table := range tables {
+				sc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Schema(ctx, table.GetParentSchemaID())
+				if err != nil {
+					return err
+				}
+				if err := addRow(
+					tree.NewDName(pub.name),        // pubname
+					tree.NewDName(sc.GetName()),    // schemaname
+					tree.NewDName(table.GetName()), // tablename
+				); err != nil {
+					return err
+				}
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4422,12 +4664,52 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `replication slots (incomplete)
+https://www.postgresql.org/docs/16/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings) != nil {
+			return nil
+		}
+		rows, err := p.InternalSQLTxn().QueryBufferedEx(
+			ctx,
+			"pg-replication-slots",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT slot_name, plugin, database_id, confirmed_flush_lsn
+FROM system.replication_slots ORDER BY slot_name`,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			dbID := descpb.ID(tree.MustBeDInt(row[2]))
+			dbName := tree.DNull
+			if db, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Database(ctx, dbID); err == nil {
+				dbName = tree.NewDName(db.GetName())
+			}
+			confirmedLSN := tree.NewDString(lsn.LSN(tree.MustBeDInt(row[3])).String())
+			if err := addRow(
+				tree.NewDName(string(tree.MustBeDString(row[0]))), // slot_name
+				tree.NewDName(string(tree.MustBeDString(row[1]))), // plugin
+				tree.NewDString("logical"),                        // slot_type
+				tree.NewDOid(oid.Oid(dbID)),                       // datoid
+				dbName,                                            // database
+				tree.DBoolFalse,                                   // temporary
+				tree.DBoolFalse,                                   // active
+				tree.DNull,                                        // active_pid
+				tree.DNull,                                        // xmin
+				tree.DNull,                                        // catalog_xmin
+				confirmedLSN,                                      // restart_lsn
+				confirmedLSN,                                      // confirmed_flush_lsn
+				tree.NewDString("reserved"),                       // wal_status
+				tree.DNull,                                        // safe_wal_size
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4770,6 +5052,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4781,6 +5064,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
 	types.PGVectorFamily:    typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
@@ -4907,11 +5191,77 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(ctx context.Context, scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						return addPgAggregateUDFRow(ctx, p, sig.ID, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDFRow adds the pg_aggregate row of the user-defined aggregate
+// with the given ID.
+func addPgAggregateUDFRow(
+	ctx context.Context, p *planner, id descpb.ID, addRow func(...tree.Datum) error,
+) error {
+	aggDesc, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).WithoutNonPublic().Get().Function(ctx, id)
+	if err != nil {
+		return err
+	}
+	agg := aggDesc.FuncDesc().Aggregate
+	regProc := func(fnID descpb.ID) (tree.Datum, error) {
+		if fnID == descpb.InvalidID {
+			return tree.NewDOidWithTypeAndName(0, types.RegProc, "-"), nil
+		}
+		fnDesc, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).WithoutNonPublic().Get().Function(ctx, fnID)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDOidWithTypeAndName(catid.FuncIDToOID(fnID), types.RegProc, fnDesc.GetName()), nil
+	}
+	transFn, err := regProc(agg.TransitionFunctionID)
+	if err != nil {
+		return err
+	}
+	finalFn, err := regProc(agg.FinalFunctionID)
+	if err != nil {
+		return err
+	}
+	regprocForZeroOid := tree.NewDOidWithTypeAndName(0, types.RegProc, "-")
+	initVal := tree.DNull
+	if agg.InitialCondition != nil {
+		initVal = tree.NewDString(*agg.InitialCondition)
+	}
+	return addRow(
+		tree.NewDOid(catid.FuncIDToOID(id)).AsRegProc(aggDesc.GetName()), // aggfnoid
+		tree.NewDString("n"),              // aggkind
+		zeroVal,                           // aggnumdirectargs
+		transFn,                           // aggtransfn
+		finalFn,                           // aggfinalfn
+		regprocForZeroOid,                 // aggcombinefn
+		regprocForZeroOid,                 // aggserialfn
+		regprocForZeroOid,                 // aggdeserialfn
+		regprocForZeroOid,                 // aggmtransfn
+		regprocForZeroOid,                 // aggminvtransfn
+		regprocForZeroOid,                 // aggmfinalfn
+		tree.DBoolFalse,                   // aggfinalextra
+		tree.DBoolFalse,                   // aggmfinalextra
+		oidZero,                           // aggsortop
+		tree.NewDOid(agg.StateType.Oid()), // aggtranstype
+		tree.DNull,                        // aggtransspace
+		tree.DNull,                        // aggmtranstype
+		tree.DNull,                        // aggmtransspace
+		initVal,                           // agginitval
+		tree.DNull,                        // aggminitval
+		tree.DNull,                        // aggfinalmodify
+		tree.DNull,                        // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -5000,6 +5350,8 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	policyTypeTag
+	publicationTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5133,6 +5485,20 @@ func (h oidHasher) UserOid(userName username.SQLUsername) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) PolicyOid(tableID descpb.ID, policyID catid.PolicyID) *tree.DOid {
+	h.writeTypeTag(policyTypeTag)
+	h.writeTable(tableID)
+	h.writeUInt32(uint32(policyID))
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func (h oidHasher) CollationOid(collation string) *tree.DOid {
 	h.writeTypeTag(collationTypeTag)
 	h.writeStr(collation)
@@ -0,0 +1,330 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogicalReplication streams changes to a published table using the
+// pgoutput plugin, and checks that the progress reported by the client is
+// persisted in the slot.
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	sqlDB, conn, cleanup := startLogicalReplicationServer(t)
+	defer cleanup()
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE unpublished (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+
+	_, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+
+	// Changes to tables which are not in the publication are not streamed.
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `INSERT INTO unpublished VALUES (1)`)
+	sqlDB.Exec(t, `UPDATE t SET v = 'b' WHERE k = 1`)
+	sqlDB.Exec(t, `BEGIN; INSERT INTO t VALUES (2, NULL); DELETE FROM t WHERE k = 1; COMMIT`)
+
+	fe := startReplication(t, conn, `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`)
+
+	expected := []string{
+		"begin",
+		"relation public.t k(key) v",
+		"insert 1 a",
+		"commit",
+		"begin",
+		"update 1 b",
+		"commit",
+		"begin",
+		"delete 1 NULL",
+		"insert 2 NULL",
+		"commit",
+	}
+	var actual []string
+	var lastCommit lsn.LSN
+	for len(actual) < len(expected) {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		data, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %T", msg)
+		switch data.Data[0] {
+		case 'w':
+			// Skip the start and end LSNs and the send time.
+			payload := data.Data[25:]
+			if payload[0] == 'C' {
+				lastCommit = lsn.LSN(binary.BigEndian.Uint64(payload[2:]))
+			}
+			actual = append(actual, describePgoutputMessage(t, payload))
+		case 'k':
+		default:
+			t.Fatalf("unexpected replication message %q", data.Data[0])
+		}
+	}
+	require.Equal(t, expected, actual)
+
+	// Acknowledge the changes and end the stream.
+	status := []byte{'r'}
+	for _, v := range []uint64{uint64(lastCommit), uint64(lastCommit), uint64(lastCommit), 0} {
+		status = binary.BigEndian.AppendUint64(status, v)
+	}
+	status = append(status, 0)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	var gotCopyDone bool
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyDone:
+			gotCopyDone = true
+		case *pgproto3.CopyData, *pgproto3.CommandComplete:
+		case *pgproto3.ErrorResponse:
+			t.Fatalf("unexpected error: %s", msg.Message)
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+	require.True(t, gotCopyDone)
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT confirmed_flush_lsn FROM pg_catalog.pg_replication_slots WHERE slot_name = 's'`,
+		[][]string{{lastCommit.String()}},
+	)
+
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT s`).ReadAll()
+	require.NoError(t, err)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM pg_catalog.pg_replication_slots`, [][]string{{"0"}})
+}
+
+// startLogicalReplicationServer starts a server with rangefeeds enabled and
+// opens a connection to it in logical replication mode.
+func startLogicalReplicationServer(
+	t *testing.T,
+) (_ *sqlutils.SQLRunner, _ *pgconn.PgConn, cleanup func()) {
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	s := srv.ApplicationLayer()
+
+	sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t)).Exec(
+		t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`,
+	)
+
+	pgURL, cleanupURL := s.PGUrl(
+		t, serverutils.CertsDirPrefix("pgrepl_logical_replication_test"), serverutils.User(username.RootUser),
+	)
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	return sqlutils.MakeSQLRunner(db), conn, func() {
+		_ = conn.Close(ctx)
+		cleanupURL()
+		srv.Stopper().Stop(ctx)
+	}
+}
+
+// startReplication sends the given START_REPLICATION command and waits for
+// the server to switch to streaming.
+func startReplication(t *testing.T, conn *pgconn.PgConn, query string) *pgproto3.Frontend {
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{String: query})
+	require.NoError(t, fe.Flush())
+	msg, err := fe.Receive()
+	require.NoError(t, err)
+	require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+	return fe
+}
+
+// TestLogicalReplicationCopyFail checks that a client can abort the stream
+// with CopyFail.
+func TestLogicalReplicationCopyFail(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	sqlDB, conn, cleanup := startLogicalReplicationServer(t)
+	defer cleanup()
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+	_, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+
+	fe := startReplication(t, conn, `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`)
+	fe.Send(&pgproto3.CopyFail{Message: "client went away"})
+	require.NoError(t, fe.Flush())
+
+	// The server does not send CopyDone, since the client already ended the
+	// stream.
+	var errResp *pgproto3.ErrorResponse
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+		case *pgproto3.ErrorResponse:
+			errResp = msg
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+	require.NotNil(t, errResp)
+	require.Equal(t, pgcode.QueryCanceled.String(), errResp.Code)
+	require.Contains(t, errResp.Message, "client went away")
+
+	// The connection can still be used.
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT s`).ReadAll()
+	require.NoError(t, err)
+}
+
+// TestLogicalReplicationUnexpectedMessage checks that the stream fails with a
+// protocol violation if the client sends a message which is not allowed
+// during streaming, and that the stream is still ended cleanly.
+func TestLogicalReplicationUnexpectedMessage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	sqlDB, conn, cleanup := startLogicalReplicationServer(t)
+	defer cleanup()
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+	_, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+
+	fe := startReplication(t, conn, `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`)
+	fe.Send(&pgproto3.Query{String: `SELECT 1`})
+	require.NoError(t, fe.Flush())
+
+	// The server asks the client to end the stream, and reports the error
+	// once it did.
+	var errResp *pgproto3.ErrorResponse
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+		case *pgproto3.CopyDone:
+			fe.Send(&pgproto3.CopyDone{})
+			require.NoError(t, fe.Flush())
+		case *pgproto3.ErrorResponse:
+			errResp = msg
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+	require.NotNil(t, errResp)
+	require.Equal(t, pgcode.ProtocolViolation.String(), errResp.Code)
+	require.Contains(t, errResp.Message, "unexpected message type")
+}
+
+// describePgoutputMessage returns a short description of a pgoutput message.
+func describePgoutputMessage(t *testing.T, msg []byte) string {
+	readString := func() string {
+		i := strings.IndexByte(string(msg), 0)
+		require.GreaterOrEqual(t, i, 0)
+		s := string(msg[:i])
+		msg = msg[i+1:]
+		return s
+	}
+	readTuple := func() string {
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		vals := make([]string, n)
+		for i := range vals {
+			kind := msg[0]
+			msg = msg[1:]
+			switch kind {
+			case 'n':
+				vals[i] = "NULL"
+			case 't':
+				l := int(binary.BigEndian.Uint32(msg))
+				vals[i] = string(msg[4 : 4+l])
+				msg = msg[4+l:]
+			default:
+				t.Fatalf("unexpected column kind %q", kind)
+			}
+		}
+		return strings.Join(vals, " ")
+	}
+
+	typ := msg[0]
+	msg = msg[1:]
+	switch typ {
+	case 'B':
+		return "begin"
+	case 'C':
+		return "commit"
+	case 'R':
+		msg = msg[4:]
+		desc := fmt.Sprintf("relation %s.%s", readString(), readString())
+		msg = msg[1:]
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		for i := 0; i < n; i++ {
+			flags := msg[0]
+			msg = msg[1:]
+			desc += " " + readString()
+			if flags&1 != 0 {
+				desc += "(key)"
+			}
+			msg = msg[8:]
+		}
+		return desc
+	case 'I', 'U', 'D':
+		msg = msg[4:]
+		var prefix string
+		switch typ {
+		case 'I':
+			prefix = "insert"
+		case 'U':
+			prefix = "update"
+		case 'D':
+			prefix = "delete"
+		}
+		var tuples []string
+		for len(msg) > 0 {
+			msg = msg[1:]
+			tuples = append(tuples, readTuple())
+		}
+		return prefix + " " + strings.Join(tuples, " / ")
+	default:
+		t.Fatalf("unexpected pgoutput message %q", typ)
+		return ""
+	}
+}
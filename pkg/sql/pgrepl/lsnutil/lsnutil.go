@@ -11,7 +11,7 @@
 package lsnutil
 
 import (
-	"time"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -19,7 +19,19 @@ import (
 
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The LSN is the wall time of the timestamp in nanoseconds. Logical ticks are
+// dropped, so all timestamps with the same wall time map to the same LSN.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime)
+}
+
+// LSNToHLC converts a LSN back to a HLC. The result is the largest timestamp
+// which maps to the given LSN, so that every change at or before the LSN is
+// at or before the returned timestamp.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	if l == 0 {
+		return hlc.Timestamp{}
+	}
+	return hlc.Timestamp{WallTime: int64(l), Logical: math.MaxInt32}
 }
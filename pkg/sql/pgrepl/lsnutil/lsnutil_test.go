@@ -0,0 +1,35 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lsnutil
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLSNRoundTrip(t *testing.T) {
+	for _, ts := range []hlc.Timestamp{
+		{WallTime: 1},
+		{WallTime: 1700000000123456789},
+		{WallTime: 1700000000123456789, Logical: 5},
+	} {
+		l := HLCToLSN(ts)
+		back := LSNToHLC(l)
+		require.Equal(t, ts.WallTime, back.WallTime)
+		require.True(t, ts.LessEq(back))
+		require.Equal(t, l, HLCToLSN(back))
+		// The next wall time maps to a strictly greater LSN.
+		require.Greater(t, HLCToLSN(back.Next()), l)
+	}
+	require.True(t, LSNToHLC(0).IsEmpty())
+}
@@ -84,9 +84,14 @@ func TestDataDriven(t *testing.T) {
 				rows.Close()
 				require.NoError(t, err)
 				return out
-			case "identify_system":
-				// IDENTIFY_SYSTEM needs some redaction to be deterministic.
-				rows, err := conn.Query(ctx, "IDENTIFY_SYSTEM", pgx.QuerySimpleProtocol(true))
+			case "identify_system", "create_replication_slot":
+				// IDENTIFY_SYSTEM and CREATE_REPLICATION_SLOT need some redaction
+				// to be deterministic.
+				query := "IDENTIFY_SYSTEM"
+				if d.Cmd == "create_replication_slot" {
+					query = d.Input
+				}
+				rows, err := conn.Query(ctx, query, pgx.QuerySimpleProtocol(true))
 				require.NoError(t, err)
 				var sb strings.Builder
 				for rows.Next() {
@@ -99,7 +104,7 @@ func TestDataDriven(t *testing.T) {
 						switch string(rows.FieldDescriptions()[i].Name) {
 						case "systemid":
 							val = "some_cluster_id"
-						case "xlogpos":
+						case "xlogpos", "consistent_point":
 							val = "some_lsn"
 						}
 						sb.Write(rows.FieldDescriptions()[i].Name)
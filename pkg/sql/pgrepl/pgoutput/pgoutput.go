@@ -0,0 +1,297 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput encodes messages in the format of PostgreSQL's pgoutput
+// logical decoding plugin, as well as the streaming replication protocol
+// messages which wrap them.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the output plugin implemented by this package.
+const PluginName = "pgoutput"
+
+// ProtocolVersion is the version of the pgoutput protocol implemented by this
+// package.
+const ProtocolVersion = 1
+
+// Message types of the logical replication protocol.
+const (
+	msgBegin    byte = 'B'
+	msgCommit   byte = 'C'
+	msgRelation byte = 'R'
+	msgInsert   byte = 'I'
+	msgUpdate   byte = 'U'
+	msgDelete   byte = 'D'
+)
+
+// Message types of the streaming replication protocol. These are sent inside
+// CopyData messages.
+const (
+	msgXLogData            byte = 'w'
+	msgPrimaryKeepalive    byte = 'k'
+	msgStandbyStatusUpdate byte = 'r'
+)
+
+// Tuple submessage types.
+const (
+	tupleNew byte = 'N'
+	tupleKey byte = 'K'
+)
+
+// Tuple column kinds.
+const (
+	columnNull byte = 'n'
+	columnText byte = 't'
+)
+
+// ReplicaIdentityDefault is the replica identity of a relation whose old
+// tuples are identified by their primary key.
+const ReplicaIdentityDefault byte = 'd'
+
+// columnFlagKey marks a column as part of the replica identity key.
+const columnFlagKey byte = 1
+
+// pgEpoch is the epoch used for timestamps in the replication protocol.
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Timestamp converts t to the number of microseconds since 2000-01-01, which
+// is how timestamps are represented in the replication protocol.
+func Timestamp(t time.Time) int64 {
+	return t.Sub(pgEpoch).Microseconds()
+}
+
+// Column describes a column of a Relation.
+type Column struct {
+	Name    string
+	TypeOID oid.Oid
+	TypeMod int32
+	// Key is set if the column is part of the replica identity, i.e. the
+	// primary key.
+	Key bool
+}
+
+// Relation describes a table whose changes are sent to the client. It must be
+// sent before the first change to the table, and again whenever the schema of
+// the table changes.
+type Relation struct {
+	ID              uint32
+	Namespace       string
+	Name            string
+	ReplicaIdentity byte
+	Columns         []Column
+}
+
+// Value is the value of a single column in a tuple. Data holds the text
+// representation of the value; it is ignored if Null is set.
+type Value struct {
+	Null bool
+	Data []byte
+}
+
+// Tuple is a row of a Relation.
+type Tuple []Value
+
+// Encoder builds pgoutput messages. The zero value is ready to use. The slice
+// returned by each method is only valid until the next call on the Encoder.
+type Encoder struct {
+	buf []byte
+}
+
+func (e *Encoder) reset(typ byte) {
+	e.buf = append(e.buf[:0], typ)
+}
+
+func (e *Encoder) putByte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *Encoder) putInt16(v int16) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v))
+}
+
+func (e *Encoder) putInt32(v int32) {
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v))
+}
+
+func (e *Encoder) putInt64(v int64) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v))
+}
+
+func (e *Encoder) putString(s string) {
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, 0)
+}
+
+func (e *Encoder) putTuple(t Tuple) {
+	e.putInt16(int16(len(t)))
+	for _, v := range t {
+		if v.Null {
+			e.putByte(columnNull)
+			continue
+		}
+		e.putByte(columnText)
+		e.putInt32(int32(len(v.Data)))
+		e.buf = append(e.buf, v.Data...)
+	}
+}
+
+// Begin encodes the start of a transaction which commits at finalLSN.
+func (e *Encoder) Begin(finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	e.reset(msgBegin)
+	e.putInt64(int64(finalLSN))
+	e.putInt64(Timestamp(commitTime))
+	e.putInt32(int32(xid))
+	return e.buf
+}
+
+// Commit encodes the end of a transaction.
+func (e *Encoder) Commit(commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	e.reset(msgCommit)
+	// Flags; currently unused.
+	e.putByte(0)
+	e.putInt64(int64(commitLSN))
+	e.putInt64(int64(endLSN))
+	e.putInt64(Timestamp(commitTime))
+	return e.buf
+}
+
+// Relation encodes the description of a table.
+func (e *Encoder) Relation(r Relation) []byte {
+	e.reset(msgRelation)
+	e.putInt32(int32(r.ID))
+	e.putString(r.Namespace)
+	e.putString(r.Name)
+	e.putByte(r.ReplicaIdentity)
+	e.putInt16(int16(len(r.Columns)))
+	for _, c := range r.Columns {
+		var flags byte
+		if c.Key {
+			flags |= columnFlagKey
+		}
+		e.putByte(flags)
+		e.putString(c.Name)
+		e.putInt32(int32(c.TypeOID))
+		e.putInt32(c.TypeMod)
+	}
+	return e.buf
+}
+
+// Insert encodes a row inserted into the given relation.
+func (e *Encoder) Insert(relID uint32, newTuple Tuple) []byte {
+	e.reset(msgInsert)
+	e.putInt32(int32(relID))
+	e.putByte(tupleNew)
+	e.putTuple(newTuple)
+	return e.buf
+}
+
+// Update encodes an updated row of the given relation. If the replica identity
+// key of the row changed, key holds the old values of the key columns (and nil
+// for all other columns); otherwise it should be nil.
+func (e *Encoder) Update(relID uint32, key, newTuple Tuple) []byte {
+	e.reset(msgUpdate)
+	e.putInt32(int32(relID))
+	if key != nil {
+		e.putByte(tupleKey)
+		e.putTuple(key)
+	}
+	e.putByte(tupleNew)
+	e.putTuple(newTuple)
+	return e.buf
+}
+
+// Delete encodes a row deleted from the given relation. key holds the values
+// of the replica identity key columns, with all other columns null.
+func (e *Encoder) Delete(relID uint32, key Tuple) []byte {
+	e.reset(msgDelete)
+	e.putInt32(int32(relID))
+	e.putByte(tupleKey)
+	e.putTuple(key)
+	return e.buf
+}
+
+// XLogData wraps a message of the logical replication protocol so that it can
+// be sent in a CopyData message of the streaming replication protocol.
+func XLogData(buf []byte, start, end lsn.LSN, now time.Time, payload []byte) []byte {
+	buf = append(buf[:0], msgXLogData)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(start))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(Timestamp(now)))
+	return append(buf, payload...)
+}
+
+// PrimaryKeepalive encodes a keepalive message. If replyRequested is set the
+// client should reply with a standby status update as soon as possible.
+func PrimaryKeepalive(buf []byte, end lsn.LSN, now time.Time, replyRequested bool) []byte {
+	buf = append(buf[:0], msgPrimaryKeepalive)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(Timestamp(now)))
+	var reply byte
+	if replyRequested {
+		reply = 1
+	}
+	return append(buf, reply)
+}
+
+// StandbyStatusUpdate is sent by the client to report its progress.
+type StandbyStatusUpdate struct {
+	// WriteLSN is the location of the last WAL byte received by the client.
+	WriteLSN lsn.LSN
+	// FlushLSN is the location of the last WAL byte durably stored by the
+	// client. Changes at or before this location will not be sent again.
+	FlushLSN lsn.LSN
+	// ApplyLSN is the location of the last WAL byte applied by the client.
+	ApplyLSN lsn.LSN
+	// ClientTime is the time of the client when the message was sent, in
+	// microseconds since 2000-01-01.
+	ClientTime int64
+	// ReplyRequested is set if the client asks for a keepalive in response.
+	ReplyRequested bool
+}
+
+// ErrUnknownMessage is returned by ParseStandbyMessage for messages other
+// than standby status updates. Clients may send hot standby feedback messages,
+// which do not apply to logical replication and can be ignored.
+var ErrUnknownMessage = errors.New("unknown standby message")
+
+// ParseStandbyMessage parses the payload of a CopyData message sent by the
+// client during streaming replication.
+func ParseStandbyMessage(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) == 0 {
+		return StandbyStatusUpdate{}, errors.New("empty standby message")
+	}
+	if data[0] != msgStandbyStatusUpdate {
+		return StandbyStatusUpdate{}, ErrUnknownMessage
+	}
+	const size = 1 + 8*4 + 1
+	if len(data) < size {
+		return StandbyStatusUpdate{}, errors.Newf(
+			"standby status update is %d bytes, expected %d", len(data), size,
+		)
+	}
+	return StandbyStatusUpdate{
+		WriteLSN:       lsn.LSN(binary.BigEndian.Uint64(data[1:])),
+		FlushLSN:       lsn.LSN(binary.BigEndian.Uint64(data[9:])),
+		ApplyLSN:       lsn.LSN(binary.BigEndian.Uint64(data[17:])),
+		ClientTime:     int64(binary.BigEndian.Uint64(data[25:])),
+		ReplyRequested: data[33] != 0,
+	}, nil
+}
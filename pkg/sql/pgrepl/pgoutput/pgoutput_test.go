@@ -0,0 +1,153 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	commitTime := time.Date(2000, time.January, 1, 0, 0, 1, 0, time.UTC)
+	var e Encoder
+
+	t.Run("begin", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'B',
+			0, 0, 0, 0, 0, 0, 0x01, 0x02, // final LSN
+			0, 0, 0, 0, 0, 0x0f, 0x42, 0x40, // commit time
+			0, 0, 0, 7, // xid
+		}, e.Begin(lsn.LSN(0x0102), commitTime, 7))
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'C',
+			0,                            // flags
+			0, 0, 0, 0, 0, 0, 0x01, 0x02, // commit LSN
+			0, 0, 0, 0, 0, 0, 0x01, 0x03, // end LSN
+			0, 0, 0, 0, 0, 0x0f, 0x42, 0x40, // commit time
+		}, e.Commit(lsn.LSN(0x0102), lsn.LSN(0x0103), commitTime))
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'R',
+			0, 0, 0, 104, // relation ID
+			'p', 'u', 'b', 'l', 'i', 'c', 0, // namespace
+			't', 0, // name
+			'd',  // replica identity
+			0, 2, // number of columns
+			1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff,
+			0, 'v', 0, 0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff,
+		}, e.Relation(Relation{
+			ID:              104,
+			Namespace:       "public",
+			Name:            "t",
+			ReplicaIdentity: ReplicaIdentityDefault,
+			Columns: []Column{
+				{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, Key: true},
+				{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+			},
+		}))
+	})
+
+	row := Tuple{{Data: []byte("1")}, {Null: true}}
+	key := Tuple{{Data: []byte("1")}, {Null: true}}
+
+	t.Run("insert", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'I',
+			0, 0, 0, 104,
+			'N',
+			0, 2,
+			't', 0, 0, 0, 1, '1',
+			'n',
+		}, e.Insert(104, row))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'U',
+			0, 0, 0, 104,
+			'N',
+			0, 2,
+			't', 0, 0, 0, 1, '1',
+			'n',
+		}, e.Update(104, nil, row))
+		require.Equal(t, []byte{
+			'U',
+			0, 0, 0, 104,
+			'K',
+			0, 2,
+			't', 0, 0, 0, 1, '1',
+			'n',
+			'N',
+			0, 2,
+			't', 0, 0, 0, 1, '1',
+			'n',
+		}, e.Update(104, key, row))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Equal(t, []byte{
+			'D',
+			0, 0, 0, 104,
+			'K',
+			0, 2,
+			't', 0, 0, 0, 1, '1',
+			'n',
+		}, e.Delete(104, key))
+	})
+}
+
+func TestStreamingMessages(t *testing.T) {
+	now := time.Date(2000, time.January, 1, 0, 0, 1, 0, time.UTC)
+
+	require.Equal(t, []byte{
+		'w',
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+		'x',
+	}, XLogData(nil, 1, 2, now, []byte("x")))
+
+	require.Equal(t, []byte{
+		'k',
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+		1,
+	}, PrimaryKeepalive(nil, 2, now, true))
+
+	msg := []byte{'r'}
+	for _, v := range []uint64{3, 2, 1, 1000000} {
+		msg = binary.BigEndian.AppendUint64(msg, v)
+	}
+	msg = append(msg, 0)
+	status, err := ParseStandbyMessage(msg)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		WriteLSN:   3,
+		FlushLSN:   2,
+		ApplyLSN:   1,
+		ClientTime: 1000000,
+	}, status)
+
+	_, err = ParseStandbyMessage([]byte{'h'})
+	require.ErrorIs(t, err, ErrUnknownMessage)
+	_, err = ParseStandbyMessage(msg[:10])
+	require.Error(t, err)
+}
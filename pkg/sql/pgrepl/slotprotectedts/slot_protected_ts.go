@@ -0,0 +1,57 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package slotprotectedts contains the protected timestamp records which keep
+// the history needed by logical replication slots.
+package slotprotectedts
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// SlotMetaType is the meta type for protected timestamp records associated
+// with replication slots.
+const SlotMetaType = "replication_slots"
+
+// MakeRecord makes a protected timestamp record to protect a timestamp on
+// behalf of a replication slot.
+func MakeRecord(
+	recordID uuid.UUID, slotName string, tsToProtect hlc.Timestamp, target *ptpb.Target,
+) *ptpb.Record {
+	return &ptpb.Record{
+		ID:        recordID.GetBytesMut(),
+		Timestamp: tsToProtect,
+		Mode:      ptpb.PROTECT_AFTER,
+		MetaType:  SlotMetaType,
+		Meta:      []byte(slotName),
+		Target:    target,
+	}
+}
+
+// MakeStatusFunc returns a function which determines whether the replication
+// slot implied with this value of meta should be removed by the reconciler.
+func MakeStatusFunc() ptreconcile.StatusFunc {
+	return func(ctx context.Context, txn isql.Txn, meta []byte) (shouldRemove bool, _ error) {
+		row, err := txn.QueryRowEx(ctx, "check-for-dropped-replication-slot", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT 1 FROM system.replication_slots WHERE slot_name = $1`, string(meta))
+		if err != nil {
+			return false, err
+		}
+		return row == nil, nil
+	}
+}
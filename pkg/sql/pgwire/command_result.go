@@ -392,6 +392,16 @@ func (r *commandResult) SendCopyOut(
 	return r.conn.bufferCopyOut(cols, format)
 }
 
+// SendCopyBoth is part of the sql.ReplicationResult interface.
+func (r *commandResult) SendCopyBoth(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBoth(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
 // SendCopyData is part of the sql.CopyOutResult interface.
 func (r *commandResult) SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error {
 	if err := r.beforeAdd(); err != nil {
@@ -484,6 +484,11 @@ func DecodeDatum(
 				return nil, err
 			}
 			return &tree.DPGVector{T: ret}, nil
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(bs)
 		}
 		switch typ.Family() {
 		case types.ArrayFamily, types.TupleFamily:
@@ -493,6 +498,15 @@ func DecodeDatum(
 				return nil, err
 			}
 			return da.NewDString(tree.DString(bs)), nil
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		}
 	case FormatBinary:
 		switch id {
@@ -809,6 +823,19 @@ func DecodeDatum(
 				return nil, err
 			}
 			return da.NewDGeography(tree.DGeography{Geography: v}), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
 		default:
 			if typ.Family() == types.ArrayFamily {
 				return decodeBinaryArray(ctx, evalCtx, typ.ArrayContents(), b, code, da)
@@ -816,6 +843,9 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b, da)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b, da)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1130,6 +1160,69 @@ func decodeBinaryTuple(
 
 }
 
+// The flags used in the binary format of ranges.
+const (
+	RangeFlagEmpty          = 0x01
+	RangeFlagLowerInclusive = 0x02
+	RangeFlagUpperInclusive = 0x04
+	RangeFlagLowerInfinite  = 0x08
+	RangeFlagUpperInfinite  = 0x10
+)
+
+// decodeBinaryRange decodes a range in the binary format, which is a byte of
+// flags followed by the length-prefixed binary encodings of the finite bounds
+// of the range.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte, da *tree.DatumAlloc,
+) (tree.Datum, error) {
+	if len(b) < 1 {
+		return nil, pgerror.New(pgcode.Syntax, "range requires a 1 byte header for binary format")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&RangeFlagEmpty != 0 {
+		if len(b) != 0 {
+			return nil, pgerror.New(pgcode.Syntax, "unexpected bytes after empty range for binary format")
+		}
+		return tree.NewDEmptyRange(typ), nil
+	}
+	decodeBound := func(infinite, inclusive bool) (tree.RangeBound, error) {
+		if infinite {
+			return tree.RangeBound{}, nil
+		}
+		if len(b) < elementSize {
+			return tree.RangeBound{}, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound size for binary format")
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[elementSize:]
+		if n < 0 || len(b) < n {
+			return tree.RangeBound{}, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound for binary format")
+		}
+		d, err := DecodeDatum(ctx, evalCtx, typ.RangeContents(), FormatBinary, b[:n], da)
+		if err != nil {
+			return tree.RangeBound{}, err
+		}
+		b = b[n:]
+		return tree.RangeBound{Val: d, Inclusive: inclusive}, nil
+	}
+	lower, err := decodeBound(flags&RangeFlagLowerInfinite != 0, flags&RangeFlagLowerInclusive != 0)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(flags&RangeFlagUpperInfinite != 0, flags&RangeFlagUpperInclusive != 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, pgerror.New(pgcode.Syntax, "unexpected bytes after range for binary format")
+	}
+	r, err := tree.NewDRange(typ, lower, upper)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 var invalidUTF8Error = pgerror.Newf(pgcode.CharacterNotInRepertoire, "invalid UTF-8 sequence")
 
 var (
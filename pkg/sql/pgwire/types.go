@@ -246,6 +246,9 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Path.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -262,6 +265,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -734,6 +741,43 @@ func writeBinaryDatumNotNull(
 	case *tree.DVoid:
 		b.putInt32(0)
 
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+
+		// Like in Postgres, the range is encoded as a byte of flags followed by
+		// the length-prefixed binary encodings of its finite bounds.
+		var flags byte
+		switch {
+		case v.Empty:
+			flags |= pgwirebase.RangeFlagEmpty
+		case v.Lower.IsInfinite():
+			flags |= pgwirebase.RangeFlagLowerInfinite
+		case v.Lower.Inclusive:
+			flags |= pgwirebase.RangeFlagLowerInclusive
+		}
+		if !v.Empty {
+			switch {
+			case v.Upper.IsInfinite():
+				flags |= pgwirebase.RangeFlagUpperInfinite
+			case v.Upper.Inclusive:
+				flags |= pgwirebase.RangeFlagUpperInclusive
+			}
+		}
+		b.writeByte(flags)
+		if !v.Empty {
+			for _, bound := range []tree.RangeBound{v.Lower, v.Upper} {
+				if !bound.IsInfinite() {
+					b.writeBinaryDatum(ctx, bound.Val, sessionLoc, t.RangeContents())
+				}
+			}
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DPGLSN:
 		b.putInt32(8)
 		b.putInt64(int64(v.LSN))
@@ -775,6 +819,14 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DJsonpath:
+		// The binary format of jsonpath is a version number followed by the
+		// text representation of the path.
+		s := v.Path.String()
+		b.putInt32(int32(len(s) + 1))
+		b.writeByte(1)
+		b.writeString(s)
+
 	case *tree.DPGVector:
 		// 2 bytes for dimensions, 2 bytes for unused, and 4 bytes for each
 		// float4.
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -818,6 +819,7 @@ func (p *PhysicalPlan) AddFilter(
 	if err != nil {
 		return err
 	}
+	p.maybePushFilterIntoTableReaders(expr, exprCtx, indexVarMap)
 	p.AddNoGroupingStage(
 		execinfrapb.ProcessorCoreUnion{Filterer: &execinfrapb.FiltererSpec{
 			Filter: filter,
@@ -829,6 +831,59 @@ func (p *PhysicalPlan) AddFilter(
 	return nil
 }
 
+// maybePushFilterIntoTableReaders adds the parts of the given filter that can
+// be evaluated by the KV server to the IndexFetchSpecs of the table readers
+// producing the results of the plan, if there are any. This allows the rows
+// that don't satisfy the filter to be discarded during direct columnar scans
+// instead of being returned to SQL. The filter is still evaluated by the
+// filterer added by AddFilter, so this is only an optimization.
+func (p *PhysicalPlan) maybePushFilterIntoTableReaders(
+	expr tree.TypedExpr, exprCtx ExprContext, indexVarMap []int,
+) {
+	// The filter is only evaluated during direct columnar scans.
+	if sd := exprCtx.EvalContext().SessionData(); sd == nil || !sd.DirectColumnarScansEnabled {
+		return
+	}
+	for _, pIdx := range p.ResultRouters {
+		tr := p.Processors[pIdx].Spec.Core.TableReader
+		if tr == nil || len(tr.FetchSpec.Filter) > 0 {
+			return
+		}
+	}
+	if err := p.CheckLastStagePost(); err != nil {
+		return
+	}
+	post := &p.Processors[p.ResultRouters[0]].Spec.Post
+	// The limit and offset of the table readers are applied before the
+	// filterer, so pushing the filter below them would change the results.
+	if post.Limit != 0 || post.Offset != 0 || len(post.RenderExprs) > 0 {
+		return
+	}
+	fetchSpec := &p.Processors[p.ResultRouters[0]].Spec.Core.TableReader.FetchSpec
+	filter := rowenc.MakeIndexFetchFilter(fetchSpec, expr, func(varIdx int) (int, bool) {
+		streamCol := varIdx
+		if indexVarMap != nil {
+			if varIdx >= len(indexVarMap) || indexVarMap[varIdx] < 0 {
+				return 0, false
+			}
+			streamCol = indexVarMap[varIdx]
+		}
+		if !post.Projection {
+			return streamCol, true
+		}
+		if streamCol >= len(post.OutputColumns) {
+			return 0, false
+		}
+		return int(post.OutputColumns[streamCol]), true
+	})
+	if len(filter) == 0 {
+		return
+	}
+	for _, pIdx := range p.ResultRouters {
+		p.Processors[pIdx].Spec.Core.TableReader.FetchSpec.Filter = filter
+	}
+}
+
 // AddLimit adds a limit and/or offset to the results of the current plan. If
 // there are multiple result streams, they are joined into a single processor
 // that is placed on the given node.
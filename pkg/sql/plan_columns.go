@@ -46,6 +46,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 	switch n := plan.(type) {
 
 	// Nodes that define their own schema.
+	case *aggregateScanNode:
+		return n.columns
 	case *delayedNode:
 		return n.columns
 	case *groupNode:
@@ -178,6 +180,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
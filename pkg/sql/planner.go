@@ -114,6 +114,15 @@ type extendedEvalContext struct {
 
 	// validateDbZoneConfig should the DB zone config on commit.
 	validateDbZoneConfig *bool
+
+	// deferredConstraints tracks the deferred constraint checks of the current
+	// transaction. It is nil for internal executors running under an outer
+	// transaction.
+	deferredConstraints *deferredConstraints
+
+	// tempTableCommitActions tracks the temporary tables of the current
+	// transaction that have ON COMMIT actions.
+	tempTableCommitActions *tempTableCommitActions
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
@@ -640,6 +649,8 @@ func (p *planner) InternalSQLTxn() descs.Txn {
 			descCollection:     p.Descriptors(),
 			jobs:               p.extendedEvalCtx.jobs,
 			schemaChangerState: p.extendedEvalCtx.SchemaChangerState,
+
+			tempTableCommitActions: p.extendedEvalCtx.tempTableCommitActions,
 		}
 		p.internalSQLTxn.init(p.txn, ie)
 	}
@@ -202,61 +202,131 @@ func (l *lexer) MakeExecSqlStmt() (*plpgsqltree.Execute, error) {
 	}, nil
 }
 
+// MakeDynamicExecuteStmt makes a DynamicExecute node. The EXECUTE keyword has
+// already been consumed.
 func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
-	cmdStr, _, err := l.ReadSqlStatement(INTO, USING, ';')
+	queryStr, terminator, err := l.ReadSqlExpr(INTO, USING, ';')
 	if err != nil {
 		return nil, err
 	}
-	ret := &plpgsqltree.DynamicExecute{
-		Query: cmdStr,
+	query, err := l.ParseExpr(queryStr)
+	if err != nil {
+		return nil, err
 	}
-
-	var lval plpgsqlSymType
-	l.Lex(&lval)
-	for {
-		if lval.id == INTO {
-			if ret.Into {
-				return nil, errors.New("multiple INTO keywords")
+	ret := &plpgsqltree.DynamicExecute{Query: query}
+	for terminator != ';' {
+		// Move past the INTO or USING keyword.
+		l.lastPos++
+		switch terminator {
+		case INTO:
+			if ret.Target != nil {
+				return nil, errors.New("INTO specified more than once")
 			}
-			ret.Into = true
-			nextTok := l.Peek()
-			if nextTok.id == int32(STRICT) {
-				l.Lex(&lval)
+			if l.Peek().id == STRICT {
+				l.lastPos++
 				ret.Strict = true
 			}
-			// TODO we need to read each "INTO" variable name instead of just a
-			// string.
-			_, _, err = l.ReadSqlExpr(USING, ';')
+			ret.Target, terminator, err = l.readIntoTarget(INTO, USING, ';')
 			if err != nil {
 				return nil, err
 			}
-			l.Lex(&lval)
-		} else if lval.id == USING {
+		case USING:
 			if ret.Params != nil {
-				return nil, errors.New("multiple USING keywords")
+				return nil, errors.New("USING specified more than once")
 			}
-			ret.Params = make([]plpgsqltree.Expr, 0)
-			for {
-				_, _, err = l.ReadSqlExpr(',', ';', INTO)
-				if err != nil {
-					return nil, err
-				}
-				ret.Params = append(ret.Params, nil)
-				l.Lex(&lval)
-				if lval.id == ';' {
-					break
-				}
+			ret.Params, terminator, err = l.readUsingParams(INTO, USING, ';')
+			if err != nil {
+				return nil, err
 			}
-		} else if lval.id == ';' {
-			break
-		} else {
-			return nil, errors.Newf("unexpected token: %s", lval.id)
+		default:
+			return nil, errors.New("missing \";\" at end of EXECUTE statement")
 		}
 	}
+	// Move past the semicolon.
+	l.lastPos++
+	return ret, nil
+}
 
+// MakeOpenDynamicStmt makes an Open node for an OPEN ... FOR EXECUTE
+// statement. The EXECUTE keyword has already been consumed.
+func (l *lexer) MakeOpenDynamicStmt(
+	curVar plpgsqltree.Variable, scroll tree.CursorScrollOption,
+) (*plpgsqltree.Open, error) {
+	queryStr, terminator, err := l.ReadSqlExpr(USING, ';')
+	if err != nil {
+		return nil, err
+	}
+	query, err := l.ParseExpr(queryStr)
+	if err != nil {
+		return nil, err
+	}
+	ret := &plpgsqltree.Open{CurVar: curVar, Scroll: scroll, DynamicQuery: query}
+	if terminator == USING {
+		// Move past the USING keyword.
+		l.lastPos++
+		if ret.Params, terminator, err = l.readUsingParams(';'); err != nil {
+			return nil, err
+		}
+	}
+	if terminator != ';' {
+		return nil, errors.New("missing \";\" at end of OPEN statement")
+	}
+	// Move past the semicolon.
+	l.lastPos++
 	return ret, nil
 }
 
+// readIntoTarget reads a comma-separated list of variables that make up the
+// INTO target of a dynamic EXECUTE statement.
+func (l *lexer) readIntoTarget(
+	terminator1 int, terminators ...int,
+) (target []plpgsqltree.Variable, terminatorMet int, err error) {
+	var startPos, endPos int
+	startPos, endPos, terminatorMet, err = l.readSQLConstruct(
+		true /* isExpr */, false /* allowEmpty */, terminator1, terminators...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	for pos := startPos; pos < endPos; pos += 2 {
+		tok := l.tokens[pos]
+		if tok.id != IDENT {
+			return nil, 0, errors.Newf("\"%s\" is not a scalar variable", tok.str)
+		}
+		if pos+1 != endPos && l.tokens[pos+1].id != ',' {
+			return nil, 0, errors.Newf("expected INTO target to be a comma-separated list")
+		}
+		variable := plpgsqltree.Variable(strings.TrimSpace(l.getStr(pos, pos+1)))
+		target = append(target, variable)
+	}
+	return target, terminatorMet, nil
+}
+
+// readUsingParams reads the comma-separated list of expressions in the USING
+// clause of a dynamic EXECUTE or OPEN ... FOR EXECUTE statement.
+func (l *lexer) readUsingParams(
+	terminator1 int, terminators ...int,
+) (params []plpgsqltree.Expr, terminatorMet int, err error) {
+	terminators = append(terminators, ',')
+	for {
+		var paramStr string
+		paramStr, terminatorMet, err = l.ReadSqlExpr(terminator1, terminators...)
+		if err != nil {
+			return nil, 0, err
+		}
+		param, err := l.ParseExpr(paramStr)
+		if err != nil {
+			return nil, 0, err
+		}
+		params = append(params, param)
+		if terminatorMet != ',' {
+			return params, terminatorMet, nil
+		}
+		// Move past the comma.
+		l.lastPos++
+	}
+}
+
 func (l *lexer) readSQLConstruct(
 	isExpr, allowEmpty bool, terminator1 int, terminators ...int,
 ) (startPos, endPos, terminatorMet int, err error) {
@@ -374,6 +444,82 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 	}, nil
 }
 
+// MakeForControl reads the part of a FOR loop between the IN and LOOP
+// keywords. If a top-level ".." token is found, the result is an integer FOR
+// loop. Otherwise, the result is a loop over the rows returned by a query. The
+// label and body of the loop are filled in by the caller.
+func (l *lexer) MakeForControl(target []plpgsqltree.Variable) (plpgsqltree.Statement, error) {
+	startPos, endPos, _, err := l.readSQLConstruct(false /* isExpr */, false /* allowEmpty */, LOOP)
+	if err != nil {
+		return nil, err
+	}
+	// Find the ".." and BY tokens that separate the bounds and step of an
+	// integer FOR loop, ignoring any that are nested within parentheses.
+	rangePos, byPos := -1, -1
+	parenLevel := 0
+	for pos := startPos; pos < endPos; pos++ {
+		switch l.tokens[pos].id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		case DOT_DOT:
+			if parenLevel == 0 && rangePos == -1 {
+				rangePos = pos
+			}
+		case BY:
+			if parenLevel == 0 && rangePos != -1 && byPos == -1 {
+				byPos = pos
+			}
+		}
+	}
+	if rangePos == -1 {
+		// This is a loop over the rows of a query.
+		stmts, err := parser.Parse(l.getStr(startPos, endPos))
+		if err != nil {
+			return nil, err
+		}
+		if len(stmts) != 1 {
+			return nil, errors.New("expected exactly one SQL statement for FOR loop")
+		}
+		return &plpgsqltree.ForSelect{
+			ForQuery: plpgsqltree.ForQuery{Target: target},
+			Query:    stmts[0].AST,
+		}, nil
+	}
+	if len(target) != 1 {
+		return nil, errors.New("integer FOR loop must have only one target variable")
+	}
+	forInt := &plpgsqltree.ForInt{Var: target[0]}
+	lowerPos := startPos
+	if l.tokens[lowerPos].id == REVERSE {
+		forInt.Reverse = true
+		lowerPos++
+	}
+	upperEndPos := endPos
+	if byPos != -1 {
+		upperEndPos = byPos
+	}
+	parseBound := func(start, end int) (plpgsqltree.Expr, error) {
+		if end <= start {
+			return nil, errors.New("missing expression")
+		}
+		return l.ParseExpr(l.getStr(start, end))
+	}
+	if forInt.Lower, err = parseBound(lowerPos, rangePos); err != nil {
+		return nil, err
+	}
+	if forInt.Upper, err = parseBound(rangePos+1, upperEndPos); err != nil {
+		return nil, err
+	}
+	if byPos != -1 {
+		if forInt.Step, err = parseBound(byPos+1, endPos); err != nil {
+			return nil, err
+		}
+	}
+	return forInt, nil
+}
+
 func (l *lexer) ReadSqlExpr(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
@@ -138,6 +138,10 @@ func (u *plpgsqlSymUnion) exprs() []plpgsqltree.Expr {
     return u.val.([]plpgsqltree.Expr)
 }
 
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
+}
+
 func (u *plpgsqlSymUnion) raiseOption() *plpgsqltree.RaiseOption {
     return u.val.(*plpgsqltree.RaiseOption)
 }
@@ -325,11 +329,11 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
+%type <[]plpgsqltree.Variable>	for_variable
 %type <*tree.NumVal>	foreach_slice
 %type <plpgsqltree.Statement>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -684,9 +688,13 @@ proc_stmt:pl_block ';'
     $$.val = $1.statement()
   }
 | stmt_for
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_foreach_a
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_exit
   {
     $$.val = $1.statement()
@@ -759,7 +767,16 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM is equivalent to a SELECT statement with the SELECT keyword
+    // replaced by PERFORM, and the result discarded.
+    stmts, err := parser.Parse("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if len(stmts) != 1 {
+      return setErr(plpgsqllex, errors.New("expected exactly one SQL statement for PERFORM"))
+    }
+    $$.val = &plpgsqltree.Perform{Query: stmts[0].AST}
   }
 ;
 
@@ -783,7 +800,16 @@ stmt_call: CALL expr_until_semi ';'
 
 stmt_do: DO expr_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "do")
+    // A nested DO statement is executed as a SQL statement, which plans the
+    // anonymous code block as a separate procedure.
+    stmts, err := parser.Parse("DO " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if len(stmts) != 1 {
+      return setErr(plpgsqllex, errors.New("expected exactly one SQL statement for DO"))
+    }
+    $$.val = &plpgsqltree.Execute{SqlStmt: stmts[0].AST}
   }
 ;
 
@@ -798,6 +824,18 @@ stmt_assign: IDENT assign_operator expr_until_semi ';'
       Value: expr,
     }
   }
+| IDENT '.' IDENT assign_operator expr_until_semi ';'
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($5)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Assignment{
+      Var: plpgsqltree.Variable($1),
+      Indirection: tree.Name($3),
+      Value: expr,
+    }
+  }
 ;
 
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
@@ -1020,54 +1058,90 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loopLabel, loopEndLabel := $1, $6
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    switch t := $3.statement().(type) {
+    case *plpgsqltree.ForInt:
+      t.Label = loopLabel
+      t.Body = $5.statements()
+    case *plpgsqltree.ForSelect:
+      t.Label = loopLabel
+      t.Body = $5.statements()
+    }
+    $$.val = $3.statement()
   }
 ;
 
-for_control: for_variable IN
-  // TODO(drewk) need to parse the sql expression here.
+for_control: for_variable IN EXECUTE
   {
-    return unimplemented(plpgsqllex, "for loop")
+    return unimplemented(plpgsqllex, "for loop over dynamic query")
+  }
+| for_variable IN
+  {
+    stmt, err := plpgsqllex.(*lexer).MakeForControl($1.variables())
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
 /*
  * Processing the for_variable is tricky because we don't yet know if the
- * FOR is an integer FOR loop or a loop over query results.  In the former
+ * FOR is an integer FOR loop or a loop over query results. In the former
  * case, the variable is just a name that we must instantiate as a loop
- * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
- *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * local variable, regardless of any other definition it might have. In the
+ * latter case, the names refer to existing variables that are assigned the
+ * columns of each row. Therefore, we only collect the names here, and leave
+ * the semantic checks to the routine builder.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    target := $3.variables()
+    if len(target) != 1 {
+      return unimplemented(plpgsqllex, "for each loop with multiple targets")
+    }
+    if $4.numVal() != nil {
+      return unimplemented(plpgsqllex, "for each loop with slice")
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: loopLabel,
+      Var: target[0],
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = (*tree.NumVal)(nil)
   }
 | SLICE ICONST
   {
+    $$.val = $2.numVal()
   }
 ;
 
@@ -1101,39 +1175,42 @@ stmt_return: RETURN return_expr ';'
     }
     $$.val = &plpgsqltree.Return{Expr: expr}
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_expr ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    var expr plpgsqltree.Expr
+    if $3 != "" {
+      var err error
+      expr, err = plpgsqllex.(*lexer).ParseExpr($3)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.ReturnNext{Expr: expr}
   }
-| RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-return_expr:
+| RETURN_QUERY QUERY EXECUTE
   {
-    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
+    return unimplemented(plpgsqllex, "return dynamic sql query")
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    stmts, err := parser.Parse($3)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    $$ = sqlStr
+    if len(stmts) != 1 {
+      return setErr(plpgsqllex, errors.New("expected exactly one SQL statement for RETURN QUERY"))
+    }
+    $$.val = &plpgsqltree.ReturnQuery{Query: stmts[0].AST}
   }
 ;
 
-query_options:
+return_expr:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
-    }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$ = sqlStr
   }
 ;
 
@@ -1340,9 +1417,15 @@ stmt_open: OPEN IDENT ';'
   {
     $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
-| OPEN IDENT opt_scrollable FOR EXECUTE 
+| OPEN IDENT opt_scrollable FOR EXECUTE
   {
-    return unimplemented(plpgsqllex, "cursor for execute")
+    open, err := plpgsqllex.(*lexer).MakeOpenDynamicStmt(
+      plpgsqltree.Variable($2), $3.cursorScrollOption(),
+    )
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = open
   }
 | OPEN IDENT opt_scrollable FOR stmt_until_semi ';'
   {
@@ -0,0 +1,40 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreatePolicy (UNIMPLEMENTED for legacy schema changer) creates a row-level
+// security policy.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	return nil, pgerror.New(pgcode.FeatureNotSupported,
+		"CREATE POLICY is only implemented in the declarative schema changer")
+}
+
+// AlterPolicy (UNIMPLEMENTED for legacy schema changer) alters a row-level
+// security policy.
+func (p *planner) AlterPolicy(ctx context.Context, n *tree.AlterPolicy) (planNode, error) {
+	return nil, pgerror.New(pgcode.FeatureNotSupported,
+		"ALTER POLICY is only implemented in the declarative schema changer")
+}
+
+// DropPolicy (UNIMPLEMENTED for legacy schema changer) drops a row-level
+// security policy.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	return nil, pgerror.New(pgcode.FeatureNotSupported,
+		"DROP POLICY is only implemented in the declarative schema changer")
+}
@@ -0,0 +1,336 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createPublicationNode struct {
+	n *tree.CreatePublication
+}
+
+// CreatePublication represents a CREATE PUBLICATION statement.
+// Privileges: CREATE on the current database, ownership of the published
+// tables, and the admin role for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &createPublicationNode{n: n}, nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	if err := p.CheckPrivilege(params.ctx, db, privilege.CREATE); err != nil {
+		return err
+	}
+	if n.n.AllTables {
+		hasAdmin, err := p.HasAdminRole(params.ctx)
+		if err != nil {
+			return err
+		}
+		if !hasAdmin {
+			return pgerror.New(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+
+	tableIDs := tree.NewDArray(types.Int)
+	var seen catalog.DescriptorIDSet
+	for i := range n.n.Tables {
+		tn := &n.n.Tables[i]
+		table, err := p.ResolveUncachedTableDescriptorEx(
+			params.ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return err
+		}
+		if err := checkTableCanBePublished(params.ctx, p, db, table); err != nil {
+			return err
+		}
+		if seen.Contains(table.GetID()) {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"table %q specified more than once", table.GetName())
+		}
+		seen.Add(table.GetID())
+		if err := tableIDs.Append(tree.NewDInt(tree.DInt(table.GetID()))); err != nil {
+			return err
+		}
+	}
+
+	exists, err := publicationExists(params.ctx, p.InternalSQLTxn(), db.GetID(), string(n.n.Name))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.n.Name)
+	}
+	_, err = p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"create-publication",
+		p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.publications (database_id, publication_name, owner, all_tables, table_ids)
+VALUES ($1, $2, $3, $4, $5)`,
+		db.GetID(), string(n.n.Name), p.User().Normalized(), n.n.AllTables, tableIDs,
+	)
+	return err
+}
+
+// checkTableCanBePublished returns an error if the changes to the given table
+// cannot be streamed to logical replication clients.
+func checkTableCanBePublished(
+	ctx context.Context, p *planner, db catalog.DatabaseDescriptor, table catalog.TableDescriptor,
+) error {
+	if table.GetParentID() != db.GetID() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot add table %q from another database to a publication", table.GetName())
+	}
+	if table.IsVirtualTable() || table.IsTemporary() {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"cannot add relation %q to publication", table.GetName())
+	}
+	hasOwnership, err := p.HasOwnership(ctx, table)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", table.GetName())
+	}
+	return nil
+}
+
+func (n *createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPublicationNode) Close(context.Context)        {}
+
+type dropPublicationNode struct {
+	n *tree.DropPublication
+}
+
+// DropPublication represents a DROP PUBLICATION statement.
+// Privileges: ownership of the publication.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n}, nil
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	hasAdmin, err := p.HasAdminRole(params.ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range n.n.Names {
+		row, err := p.InternalSQLTxn().QueryRowEx(
+			params.ctx,
+			"get-publication-owner",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT owner FROM system.publications WHERE database_id = $1 AND publication_name = $2`,
+			db.GetID(), string(name),
+		)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			if n.n.IfExists {
+				p.BufferClientNotice(params.ctx,
+					pgnotice.Newf("publication %q does not exist, skipping", name))
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		if owner := string(tree.MustBeDString(row[0])); !hasAdmin && owner != p.User().Normalized() {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of publication %s", name)
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			params.ctx,
+			"drop-publication",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.publications WHERE database_id = $1 AND publication_name = $2`,
+			db.GetID(), string(name),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPublicationNode) Close(context.Context)        {}
+
+// checkLogicalReplicationSupported returns an error if the system tables which
+// store publications and replication slots have not been created yet.
+func checkLogicalReplicationSupported(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V24_3_ReplicationSlotsAndPublications) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use logical replication",
+			clusterversion.V24_3_ReplicationSlotsAndPublications.Version())
+	}
+	return nil
+}
+
+func publicationExists(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID, name string,
+) (bool, error) {
+	row, err := txn.QueryRowEx(
+		ctx,
+		"check-publication-exists",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT 1 FROM system.publications WHERE database_id = $1 AND publication_name = $2`,
+		dbID, name,
+	)
+	if err != nil {
+		return false, err
+	}
+	return row != nil, nil
+}
+
+// publication is a publication read from system.publications.
+type publication struct {
+	name      string
+	owner     string
+	allTables bool
+	tableIDs  []descpb.ID
+}
+
+// getPublications returns the publications of the given database with the
+// given names, in the same order. An error is returned if any of them does
+// not exist. If names is empty, all the publications of the database are
+// returned.
+func getPublications(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID, names []string,
+) ([]publication, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx,
+		"get-publications",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT publication_name, owner, all_tables, table_ids FROM system.publications
+WHERE database_id = $1 ORDER BY publication_name`,
+		dbID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]publication, len(rows))
+	all := make([]publication, 0, len(rows))
+	for _, row := range rows {
+		pub := publication{
+			name:      string(tree.MustBeDString(row[0])),
+			owner:     string(tree.MustBeDString(row[1])),
+			allTables: bool(tree.MustBeDBool(row[2])),
+		}
+		if row[3] != tree.DNull {
+			for _, id := range tree.MustBeDArray(row[3]).Array {
+				pub.tableIDs = append(pub.tableIDs, descpb.ID(tree.MustBeDInt(id)))
+			}
+		}
+		byName[pub.name] = pub
+		all = append(all, pub)
+	}
+	if len(names) == 0 {
+		return all, nil
+	}
+	ret := make([]publication, len(names))
+	for i, name := range names {
+		pub, ok := byName[name]
+		if !ok {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name),
+				"use CREATE PUBLICATION to create it",
+			)
+		}
+		ret[i] = pub
+	}
+	return ret, nil
+}
+
+// getPublishedTables returns the tables of the database which are in the
+// publication. Tables which have been dropped since they were added to the
+// publication are skipped.
+func getPublishedTables(
+	ctx context.Context,
+	col *descs.Collection,
+	txn *kv.Txn,
+	db catalog.DatabaseDescriptor,
+	pub publication,
+) ([]catalog.TableDescriptor, error) {
+	var tables []catalog.TableDescriptor
+	if pub.allTables {
+		inDB, err := col.GetAllTablesInDatabase(ctx, txn, db)
+		if err != nil {
+			return nil, err
+		}
+		if err := inDB.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			table, err := catalog.AsTableDescriptor(desc)
+			if err != nil {
+				return err
+			}
+			if !table.IsView() && !table.IsSequence() && !table.IsVirtualTable() &&
+				!table.IsTemporary() && table.Public() {
+				tables = append(tables, table)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return tables, nil
+	}
+	for _, id := range pub.tableIDs {
+		table, err := col.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			if errors.Is(err, catalog.ErrDescriptorDropped) || errors.Is(err, catalog.ErrDescriptorNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -329,8 +330,35 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(jsonpath.Random(rng))
 	case types.PGVectorFamily:
 		return tree.NewDPGVector(vector.Random(rng))
+	case types.RangeFamily:
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(typ)
+		}
+		var bounds [2]tree.RangeBound
+		for i := range bounds {
+			// A nullChance of 5 makes one in five bounds infinite.
+			bounds[i].Val = RandDatumWithNullChance(
+				rng, typ.RangeContents(), 5 /* nullChance */, favorCommonData, false, /* targetColumnIsUnique */
+			)
+			if bounds[i].Val == tree.DNull {
+				bounds[i].Val = nil
+			}
+			bounds[i].Inclusive = rng.Intn(2) == 0
+		}
+		r, err := tree.NewDRange(typ, bounds[0], bounds[1])
+		if err != nil {
+			// The lower bound was greater than the upper bound, so swap them.
+			bounds[0].Val, bounds[1].Val = bounds[1].Val, bounds[0].Val
+			if r, err = tree.NewDRange(typ, bounds[0], bounds[1]); err != nil {
+				// Canonicalizing the range can overflow the element type.
+				return tree.NewDEmptyRange(typ)
+			}
+		}
+		return r
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
@@ -0,0 +1,248 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/slotprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// maxReplicationSlotNameLength is the maximum length of a replication slot
+// name, matching NAMEDATALEN-1 in Postgres.
+const maxReplicationSlotNameLength = 63
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n *pgrepltree.CreateReplicationSlot
+
+	consistentPoint lsn.LSN
+	shown           bool
+}
+
+// CreateReplicationSlot creates a logical replication slot for the current
+// database. The slot records the position up to which the changes have been
+// consumed, and protects the history of the database after that position
+// from garbage collection until the slot is dropped.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication slots", "physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary replication slots", "temporary replication slots are not supported")
+	}
+	if string(n.Plugin) != pgoutput.PluginName {
+		return nil, errors.WithHintf(
+			pgerror.Newf(pgcode.UndefinedObject, "output plugin %q is not supported", n.Plugin),
+			"the only supported output plugin is %q", pgoutput.PluginName,
+		)
+	}
+	if err := checkLogicalReplicationConnection(p.SessionData()); err != nil {
+		return nil, err
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	slot := string(n.n.Slot)
+	if _, err := getReplicationSlot(params.ctx, p.InternalSQLTxn(), slot); err == nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "replication slot %q already exists", slot)
+	} else if pgerror.GetPGCode(err) != pgcode.UndefinedObject {
+		return err
+	}
+
+	// Changes which commit after the read timestamp of this transaction are
+	// streamed to the slot.
+	ts := p.Txn().ReadTimestamp()
+	n.consistentPoint = lsnutil.HLCToLSN(ts)
+	recordID := uuid.MakeV4()
+	if _, err := p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"create-replication-slot",
+		p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots
+  (slot_name, plugin, database_id, confirmed_flush_lsn, protected_timestamp_record_id)
+VALUES ($1, $2, $3, $4, $5)`,
+		slot, pgoutput.PluginName, db.GetID(), int64(n.consistentPoint),
+		tree.NewDUuid(tree.DUuid{UUID: recordID}),
+	); err != nil {
+		return err
+	}
+	target := ptpb.MakeSchemaObjectsTarget(descpb.IDs{db.GetID()})
+	return p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn()).Protect(
+		params.ctx, slotprotectedts.MakeRecord(recordID, slot, ts, target),
+	)
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(string(n.n.Slot)),
+		tree.NewDString(n.consistentPoint.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(pgoutput.PluginName),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a replication slot and releases the history it
+// protected.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	slot, err := getReplicationSlot(params.ctx, p.InternalSQLTxn(), string(n.n.Slot))
+	if err != nil {
+		return err
+	}
+	if _, err := p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"drop-replication-slot",
+		p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1`,
+		slot.name,
+	); err != nil {
+		return err
+	}
+	err = p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn()).Release(
+		params.ctx, slot.protectedTimestampRecordID,
+	)
+	// The record may have already been removed by the reconciler.
+	if errors.Is(err, protectedts.ErrNotExists) {
+		return nil
+	}
+	return err
+}
+
+func (n *dropReplicationSlotNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(context.Context)        {}
+
+// validateReplicationSlotName checks that the name only contains lower case
+// letters, numbers and underscores, as Postgres requires.
+func validateReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.New(pgcode.InvalidName, "replication slot name cannot be empty")
+	}
+	if len(name) > maxReplicationSlotNameLength {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName, "replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// checkLogicalReplicationConnection returns an error unless the session was
+// opened with replication=database, which logical replication requires.
+func checkLogicalReplicationConnection(sd *sessiondata.SessionData) error {
+	if sd.ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a connection with replication=database")
+	}
+	if sd.Database == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a database to be specified")
+	}
+	return nil
+}
+
+// replicationSlot is a replication slot read from system.replication_slots.
+type replicationSlot struct {
+	name                       string
+	plugin                     string
+	databaseID                 descpb.ID
+	confirmedFlushLSN          lsn.LSN
+	protectedTimestampRecordID uuid.UUID
+}
+
+// getReplicationSlot reads the replication slot with the given name. An
+// UndefinedObject error is returned if it does not exist.
+func getReplicationSlot(ctx context.Context, txn isql.Txn, name string) (replicationSlot, error) {
+	row, err := txn.QueryRowEx(
+		ctx,
+		"get-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT plugin, database_id, confirmed_flush_lsn, protected_timestamp_record_id
+FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil {
+		return replicationSlot{}, err
+	}
+	if row == nil {
+		return replicationSlot{}, pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", name)
+	}
+	return replicationSlot{
+		name:                       name,
+		plugin:                     string(tree.MustBeDString(row[0])),
+		databaseID:                 descpb.ID(tree.MustBeDInt(row[1])),
+		confirmedFlushLSN:          lsn.LSN(tree.MustBeDInt(row[2])),
+		protectedTimestampRecordID: tree.MustBeDUuid(row[3]).UUID,
+	}, nil
+}
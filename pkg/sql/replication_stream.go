@@ -0,0 +1,843 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var replicationKeepaliveInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.pgrepl.keepalive_interval",
+	"the interval at which keepalive messages are sent to logical replication "+
+		"clients; the progress confirmed by the clients is persisted at most once "+
+		"per interval",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+var replicationMaxBufferSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.pgrepl.max_buffer_size",
+	"the maximum memory a logical replication stream may use to buffer the "+
+		"changes which are not known to be complete yet; the stream fails if it "+
+		"is exceeded",
+	64<<20,
+)
+
+// replicationStream streams the changes to the tables of a set of
+// publications to a client with the pgoutput plugin, for START_REPLICATION.
+//
+// Changes are read with a rangefeed over the primary indexes of the published
+// tables. They are buffered until the frontier of the rangefeed passes them,
+// and are then sent to the client.
+//
+// The rangefeed does not tell which transaction wrote a change, so the
+// transactions sent to the client are synthetic: all the changes with the
+// same LSN are sent as one transaction. The LSN of a change is the wall time
+// of its MVCC timestamp (see lsnutil), so the changes of a transaction are
+// always sent together, but the changes of unrelated transactions which
+// committed at the same wall time are merged into a single one. The xids of
+// the synthetic transactions are assigned sequentially, and are only unique
+// within a stream.
+type replicationStream struct {
+	cfg       *ExecutorConfig
+	sd        *sessiondata.SessionData
+	parentMon *mon.BytesMonitor
+	stmt      *pgrepltree.StartReplication
+	conn      pgwirebase.Conn
+	res       ReplicationResult
+
+	slot             replicationSlot
+	publicationNames []string
+	// tables are the published tables, keyed by ID.
+	tables map[descpb.ID]*publishedTable
+	spans  []roachpb.Span
+
+	// buffered are the changes received from the rangefeed which have not
+	// been sent yet. Their memory is accounted for in bufferAcc, which is
+	// limited by sql.pgrepl.max_buffer_size.
+	buffered  []*kvpb.RangeFeedValue
+	bufferAcc mon.BoundAccount
+	// nextXID is the xid of the next synthetic transaction.
+	nextXID uint32
+	// sentLSN is the position up to which all the changes have been sent to
+	// the client.
+	sentLSN lsn.LSN
+	// confirmedLSN is the position up to which the client has confirmed that
+	// it durably stored the changes.
+	confirmedLSN lsn.LSN
+	// persistedLSN is the confirmed position stored in the slot.
+	persistedLSN  lsn.LSN
+	lastPersisted time.Time
+
+	enc    pgoutput.Encoder
+	msgBuf []byte
+	fmtCtx *tree.FmtCtx
+	alloc  tree.DatumAlloc
+}
+
+// publishedTable is a table whose changes are streamed.
+type publishedTable struct {
+	schemaName string
+	// decoder decodes the rows of the version of the table which was used
+	// for the last change. It is nil if no change has been sent yet.
+	decoder *replicationRowDecoder
+	// relationSent is set once the Relation message for the decoder has been
+	// sent to the client.
+	relationSent bool
+}
+
+// replicationRowDecoder decodes the rows of a version of a published table.
+type replicationRowDecoder struct {
+	desc     catalog.TableDescriptor
+	relation pgoutput.Relation
+	fetcher  row.Fetcher
+}
+
+// replicationEvent is sent by the rangefeed to the stream.
+type replicationEvent struct {
+	value *kvpb.RangeFeedValue
+	// frontier is set if the frontier of the rangefeed advanced.
+	frontier hlc.Timestamp
+}
+
+// clientMessage is a message read from the client during streaming.
+type clientMessage struct {
+	typ  pgwirebase.ClientMessageType
+	data []byte
+	err  error
+}
+
+func newReplicationStream(
+	cfg *ExecutorConfig,
+	sd *sessiondata.SessionData,
+	parentMon *mon.BytesMonitor,
+	stmt *pgrepltree.StartReplication,
+	conn pgwirebase.Conn,
+	res ReplicationResult,
+) *replicationStream {
+	return &replicationStream{
+		cfg:       cfg,
+		sd:        sd,
+		parentMon: parentMon,
+		stmt:      stmt,
+		conn:      conn,
+		res:       res,
+		// Postgres reserves the xids below 3.
+		nextXID: 3,
+		fmtCtx: tree.NewFmtCtx(
+			tree.FmtPgwireText,
+			tree.FmtLocation(sd.GetLocation()),
+			tree.FmtDataConversionConfig(sd.DataConversionConfig),
+		),
+	}
+}
+
+// run streams changes until the client ends the stream.
+func (s *replicationStream) run(ctx context.Context) error {
+	if err := s.init(ctx); err != nil {
+		return err
+	}
+	if err := s.res.SendCopyBoth(ctx); err != nil {
+		return err
+	}
+
+	bufferMon := mon.NewMonitorInheritWithLimit(
+		"logical-replication-buffer", replicationMaxBufferSize.Get(&s.cfg.Settings.SV),
+		s.parentMon, false, /* longLiving */
+	)
+	bufferMon.StartNoReserved(ctx, s.parentMon)
+	defer bufferMon.Stop(ctx)
+	s.bufferAcc = bufferMon.MakeBoundAccount()
+	defer s.bufferAcc.Close(ctx)
+
+	// The client sends its status updates while we are streaming, so they are
+	// read by a separate goroutine. It stops once the client ends the stream or
+	// the connection fails, and we always wait for that before handing the
+	// connection back to the network routine.
+	clientMsgs := make(chan clientMessage)
+	go s.readClientMessages(clientMsgs)
+	clientDone, streamErr := s.stream(ctx, clientMsgs)
+	if !clientDone {
+		// Ask the client to end the stream. A client which receives CopyDone must
+		// reply with CopyDone.
+		_ = s.res.SendCopyDone(ctx)
+		for m := range clientMsgs {
+			if m.err != nil || m.typ == pgwirebase.ClientMsgCopyDone || m.typ == pgwirebase.ClientMsgCopyFail {
+				break
+			}
+		}
+	}
+	if streamErr != nil {
+		return streamErr
+	}
+	return s.persistProgress(ctx)
+}
+
+// init reads the slot and the publications, and determines the tables to
+// stream.
+func (s *replicationStream) init(ctx context.Context) error {
+	if err := checkLogicalReplicationSupported(ctx, s.cfg.Settings); err != nil {
+		return err
+	}
+	if err := checkLogicalReplicationConnection(s.sd); err != nil {
+		return err
+	}
+	if s.stmt.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.New("physical replication", "physical replication is not supported")
+	}
+	if s.stmt.Slot == "" {
+		return pgerror.New(pgcode.Syntax, "logical replication requires a replication slot")
+	}
+	if err := s.parseOptions(); err != nil {
+		return err
+	}
+
+	if err := s.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		s.tables = make(map[descpb.ID]*publishedTable)
+		s.spans = s.spans[:0]
+		slot, err := getReplicationSlot(ctx, txn, string(s.stmt.Slot))
+		if err != nil {
+			return err
+		}
+		s.slot = slot
+		db, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Database(ctx, s.sd.Database)
+		if err != nil {
+			return err
+		}
+		if db.GetID() != slot.databaseID {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", slot.name)
+		}
+		pubs, err := getPublications(ctx, txn, db.GetID(), s.publicationNames)
+		if err != nil {
+			return err
+		}
+		var seen catalog.DescriptorIDSet
+		for _, pub := range pubs {
+			tables, err := getPublishedTables(ctx, txn.Descriptors(), txn.KV(), db, pub)
+			if err != nil {
+				return err
+			}
+			for _, table := range tables {
+				if seen.Contains(table.GetID()) {
+					continue
+				}
+				seen.Add(table.GetID())
+				if len(table.GetFamilies()) > 1 {
+					return unimplemented.Newf("logical replication column families",
+						"logical replication of table %q with multiple column families", table.GetName())
+				}
+				sc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Schema(ctx, table.GetParentSchemaID())
+				if err != nil {
+					return err
+				}
+				s.tables[table.GetID()] = &publishedTable{schemaName: sc.GetName()}
+				s.spans = append(s.spans, table.PrimaryIndexSpan(s.cfg.Codec))
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Changes at or before the position confirmed by the client are not sent
+	// again.
+	s.sentLSN = s.stmt.LSN
+	if s.slot.confirmedFlushLSN > s.sentLSN {
+		s.sentLSN = s.slot.confirmedFlushLSN
+	}
+	s.confirmedLSN = s.slot.confirmedFlushLSN
+	s.persistedLSN = s.slot.confirmedFlushLSN
+	return nil
+}
+
+// parseOptions parses the options of the pgoutput plugin.
+func (s *replicationStream) parseOptions() error {
+	seen := make(map[string]bool, len(s.stmt.Options))
+	for _, o := range s.stmt.Options {
+		key := string(o.Key)
+		if seen[key] {
+			return pgerror.Newf(pgcode.Syntax, "conflicting or redundant options")
+		}
+		seen[key] = true
+		val := replicationOptionValue(o)
+		switch key {
+		case "proto_version":
+			if val != strconv.Itoa(pgoutput.ProtocolVersion) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d",
+					val, pgoutput.ProtocolVersion)
+			}
+		case "publication_names":
+			for _, name := range strings.Split(val, ",") {
+				name = strings.TrimSpace(name)
+				if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+					name = name[1 : len(name)-1]
+				} else {
+					name = strings.ToLower(name)
+				}
+				if name == "" {
+					return pgerror.New(pgcode.InvalidParameterValue, "invalid publication_names syntax")
+				}
+				s.publicationNames = append(s.publicationNames, name)
+			}
+		case "binary", "messages", "streaming":
+			enabled, err := strconv.ParseBool(val)
+			if val == "on" || val == "off" {
+				enabled, err = val == "on", nil
+			}
+			if err != nil {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value for parameter %q: %q", key, val)
+			}
+			if enabled {
+				return unimplemented.Newf("pgoutput "+key, "pgoutput option %s", key)
+			}
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized pgoutput option: %s", key)
+		}
+	}
+	if !seen["proto_version"] {
+		return pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if !seen["publication_names"] {
+		return pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return nil
+}
+
+// replicationOptionValue returns the value of the option as a string.
+func replicationOptionValue(o pgrepltree.Option) string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
+
+// readClientMessages reads the messages sent by the client until it ends the
+// stream or the connection fails.
+func (s *replicationStream) readClientMessages(msgs chan<- clientMessage) {
+	defer close(msgs)
+	readBuf := pgwirebase.MakeReadBuffer(
+		pgwirebase.ReadBufferOptionWithClusterSettings(&s.cfg.Settings.SV),
+	)
+	for {
+		typ, _, err := readBuf.ReadTypedMsg(s.conn.Rd())
+		m := clientMessage{typ: typ, err: err}
+		if err == nil && (typ == pgwirebase.ClientMsgCopyData || typ == pgwirebase.ClientMsgCopyFail) {
+			m.data = append([]byte(nil), readBuf.Msg...)
+		}
+		msgs <- m
+		if err != nil || typ == pgwirebase.ClientMsgCopyDone || typ == pgwirebase.ClientMsgCopyFail {
+			return
+		}
+	}
+}
+
+// stream sends changes to the client until the client ends the stream or an
+// error occurs. clientDone is set if the client ended the stream, in which
+// case no more messages will be read from it.
+func (s *replicationStream) stream(
+	ctx context.Context, clientMsgs <-chan clientMessage,
+) (clientDone bool, _ error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan replicationEvent, 128)
+	rangefeedErr := make(chan error, 1)
+	if len(s.spans) > 0 {
+		send := func(ctx context.Context, ev replicationEvent) {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+			}
+		}
+		rf, err := s.cfg.RangeFeedFactory.RangeFeed(
+			ctx,
+			"logical-replication-"+s.slot.name,
+			s.spans,
+			lsnutil.LSNToHLC(s.sentLSN),
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				send(ctx, replicationEvent{value: value})
+			},
+			rangefeed.WithDiff(true),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+				send(ctx, replicationEvent{frontier: ts})
+			}),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				select {
+				case rangefeedErr <- err:
+				default:
+				}
+			}),
+		)
+		if err != nil {
+			return false, err
+		}
+		defer rf.Close()
+	}
+
+	keepalive := time.NewTicker(replicationKeepaliveInterval.Get(&s.cfg.Settings.SV))
+	defer keepalive.Stop()
+	if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+		return false, err
+	}
+	for {
+		select {
+		case ev := <-events:
+			if ev.value != nil {
+				if err := s.bufferChange(ctx, ev.value); err != nil {
+					return false, err
+				}
+				continue
+			}
+			if err := s.sendResolved(ctx, ev.frontier); err != nil {
+				return false, err
+			}
+		case m, ok := <-clientMsgs:
+			if !ok {
+				return true, errors.AssertionFailedf("client message reader stopped unexpectedly")
+			}
+			if m.err != nil {
+				return true, m.err
+			}
+			switch m.typ {
+			case pgwirebase.ClientMsgCopyData:
+				if err := s.handleStandbyMessage(ctx, m.data); err != nil {
+					return false, err
+				}
+			case pgwirebase.ClientMsgCopyDone:
+				return true, s.res.SendCopyDone(ctx)
+			case pgwirebase.ClientMsgCopyFail:
+				return true, pgerror.Newf(pgcode.QueryCanceled,
+					"COPY from stdin failed: %s", strings.TrimSuffix(string(m.data), "\x00"))
+			case pgwirebase.ClientMsgFlush, pgwirebase.ClientMsgSync:
+				// Like Postgres, ignore these during streaming.
+			default:
+				return false, pgwirebase.NewProtocolViolationErrorf(
+					"unexpected message type %s during replication", m.typ)
+			}
+		case <-keepalive.C:
+			if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return false, err
+			}
+		case err := <-rangefeedErr:
+			return false, err
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+}
+
+// bufferChange buffers a change received from the rangefeed until it is
+// known to be complete.
+func (s *replicationStream) bufferChange(ctx context.Context, v *kvpb.RangeFeedValue) error {
+	// The rangefeed may deliver a change more than once.
+	if lsnutil.HLCToLSN(v.Value.Timestamp) <= s.sentLSN {
+		return nil
+	}
+	if err := s.bufferAcc.Grow(ctx, int64(v.Size())); err != nil {
+		return errors.WithHint(
+			errors.Wrap(err, "buffering logical replication changes"),
+			"The resolved timestamp of the published tables is not advancing. "+
+				"Consider increasing sql.pgrepl.max_buffer_size.",
+		)
+	}
+	s.buffered = append(s.buffered, v)
+	return nil
+}
+
+// sendResolved sends all the buffered changes which are known to be complete
+// once the rangefeed frontier reached the given timestamp.
+func (s *replicationStream) sendResolved(ctx context.Context, frontier hlc.Timestamp) error {
+	// Changes at the wall time of the frontier may still arrive, so only the
+	// LSNs before it are complete.
+	resolved := lsn.LSN(frontier.WallTime - 1)
+	if resolved <= s.sentLSN {
+		return nil
+	}
+	txns := groupSyntheticTxns(s.buffered, resolved)
+	var sent int
+	var sentBytes int64
+	for _, txn := range txns {
+		if err := s.sendTransaction(ctx, lsnutil.HLCToLSN(txn[0].Value.Timestamp), txn); err != nil {
+			return err
+		}
+		for _, v := range txn {
+			sentBytes += int64(v.Size())
+		}
+		sent += len(txn)
+	}
+	s.buffered = append(s.buffered[:0], s.buffered[sent:]...)
+	s.bufferAcc.Shrink(ctx, sentBytes)
+	s.sentLSN = resolved
+	return nil
+}
+
+// groupSyntheticTxns sorts the given changes, and returns those with an LSN at
+// or before resolved grouped into synthetic transactions, in order. All the
+// changes with the same LSN form one transaction, in which they are ordered by
+// timestamp and then by key.
+func groupSyntheticTxns(values []*kvpb.RangeFeedValue, resolved lsn.LSN) [][]*kvpb.RangeFeedValue {
+	sort.Slice(values, func(i, j int) bool {
+		a, b := values[i], values[j]
+		if c := a.Value.Timestamp.Compare(b.Value.Timestamp); c != 0 {
+			return c < 0
+		}
+		return a.Key.Compare(b.Key) < 0
+	})
+	var txns [][]*kvpb.RangeFeedValue
+	for i := 0; i < len(values); {
+		txnLSN := lsnutil.HLCToLSN(values[i].Value.Timestamp)
+		if txnLSN > resolved {
+			break
+		}
+		j := i + 1
+		for j < len(values) && lsnutil.HLCToLSN(values[j].Value.Timestamp) == txnLSN {
+			j++
+		}
+		txns = append(txns, values[i:j])
+		i = j
+	}
+	return txns
+}
+
+// replicationChange is a decoded change to a row of a published table.
+type replicationChange struct {
+	table          *publishedTable
+	oldRow, newRow tree.Datums
+}
+
+// sendTransaction sends the given changes, which all have the given LSN, as a
+// single synthetic transaction.
+func (s *replicationStream) sendTransaction(
+	ctx context.Context, txnLSN lsn.LSN, values []*kvpb.RangeFeedValue,
+) error {
+	changes := make([]replicationChange, 0, len(values))
+	for _, v := range values {
+		c, ok, err := s.decodeChange(ctx, v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	commitTime := timeutil.Unix(0, int64(txnLSN))
+	xid := s.nextXID
+	s.nextXID++
+	if s.nextXID < 3 {
+		s.nextXID = 3
+	}
+	if err := s.send(ctx, txnLSN, s.enc.Begin(txnLSN, commitTime, xid)); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		dec := c.table.decoder
+		if !c.table.relationSent {
+			if err := s.send(ctx, txnLSN, s.enc.Relation(dec.relation)); err != nil {
+				return err
+			}
+			c.table.relationSent = true
+		}
+		relID := dec.relation.ID
+		var msg []byte
+		switch {
+		case c.oldRow == nil:
+			msg = s.enc.Insert(relID, s.makeTuple(dec, c.newRow, false /* keyOnly */))
+		case c.newRow != nil:
+			// The primary key of a row cannot change without deleting it, so the
+			// old key does not need to be sent.
+			msg = s.enc.Update(relID, nil /* key */, s.makeTuple(dec, c.newRow, false /* keyOnly */))
+		default:
+			msg = s.enc.Delete(relID, s.makeTuple(dec, c.oldRow, true /* keyOnly */))
+		}
+		if err := s.send(ctx, txnLSN, msg); err != nil {
+			return err
+		}
+	}
+	return s.send(ctx, txnLSN, s.enc.Commit(txnLSN, txnLSN, commitTime))
+}
+
+// decodeChange decodes a change received from the rangefeed. ok is false if
+// the change should not be sent, e.g. because it deleted a row which did not
+// exist.
+func (s *replicationStream) decodeChange(
+	ctx context.Context, v *kvpb.RangeFeedValue,
+) (_ replicationChange, ok bool, _ error) {
+	_, id, err := s.cfg.Codec.DecodeTablePrefix(v.Key)
+	if err != nil {
+		return replicationChange{}, false, err
+	}
+	table, ok := s.tables[descpb.ID(id)]
+	if !ok {
+		return replicationChange{}, false, nil
+	}
+	dec, err := s.rowDecoder(ctx, descpb.ID(id), table, v.Value.Timestamp)
+	if err != nil || dec == nil {
+		return replicationChange{}, false, err
+	}
+	c := replicationChange{table: table}
+	if v.Value.IsPresent() {
+		if c.newRow, err = dec.decode(ctx, v.Key, v.Value); err != nil {
+			return replicationChange{}, false, err
+		}
+	}
+	if v.PrevValue.IsPresent() {
+		if c.oldRow, err = dec.decode(ctx, v.Key, v.PrevValue); err != nil {
+			return replicationChange{}, false, err
+		}
+	}
+	return c, c.oldRow != nil || c.newRow != nil, nil
+}
+
+// rowDecoder returns the decoder for the version of the table at the given
+// timestamp. nil is returned if the table was dropped.
+func (s *replicationStream) rowDecoder(
+	ctx context.Context, id descpb.ID, table *publishedTable, ts hlc.Timestamp,
+) (*replicationRowDecoder, error) {
+	desc, err := s.tableDescAt(ctx, id, ts)
+	if err != nil {
+		if errors.Is(err, catalog.ErrDescriptorDropped) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if dec := table.decoder; dec != nil && dec.desc.GetVersion() == desc.GetVersion() &&
+		catalog.UserDefinedTypeColsHaveSameVersion(dec.desc, desc) {
+		return dec, nil
+	}
+	if len(desc.GetFamilies()) > 1 {
+		return nil, unimplemented.Newf("logical replication column families",
+			"logical replication of table %q with multiple column families", desc.GetName())
+	}
+	dec := &replicationRowDecoder{
+		desc: desc,
+		relation: pgoutput.Relation{
+			ID:              uint32(desc.GetID()),
+			Namespace:       table.schemaName,
+			Name:            desc.GetName(),
+			ReplicaIdentity: pgoutput.ReplicaIdentityDefault,
+		},
+	}
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	var colIDs []descpb.ColumnID
+	for _, col := range desc.PublicColumns() {
+		isKey := keyCols.Contains(col.GetID())
+		// Hidden columns are only sent if they are part of the primary key, as
+		// is the case for rowid.
+		if col.IsVirtual() || col.IsInaccessible() || (col.IsHidden() && !isKey) {
+			continue
+		}
+		colIDs = append(colIDs, col.GetID())
+		dec.relation.Columns = append(dec.relation.Columns, pgoutput.Column{
+			Name:    col.GetName(),
+			TypeOID: col.GetType().Oid(),
+			TypeMod: col.GetType().TypeModifier(),
+			Key:     isKey,
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, s.cfg.Codec, desc, desc.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return nil, err
+	}
+	if err := dec.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &s.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	table.decoder = dec
+	table.relationSent = false
+	return dec, nil
+}
+
+// tableDescAt returns the descriptor of the table at the given timestamp, with
+// its user-defined types hydrated.
+func (s *replicationStream) tableDescAt(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (catalog.TableDescriptor, error) {
+	leased, err := s.cfg.LeaseManager.Acquire(ctx, ts, id)
+	if err != nil {
+		return nil, err
+	}
+	desc := leased.Underlying().(catalog.TableDescriptor)
+	// Immediately release the lease, since we only need it for the exact
+	// timestamp requested.
+	leased.Release(ctx)
+	if !catalog.MaybeRequiresHydration(desc) {
+		return desc, nil
+	}
+	if err := s.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		desc, err = txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// decode decodes the row stored in the given primary index KV.
+func (d *replicationRowDecoder) decode(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (tree.Datums, error) {
+	if err := d.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{
+		KVs: []roachpb.KeyValue{{Key: key, Value: value}},
+	}); err != nil {
+		return nil, err
+	}
+	datums, err := d.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded for key %s", key)
+	}
+	// The fetcher reuses the slice for the next row.
+	return append(tree.Datums(nil), datums...), nil
+}
+
+// makeTuple formats the given row. If keyOnly is set, only the values of the
+// primary key columns are included.
+func (s *replicationStream) makeTuple(
+	dec *replicationRowDecoder, row tree.Datums, keyOnly bool,
+) pgoutput.Tuple {
+	t := make(pgoutput.Tuple, len(row))
+	for i, d := range row {
+		if d == tree.DNull || (keyOnly && !dec.relation.Columns[i].Key) {
+			t[i].Null = true
+			continue
+		}
+		s.fmtCtx.Buffer.Reset()
+		s.fmtCtx.FormatNode(d)
+		t[i].Data = append([]byte(nil), s.fmtCtx.Buffer.Bytes()...)
+	}
+	return t
+}
+
+// send sends a message of the logical replication protocol to the client.
+func (s *replicationStream) send(ctx context.Context, l lsn.LSN, payload []byte) error {
+	s.msgBuf = pgoutput.XLogData(s.msgBuf, l, l, timeutil.Now(), payload)
+	return s.res.SendCopyData(ctx, s.msgBuf, false /* isHeader */)
+}
+
+func (s *replicationStream) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	s.msgBuf = pgoutput.PrimaryKeepalive(s.msgBuf, s.sentLSN, timeutil.Now(), replyRequested)
+	return s.res.SendCopyData(ctx, s.msgBuf, true /* isHeader */)
+}
+
+// handleStandbyMessage processes a message sent by the client.
+func (s *replicationStream) handleStandbyMessage(ctx context.Context, data []byte) error {
+	status, err := pgoutput.ParseStandbyMessage(data)
+	if errors.Is(err, pgoutput.ErrUnknownMessage) {
+		return nil
+	}
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.ProtocolViolation, "invalid standby message")
+	}
+	// Clients may confirm positions up to the end of the stream they were
+	// told about in keepalives, but never past what was sent.
+	flushed := status.FlushLSN
+	if flushed > s.sentLSN {
+		flushed = s.sentLSN
+	}
+	if flushed > s.confirmedLSN {
+		s.confirmedLSN = flushed
+	}
+	if timeutil.Since(s.lastPersisted) >= replicationKeepaliveInterval.Get(&s.cfg.Settings.SV) {
+		if err := s.persistProgress(ctx); err != nil {
+			return err
+		}
+	}
+	if status.ReplyRequested {
+		return s.sendKeepalive(ctx, false /* replyRequested */)
+	}
+	return nil
+}
+
+// persistProgress stores the position confirmed by the client in the slot,
+// and allows the history before it to be garbage collected.
+func (s *replicationStream) persistProgress(ctx context.Context) error {
+	if s.confirmedLSN <= s.persistedLSN {
+		return nil
+	}
+	if err := s.cfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		n, err := txn.ExecEx(
+			ctx,
+			"update-replication-slot",
+			txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $1 WHERE slot_name = $2`,
+			int64(s.confirmedLSN), s.slot.name,
+		)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", s.slot.name)
+		}
+		return s.cfg.ProtectedTimestampProvider.WithTxn(txn).UpdateTimestamp(
+			ctx, s.slot.protectedTimestampRecordID, lsnutil.LSNToHLC(s.confirmedLSN),
+		)
+	}); err != nil {
+		return err
+	}
+	s.persistedLSN = s.confirmedLSN
+	s.lastPersisted = timeutil.Now()
+	return nil
+}
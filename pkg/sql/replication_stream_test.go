@@ -0,0 +1,94 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestRangeFeedValue(key string, ts hlc.Timestamp) *kvpb.RangeFeedValue {
+	v := roachpb.MakeValueFromString("v")
+	v.Timestamp = ts
+	return &kvpb.RangeFeedValue{Key: roachpb.Key(key), Value: v}
+}
+
+// TestGroupSyntheticTxns checks that the changes streamed for logical
+// replication are grouped into one synthetic transaction per LSN, even when
+// they were written by unrelated transactions.
+func TestGroupSyntheticTxns(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	a := makeTestRangeFeedValue("a", hlc.Timestamp{WallTime: 10, Logical: 1})
+	b := makeTestRangeFeedValue("b", hlc.Timestamp{WallTime: 10})
+	c := makeTestRangeFeedValue("c", hlc.Timestamp{WallTime: 20})
+	d := makeTestRangeFeedValue("d", hlc.Timestamp{WallTime: 20})
+	e := makeTestRangeFeedValue("e", hlc.Timestamp{WallTime: 30})
+
+	values := []*kvpb.RangeFeedValue{e, d, a, c, b}
+	txns := groupSyntheticTxns(values, lsn.LSN(25))
+	// a and b were written by different transactions at the same wall time,
+	// so they share an LSN and are sent as one transaction. e is not resolved
+	// yet.
+	require.Equal(t, [][]*kvpb.RangeFeedValue{{b, a}, {c, d}}, txns)
+	require.Equal(t, []*kvpb.RangeFeedValue{b, a, c, d, e}, values)
+
+	require.Empty(t, groupSyntheticTxns(values, lsn.LSN(5)))
+	require.Len(t, groupSyntheticTxns(values, lsn.LSN(30)), 3)
+}
+
+// TestReplicationStreamBufferLimit checks that a stream fails instead of
+// buffering more changes than allowed by its memory budget.
+func TestReplicationStreamBufferLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	v := makeTestRangeFeedValue("a", hlc.Timestamp{WallTime: 10})
+	monitor := mon.NewMonitor(mon.Options{
+		Name:      "test",
+		Limit:     int64(3 * v.Size()),
+		Increment: 1,
+		Settings:  st,
+	})
+	monitor.Start(ctx, nil, mon.NewStandaloneBudget(math.MaxInt64))
+	defer monitor.Stop(ctx)
+
+	s := &replicationStream{bufferAcc: monitor.MakeBoundAccount()}
+	defer s.bufferAcc.Close(ctx)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.bufferChange(ctx, v))
+	}
+	err := s.bufferChange(ctx, v)
+	require.Error(t, err)
+	require.Equal(t, pgcode.OutOfMemory, pgerror.GetPGCode(err))
+	require.Len(t, s.buffered, 3)
+
+	// Changes which were already sent are not buffered again.
+	s.sentLSN = lsn.LSN(10)
+	require.NoError(t, s.bufferChange(ctx, v))
+	require.Len(t, s.buffered, 3)
+}
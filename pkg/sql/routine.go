@@ -198,6 +198,12 @@ type routineGenerator struct {
 		expr *tree.RoutineExpr
 		args tree.Datums
 	}
+	// resultBuffer accumulates the rows produced by RETURN NEXT and RETURN
+	// QUERY statements in a set-returning PL/pgSQL routine. It is shared by all
+	// routines that are executed on behalf of the set-returning routine, and is
+	// only closed by the routineGenerator that owns it.
+	resultBuffer     *routineResultBuffer
+	ownsResultBuffer bool
 }
 
 var _ eval.ValueGenerator = &routineGenerator{}
@@ -210,15 +216,26 @@ func (g *routineGenerator) init(p *planner, expr *tree.RoutineExpr, args tree.Da
 		expr: expr,
 		args: args,
 	}
+	if expr.OwnsResultBuffer {
+		g.ownsResultBuffer = true
+	} else if parent, ok := p.EvalContext().RoutineSender.(*routineGenerator); ok {
+		// A nested routine adds rows to the result buffer of the set-returning
+		// routine that invoked it, if any.
+		g.resultBuffer = parent.resultBuffer
+	}
 }
 
-// reset closes and re-initializes a routineGenerator for reuse.
+// reset closes and re-initializes a routineGenerator for reuse. The result
+// buffer, if any, is preserved.
 // TODO(drewk): we should hold on to memory for the row container.
 func (g *routineGenerator) reset(
 	ctx context.Context, p *planner, expr *tree.RoutineExpr, args tree.Datums,
 ) {
+	resultBuffer, ownsResultBuffer := g.resultBuffer, g.ownsResultBuffer
+	g.resultBuffer, g.ownsResultBuffer = nil, false
 	g.Close(ctx)
 	g.init(p, expr, args)
+	g.resultBuffer, g.ownsResultBuffer = resultBuffer, ownsResultBuffer
 }
 
 // ResolvedType is part of the eval.ValueGenerator interface.
@@ -259,6 +276,13 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		retTypes = []*types.T{g.expr.ResolvedType()}
 	}
 	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
+	if g.ownsResultBuffer && g.resultBuffer == nil {
+		g.resultBuffer = &routineResultBuffer{
+			expandTuple: g.expr.MultiColOutput,
+			numCols:     len(retTypes),
+		}
+		g.resultBuffer.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine_result_buffer" /* opName */)
+	}
 
 	// If this is the start of a PLpgSQL block with an exception handler, create a
 	// savepoint.
@@ -296,9 +320,24 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 
 		var w rowResultWriter
 		openCursor := stmtIdx == 1 && g.expr.CursorDeclaration != nil
-		if isFinalPlan {
+		addToResultBuffer := stmtIdx == 1 && g.expr.AddsToResultBuffer
+		if isFinalPlan && g.ownsResultBuffer {
+			// The output of a set-returning PL/pgSQL routine is the contents of its
+			// result buffer, so the result of the final statement is not needed.
+			w = &droppingResultWriter{}
+		} else if isFinalPlan {
 			// The result of this statement is the routine's output.
 			w = rrw
+		} else if addToResultBuffer {
+			// The result of the first statement is added to the result of the
+			// set-returning routine that invoked this routine.
+			if g.resultBuffer == nil {
+				return errors.AssertionFailedf("expected a result buffer for RETURN NEXT or RETURN QUERY")
+			}
+			// Clear any error left by a previous statement that was caught by an
+			// exception handler.
+			g.resultBuffer.err = nil
+			w = g.resultBuffer
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
 			cursorHelper, err = g.newCursorHelper(plan.(*planComponents))
@@ -341,7 +380,11 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		}
 		return g.handleException(ctx, err)
 	}
-	g.rci = newRowContainerIterator(ctx, g.rch)
+	if g.ownsResultBuffer {
+		g.rci = newRowContainerIterator(ctx, g.resultBuffer.rch)
+	} else {
+		g.rci = newRowContainerIterator(ctx, g.rch)
+	}
 	return nil
 }
 
@@ -492,6 +535,9 @@ func (g *routineGenerator) Close(ctx context.Context) {
 		g.rci.Close()
 	}
 	g.rch.Close(ctx)
+	if g.ownsResultBuffer && g.resultBuffer != nil {
+		g.resultBuffer.rch.Close(ctx)
+	}
 	*g = routineGenerator{}
 }
 
@@ -527,6 +573,49 @@ func (g *routineGenerator) SendDeferredRoutine(nestedRoutine *tree.RoutineExpr,
 	g.deferredRoutine.args = args
 }
 
+// routineResultBuffer is a rowResultWriter that accumulates the result rows of
+// a set-returning PL/pgSQL routine. Each row is produced by a RETURN NEXT or
+// RETURN QUERY statement as a single column with the routine's return type.
+type routineResultBuffer struct {
+	rch rowContainerHelper
+	// expandTuple is true if the routine returns multiple columns, in which case
+	// the elements of each tuple row are added as individual columns.
+	expandTuple bool
+	numCols     int
+	err         error
+}
+
+var _ rowResultWriter = &routineResultBuffer{}
+
+// AddRow is part of the rowResultWriter interface.
+func (r *routineResultBuffer) AddRow(ctx context.Context, row tree.Datums) error {
+	if r.expandTuple && len(row) == 1 {
+		if row[0] == tree.DNull {
+			// A NULL composite value is expanded into NULL columns.
+			row = make(tree.Datums, r.numCols)
+			for i := range row {
+				row[i] = tree.DNull
+			}
+		} else if tuple, ok := tree.AsDTuple(row[0]); ok {
+			row = tuple.D
+		}
+	}
+	return r.rch.AddRow(ctx, row)
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (r *routineResultBuffer) SetRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (r *routineResultBuffer) SetError(err error) {
+	r.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (r *routineResultBuffer) Err() error {
+	return r.err
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
@@ -560,6 +649,15 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 		return nil, errors.AssertionFailedf("expected non-null cursor name")
 	}
 	cursorName := tree.Name(tree.MustBeDString(g.args[open.NameArgIdx]))
+	return g.p.newPLpgSQLCursorHelper(cursorName, open.CursorSQL, plan.main.planColumns())
+}
+
+// newPLpgSQLCursorHelper returns a plpgsqlCursorHelper that can be used to
+// buffer the rows with the given columns for a cursor opened by a PLpgSQL
+// routine.
+func (p *planner) newPLpgSQLCursorHelper(
+	cursorName tree.Name, cursorSQL string, cols colinfo.ResultColumns,
+) (*plpgsqlCursorHelper, error) {
 	if cursorName == "" {
 		// Specifying the empty string as a cursor name conflicts with the
 		// "unnamed" portal, which always exists.
@@ -567,26 +665,25 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 	}
 	// Use context.Background(), since the cursor can outlive the context in which
 	// it was created.
-	planCols := plan.main.planColumns()
 	cursorHelper := &plpgsqlCursorHelper{
 		ctx:        context.Background(),
 		cursorName: cursorName,
-		resultCols: make(colinfo.ResultColumns, len(planCols)),
-		cursorSql:  open.CursorSQL,
+		resultCols: make(colinfo.ResultColumns, len(cols)),
+		cursorSql:  cursorSQL,
 	}
-	copy(cursorHelper.resultCols, planCols)
-	mon := g.p.Mon()
-	if !g.p.SessionData().CloseCursorsAtCommit {
-		mon = g.p.sessionMonitor
+	copy(cursorHelper.resultCols, cols)
+	mon := p.Mon()
+	if !p.SessionData().CloseCursorsAtCommit {
+		mon = p.sessionMonitor
 		if mon == nil {
 			return nil, errors.AssertionFailedf("cannot open cursor WITH HOLD without an active session")
 		}
 	}
 	cursorHelper.container.InitWithParentMon(
 		cursorHelper.ctx,
-		getTypesFromResultColumns(planCols),
+		getTypesFromResultColumns(cols),
 		mon,
-		g.p.ExtendedEvalContextCopy(),
+		p.ExtendedEvalContextCopy(),
 		"routine_open_cursor", /* opName */
 	)
 	return cursorHelper, nil
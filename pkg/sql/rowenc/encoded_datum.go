@@ -320,12 +320,13 @@ func (ed *EncDatum) Encode(
 
 func mustUseValueEncodingForFingerprinting(t *types.T) bool {
 	switch t.Family() {
-	// Both TSQuery and TSVector types don't have key-encoding, so we must use
-	// the value encoding for them. JSON type now (as of 23.2) has key-encoding
+	// TSQuery, TSVector and Jsonpath types don't have key-encoding, so we must
+	// use the value encoding for them. JSON type now (as of 23.2) has key-encoding
 	// available, but for historical reasons we will keep on using the
 	// value-encoding (Fingerprint is used by hash routers, so changing its
 	// behavior can result in incorrect results in mixed version clusters).
-	case types.JsonFamily, types.TSQueryFamily, types.TSVectorFamily, types.PGVectorFamily:
+	case types.JsonFamily, types.TSQueryFamily, types.TSVectorFamily, types.PGVectorFamily,
+		types.JsonpathFamily:
 		return true
 	case types.ArrayFamily:
 		// Note that at time of this writing we don't support arrays of JSON
@@ -0,0 +1,246 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// MakeIndexFetchFilter returns the predicates of an IndexFetchSpec filter (see
+// fetchpb.IndexFetchSpec.Filter) implied by the given filter expression. Only
+// the conjuncts of the expression that are simple comparisons of a fetched
+// column with constants are converted; the others are ignored, so the result
+// is in general weaker than the expression.
+//
+// varToFetchedColumn maps the index of an IndexedVar in the expression to the
+// ordinal of the corresponding fetched column, if there is one.
+func MakeIndexFetchFilter(
+	spec *fetchpb.IndexFetchSpec,
+	expr tree.TypedExpr,
+	varToFetchedColumn func(varIdx int) (ordinal int, ok bool),
+) []fetchpb.IndexFetchSpec_Predicate {
+	res, _ := MakeExactIndexFetchFilter(spec, expr, varToFetchedColumn)
+	return res
+}
+
+// MakeExactIndexFetchFilter is like MakeIndexFetchFilter, but it also returns
+// whether all the conjuncts of the expression were converted, in which case a
+// row satisfies the predicates if and only if it satisfies the expression.
+func MakeExactIndexFetchFilter(
+	spec *fetchpb.IndexFetchSpec,
+	expr tree.TypedExpr,
+	varToFetchedColumn func(varIdx int) (ordinal int, ok bool),
+) (_ []fetchpb.IndexFetchSpec_Predicate, exact bool) {
+	var res []fetchpb.IndexFetchSpec_Predicate
+	exact = true
+	var addConjuncts func(expr tree.Expr)
+	addConjuncts = func(expr tree.Expr) {
+		expr = tree.StripParens(expr)
+		if and, ok := expr.(*tree.AndExpr); ok {
+			addConjuncts(and.Left)
+			addConjuncts(and.Right)
+			return
+		}
+		if p, ok := makeIndexFetchPredicate(spec, expr, varToFetchedColumn); ok {
+			res = append(res, p)
+		} else {
+			exact = false
+		}
+	}
+	addConjuncts(expr)
+	return res, exact
+}
+
+// makeIndexFetchPredicate converts the given expression to a predicate, if it
+// is one of:
+//   - <column> <op> <constant>, where <op> is =, !=, <, <=, > or >=;
+//   - <constant> <op> <column>;
+//   - <column> IS NULL or <column> IS NOT NULL;
+//   - <column> IN (<constant>, ...).
+func makeIndexFetchPredicate(
+	spec *fetchpb.IndexFetchSpec,
+	expr tree.Expr,
+	varToFetchedColumn func(varIdx int) (ordinal int, ok bool),
+) (p fetchpb.IndexFetchSpec_Predicate, ok bool) {
+	// fetchedColumn returns the ordinal of the fetched column referenced by the
+	// given expression, if it is a column with a type supported by the filter.
+	fetchedColumn := func(expr tree.Expr) (int, bool) {
+		v, ok := tree.StripParens(expr).(*tree.IndexedVar)
+		if !ok {
+			return 0, false
+		}
+		ord, ok := varToFetchedColumn(v.Idx)
+		if !ok || ord < 0 || ord >= len(spec.FetchedColumns) {
+			return 0, false
+		}
+		if !IndexFetchFilterSupportsType(spec.FetchedColumns[ord].Type) {
+			return 0, false
+		}
+		return ord, true
+	}
+	// encodeConstant encodes the given expression if it is a non-NULL constant
+	// comparable to the values of the given column.
+	encodeConstant := func(ord int, expr tree.Expr) ([]byte, bool) {
+		d, ok := tree.StripParens(expr).(tree.Datum)
+		if !ok || d == tree.DNull {
+			return nil, false
+		}
+		if !d.ResolvedType().Equivalent(spec.FetchedColumns[ord].Type) {
+			return nil, false
+		}
+		enc, err := valueside.Encode(nil /* appendTo */, valueside.NoColumnID, d, nil /* scratch */)
+		if err != nil {
+			return nil, false
+		}
+		return enc, true
+	}
+
+	switch t := expr.(type) {
+	case *tree.IsNullExpr:
+		if ord, ok := fetchedColumn(t.Expr); ok {
+			return fetchpb.IndexFetchSpec_Predicate{
+				FetchedColumnOrdinal: uint32(ord),
+				Op:                   fetchpb.IndexFetchSpec_Predicate_IS_NULL,
+			}, true
+		}
+
+	case *tree.IsNotNullExpr:
+		if ord, ok := fetchedColumn(t.Expr); ok {
+			return fetchpb.IndexFetchSpec_Predicate{
+				FetchedColumnOrdinal: uint32(ord),
+				Op:                   fetchpb.IndexFetchSpec_Predicate_IS_NOT_NULL,
+			}, true
+		}
+
+	case *tree.ComparisonExpr:
+		if t.Operator.IsExplicitOperator {
+			return p, false
+		}
+		switch sym := t.Operator.Symbol; sym {
+		case treecmp.IsNotDistinctFrom, treecmp.IsDistinctFrom:
+			ord, ok := fetchedColumn(t.Left)
+			if !ok || tree.StripParens(t.Right) != tree.DNull {
+				return p, false
+			}
+			p = fetchpb.IndexFetchSpec_Predicate{
+				FetchedColumnOrdinal: uint32(ord),
+				Op:                   fetchpb.IndexFetchSpec_Predicate_IS_NULL,
+			}
+			if sym == treecmp.IsDistinctFrom {
+				p.Op = fetchpb.IndexFetchSpec_Predicate_IS_NOT_NULL
+			}
+			return p, true
+
+		case treecmp.In:
+			ord, ok := fetchedColumn(t.Left)
+			if !ok {
+				return p, false
+			}
+			tuple, ok := tree.StripParens(t.Right).(*tree.DTuple)
+			if !ok {
+				return p, false
+			}
+			p = fetchpb.IndexFetchSpec_Predicate{
+				FetchedColumnOrdinal: uint32(ord),
+				Op:                   fetchpb.IndexFetchSpec_Predicate_IN,
+			}
+			for _, d := range tuple.D {
+				if d == tree.DNull {
+					// A NULL element never makes the predicate true.
+					continue
+				}
+				enc, ok := encodeConstant(ord, d)
+				if !ok {
+					return p, false
+				}
+				p.Values = append(p.Values, enc)
+			}
+			// An IN with only NULL elements is never true, but we leave it to
+			// the SQL layer.
+			return p, len(p.Values) > 0
+
+		case treecmp.EQ, treecmp.NE, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+			left, right := t.Left, t.Right
+			ord, ok := fetchedColumn(left)
+			if !ok {
+				// Try the commuted comparison.
+				left, right = right, left
+				if ord, ok = fetchedColumn(left); !ok {
+					return p, false
+				}
+				sym = commuteIndexFetchComparison(sym)
+			}
+			enc, ok := encodeConstant(ord, right)
+			if !ok {
+				return p, false
+			}
+			return fetchpb.IndexFetchSpec_Predicate{
+				FetchedColumnOrdinal: uint32(ord),
+				Op:                   indexFetchComparisonOps[sym],
+				Values:               [][]byte{enc},
+			}, true
+		}
+	}
+	return p, false
+}
+
+var indexFetchComparisonOps = map[treecmp.ComparisonOperatorSymbol]fetchpb.IndexFetchSpec_Predicate_Operator{
+	treecmp.EQ: fetchpb.IndexFetchSpec_Predicate_EQ,
+	treecmp.NE: fetchpb.IndexFetchSpec_Predicate_NE,
+	treecmp.LT: fetchpb.IndexFetchSpec_Predicate_LT,
+	treecmp.LE: fetchpb.IndexFetchSpec_Predicate_LE,
+	treecmp.GT: fetchpb.IndexFetchSpec_Predicate_GT,
+	treecmp.GE: fetchpb.IndexFetchSpec_Predicate_GE,
+}
+
+// commuteIndexFetchComparison returns the operator op' such that a op b is
+// equivalent to b op' a.
+func commuteIndexFetchComparison(
+	sym treecmp.ComparisonOperatorSymbol,
+) treecmp.ComparisonOperatorSymbol {
+	switch sym {
+	case treecmp.LT:
+		return treecmp.GT
+	case treecmp.LE:
+		return treecmp.GE
+	case treecmp.GT:
+		return treecmp.LT
+	case treecmp.GE:
+		return treecmp.LE
+	}
+	return sym
+}
+
+// IndexFetchFilterSupportsType returns whether the IndexFetchSpec filter can
+// have predicates on columns of the given type. Only the types whose values
+// can be compared without any session context are supported, and user-defined
+// types are excluded since the KV server cannot hydrate them.
+func IndexFetchFilterSupportsType(typ *types.T) bool {
+	if typ.UserDefined() {
+		return false
+	}
+	switch typ.Family() {
+	case types.StringFamily:
+		// CHAR and "char" values have special comparison semantics.
+		return typ.Oid() == oid.T_text || typ.Oid() == oid.T_varchar
+	case types.BoolFamily, types.IntFamily, types.FloatFamily, types.DecimalFamily,
+		types.BytesFamily, types.DateFamily, types.TimeFamily,
+		types.TimestampFamily, types.TimestampTZFamily, types.IntervalFamily,
+		types.UuidFamily:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeIndexFetchFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	spec := &fetchpb.IndexFetchSpec{
+		FetchedColumns: []fetchpb.IndexFetchSpec_Column{
+			{Name: "a", Type: types.Int},
+			{Name: "b", Type: types.String},
+			{Name: "c", Type: types.MakeChar(1)},
+		},
+	}
+	// The expressions below refer to the fetched columns in reverse order.
+	varToFetchedColumn := func(varIdx int) (int, bool) {
+		if varIdx < 0 || varIdx >= len(spec.FetchedColumns) {
+			return 0, false
+		}
+		return len(spec.FetchedColumns) - 1 - varIdx, true
+	}
+	a := tree.NewTypedOrdinalReference(2, types.Int)
+	b := tree.NewTypedOrdinalReference(1, types.String)
+	c := tree.NewTypedOrdinalReference(0, types.MakeChar(1))
+	cmp := func(sym treecmp.ComparisonOperatorSymbol, left, right tree.TypedExpr) tree.TypedExpr {
+		return tree.NewTypedComparisonExpr(treecmp.MakeComparisonOperator(sym), left, right)
+	}
+	enc := func(d tree.Datum) []byte {
+		res, err := valueside.Encode(nil /* appendTo */, valueside.NoColumnID, d, nil /* scratch */)
+		require.NoError(t, err)
+		return res
+	}
+
+	for _, tc := range []struct {
+		name     string
+		expr     tree.TypedExpr
+		expected []fetchpb.IndexFetchSpec_Predicate
+	}{
+		{
+			name: "comparison",
+			expr: cmp(treecmp.GT, a, tree.NewDInt(1)),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 0, Op: fetchpb.IndexFetchSpec_Predicate_GT, Values: [][]byte{enc(tree.NewDInt(1))}},
+			},
+		},
+		{
+			name: "commuted comparison",
+			expr: cmp(treecmp.LE, tree.NewDString("foo"), b),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 1, Op: fetchpb.IndexFetchSpec_Predicate_GE, Values: [][]byte{enc(tree.NewDString("foo"))}},
+			},
+		},
+		{
+			name: "is null",
+			expr: tree.NewTypedIsNullExpr(b),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 1, Op: fetchpb.IndexFetchSpec_Predicate_IS_NULL},
+			},
+		},
+		{
+			name: "is distinct from null",
+			expr: cmp(treecmp.IsDistinctFrom, a, tree.DNull),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 0, Op: fetchpb.IndexFetchSpec_Predicate_IS_NOT_NULL},
+			},
+		},
+		{
+			name: "in",
+			expr: cmp(treecmp.In, a, tree.NewDTuple(
+				types.MakeTuple([]*types.T{types.Int, types.Int, types.Int}),
+				tree.NewDInt(1), tree.DNull, tree.NewDInt(3),
+			)),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 0, Op: fetchpb.IndexFetchSpec_Predicate_IN, Values: [][]byte{
+					enc(tree.NewDInt(1)), enc(tree.NewDInt(3)),
+				}},
+			},
+		},
+		{
+			name: "conjunction with unsupported conjuncts",
+			expr: tree.NewTypedAndExpr(
+				tree.NewTypedAndExpr(
+					cmp(treecmp.NE, a, tree.NewDInt(5)),
+					// Unsupported type.
+					cmp(treecmp.EQ, c, tree.NewDString("x")),
+				),
+				tree.NewTypedAndExpr(
+					// Comparison with NULL.
+					cmp(treecmp.EQ, b, tree.DNull),
+					// Comparison of two columns.
+					cmp(treecmp.LT, a, a),
+				),
+			),
+			expected: []fetchpb.IndexFetchSpec_Predicate{
+				{FetchedColumnOrdinal: 0, Op: fetchpb.IndexFetchSpec_Predicate_NE, Values: [][]byte{enc(tree.NewDInt(5))}},
+			},
+		},
+		{
+			name: "disjunction",
+			expr: tree.NewTypedOrExpr(
+				cmp(treecmp.EQ, a, tree.NewDInt(1)),
+				cmp(treecmp.EQ, a, tree.NewDInt(2)),
+			),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, rowenc.MakeIndexFetchFilter(spec, tc.expr, varToFetchedColumn))
+		})
+	}
+}
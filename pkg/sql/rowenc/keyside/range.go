@@ -0,0 +1,132 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The markers used in the key encoding of ranges. They are chosen so that the
+// encoding sorts in the same order as tree.DRange.Compare: empty ranges sort
+// first, an infinite lower bound sorts before all finite lower bounds, an
+// infinite upper bound sorts after all finite upper bounds, an inclusive lower
+// bound sorts before an exclusive one with the same value, and an exclusive
+// upper bound sorts before an inclusive one with the same value.
+const (
+	rangeKeyEmpty    = 0
+	rangeKeyNonEmpty = 1
+
+	rangeKeyLowerInfinite = 0
+	rangeKeyLowerFinite   = 1
+	rangeKeyUpperFinite   = 0
+	rangeKeyUpperInfinite = 1
+
+	rangeKeyLowerInclusive = 0
+	rangeKeyLowerExclusive = 1
+	rangeKeyUpperExclusive = 0
+	rangeKeyUpperInclusive = 1
+)
+
+// encodeRangeKey generates an ordered key encoding of a range.
+// The encoding format for a non-empty range [a, b) is as follows:
+// [nonEmptyMarker, lowerFiniteMarker, enc(a), lowerInclusiveMarker,
+// upperFiniteMarker, enc(b), upperExclusiveMarker]. The value and inclusivity
+// markers are omitted for infinite bounds, and an empty range is encoded as
+// [emptyMarker].
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	encodeMarker := func(b []byte, marker int64) []byte {
+		if dir == encoding.Ascending {
+			return encoding.EncodeVarintAscending(b, marker)
+		}
+		return encoding.EncodeVarintDescending(b, marker)
+	}
+	if r.Empty {
+		return encodeMarker(b, rangeKeyEmpty), nil
+	}
+	b = encodeMarker(b, rangeKeyNonEmpty)
+	var err error
+	if r.Lower.IsInfinite() {
+		b = encodeMarker(b, rangeKeyLowerInfinite)
+	} else {
+		b = encodeMarker(b, rangeKeyLowerFinite)
+		if b, err = Encode(b, r.Lower.Val, dir); err != nil {
+			return nil, err
+		}
+		if r.Lower.Inclusive {
+			b = encodeMarker(b, rangeKeyLowerInclusive)
+		} else {
+			b = encodeMarker(b, rangeKeyLowerExclusive)
+		}
+	}
+	if r.Upper.IsInfinite() {
+		return encodeMarker(b, rangeKeyUpperInfinite), nil
+	}
+	b = encodeMarker(b, rangeKeyUpperFinite)
+	if b, err = Encode(b, r.Upper.Val, dir); err != nil {
+		return nil, err
+	}
+	if r.Upper.Inclusive {
+		return encodeMarker(b, rangeKeyUpperInclusive), nil
+	}
+	return encodeMarker(b, rangeKeyUpperExclusive), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	decodeMarker := func(buf []byte) ([]byte, int64, error) {
+		if dir == encoding.Ascending {
+			return encoding.DecodeVarintAscending(buf)
+		}
+		return encoding.DecodeVarintDescending(buf)
+	}
+	buf, marker, err := decodeMarker(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyEmpty {
+		return tree.NewDEmptyRange(t), buf, nil
+	}
+	var lower, upper tree.RangeBound
+	if buf, marker, err = decodeMarker(buf); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyLowerFinite {
+		if lower.Val, buf, err = Decode(a, t.RangeContents(), buf, dir); err != nil {
+			return nil, nil, err
+		}
+		if buf, marker, err = decodeMarker(buf); err != nil {
+			return nil, nil, err
+		}
+		lower.Inclusive = marker == rangeKeyLowerInclusive
+	}
+	if buf, marker, err = decodeMarker(buf); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyUpperFinite {
+		if upper.Val, buf, err = Decode(a, t.RangeContents(), buf, dir); err != nil {
+			return nil, nil, err
+		}
+		if buf, marker, err = decodeMarker(buf); err != nil {
+			return nil, nil, err
+		}
+		upper.Inclusive = marker == rangeKeyUpperInclusive
+	}
+	r, err := tree.NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, nil, errors.NewAssertionErrorWithWrappedErrf(err, "invalid range encoding")
+	}
+	return r, buf, nil
+}
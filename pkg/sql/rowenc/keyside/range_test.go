@@ -0,0 +1,113 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestRanges returns the empty range of the given type and every valid
+// range whose bounds are either infinite or one of the given values, with
+// each combination of inclusive and exclusive bounds.
+func makeTestRanges(typ *types.T, vals []tree.Datum) []*tree.DRange {
+	bounds := []tree.RangeBound{{}}
+	for _, v := range vals {
+		bounds = append(bounds, tree.RangeBound{Val: v, Inclusive: true}, tree.RangeBound{Val: v})
+	}
+	ranges := []*tree.DRange{tree.NewDEmptyRange(typ)}
+	for _, lower := range bounds {
+		for _, upper := range bounds {
+			r, err := tree.NewDRange(typ, lower, upper)
+			if err != nil {
+				// The lower bound is greater than the upper bound.
+				continue
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+func TestEncodeDecodeRange(t *testing.T) {
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	decimal := func(s string) tree.Datum {
+		d, err := tree.ParseDDecimal(s)
+		require.NoError(t, err)
+		return d
+	}
+	testCases := []struct {
+		typ  *types.T
+		vals []tree.Datum
+	}{
+		// Ranges of a discrete type are canonicalized, so most of them end
+		// up with an inclusive lower bound and an exclusive upper bound.
+		{types.Int8Range, []tree.Datum{tree.NewDInt(-5), tree.NewDInt(0), tree.NewDInt(1), tree.NewDInt(10)}},
+		{types.NumRange, []tree.Datum{decimal("-1.5"), decimal("0"), decimal("0.5"), decimal("1e10")}},
+	}
+	for _, tc := range testCases {
+		ranges := makeTestRanges(tc.typ, tc.vals)
+		for _, dir := range []encoding.Direction{encoding.Ascending, encoding.Descending} {
+			t.Run(fmt.Sprintf("%s/direction:%d", tc.typ, dir), func(t *testing.T) {
+				encoded := make([][]byte, len(ranges))
+				for i, r := range ranges {
+					b, err := keyside.Encode(nil, r, dir)
+					require.NoError(t, err)
+					encoded[i] = b
+
+					// Append another key to check that decoding stops at the
+					// end of the range.
+					b = encoding.EncodeVarintAscending(b, 42)
+					a := &tree.DatumAlloc{}
+					decoded, rest, err := keyside.Decode(a, tc.typ, b, dir)
+					require.NoError(t, err)
+					_, v, err := encoding.DecodeVarintAscending(rest)
+					require.NoError(t, err)
+					require.Equal(t, int64(42), v)
+
+					d := decoded.(*tree.DRange)
+					require.Equal(t, r.Empty, d.Empty, "%s", r)
+					require.Equal(t, r.Lower.Inclusive, d.Lower.Inclusive, "%s", r)
+					require.Equal(t, r.Upper.Inclusive, d.Upper.Inclusive, "%s", r)
+					require.Equal(t, r.Lower.IsInfinite(), d.Lower.IsInfinite(), "%s", r)
+					require.Equal(t, r.Upper.IsInfinite(), d.Upper.IsInfinite(), "%s", r)
+					cmp, err := d.Compare(ctx, evalCtx, r)
+					require.NoError(t, err)
+					require.Equal(t, 0, cmp, "%s decoded as %s", r, d)
+				}
+
+				// The encoding must sort in the same order as the ranges.
+				for i, r1 := range ranges {
+					for j, r2 := range ranges {
+						expected, err := r1.Compare(ctx, evalCtx, r2)
+						require.NoError(t, err)
+						if dir == encoding.Descending {
+							expected = -expected
+						}
+						require.Equal(t, expected, bytes.Compare(encoded[i], encoded[j]),
+							"%s vs %s", r1, r2)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Inverted indexes on ranges are similar to the S2 cell coverings used for
+// geospatial indexes, but in a single dimension. Each element of a range is
+// mapped to a position in [0, 2^63) such that the mapping preserves the order
+// of the elements (but not necessarily their distinctness). The positions are
+// then organized into a hierarchy of cells: the cell at level k with start
+// position a covers the positions [a, a + 2^k), where a is a multiple of 2^k.
+// The single cell at level 63 covers all positions.
+//
+// A cell is identified by the number 2a + 2^k, which has the property that
+// its lowest set bit identifies its level and that the identifiers of all
+// the descendants of a cell (including the cell itself) form the contiguous
+// interval [id - 2^k + 1, id + 2^k - 1].
+//
+// A non-empty range is indexed using at most rangeInvertedIndexMaxCells cells
+// at the lowest level that covers the range with that many cells. Empty ranges
+// do not produce any keys, since they do not overlap or contain any non-empty
+// range.
+//
+// Two ranges can only overlap if one of the cells covering the first range is
+// an ancestor or a descendant of one of the cells covering the second range.
+// To find the ranges overlapping a given range, the spans covering the
+// descendants and the keys of the ancestors of each cell covering the given
+// range are scanned. Since the covering is not exact, the original filter must
+// always be applied to the results.
+
+// rangeInvertedIndexMaxCells is the maximum number of cells used to cover a
+// range in an inverted index.
+const rangeInvertedIndexMaxCells = 4
+
+// rangeInvertedIndexMaxLevel is the level of the cell covering all positions.
+const rangeInvertedIndexMaxLevel = 63
+
+// rangeElemPosition maps an element of a range to a position in [0, 2^63). The
+// mapping preserves the order of elements of the same type.
+func rangeElemPosition(d tree.Datum) (uint64, error) {
+	var u uint64
+	switch t := tree.UnwrapDOidWrapper(d).(type) {
+	case *tree.DInt:
+		u = uint64(*t) ^ (1 << 63)
+	case *tree.DDate:
+		u = uint64(t.UnixEpochDays()) ^ (1 << 63)
+	case *tree.DTimestamp:
+		u = uint64(t.UnixMicro()) ^ (1 << 63)
+	case *tree.DTimestampTZ:
+		u = uint64(t.UnixMicro()) ^ (1 << 63)
+	case *tree.DDecimal:
+		f, err := t.Float64()
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(f) {
+			// NaN sorts before all other decimals.
+			return 0, nil
+		}
+		u = math.Float64bits(f)
+		if u&(1<<63) != 0 {
+			u = ^u
+		} else {
+			u |= 1 << 63
+		}
+	default:
+		return 0, errors.AssertionFailedf("unsupported range element type %s", d.ResolvedType())
+	}
+	return u >> 1, nil
+}
+
+// rangePositions returns the first and last positions of a non-empty range.
+// Infinite bounds are mapped to the extremes, and the inclusivity of the
+// bounds is ignored, which can only make the covering larger.
+func rangePositions(r *tree.DRange) (lo, hi uint64, err error) {
+	lo, hi = 0, 1<<63-1
+	if !r.Lower.IsInfinite() {
+		if lo, err = rangeElemPosition(r.Lower.Val); err != nil {
+			return 0, 0, err
+		}
+	}
+	if !r.Upper.IsInfinite() {
+		if hi, err = rangeElemPosition(r.Upper.Val); err != nil {
+			return 0, 0, err
+		}
+	}
+	if hi < lo {
+		// This can only happen due to precision loss in the mapping of an
+		// element to a position.
+		lo, hi = hi, lo
+	}
+	return lo, hi, nil
+}
+
+// rangeCovering returns the identifiers of the cells covering the positions
+// [lo, hi], in increasing order, along with their level.
+func rangeCovering(lo, hi uint64) (cells []uint64, level int) {
+	for level = 0; level < rangeInvertedIndexMaxLevel; level++ {
+		if (hi>>level)-(lo>>level) < rangeInvertedIndexMaxCells {
+			break
+		}
+	}
+	for a := lo >> level; a <= hi>>level; a++ {
+		cells = append(cells, (a<<level)<<1|1<<level)
+	}
+	return cells, level
+}
+
+// encodeRangeInvertedIndexTableKeys produces the inverted index keys for a
+// range. See the comment at the top of this file for details.
+func encodeRangeInvertedIndexTableKeys(r *tree.DRange, inKey []byte) ([][]byte, error) {
+	if r.Empty {
+		return nil, nil
+	}
+	lo, hi, err := rangePositions(r)
+	if err != nil {
+		return nil, err
+	}
+	cells, _ := rangeCovering(lo, hi)
+	// Avoid per-key heap allocations.
+	b := make([]byte, 0, len(cells)*(len(inKey)+encoding.MaxVarintLen))
+	keys := make([][]byte, len(cells))
+	for i, c := range cells {
+		prev := len(b)
+		b = append(b, inKey...)
+		b = encoding.EncodeUvarintAscending(b, c)
+		// Set capacity so that the caller appending does not corrupt later keys.
+		keys[i] = b[prev:len(b):len(b)]
+	}
+	return keys, nil
+}
+
+// EncodeOverlappingRangeInvertedIndexSpans returns the spans that must be
+// scanned in an inverted index on a range column to find the ranges that may
+// overlap the given range. Ranges that contain the given range also overlap
+// it, so the spans can be used to evaluate contains (@>) predicates as well.
+// The returned expression is never tight. An error is returned if the range is
+// empty, since an empty range does not overlap any range.
+func EncodeOverlappingRangeInvertedIndexSpans(r *tree.DRange) (inverted.Expression, error) {
+	if r.Empty {
+		return nil, errors.New("cannot search for an empty range")
+	}
+	lo, hi, err := rangePositions(r)
+	if err != nil {
+		return nil, err
+	}
+	cells, level := rangeCovering(lo, hi)
+
+	// Collect the inclusive intervals of cell identifiers that must be read:
+	// the descendants of each covering cell and each of their ancestors.
+	type interval struct{ start, end uint64 }
+	intervals := make([]interval, 0, len(cells)*(rangeInvertedIndexMaxLevel-level+1))
+	for _, c := range cells {
+		intervals = append(intervals, interval{start: c - (1<<level - 1), end: c + (1<<level - 1)})
+		// Recover the start position of the cell and find the start position
+		// of its ancestor at each level above it.
+		pos := (c ^ 1<<level) >> 1
+		for l := level + 1; l <= rangeInvertedIndexMaxLevel; l++ {
+			ancestor := (pos&^(1<<l-1))<<1 | 1<<l
+			intervals = append(intervals, interval{start: ancestor, end: ancestor})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start < intervals[j].start
+	})
+	merged := intervals[:1]
+	for _, iv := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if last.end == math.MaxUint64 || iv.start <= last.end+1 {
+			if iv.end > last.end {
+				last.end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+
+	spans := make([]inverted.Span, len(merged))
+	for i, iv := range merged {
+		spans[i].Start = encoding.EncodeUvarintAscending(nil, iv.start)
+		// The interval end is inclusive, while the span end is exclusive.
+		if iv.end < math.MaxUint64 {
+			spans[i].End = encoding.EncodeUvarintAscending(nil, iv.end+1)
+		} else {
+			spans[i].End = roachpb.Key(encoding.EncodeUvarintAscending(nil, iv.end)).PrefixEnd()
+		}
+	}
+	return &inverted.SpanExpression{
+		SpansToRead:        spans,
+		FactoredUnionSpans: spans,
+	}, nil
+}
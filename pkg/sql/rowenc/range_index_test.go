@@ -0,0 +1,97 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	. "github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeOverlappingRangeInvertedIndexSpans(t *testing.T) {
+	decimal := func(s string) tree.Datum {
+		d, err := tree.ParseDDecimal(s)
+		require.NoError(t, err)
+		return d
+	}
+	testCases := []struct {
+		typ  *types.T
+		vals []tree.Datum
+	}{
+		{types.Int8Range, []tree.Datum{
+			tree.NewDInt(math.MinInt64), tree.NewDInt(-1000), tree.NewDInt(-1), tree.NewDInt(0),
+			tree.NewDInt(1), tree.NewDInt(3), tree.NewDInt(1 << 40), tree.NewDInt(math.MaxInt64 - 1),
+		}},
+		{types.NumRange, []tree.Datum{
+			decimal("-1e100"), decimal("-2.5"), decimal("-0.001"), decimal("0"),
+			decimal("0.001"), decimal("1"), decimal("1.5"), decimal("1e100"),
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.typ.String(), func(t *testing.T) {
+			// Build the empty range and every valid range whose bounds are
+			// either infinite or one of the test values, with each combination
+			// of inclusive and exclusive bounds.
+			bounds := []tree.RangeBound{{}}
+			for _, v := range tc.vals {
+				bounds = append(bounds, tree.RangeBound{Val: v, Inclusive: true}, tree.RangeBound{Val: v})
+			}
+			ranges := []*tree.DRange{tree.NewDEmptyRange(tc.typ)}
+			for _, lower := range bounds {
+				for _, upper := range bounds {
+					if r, err := tree.NewDRange(tc.typ, lower, upper); err == nil {
+						ranges = append(ranges, r)
+					}
+				}
+			}
+
+			keys := make([][][]byte, len(ranges))
+			for i, r := range ranges {
+				var err error
+				keys[i], err = EncodeInvertedIndexTableKeys(r, nil, descpb.LatestIndexDescriptorVersion)
+				require.NoError(t, err)
+				if r.Empty {
+					require.Empty(t, keys[i], "empty range should not be indexed")
+				} else {
+					require.NotEmpty(t, keys[i], "%s", r)
+				}
+			}
+
+			for _, query := range ranges {
+				invertedExpr, err := EncodeOverlappingRangeInvertedIndexSpans(query)
+				if query.Empty {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+				require.True(t, ok, "%v is not a SpanExpression", invertedExpr)
+				require.False(t, spanExpr.Tight)
+
+				for i, indexed := range ranges {
+					found, err := spanExpr.ContainsKeys(keys[i])
+					require.NoError(t, err)
+					// The spans are not tight, so they may contain ranges which
+					// do not overlap the query, but must contain all that do.
+					if indexed.Overlaps(query) && !found {
+						t.Errorf("spans for %s do not contain overlapping range %s", query, indexed)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags stored in the first byte of the value encoding of a range. They
+// match the flags used by Postgres in the binary format of ranges.
+const (
+	rangeFlagEmpty          = 0x01
+	rangeFlagLowerInclusive = 0x02
+	rangeFlagUpperInclusive = 0x04
+	rangeFlagLowerInfinite  = 0x08
+	rangeFlagUpperInfinite  = 0x10
+)
+
+// encodeRange produces the value encoding for a range, without a value tag or
+// length prefix. The encoding is a byte of flags followed by the value
+// encodings of the finite bounds of the range.
+func encodeRange(r *tree.DRange, scratch []byte) ([]byte, error) {
+	var flags byte
+	switch {
+	case r.Empty:
+		return append(scratch, rangeFlagEmpty), nil
+	case r.Lower.IsInfinite():
+		flags |= rangeFlagLowerInfinite
+	case r.Lower.Inclusive:
+		flags |= rangeFlagLowerInclusive
+	}
+	switch {
+	case r.Upper.IsInfinite():
+		flags |= rangeFlagUpperInfinite
+	case r.Upper.Inclusive:
+		flags |= rangeFlagUpperInclusive
+	}
+	b := append(scratch, flags)
+	var err error
+	for _, bound := range []tree.RangeBound{r.Lower, r.Upper} {
+		if bound.IsInfinite() {
+			continue
+		}
+		if b, err = Encode(b, NoColumnID, bound.Val, nil /* scratch */); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range from its value encoding. It is the counterpart
+// of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (tree.Datum, error) {
+	if len(b) == 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeFlagEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	var lower, upper tree.RangeBound
+	var err error
+	if flags&rangeFlagLowerInfinite == 0 {
+		if lower.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, err
+		}
+		lower.Inclusive = flags&rangeFlagLowerInclusive != 0
+	}
+	if flags&rangeFlagUpperInfinite == 0 {
+		if upper.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, err
+		}
+		upper.Inclusive = flags&rangeFlagUpperInclusive != 0
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(b))
+	}
+	r, err := tree.NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, errors.NewAssertionErrorWithWrappedErrf(err, "invalid range encoding")
+	}
+	return r, nil
+}
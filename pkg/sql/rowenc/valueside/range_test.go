@@ -0,0 +1,103 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeRange(t *testing.T) {
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	decimal := func(s string) tree.Datum {
+		d, err := tree.ParseDDecimal(s)
+		require.NoError(t, err)
+		return d
+	}
+	bounds := []tree.RangeBound{
+		{},
+		{Val: decimal("-1.5"), Inclusive: true},
+		{Val: decimal("-1.5")},
+		{Val: decimal("2"), Inclusive: true},
+		{Val: decimal("2")},
+	}
+	ranges := []*tree.DRange{tree.NewDEmptyRange(types.NumRange)}
+	for _, lower := range bounds {
+		for _, upper := range bounds {
+			r, err := tree.NewDRange(types.NumRange, lower, upper)
+			if err != nil {
+				// The lower bound is greater than the upper bound.
+				continue
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	// Ranges of a discrete type are canonicalized on construction.
+	for _, b := range [][2]tree.RangeBound{
+		{{}, {}},
+		{{Val: tree.NewDInt(1), Inclusive: true}, {}},
+		{{}, {Val: tree.NewDInt(1)}},
+		{{Val: tree.NewDInt(-3), Inclusive: true}, {Val: tree.NewDInt(7)}},
+	} {
+		r, err := tree.NewDRange(types.Int4Range, b[0], b[1])
+		require.NoError(t, err)
+		ranges = append(ranges, r)
+	}
+
+	a := &tree.DatumAlloc{}
+	for _, r := range ranges {
+		t.Run(r.String(), func(t *testing.T) {
+			// Check the flags, which must match the Postgres binary format.
+			encoded, err := encodeRange(r, nil /* scratch */)
+			require.NoError(t, err)
+			var expectedFlags byte
+			switch {
+			case r.Empty:
+				expectedFlags = rangeFlagEmpty
+			default:
+				if r.Lower.IsInfinite() {
+					expectedFlags |= rangeFlagLowerInfinite
+				} else if r.Lower.Inclusive {
+					expectedFlags |= rangeFlagLowerInclusive
+				}
+				if r.Upper.IsInfinite() {
+					expectedFlags |= rangeFlagUpperInfinite
+				} else if r.Upper.Inclusive {
+					expectedFlags |= rangeFlagUpperInclusive
+				}
+			}
+			require.Equal(t, expectedFlags, encoded[0])
+
+			b, err := Encode(nil, NoColumnID, r, nil /* scratch */)
+			require.NoError(t, err)
+			decoded, rest, err := Decode(a, r.ResolvedType(), b)
+			require.NoError(t, err)
+			require.Empty(t, rest)
+
+			d := decoded.(*tree.DRange)
+			require.Equal(t, r.Empty, d.Empty)
+			require.Equal(t, r.Lower.Inclusive, d.Lower.Inclusive)
+			require.Equal(t, r.Upper.Inclusive, d.Upper.Inclusive)
+			require.Equal(t, r.Lower.IsInfinite(), d.Lower.IsInfinite())
+			require.Equal(t, r.Upper.IsInfinite(), d.Upper.IsInfinite())
+			cmp, err := d.Compare(ctx, evalCtx, r)
+			require.NoError(t, err)
+			require.Equal(t, 0, cmp, "decoded as %s", d)
+		})
+	}
+}
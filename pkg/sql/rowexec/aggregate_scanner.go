@@ -0,0 +1,279 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// aggregateScanner computes scalar aggregations over the rows of an index by
+// sending AggregateScan requests, which have each range compute partial
+// aggregates over its rows at the read timestamp of the transaction. The
+// partial aggregates of all the ranges are combined into a single output row.
+type aggregateScanner struct {
+	execinfra.ProcessorBase
+
+	spec            *execinfrapb.AggregateScannerSpec
+	batchBytesLimit rowinfra.BytesLimit
+
+	// aggregates contains the combined aggregates of the ranges scanned so far,
+	// one per aggregate. A nil entry means that no range has returned a value
+	// for the aggregate yet.
+	aggregates tree.Datums
+	// aggTypes are the types of the aggregates.
+	aggTypes []*types.T
+
+	alloc     tree.DatumAlloc
+	bytesRead int64
+}
+
+var _ execinfra.Processor = &aggregateScanner{}
+var _ execinfra.RowSource = &aggregateScanner{}
+
+const aggregateScannerProcName = "aggregate scanner"
+
+// aggregateScanResultType returns the type of the (partial) result of the
+// given aggregate over a column of the given type. It is the same as the type
+// of the corresponding SQL aggregate function.
+func aggregateScanResultType(
+	fn kvpb.AggregateScanRequest_Aggregate_Func, argType *types.T,
+) (*types.T, error) {
+	switch fn {
+	case kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS, kvpb.AggregateScanRequest_Aggregate_COUNT:
+		return types.Int, nil
+	case kvpb.AggregateScanRequest_Aggregate_SUM:
+		switch argType.Family() {
+		case types.IntFamily, types.DecimalFamily:
+			return types.Decimal, nil
+		case types.FloatFamily:
+			return types.Float, nil
+		}
+		return nil, errors.AssertionFailedf("unsupported SUM argument type %s", argType.SQLStringForError())
+	case kvpb.AggregateScanRequest_Aggregate_MIN, kvpb.AggregateScanRequest_Aggregate_MAX:
+		return argType, nil
+	}
+	return nil, errors.AssertionFailedf("unknown aggregate function %s", fn)
+}
+
+func newAggregateScanner(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec *execinfrapb.AggregateScannerSpec,
+	post *execinfrapb.PostProcessSpec,
+) (*aggregateScanner, error) {
+	as := &aggregateScanner{
+		spec:       spec,
+		aggregates: make(tree.Datums, len(spec.Aggregates)),
+	}
+	as.batchBytesLimit = rowinfra.BytesLimit(spec.BatchBytesLimit)
+	if as.batchBytesLimit == 0 {
+		as.batchBytesLimit = rowinfra.GetDefaultBatchBytesLimit(flowCtx.EvalCtx.TestingKnobs.ForceProductionValues)
+	}
+
+	as.aggTypes = make([]*types.T, len(spec.Aggregates))
+	for i, agg := range spec.Aggregates {
+		var argType *types.T
+		if agg.Func != kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS {
+			if int(agg.FetchedColumnOrdinal) >= len(spec.FetchSpec.FetchedColumns) {
+				return nil, errors.AssertionFailedf(
+					"invalid fetched column ordinal %d in aggregate", agg.FetchedColumnOrdinal,
+				)
+			}
+			argType = spec.FetchSpec.FetchedColumns[agg.FetchedColumnOrdinal].Type
+		}
+		var err error
+		if as.aggTypes[i], err = aggregateScanResultType(agg.Func, argType); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := as.Init(
+		ctx,
+		as,
+		post,
+		as.aggTypes,
+		flowCtx,
+		processorID,
+		nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			TrailingMetaCallback: as.generateTrailingMeta,
+		},
+	); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// Start is part of the RowSource interface.
+func (as *aggregateScanner) Start(ctx context.Context) {
+	if as.FlowCtx.Txn == nil {
+		log.Fatalf(ctx, "aggregateScanner outside of txn")
+	}
+	as.StartInternal(ctx, aggregateScannerProcName)
+}
+
+// Next is part of the RowSource interface.
+func (as *aggregateScanner) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	if as.State == execinfra.StateRunning {
+		if err := as.scan(as.Ctx()); err != nil {
+			as.MoveToDraining(err)
+			return nil, as.DrainHelper()
+		}
+		row := make(rowenc.EncDatumRow, len(as.aggregates))
+		for i, d := range as.aggregates {
+			if d == nil {
+				d = as.emptyResult(i)
+			}
+			row[i] = rowenc.EncDatum{Datum: d}
+		}
+		rendered, _, err := as.OutputHelper.ProcessRow(as.Ctx(), row)
+		// We're done as soon as we process our one output row, so we
+		// transition into draining state. We will, however, return non-nil
+		// error (if such occurs during rendering) separately below.
+		as.MoveToDraining(nil /* err */)
+		if err != nil {
+			return nil, &execinfrapb.ProducerMetadata{Err: err}
+		}
+		return rendered, nil
+	}
+	return nil, as.DrainHelper()
+}
+
+// emptyResult returns the result of the i-th aggregate over no rows.
+func (as *aggregateScanner) emptyResult(i int) tree.Datum {
+	switch as.spec.Aggregates[i].Func {
+	case kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS, kvpb.AggregateScanRequest_Aggregate_COUNT:
+		return tree.NewDInt(0)
+	}
+	return tree.DNull
+}
+
+// scan sends the AggregateScan requests for all the spans and combines their
+// results into as.aggregates.
+func (as *aggregateScanner) scan(ctx context.Context) error {
+	spans := make(roachpb.Spans, len(as.spec.Spans))
+	copy(spans, as.spec.Spans)
+	for len(spans) > 0 {
+		ba := &kvpb.BatchRequest{}
+		ba.Header.TargetBytes = int64(as.batchBytesLimit)
+		// Never split the SQL rows across the responses, so that each row is
+		// aggregated exactly once.
+		ba.Header.WholeRowsOfSize = int32(as.spec.FetchSpec.MaxKeysPerRow)
+		ba.AdmissionHeader = as.FlowCtx.Txn.AdmissionHeader()
+		ba.Requests = make([]kvpb.RequestUnion, len(spans))
+		reqs := make([]kvpb.AggregateScanRequest, len(spans))
+		for i := range spans {
+			reqs[i] = kvpb.AggregateScanRequest{
+				RequestHeader:  kvpb.RequestHeaderFromSpan(spans[i]),
+				IndexFetchSpec: as.spec.FetchSpec,
+				Aggregates:     as.spec.Aggregates,
+			}
+			ba.Requests[i].MustSetInner(&reqs[i])
+		}
+		br, pErr := as.FlowCtx.Txn.Send(ctx, ba)
+		if pErr != nil {
+			return pErr.GoError()
+		}
+		spans = spans[:0]
+		for i := range br.Responses {
+			resp := br.Responses[i].GetAggregateScan()
+			as.bytesRead += resp.NumBytes
+			for _, result := range resp.Results {
+				if err := as.combine(ctx, result); err != nil {
+					return err
+				}
+			}
+			if resp.ResumeSpan != nil {
+				spans = append(spans, *resp.ResumeSpan)
+			}
+		}
+	}
+	return nil
+}
+
+// combine decodes the given row of partial aggregates of a range (see
+// kvpb.AggregateScanResponse) and combines it into as.aggregates.
+func (as *aggregateScanner) combine(ctx context.Context, result []byte) error {
+	for i, agg := range as.spec.Aggregates {
+		var d tree.Datum
+		var err error
+		d, result, err = valueside.Decode(&as.alloc, as.aggTypes[i], result)
+		if err != nil {
+			return err
+		}
+		if d == tree.DNull {
+			continue
+		}
+		prev := as.aggregates[i]
+		if prev == nil {
+			as.aggregates[i] = d
+			continue
+		}
+		switch agg.Func {
+		case kvpb.AggregateScanRequest_Aggregate_COUNT_ROWS, kvpb.AggregateScanRequest_Aggregate_COUNT:
+			as.aggregates[i] = tree.NewDInt(*prev.(*tree.DInt) + *d.(*tree.DInt))
+		case kvpb.AggregateScanRequest_Aggregate_SUM:
+			switch t := d.(type) {
+			case *tree.DFloat:
+				as.aggregates[i] = tree.NewDFloat(*prev.(*tree.DFloat) + *t)
+			case *tree.DDecimal:
+				sum := &tree.DDecimal{}
+				if _, err := tree.ExactCtx.Add(&sum.Decimal, &prev.(*tree.DDecimal).Decimal, &t.Decimal); err != nil {
+					return err
+				}
+				as.aggregates[i] = sum
+			default:
+				return errors.AssertionFailedf("unexpected partial SUM %T", d)
+			}
+		case kvpb.AggregateScanRequest_Aggregate_MIN, kvpb.AggregateScanRequest_Aggregate_MAX:
+			cmp, err := d.Compare(ctx, as.FlowCtx.EvalCtx, prev)
+			if err != nil {
+				return err
+			}
+			if (agg.Func == kvpb.AggregateScanRequest_Aggregate_MIN && cmp < 0) ||
+				(agg.Func == kvpb.AggregateScanRequest_Aggregate_MAX && cmp > 0) {
+				as.aggregates[i] = d
+			}
+		default:
+			return errors.AssertionFailedf("unknown aggregate function %s", agg.Func)
+		}
+	}
+	if len(result) > 0 {
+		return errors.AssertionFailedf("unexpected trailing bytes in the partial aggregates")
+	}
+	return nil
+}
+
+func (as *aggregateScanner) generateTrailingMeta() []execinfrapb.ProducerMetadata {
+	var trailingMeta []execinfrapb.ProducerMetadata
+	if tfs := execinfra.GetLeafTxnFinalState(as.Ctx(), as.FlowCtx.Txn); tfs != nil {
+		trailingMeta = append(trailingMeta, execinfrapb.ProducerMetadata{LeafTxnFinalState: tfs})
+	}
+	meta := execinfrapb.GetProducerMeta()
+	meta.Metrics = execinfrapb.GetMetricsMeta()
+	meta.Metrics.BytesRead = as.bytesRead
+	trailingMeta = append(trailingMeta, *meta)
+	as.InternalClose()
+	return trailingMeta
+}
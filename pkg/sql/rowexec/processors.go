@@ -127,6 +127,12 @@ func NewProcessor(
 		}
 		return newTableReader(ctx, flowCtx, processorID, core.TableReader, post)
 	}
+	if core.AggregateScanner != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		return newAggregateScanner(ctx, flowCtx, processorID, core.AggregateScanner, post)
+	}
 	if core.Filterer != nil {
 		if err := checkNumIn(inputs, 1); err != nil {
 			return nil, err
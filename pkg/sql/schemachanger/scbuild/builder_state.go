@@ -489,6 +489,32 @@ func (b *builderState) NextTableConstraintID(tableID catid.DescID) (ret catid.Co
 	return ret
 }
 
+// NextTablePolicyID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTablePolicyID(tableID catid.DescID) (ret catid.PolicyID) {
+	{
+		b.ensureDescriptor(tableID)
+		desc := b.descCache[tableID].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextPolicyID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	// Consult all present policy elements in case their ID is larger.
+	scpb.ForEachPolicy(b.QueryByID(tableID), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.Policy,
+	) {
+		if e.PolicyID >= ret {
+			ret = e.PolicyID + 1
+		}
+	})
+	return ret
+}
+
 // NextTableTentativeIndexID implements the scbuildstmt.TableHelpers interface.
 func (b *builderState) NextTableTentativeIndexID(tableID catid.DescID) (ret catid.IndexID) {
 	ret = catid.IndexID(scbuildstmt.TableTentativeIdsStart)
@@ -1010,6 +1036,9 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		b.ensureDescriptor(typ.GetID())
+		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -1363,6 +1392,11 @@ func (b *builderState) ResolveRoutine(
 		)
 	}
 
+	// User-defined aggregates are only supported by the legacy schema changer.
+	if ol.Class == tree.AggregateClass {
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "aggregate function %s", routineObj.FuncName.Object()))
+	}
+
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	b.mustOwn(fnID)
 	b.ensureDescriptor(fnID)
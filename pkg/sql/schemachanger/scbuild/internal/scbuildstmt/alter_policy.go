@@ -0,0 +1,58 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+// AlterPolicy implements ALTER POLICY.
+//
+// Policy elements are never modified in place: the existing element is dropped
+// and replaced by an updated copy with a new policy ID.
+func AlterPolicy(b BuildCtx, n *tree.AlterPolicy) {
+	tn, tbl := resolveTableForPolicy(b, n.TableName)
+	old := findPolicyByName(b, tbl.TableID, n.PolicyName)
+	if old == nil {
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.PolicyName, tn.Object()))
+	}
+	p := protoutil.Clone(old).(*scpb.Policy)
+	if n.NewPolicyName != "" {
+		if n.NewPolicyName == n.PolicyName {
+			return
+		}
+		if findPolicyByName(b, tbl.TableID, n.NewPolicyName) != nil {
+			panic(pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.NewPolicyName, tn.Object()))
+		}
+		p.Name = string(n.NewPolicyName)
+	} else {
+		validatePolicyExpressions(p.Command, n.Exprs)
+		if len(n.Roles) > 0 {
+			p.RoleNames = policyRoleNames(b, n.Roles)
+		}
+		if n.Exprs.Using != nil {
+			p.UsingExpr = policyExpression(b, tn, tbl, n.Exprs.Using, tree.PolicyUsingExpr)
+		}
+		if n.Exprs.WithCheck != nil {
+			p.WithCheckExpr = policyExpression(b, tn, tbl, n.Exprs.WithCheck, tree.PolicyWithCheckExpr)
+		}
+	}
+	b.Drop(old)
+	p.PolicyID = b.NextTablePolicyID(tbl.TableID)
+	b.Add(p)
+	b.LogEventForExistingTarget(p)
+}
@@ -39,11 +39,28 @@ var supportedAlterTableStatements = map[reflect.Type]supportedAlterTableCommand{
 	reflect.TypeOf((*tree.AlterTableDropColumn)(nil)):         {fn: alterTableDropColumn, on: true, checks: nil},
 	reflect.TypeOf((*tree.AlterTableAlterPrimaryKey)(nil)):    {fn: alterTableAlterPrimaryKey, on: true, checks: nil},
 	reflect.TypeOf((*tree.AlterTableSetNotNull)(nil)):         {fn: alterTableSetNotNull, on: true, checks: nil},
-	reflect.TypeOf((*tree.AlterTableAddConstraint)(nil)):      {fn: alterTableAddConstraint, on: true, checks: nil},
+	reflect.TypeOf((*tree.AlterTableAddConstraint)(nil)):      {fn: alterTableAddConstraint, on: true, checks: isSupportedConstraintDef},
 	reflect.TypeOf((*tree.AlterTableDropConstraint)(nil)):     {fn: alterTableDropConstraint, on: true, checks: nil},
 	reflect.TypeOf((*tree.AlterTableValidateConstraint)(nil)): {fn: alterTableValidateConstraint, on: true, checks: nil},
 	reflect.TypeOf((*tree.AlterTableSetDefault)(nil)):         {fn: alterTableSetDefault, on: true, checks: nil},
 	reflect.TypeOf((*tree.AlterTableAlterColumnType)(nil)):    {fn: alterTableAlterColumnType, on: true, checks: isV242Active},
+	reflect.TypeOf((*tree.AlterTableSetRLSMode)(nil)):         {fn: alterTableSetRLSMode, on: true, checks: isV243Active},
+}
+
+// isSupportedConstraintDef returns true if the constraint added by an ALTER
+// TABLE ... ADD CONSTRAINT command is supported by the declarative schema
+// changer. Deferrable constraints and exclusion constraints are only supported
+// by the legacy schema changer.
+var isSupportedConstraintDef = func(n tree.NodeFormatter, _ sessiondatapb.NewSchemaChangerMode, _ clusterversion.ClusterVersion) bool {
+	switch d := n.(*tree.AlterTableAddConstraint).ConstraintDef.(type) {
+	case *tree.UniqueConstraintTableDef:
+		return d.Deferrable == tree.ConstraintNotDeferrable
+	case *tree.ForeignKeyConstraintTableDef:
+		return d.Deferrable == tree.ConstraintNotDeferrable
+	case *tree.ExclusionConstraintTableDef:
+		return false
+	}
+	return true
 }
 
 func init() {
@@ -72,6 +72,8 @@ func alterTableAlterColumnType(
 			// Otherwise, it is a dependency on the column used in the expiration
 			// expression.
 			panic(sqlerrors.NewAlterDependsOnExpirationExprError(op, objType, t.Column.String(), tn.Object(), string(e.ExpirationExpr)))
+		case *scpb.Policy:
+			panic(sqlerrors.NewDependentBlocksOpError(op, objType, t.Column.String(), "policy", e.Name))
 		}
 	})
 
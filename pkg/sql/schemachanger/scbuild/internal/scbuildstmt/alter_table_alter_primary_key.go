@@ -1125,7 +1125,7 @@ func checkIfColumnCanBeDropped(b BuildCtx, columnToDrop *scpb.Column) bool {
 			} else {
 				canBeDropped = false
 			}
-		case *scpb.View, *scpb.Sequence:
+		case *scpb.View, *scpb.Sequence, *scpb.Policy:
 			canBeDropped = false
 		case *scpb.SecondaryIndex:
 			isOnlyKeySuffixColumn := true
@@ -244,6 +244,11 @@ func dropColumn(
 			// Otherwise, it is a dependency on the column used in the expiration
 			// expression.
 			panic(sqlerrors.NewAlterDependsOnExpirationExprError(op, objType, cn.Name, tn.Object(), string(e.ExpirationExpr)))
+		case *scpb.Policy:
+			if behavior != tree.DropCascade {
+				panic(sqlerrors.NewDependentBlocksOpError(op, objType, cn.Name, "policy", e.Name))
+			}
+			b.Drop(e)
 		default:
 			b.Drop(e)
 		}
@@ -291,7 +296,7 @@ func walkColumnDependencies(
 				*scpb.ColumnDefaultExpression, *scpb.ColumnOnUpdateExpression,
 				*scpb.UniqueWithoutIndexConstraint, *scpb.CheckConstraint,
 				*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.CheckConstraintUnvalidated,
-				*scpb.RowLevelTTL:
+				*scpb.RowLevelTTL, *scpb.Policy:
 				fn(e, op, objType)
 			case *scpb.ColumnType:
 				if elt.ColumnID == col.ColumnID {
@@ -0,0 +1,50 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// alterTableSetRLSMode implements
+// `ALTER TABLE ... { ENABLE | DISABLE | [NO] FORCE } ROW LEVEL SECURITY`.
+func alterTableSetRLSMode(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableSetRLSMode,
+) {
+	if !b.HasOwnership(tbl) {
+		panic(pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tn.Object()))
+	}
+	tableElts := b.QueryByID(tbl.TableID).Filter(publicTargetFilter)
+	_, _, enabled := scpb.FindRowLevelSecurityEnabled(tableElts)
+	_, _, forced := scpb.FindRowLevelSecurityForced(tableElts)
+	switch t.Mode {
+	case tree.TableRLSEnable:
+		if enabled == nil {
+			b.Add(&scpb.RowLevelSecurityEnabled{TableID: tbl.TableID})
+		}
+	case tree.TableRLSDisable:
+		if enabled != nil {
+			b.Drop(enabled)
+		}
+	case tree.TableRLSForce:
+		if forced == nil {
+			b.Add(&scpb.RowLevelSecurityForced{TableID: tbl.TableID})
+		}
+	case tree.TableRLSNoForce:
+		if forced != nil {
+			b.Drop(forced)
+		}
+	}
+}
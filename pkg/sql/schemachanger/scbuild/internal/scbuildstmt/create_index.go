@@ -367,6 +367,13 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
+		case types.RangeFamily:
+			switch columnNode.OpClass {
+			case "range_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
+			b.IncrementSchemaChangeIndexCounter("range_inverted")
 
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
@@ -0,0 +1,179 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// CreatePolicy implements CREATE POLICY.
+func CreatePolicy(b BuildCtx, n *tree.CreatePolicy) {
+	tn, tbl := resolveTableForPolicy(b, n.TableName)
+	if findPolicyByName(b, tbl.TableID, n.PolicyName) != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"policy %q for table %q already exists", n.PolicyName, tn.Object()))
+	}
+	cmd := policyCommandFromTree(n.Cmd)
+	validatePolicyExpressions(cmd, n.Exprs)
+	p := &scpb.Policy{
+		TableID:   tbl.TableID,
+		PolicyID:  b.NextTablePolicyID(tbl.TableID),
+		Name:      string(n.PolicyName),
+		Type:      policyTypeFromTree(n.Type),
+		Command:   cmd,
+		RoleNames: policyRoleNames(b, n.Roles),
+	}
+	if n.Exprs.Using != nil {
+		p.UsingExpr = policyExpression(b, tn, tbl, n.Exprs.Using, tree.PolicyUsingExpr)
+	}
+	if n.Exprs.WithCheck != nil {
+		p.WithCheckExpr = policyExpression(b, tn, tbl, n.Exprs.WithCheck, tree.PolicyWithCheckExpr)
+	}
+	b.Add(p)
+	b.LogEventForExistingTarget(p)
+}
+
+// resolveTableForPolicy resolves the table targeted by a policy statement. Only
+// the owner of a table may create, alter or drop its policies.
+func resolveTableForPolicy(
+	b BuildCtx, name *tree.UnresolvedObjectName,
+) (*tree.TableName, *scpb.Table) {
+	elts := b.ResolveTable(name, ResolveParams{RequireOwnership: true})
+	_, target, tbl := scpb.FindTable(elts)
+	if target != scpb.ToPublic {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %q is being dropped, try again later", name.Object()))
+	}
+	panicIfSchemaIsLocked(elts)
+	tn := name.ToTableName()
+	tn.ObjectNamePrefix = b.NamePrefix(tbl)
+	b.SetUnresolvedNameAnnotation(name, &tn)
+	return &tn, tbl
+}
+
+// findPolicyByName returns the public policy element with the given name on
+// the table, or nil if there is none.
+func findPolicyByName(b BuildCtx, tableID catid.DescID, name tree.Name) (ret *scpb.Policy) {
+	scpb.ForEachPolicy(b.QueryByID(tableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.Policy,
+	) {
+		if e.Name == string(name) {
+			ret = e
+		}
+	})
+	return ret
+}
+
+func policyTypeFromTree(t tree.PolicyType) catpb.PolicyType {
+	if t == tree.PolicyTypeRestrictive {
+		return catpb.PolicyType_RESTRICTIVE
+	}
+	return catpb.PolicyType_PERMISSIVE
+}
+
+func policyCommandFromTree(c tree.PolicyCommand) catpb.PolicyCommand {
+	switch c {
+	case tree.PolicyCommandSelect:
+		return catpb.PolicyCommand_SELECT
+	case tree.PolicyCommandInsert:
+		return catpb.PolicyCommand_INSERT
+	case tree.PolicyCommandUpdate:
+		return catpb.PolicyCommand_UPDATE
+	case tree.PolicyCommandDelete:
+		return catpb.PolicyCommand_DELETE
+	default:
+		return catpb.PolicyCommand_ALL
+	}
+}
+
+// validatePolicyExpressions checks that the expressions given to a policy are
+// meaningful for the command it applies to.
+func validatePolicyExpressions(cmd catpb.PolicyCommand, exprs tree.PolicyExpressions) {
+	switch cmd {
+	case catpb.PolicyCommand_INSERT:
+		if exprs.Using != nil {
+			panic(pgerror.New(pgcode.Syntax, "only WITH CHECK expression allowed for INSERT"))
+		}
+	case catpb.PolicyCommand_SELECT, catpb.PolicyCommand_DELETE:
+		if exprs.WithCheck != nil {
+			panic(pgerror.New(pgcode.Syntax, "WITH CHECK cannot be applied to SELECT or DELETE"))
+		}
+	}
+}
+
+// policyRoleNames returns the normalized names of the roles to which a policy
+// applies. A policy without roles applies to all roles, which is represented
+// by the public role.
+func policyRoleNames(b BuildCtx, roles tree.RoleSpecList) []string {
+	if len(roles) == 0 {
+		return []string{username.PublicRole}
+	}
+	users, err := decodeusername.FromRoleSpecList(
+		b.SessionData(), username.PurposeValidation, roles,
+	)
+	if err != nil {
+		panic(err)
+	}
+	ret := make([]string, 0, len(users))
+	for _, u := range users {
+		if !u.IsPublicRole() {
+			if err := b.CheckRoleExists(b, u); err != nil {
+				panic(err)
+			}
+		}
+		ret = append(ret, u.Normalized())
+	}
+	return ret
+}
+
+// policyExpression validates a USING or WITH CHECK expression of a policy and
+// wraps it into an scpb.Expression.
+func policyExpression(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, expr tree.Expr, ctx tree.SchemaExprContext,
+) *scpb.Expression {
+	validExpr, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, expr, types.Bool,
+		ctx, b.SemaCtx(), volatility.Volatile, tn, b.ClusterSettings().Version.ActiveVersion(b),
+		func() colinfo.ResultColumns {
+			return getNonDropResultColumns(b, tbl.TableID)
+		},
+		func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+			return columnLookupFn(b, tbl.TableID, columnName)
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	typedExpr, err := parser.ParseExpr(validExpr)
+	if err != nil {
+		panic(err)
+	}
+	ret := b.WrapExpression(tbl.TableID, typedExpr)
+	// Policies do not maintain back-references to the objects used by their
+	// expressions.
+	if len(ret.UsesTypeIDs) > 0 || len(ret.UsesSequenceIDs) > 0 || len(ret.UsesFunctionIDs) > 0 {
+		panic(unimplemented.NewWithIssue(73372,
+			"policy expressions referencing user-defined types, sequences or functions are not supported"))
+	}
+	return ret
+}
@@ -280,6 +280,10 @@ type TableHelpers interface {
 	// added to this table.
 	NextTableConstraintID(tableID catid.DescID) catid.ConstraintID
 
+	// NextTablePolicyID returns the ID that should be used for any new
+	// row-level security policy added to this table.
+	NextTablePolicyID(tableID catid.DescID) catid.PolicyID
+
 	// NextTableTentativeIndexID returns the tentative ID, starting from
 	// scbuild.TABLE_TENTATIVE_IDS_START, that should be used for any new index added to
 	// this table.
@@ -0,0 +1,35 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropPolicy implements DROP POLICY.
+func DropPolicy(b BuildCtx, n *tree.DropPolicy) {
+	tn, tbl := resolveTableForPolicy(b, n.TableName)
+	p := findPolicyByName(b, tbl.TableID, n.PolicyName)
+	if p == nil {
+		if n.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"policy %q for relation %q does not exist, skipping", n.PolicyName, tn.Object()))
+			return
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.PolicyName, tn.Object()))
+	}
+	b.Drop(p)
+	b.LogEventForExistingTarget(p)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
 )
 
 // DropType implements DROP TYPE.
@@ -36,7 +37,20 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		})
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
-		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
+		_, _, domain := scpb.FindDomainType(elts)
+		if n.Domain && domain == nil && !elts.IsEmpty() {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name.Object()))
+		}
+		if !n.Domain && domain != nil {
+			panic(errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is not a type", name.Object()),
+				"Use DROP DOMAIN to remove a domain.",
+			))
+		}
+		if domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
+		} else if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
 			typ = enum
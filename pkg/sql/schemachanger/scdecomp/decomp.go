@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -219,6 +220,11 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      domain.GetID(),
+			ArrayTypeID: domain.GetArrayTypeID(),
+		})
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -347,6 +353,19 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 			}(tbl),
 		})
 	default:
+		// Triggers are not modeled as elements, so schema changes involving a
+		// table with triggers are handled by the legacy schema changer.
+		if len(tbl.GetTriggers()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, /* n */
+				"table %q has triggers", tbl.GetName()))
+		}
+		// Likewise, inheritance is not modeled as elements, so schema changes
+		// involving a table in an inheritance hierarchy are handled by the
+		// legacy schema changer.
+		if len(tbl.GetInherits()) > 0 || len(tbl.GetInheritedBy()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, /* n */
+				"table %q is part of an inheritance hierarchy", tbl.GetName()))
+		}
 		w.ev(descriptorStatus(tbl), &scpb.Table{
 			TableID:     tbl.GetID(),
 			IsTemporary: tbl.IsTemporary(),
@@ -413,6 +432,10 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	policies := tbl.GetPolicies()
+	for i := range policies {
+		w.walkPolicy(tbl, &policies[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -454,6 +477,12 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	if tbl.IsSchemaLocked() {
 		w.ev(scpb.Status_PUBLIC, &scpb.TableSchemaLocked{TableID: tbl.GetID()})
 	}
+	if tbl.IsRowLevelSecurityEnabled() {
+		w.ev(scpb.Status_PUBLIC, &scpb.RowLevelSecurityEnabled{TableID: tbl.GetID()})
+	}
+	if tbl.IsRowLevelSecurityForced() {
+		w.ev(scpb.Status_PUBLIC, &scpb.RowLevelSecurityForced{TableID: tbl.GetID()})
+	}
 }
 
 func (w *walkCtx) walkLocality(tbl catalog.TableDescriptor, l *catpb.LocalityConfig) {
@@ -754,6 +783,35 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 	}
 }
 
+func (w *walkCtx) walkPolicy(tbl catalog.TableDescriptor, p *descpb.PolicyDescriptor) {
+	policy := &scpb.Policy{
+		TableID:   tbl.GetID(),
+		PolicyID:  p.ID,
+		Name:      p.Name,
+		Type:      p.Type,
+		Command:   p.Command,
+		RoleNames: p.RoleNames,
+	}
+	for _, e := range []struct {
+		expr string
+		dst  **scpb.Expression
+	}{
+		{expr: p.UsingExpr, dst: &policy.UsingExpr},
+		{expr: p.WithCheckExpr, dst: &policy.WithCheckExpr},
+	} {
+		if e.expr == "" {
+			continue
+		}
+		expr, err := w.newExpression(e.expr)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "policy %q in table %q (%d)",
+				p.Name, tbl.GetName(), tbl.GetID()))
+		}
+		*e.dst = expr
+	}
+	w.ev(scpb.Status_PUBLIC, policy)
+}
+
 func (w *walkCtx) walkCheckConstraint(tbl catalog.TableDescriptor, c catalog.CheckConstraint) {
 	expr, err := w.newExpression(c.GetExpr())
 	if err != nil {
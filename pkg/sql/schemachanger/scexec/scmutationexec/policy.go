@@ -0,0 +1,65 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+)
+
+func (i *immediateVisitor) AddPolicy(ctx context.Context, op scop.AddPolicy) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	if op.Policy.ID >= tbl.NextPolicyID {
+		tbl.NextPolicyID = op.Policy.ID + 1
+	}
+	tbl.Policies = append(tbl.Policies, op.Policy)
+	return nil
+}
+
+func (i *immediateVisitor) RemovePolicy(ctx context.Context, op scop.RemovePolicy) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	for j := range tbl.Policies {
+		if tbl.Policies[j].ID == op.PolicyID {
+			tbl.Policies = append(tbl.Policies[:j], tbl.Policies[j+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) SetRowLevelSecurityEnabled(
+	ctx context.Context, op scop.SetRowLevelSecurityEnabled,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	tbl.RowLevelSecurityEnabled = op.Enabled
+	return nil
+}
+
+func (i *immediateVisitor) SetRowLevelSecurityForced(
+	ctx context.Context, op scop.SetRowLevelSecurityForced,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || tbl.Dropped() {
+		return err
+	}
+	tbl.RowLevelSecurityForced = op.Forced
+	return nil
+}
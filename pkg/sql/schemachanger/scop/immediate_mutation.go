@@ -927,3 +927,33 @@ type AddIndexZoneConfig struct {
 	Subzone      zonepb.Subzone
 	SubzoneSpans []zonepb.SubzoneSpan
 }
+
+// AddPolicy adds a row-level security policy to a table.
+type AddPolicy struct {
+	immediateMutationOp
+	TableID descpb.ID
+	Policy  descpb.PolicyDescriptor
+}
+
+// RemovePolicy removes a row-level security policy from a table.
+type RemovePolicy struct {
+	immediateMutationOp
+	TableID  descpb.ID
+	PolicyID descpb.PolicyID
+}
+
+// SetRowLevelSecurityEnabled sets whether the row-level security policies of
+// a table are enforced.
+type SetRowLevelSecurityEnabled struct {
+	immediateMutationOp
+	TableID descpb.ID
+	Enabled bool
+}
+
+// SetRowLevelSecurityForced sets whether the row-level security policies of a
+// table are also enforced for the table owner.
+type SetRowLevelSecurityForced struct {
+	immediateMutationOp
+	TableID descpb.ID
+	Forced  bool
+}
@@ -140,6 +140,10 @@ type ImmediateMutationVisitor interface {
 	AddDatabaseZoneConfig(context.Context, AddDatabaseZoneConfig) error
 	AddTableZoneConfig(context.Context, AddTableZoneConfig) error
 	AddIndexZoneConfig(context.Context, AddIndexZoneConfig) error
+	AddPolicy(context.Context, AddPolicy) error
+	RemovePolicy(context.Context, RemovePolicy) error
+	SetRowLevelSecurityEnabled(context.Context, SetRowLevelSecurityEnabled) error
+	SetRowLevelSecurityForced(context.Context, SetRowLevelSecurityForced) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -731,3 +735,23 @@ func (op AddTableZoneConfig) Visit(ctx context.Context, v ImmediateMutationVisit
 func (op AddIndexZoneConfig) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.AddIndexZoneConfig(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddPolicy) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddPolicy(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemovePolicy) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemovePolicy(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetRowLevelSecurityEnabled) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetRowLevelSecurityEnabled(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetRowLevelSecurityForced) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetRowLevelSecurityForced(ctx, op)
+}
@@ -866,6 +866,43 @@ func (c *ElementCollection[E]) FilterDatabaseZoneConfig() *ElementCollection[*Da
 	return (*ElementCollection[*DatabaseZoneConfig])(ret)
 }
 
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -1569,6 +1606,43 @@ func (c *ElementCollection[E]) FilterOwner() *ElementCollection[*Owner] {
 	return (*ElementCollection[*Owner])(ret)
 }
 
+func (e Policy) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_Policy) Element() Element {
+	return e.Policy
+}
+
+// ForEachPolicy iterates over elements of type Policy.
+// Deprecated
+func ForEachPolicy(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *Policy),
+) {
+  c.FilterPolicy().ForEach(fn)
+}
+
+// FindPolicy finds the first element of type Policy.
+// Deprecated
+func FindPolicy(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *Policy) {
+	if tc := c.FilterPolicy(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*Policy)
+	}
+	return current, target, element
+}
+
+// PolicyElements filters elements of type Policy.
+func (c *ElementCollection[E]) FilterPolicy() *ElementCollection[*Policy] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*Policy)
+		return ok
+	})
+	return (*ElementCollection[*Policy])(ret)
+}
+
 func (e PrimaryIndex) element() {}
 
 // Element implements ElementGetter.
@@ -1606,6 +1680,80 @@ func (c *ElementCollection[E]) FilterPrimaryIndex() *ElementCollection[*PrimaryI
 	return (*ElementCollection[*PrimaryIndex])(ret)
 }
 
+func (e RowLevelSecurityEnabled) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_RowLevelSecurityEnabled) Element() Element {
+	return e.RowLevelSecurityEnabled
+}
+
+// ForEachRowLevelSecurityEnabled iterates over elements of type RowLevelSecurityEnabled.
+// Deprecated
+func ForEachRowLevelSecurityEnabled(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *RowLevelSecurityEnabled),
+) {
+  c.FilterRowLevelSecurityEnabled().ForEach(fn)
+}
+
+// FindRowLevelSecurityEnabled finds the first element of type RowLevelSecurityEnabled.
+// Deprecated
+func FindRowLevelSecurityEnabled(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *RowLevelSecurityEnabled) {
+	if tc := c.FilterRowLevelSecurityEnabled(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*RowLevelSecurityEnabled)
+	}
+	return current, target, element
+}
+
+// RowLevelSecurityEnabledElements filters elements of type RowLevelSecurityEnabled.
+func (c *ElementCollection[E]) FilterRowLevelSecurityEnabled() *ElementCollection[*RowLevelSecurityEnabled] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*RowLevelSecurityEnabled)
+		return ok
+	})
+	return (*ElementCollection[*RowLevelSecurityEnabled])(ret)
+}
+
+func (e RowLevelSecurityForced) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_RowLevelSecurityForced) Element() Element {
+	return e.RowLevelSecurityForced
+}
+
+// ForEachRowLevelSecurityForced iterates over elements of type RowLevelSecurityForced.
+// Deprecated
+func ForEachRowLevelSecurityForced(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *RowLevelSecurityForced),
+) {
+  c.FilterRowLevelSecurityForced().ForEach(fn)
+}
+
+// FindRowLevelSecurityForced finds the first element of type RowLevelSecurityForced.
+// Deprecated
+func FindRowLevelSecurityForced(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *RowLevelSecurityForced) {
+	if tc := c.FilterRowLevelSecurityForced(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*RowLevelSecurityForced)
+	}
+	return current, target, element
+}
+
+// RowLevelSecurityForcedElements filters elements of type RowLevelSecurityForced.
+func (c *ElementCollection[E]) FilterRowLevelSecurityForced() *ElementCollection[*RowLevelSecurityForced] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*RowLevelSecurityForced)
+		return ok
+	})
+	return (*ElementCollection[*RowLevelSecurityForced])(ret)
+}
+
 func (e RowLevelTTL) element() {}
 
 // Element implements ElementGetter.
@@ -2619,6 +2767,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
 		case *DatabaseZoneConfig:
 			e.ElementOneOf = &ElementProto_DatabaseZoneConfig{ DatabaseZoneConfig: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -2657,8 +2807,14 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_Namespace{ Namespace: t}
 		case *Owner:
 			e.ElementOneOf = &ElementProto_Owner{ Owner: t}
+		case *Policy:
+			e.ElementOneOf = &ElementProto_Policy{ Policy: t}
 		case *PrimaryIndex:
 			e.ElementOneOf = &ElementProto_PrimaryIndex{ PrimaryIndex: t}
+		case *RowLevelSecurityEnabled:
+			e.ElementOneOf = &ElementProto_RowLevelSecurityEnabled{ RowLevelSecurityEnabled: t}
+		case *RowLevelSecurityForced:
+			e.ElementOneOf = &ElementProto_RowLevelSecurityForced{ RowLevelSecurityForced: t}
 		case *RowLevelTTL:
 			e.ElementOneOf = &ElementProto_RowLevelTTL{ RowLevelTTL: t}
 		case *Schema:
@@ -2740,6 +2896,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
 	((*ElementProto_DatabaseZoneConfig)(nil)),
+	((*ElementProto_DomainType)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -2759,7 +2916,10 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_IndexZoneConfig)(nil)),
 	((*ElementProto_Namespace)(nil)),
 	((*ElementProto_Owner)(nil)),
+	((*ElementProto_Policy)(nil)),
 	((*ElementProto_PrimaryIndex)(nil)),
+	((*ElementProto_RowLevelSecurityEnabled)(nil)),
+	((*ElementProto_RowLevelSecurityForced)(nil)),
 	((*ElementProto_RowLevelTTL)(nil)),
 	((*ElementProto_Schema)(nil)),
 	((*ElementProto_SchemaChild)(nil)),
@@ -2835,7 +2995,10 @@ func GetElementTypes() []interface{} {
 	((*IndexZoneConfig)(nil)),
 	((*Namespace)(nil)),
 	((*Owner)(nil)),
+	((*Policy)(nil)),
 	((*PrimaryIndex)(nil)),
+	((*RowLevelSecurityEnabled)(nil)),
+	((*RowLevelSecurityForced)(nil)),
 	((*RowLevelTTL)(nil)),
 	((*Schema)(nil)),
 	((*SchemaChild)(nil)),
@@ -0,0 +1,67 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.Policy)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.Policy) *scop.AddPolicy {
+					return &scop.AddPolicy{
+						TableID: this.TableID,
+						Policy:  makePolicyDescriptor(this),
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.Policy) *scop.RemovePolicy {
+					return &scop.RemovePolicy{
+						TableID:  this.TableID,
+						PolicyID: this.PolicyID,
+					}
+				}),
+			),
+		),
+	)
+}
+
+// makePolicyDescriptor returns the descriptor representation of the policy.
+func makePolicyDescriptor(this *scpb.Policy) descpb.PolicyDescriptor {
+	p := descpb.PolicyDescriptor{
+		ID:        this.PolicyID,
+		Name:      this.Name,
+		Type:      this.Type,
+		Command:   this.Command,
+		RoleNames: this.RoleNames,
+	}
+	var colIDs catalog.TableColSet
+	if this.UsingExpr != nil {
+		p.UsingExpr = string(this.UsingExpr.Expr)
+		colIDs.UnionWith(catalog.MakeTableColSet(this.UsingExpr.ReferencedColumnIDs...))
+	}
+	if this.WithCheckExpr != nil {
+		p.WithCheckExpr = string(this.WithCheckExpr.Expr)
+		colIDs.UnionWith(catalog.MakeTableColSet(this.WithCheckExpr.ReferencedColumnIDs...))
+	}
+	p.ColumnIDs = colIDs.Ordered()
+	return p
+}
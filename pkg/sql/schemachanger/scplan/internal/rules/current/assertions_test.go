@@ -116,8 +116,8 @@ func checkIsWithExpression(e scpb.Element) error {
 	return screl.WalkExpressions(e, func(t *catpb.Expression) error {
 		switch e.(type) {
 		// Ignore elements which have catpb.Expression fields but which don't
-		// have them within an scpb.Expression for valid reasons.
-		case *scpb.RowLevelTTL:
+		// have them within a single scpb.Expression for valid reasons.
+		case *scpb.RowLevelTTL, *scpb.Policy:
 			return nil
 		}
 		if isWithExpression(e) {
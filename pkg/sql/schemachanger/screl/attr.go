@@ -79,6 +79,8 @@ const (
 	// zone config element. Those two DatabaseZoneConfig nodes in the graph will
 	// have different SeqNum attribute.
 	SeqNum
+	// PolicyID is the ID of a row-level security policy.
+	PolicyID
 
 	// TargetStatus is the target status of an element.
 	TargetStatus
@@ -149,6 +151,9 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.CompositeType)(nil)),
 		rel.EntityAttr(DescID, "TypeID"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
 	rel.EntityMapping(t((*scpb.CompositeTypeAttrName)(nil)),
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(Name, "Name"),
@@ -239,6 +244,17 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.RowLevelTTL)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
+	rel.EntityMapping(t((*scpb.Policy)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(PolicyID, "PolicyID"),
+		rel.EntityAttr(Name, "Name"),
+	),
+	rel.EntityMapping(t((*scpb.RowLevelSecurityEnabled)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+	),
+	rel.EntityMapping(t((*scpb.RowLevelSecurityForced)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+	),
 	// Multi-region elements.
 	rel.EntityMapping(t((*scpb.TableLocalityGlobal)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
@@ -1371,6 +1371,7 @@ const sizeOfDecimalVarPopAggregate = int64(unsafe.Sizeof(decimalVarPopAggregate{
 const sizeOfFloatStdDevAggregate = int64(unsafe.Sizeof(floatStdDevAggregate{}))
 const sizeOfDecimalStdDevAggregate = int64(unsafe.Sizeof(decimalStdDevAggregate{}))
 const sizeOfAnyNotNullAggregate = int64(unsafe.Sizeof(anyNotNullAggregate{}))
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
 const sizeOfConcatAggregate = int64(unsafe.Sizeof(concatAggregate{}))
 const sizeOfBoolAndAggregate = int64(unsafe.Sizeof(boolAndAggregate{}))
 const sizeOfBoolOrAggregate = int64(unsafe.Sizeof(boolOrAggregate{}))
@@ -1583,6 +1584,95 @@ func (a *anyNotNullAggregate) Size() int64 {
 	return sizeOfAnyNotNullAggregate
 }
 
+// See NewUserDefinedAggregate.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx *eval.Context
+	udAgg   *tree.UserDefinedAggregate
+	state   tree.Datum
+	// ctx is the context of the most recent call to Add or Reset. Result does
+	// not take a context, so it is used to evaluate the final function.
+	ctx context.Context
+}
+
+// NewUserDefinedAggregate returns an aggregate function that folds its input
+// into a state by evaluating the transition routine of a user-defined
+// aggregate, and produces its result by evaluating the final routine, if any,
+// on the final state.
+//
+// Like in Postgres, if the transition routine is strict, input rows with NULL
+// arguments are skipped and, if the initial state is NULL, the first non-NULL
+// argument becomes the state.
+func NewUserDefinedAggregate(
+	evalCtx *eval.Context, udAgg *tree.UserDefinedAggregate,
+) eval.AggregateFunc {
+	return &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		udAgg:                    udAgg,
+		state:                    udAgg.InitialState,
+		ctx:                      context.Background(),
+	}
+}
+
+// Add evaluates the transition routine with the current state and the given
+// arguments, and stores the result as the new state.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	if !a.udAgg.Transition.CalledOnNullInput {
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			a.state = firstArg
+			return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+		}
+	}
+	args := make(tree.Datums, 0, 2+len(otherArgs))
+	args = append(args, a.state, firstArg)
+	args = append(args, otherArgs...)
+	state, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.udAgg.Transition, args)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+}
+
+// Result returns the result of the final routine applied to the current
+// state, or the current state if there is no final routine.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.udAgg.Final == nil {
+		return a.state, nil
+	}
+	return a.evalCtx.Planner.EvalRoutineExpr(a.ctx, a.udAgg.Final, tree.Datums{a.state})
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.ctx = ctx
+	a.state = a.udAgg.InitialState
+	a.reset(ctx)
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
 type arrayAggregate struct {
 	arr *tree.DArray
 	// Note that we do not embed singleDatumAggregateBase struct to help with
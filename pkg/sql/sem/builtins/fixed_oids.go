@@ -1400,7 +1400,7 @@ var builtinOidsArray = []string{
 	1424: `obj_description(object_oid: oid, catalog_name: string) -> string`,
 	1425: `oid(int: int) -> oid`,
 	1426: `shobj_description(object_oid: oid, catalog_name: string) -> string`,
-	1427: `pg_try_advisory_lock(int: int) -> bool`,
+	1427: `pg_try_advisory_lock(key: int) -> bool`,
 	1428: `pg_advisory_unlock(key: int) -> bool`,
 	1429: `pg_client_encoding() -> string`,
 	1430: `pg_function_is_visible(oid: oid) -> bool`,
@@ -2607,6 +2607,185 @@ var builtinOidsArray = []string{
 	2639: `crdb_internal.start_replication_stream_for_tables(req: bytes) -> bytes`,
 	2640: `crdb_internal.clear_query_plan_cache() -> void`,
 	2641: `crdb_internal.clear_table_stats_cache() -> void`,
+	2642: `int4rangesend(int4range: int4range) -> bytes`,
+	2643: `int4rangerecv(input: anyelement) -> int4range`,
+	2644: `int4rangeout(int4range: int4range) -> bytes`,
+	2645: `int4rangein(input: anyelement) -> int4range`,
+	2646: `char(int4range: int4range) -> "char"`,
+	2647: `name(int4range: int4range) -> name`,
+	2648: `text(int4range: int4range) -> string`,
+	2649: `varchar(int4range: int4range) -> varchar`,
+	2650: `bpchar(int4range: int4range) -> char`,
+	2651: `int4range(string: string) -> int4range`,
+	2652: `int4range(int4range: int4range) -> int4range`,
+	2653: `int4range(lower: int4, upper: int4) -> int4range`,
+	2654: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2655: `lower(range: int4range) -> int4`,
+	2656: `upper(range: int4range) -> int4`,
+	2657: `isempty(range: int4range) -> bool`,
+	2658: `lower_inc(range: int4range) -> bool`,
+	2659: `upper_inc(range: int4range) -> bool`,
+	2660: `lower_inf(range: int4range) -> bool`,
+	2661: `upper_inf(range: int4range) -> bool`,
+	2662: `range_adjacent(left: int4range, right: int4range) -> bool`,
+	2663: `range_merge(left: int4range, right: int4range) -> int4range`,
+	2664: `int8rangesend(int8range: int8range) -> bytes`,
+	2665: `int8rangerecv(input: anyelement) -> int8range`,
+	2666: `int8rangeout(int8range: int8range) -> bytes`,
+	2667: `int8rangein(input: anyelement) -> int8range`,
+	2668: `char(int8range: int8range) -> "char"`,
+	2669: `name(int8range: int8range) -> name`,
+	2670: `text(int8range: int8range) -> string`,
+	2671: `varchar(int8range: int8range) -> varchar`,
+	2672: `bpchar(int8range: int8range) -> char`,
+	2673: `int8range(string: string) -> int8range`,
+	2674: `int8range(int8range: int8range) -> int8range`,
+	2675: `int8range(lower: int, upper: int) -> int8range`,
+	2676: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2677: `lower(range: int8range) -> int`,
+	2678: `upper(range: int8range) -> int`,
+	2679: `isempty(range: int8range) -> bool`,
+	2680: `lower_inc(range: int8range) -> bool`,
+	2681: `upper_inc(range: int8range) -> bool`,
+	2682: `lower_inf(range: int8range) -> bool`,
+	2683: `upper_inf(range: int8range) -> bool`,
+	2684: `range_adjacent(left: int8range, right: int8range) -> bool`,
+	2685: `range_merge(left: int8range, right: int8range) -> int8range`,
+	2686: `numrangesend(numrange: numrange) -> bytes`,
+	2687: `numrangerecv(input: anyelement) -> numrange`,
+	2688: `numrangeout(numrange: numrange) -> bytes`,
+	2689: `numrangein(input: anyelement) -> numrange`,
+	2690: `char(numrange: numrange) -> "char"`,
+	2691: `name(numrange: numrange) -> name`,
+	2692: `text(numrange: numrange) -> string`,
+	2693: `varchar(numrange: numrange) -> varchar`,
+	2694: `bpchar(numrange: numrange) -> char`,
+	2695: `numrange(string: string) -> numrange`,
+	2696: `numrange(numrange: numrange) -> numrange`,
+	2697: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2698: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2699: `lower(range: numrange) -> decimal`,
+	2700: `upper(range: numrange) -> decimal`,
+	2701: `isempty(range: numrange) -> bool`,
+	2702: `lower_inc(range: numrange) -> bool`,
+	2703: `upper_inc(range: numrange) -> bool`,
+	2704: `lower_inf(range: numrange) -> bool`,
+	2705: `upper_inf(range: numrange) -> bool`,
+	2706: `range_adjacent(left: numrange, right: numrange) -> bool`,
+	2707: `range_merge(left: numrange, right: numrange) -> numrange`,
+	2708: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2709: `tsrangerecv(input: anyelement) -> tsrange`,
+	2710: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2711: `tsrangein(input: anyelement) -> tsrange`,
+	2712: `char(tsrange: tsrange) -> "char"`,
+	2713: `name(tsrange: tsrange) -> name`,
+	2714: `text(tsrange: tsrange) -> string`,
+	2715: `varchar(tsrange: tsrange) -> varchar`,
+	2716: `bpchar(tsrange: tsrange) -> char`,
+	2717: `tsrange(string: string) -> tsrange`,
+	2718: `tsrange(tsrange: tsrange) -> tsrange`,
+	2719: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2720: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2721: `lower(range: tsrange) -> timestamp`,
+	2722: `upper(range: tsrange) -> timestamp`,
+	2723: `isempty(range: tsrange) -> bool`,
+	2724: `lower_inc(range: tsrange) -> bool`,
+	2725: `upper_inc(range: tsrange) -> bool`,
+	2726: `lower_inf(range: tsrange) -> bool`,
+	2727: `upper_inf(range: tsrange) -> bool`,
+	2728: `range_adjacent(left: tsrange, right: tsrange) -> bool`,
+	2729: `range_merge(left: tsrange, right: tsrange) -> tsrange`,
+	2730: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2731: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2732: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2733: `tstzrangein(input: anyelement) -> tstzrange`,
+	2734: `char(tstzrange: tstzrange) -> "char"`,
+	2735: `name(tstzrange: tstzrange) -> name`,
+	2736: `text(tstzrange: tstzrange) -> string`,
+	2737: `varchar(tstzrange: tstzrange) -> varchar`,
+	2738: `bpchar(tstzrange: tstzrange) -> char`,
+	2739: `tstzrange(string: string) -> tstzrange`,
+	2740: `tstzrange(tstzrange: tstzrange) -> tstzrange`,
+	2741: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2742: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2743: `lower(range: tstzrange) -> timestamptz`,
+	2744: `upper(range: tstzrange) -> timestamptz`,
+	2745: `isempty(range: tstzrange) -> bool`,
+	2746: `lower_inc(range: tstzrange) -> bool`,
+	2747: `upper_inc(range: tstzrange) -> bool`,
+	2748: `lower_inf(range: tstzrange) -> bool`,
+	2749: `upper_inf(range: tstzrange) -> bool`,
+	2750: `range_adjacent(left: tstzrange, right: tstzrange) -> bool`,
+	2751: `range_merge(left: tstzrange, right: tstzrange) -> tstzrange`,
+	2752: `daterangesend(daterange: daterange) -> bytes`,
+	2753: `daterangerecv(input: anyelement) -> daterange`,
+	2754: `daterangeout(daterange: daterange) -> bytes`,
+	2755: `daterangein(input: anyelement) -> daterange`,
+	2756: `char(daterange: daterange) -> "char"`,
+	2757: `name(daterange: daterange) -> name`,
+	2758: `text(daterange: daterange) -> string`,
+	2759: `varchar(daterange: daterange) -> varchar`,
+	2760: `bpchar(daterange: daterange) -> char`,
+	2761: `daterange(string: string) -> daterange`,
+	2762: `daterange(daterange: daterange) -> daterange`,
+	2763: `daterange(lower: date, upper: date) -> daterange`,
+	2764: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2765: `lower(range: daterange) -> date`,
+	2766: `upper(range: daterange) -> date`,
+	2767: `isempty(range: daterange) -> bool`,
+	2768: `lower_inc(range: daterange) -> bool`,
+	2769: `upper_inc(range: daterange) -> bool`,
+	2770: `lower_inf(range: daterange) -> bool`,
+	2771: `upper_inf(range: daterange) -> bool`,
+	2772: `range_adjacent(left: daterange, right: daterange) -> bool`,
+	2773: `range_merge(left: daterange, right: daterange) -> daterange`,
+	2774: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2775: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2776: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2777: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2778: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2779: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2780: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2781: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2782: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2783: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2784: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2785: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2786: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2787: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2788: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2789: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2790: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2791: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2792: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2793: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2794: `jsonpathin(input: anyelement) -> jsonpath`,
+	2795: `char(jsonpath: jsonpath) -> "char"`,
+	2796: `name(jsonpath: jsonpath) -> name`,
+	2797: `text(jsonpath: jsonpath) -> string`,
+	2798: `varchar(jsonpath: jsonpath) -> varchar`,
+	2799: `bpchar(jsonpath: jsonpath) -> char`,
+	2800: `jsonpath(string: string) -> jsonpath`,
+	2801: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2802: `crdb_internal.plpgsql_execute(query: string, params: anyelement, into: bool, strict: bool, resultTypes: anyelement) -> anyelement`,
+	2803: `crdb_internal.plpgsql_open_dynamic(name: refcursor, query: string, params: anyelement) -> int`,
+	2804: `pg_advisory_lock(key: int) -> void`,
+	2805: `pg_advisory_lock(key1: int4, key2: int4) -> void`,
+	2806: `pg_advisory_lock_shared(key: int) -> void`,
+	2807: `pg_advisory_lock_shared(key1: int4, key2: int4) -> void`,
+	2808: `pg_advisory_xact_lock(key: int) -> void`,
+	2809: `pg_advisory_xact_lock(key1: int4, key2: int4) -> void`,
+	2810: `pg_advisory_xact_lock_shared(key: int) -> void`,
+	2811: `pg_advisory_xact_lock_shared(key1: int4, key2: int4) -> void`,
+	2812: `pg_try_advisory_lock(key1: int4, key2: int4) -> bool`,
+	2813: `pg_try_advisory_lock_shared(key: int) -> bool`,
+	2814: `pg_try_advisory_lock_shared(key1: int4, key2: int4) -> bool`,
+	2815: `pg_try_advisory_xact_lock(key: int) -> bool`,
+	2816: `pg_try_advisory_xact_lock(key1: int4, key2: int4) -> bool`,
+	2817: `pg_try_advisory_xact_lock_shared(key: int) -> bool`,
+	2818: `pg_try_advisory_xact_lock_shared(key1: int4, key2: int4) -> bool`,
+	2819: `pg_listening_channels() -> string`,
+	2820: `pg_notify(channel: string, payload: string) -> void`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
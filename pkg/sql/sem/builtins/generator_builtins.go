@@ -259,6 +259,20 @@ var generators = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Returns the names of the channels that the current session is listening on.",
+			volatility.Volatile,
+		),
+	),
 	`pg_options_to_table`: makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -414,6 +428,7 @@ var generators = map[string]builtinDefinition{
 	"jsonb_array_elements_text": makeBuiltin(jsonGenPropsWithLabels(jsonArrayGeneratorLabels), jsonArrayElementsTextImpl),
 	"json_object_keys":          makeBuiltin(genProps(), jsonObjectKeysImpl),
 	"jsonb_object_keys":         makeBuiltin(genProps(), jsonObjectKeysImpl),
+	"jsonb_path_query":          makeBuiltin(jsonProps(), jsonPathQueryImpls...),
 	"json_each":                 makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachImpl),
 	"jsonb_each":                makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachImpl),
 	"json_each_text":            makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachTextImpl),
@@ -1234,6 +1249,20 @@ func makeWorkloadIndexRecsGeneratorFactory(hasTimestamp bool) eval.GeneratorOver
 	}
 }
 
+func makeListeningChannelsGenerator(
+	_ context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	arr := tree.NewDArray(types.String)
+	if evalCtx.Notifications != nil {
+		for _, channel := range evalCtx.Notifications.ListeningChannels() {
+			if err := arr.Append(tree.NewDString(channel)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
 func makeArrayGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
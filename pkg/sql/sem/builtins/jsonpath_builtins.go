@@ -0,0 +1,238 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeBuiltin(jsonProps(),
+		makeJSONPathOverloads(types.Bool, func(target json.JSON, args jsonpathArgs) (tree.Datum, error) {
+			exists, ok, err := args.path.Exists(target, args.vars, args.silent)
+			if err != nil || !ok {
+				return tree.DNull, err
+			}
+			return tree.MakeDBool(tree.DBool(exists)), nil
+		}, "Returns whether the JSON path returns any item for the specified JSON value.")...,
+	),
+	"jsonb_path_exists_opr": makeBuiltin(jsonProps(),
+		makeJSONPathOprOverload(types.Bool, func(target json.JSON, args jsonpathArgs) (tree.Datum, error) {
+			exists, ok, err := args.path.Exists(target, args.vars, args.silent)
+			if err != nil || !ok {
+				return tree.DNull, err
+			}
+			return tree.MakeDBool(tree.DBool(exists)), nil
+		}, "Returns whether the JSON path returns any item for the specified JSON value. "+
+			"This is the implementation of the @? operator."),
+	),
+	"jsonb_path_match": makeBuiltin(jsonProps(),
+		makeJSONPathOverloads(types.Bool, jsonPathMatch,
+			"Returns the result of a JSON path predicate check for the specified JSON value. "+
+				"Only the first item of the result is taken into account. "+
+				"If the result is not Boolean, then NULL is returned.")...,
+	),
+	"jsonb_path_match_opr": makeBuiltin(jsonProps(),
+		makeJSONPathOprOverload(types.Bool, jsonPathMatch,
+			"Returns the result of a JSON path predicate check for the specified JSON value. "+
+				"This is the implementation of the @@ operator."),
+	),
+	"jsonb_path_query_array": makeBuiltin(jsonProps(),
+		makeJSONPathOverloads(types.Jsonb, func(target json.JSON, args jsonpathArgs) (tree.Datum, error) {
+			res, err := args.path.Query(target, args.vars, args.silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		}, "Returns all JSON items returned by the JSON path for the specified JSON value, as a JSON array.")...,
+	),
+	"jsonb_path_query_first": makeBuiltin(jsonProps(),
+		makeJSONPathOverloads(types.Jsonb, func(target json.JSON, args jsonpathArgs) (tree.Datum, error) {
+			res, err := args.path.Query(target, args.vars, args.silent)
+			if err != nil || len(res) == 0 {
+				return tree.DNull, err
+			}
+			return tree.NewDJSON(res[0]), nil
+		}, "Returns the first JSON item returned by the JSON path for the specified JSON value.")...,
+	),
+}
+
+// jsonpathArgs are the arguments of the jsonb_path builtins, other than the
+// target JSON value.
+type jsonpathArgs struct {
+	path *tree.DJsonpath
+	// vars is nil if the vars argument was not specified.
+	vars   json.JSON
+	silent bool
+}
+
+// makeJSONPathArgs extracts the arguments of a jsonb_path builtin. The vars and
+// silent arguments are optional.
+func makeJSONPathArgs(args tree.Datums) jsonpathArgs {
+	res := jsonpathArgs{path: tree.MustBeDJsonpath(args[1])}
+	if len(args) > 2 {
+		res.vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		res.silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return res
+}
+
+// makeJSONPathOverloads returns the overloads of a jsonb_path builtin, which
+// take a target JSON value, a JSON path, and optionally the values of the
+// variables of the path and whether errors should be suppressed.
+func makeJSONPathOverloads(
+	ret *types.T, fn func(target json.JSON, args jsonpathArgs) (tree.Datum, error), info string,
+) []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "target", Typ: types.Jsonb},
+		{Name: "path", Typ: types.Jsonpath},
+		{Name: "vars", Typ: types.Jsonb},
+		{Name: "silent", Typ: types.Bool},
+	}
+	overloads := make([]tree.Overload, 0, 3)
+	for n := 2; n <= len(params); n++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      params[:n],
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDJSON(args[0]).JSON, makeJSONPathArgs(args))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeJSONPathOprOverload returns the overload of a builtin implementing a
+// jsonpath operator. Like the operators, these builtins suppress errors.
+func makeJSONPathOprOverload(
+	ret *types.T, fn func(target json.JSON, args jsonpathArgs) (tree.Datum, error), info string,
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+		ReturnType: tree.FixedReturnType(ret),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			a := makeJSONPathArgs(args)
+			a.silent = true
+			return fn(tree.MustBeDJSON(args[0]).JSON, a)
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+func jsonPathMatch(target json.JSON, args jsonpathArgs) (tree.Datum, error) {
+	res, ok, err := args.path.Match(target, args.vars, args.silent)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	switch res.Type() {
+	case json.TrueJSONType:
+		return tree.DBoolTrue, nil
+	case json.FalseJSONType:
+		return tree.DBoolFalse, nil
+	}
+	return tree.DNull, nil
+}
+
+var jsonPathQueryImpls = func() []tree.Overload {
+	params := tree.ParamTypes{
+		{Name: "target", Typ: types.Jsonb},
+		{Name: "path", Typ: types.Jsonpath},
+		{Name: "vars", Typ: types.Jsonb},
+		{Name: "silent", Typ: types.Bool},
+	}
+	overloads := make([]tree.Overload, 0, 3)
+	for n := 2; n <= len(params); n++ {
+		overloads = append(overloads, makeGeneratorOverload(
+			params[:n],
+			types.Jsonb,
+			makeJSONPathQueryGenerator,
+			"Returns all JSON items returned by the JSON path for the specified JSON value.",
+			volatility.Immutable,
+		))
+	}
+	return overloads
+}()
+
+// jsonPathQueryGenerator supports jsonb_path_query.
+type jsonPathQueryGenerator struct {
+	target json.JSON
+	args   jsonpathArgs
+	res    []json.JSON
+	// nextIndex is the index of the next item of res to return.
+	nextIndex int
+	buf       [1]tree.Datum
+}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &jsonPathQueryGenerator{
+		target: tree.MustBeDJSON(args[0]).JSON,
+		args:   makeJSONPathArgs(args),
+	}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	res, err := g.args.path.Query(g.target, g.args.vars, g.args.silent)
+	if err != nil {
+		return err
+	}
+	g.res = res
+	g.nextIndex = 0
+	return nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	if g.nextIndex >= len(g.res) {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.res[g.nextIndex])
+	g.nextIndex++
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
@@ -206,6 +207,13 @@ func init() {
 			},
 		)
 	}
+	// Range types are also constructed by calling the function named after the
+	// type with the bounds of the range.
+	for _, typ := range types.RangeTypes {
+		if def, ok := castBuiltins[typ.Oid()]; ok {
+			def.overloads = append(def.overloads, makeRangeConstructorOverloads(typ)...)
+		}
+	}
 	for toOID, def := range castBuiltins {
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
@@ -587,6 +595,119 @@ func makeToRegOverload(typ *types.T, helpText string) builtinDefinition {
 	)
 }
 
+var errAdvisoryLocksNotSupported = pgerror.New(
+	pgcode.FeatureNotSupported, "advisory locks are not supported in this context",
+)
+
+// advisoryLockParamTypes are the parameters of the two overloads of the
+// advisory lock builtins: a single bigint key or a pair of int4 keys.
+var advisoryLockParamTypes = []tree.ParamTypes{
+	{{Name: "key", Typ: types.Int}},
+	{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
+}
+
+// makeAdvisoryLockKey returns the key of the advisory lock identified by the
+// arguments of an advisory lock builtin. As in Postgres, advisory locks are
+// scoped to the current database.
+func makeAdvisoryLockKey(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.AdvisoryLockKey, error) {
+	var key eval.AdvisoryLockKey
+	if dbName := evalCtx.SessionData().Database; dbName != "" {
+		id, found, err := evalCtx.PrivilegedAccessor.LookupNamespaceID(ctx, 0, 0, dbName)
+		if err != nil {
+			return key, err
+		}
+		if found {
+			key.DatabaseID = uint32(id)
+		}
+	}
+	if len(args) == 1 {
+		k := uint64(tree.MustBeDInt(args[0]))
+		key.ClassID, key.ObjID, key.ObjSubID = uint32(k>>32), uint32(k), 1
+	} else {
+		key.ClassID = uint32(tree.MustBeDInt(args[0]))
+		key.ObjID = uint32(tree.MustBeDInt(args[1]))
+		key.ObjSubID = 2
+	}
+	return key, nil
+}
+
+// makeAdvisoryLockBuiltin creates a builtin that acquires an advisory lock. If
+// try is set, the builtin returns whether the lock was acquired instead of
+// waiting for it.
+func makeAdvisoryLockBuiltin(shared, xact, try bool, info string) builtinDefinition {
+	retType := types.Void
+	if try {
+		retType = types.Bool
+	}
+	overloads := make([]tree.Overload, len(advisoryLockParamTypes))
+	for i, params := range advisoryLockParamTypes {
+		overloads[i] = tree.Overload{
+			Types:      params,
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if evalCtx.AdvisoryLocks == nil {
+					return nil, errAdvisoryLocksNotSupported
+				}
+				key, err := makeAdvisoryLockKey(ctx, evalCtx, args)
+				if err != nil {
+					return nil, err
+				}
+				acquired, err := evalCtx.AdvisoryLocks.Lock(ctx, key, shared, xact, !try /* wait */)
+				if err != nil {
+					return nil, err
+				}
+				if try {
+					return tree.MakeDBool(tree.DBool(acquired)), nil
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true}, overloads...)
+}
+
+// makeAdvisoryUnlockBuiltin creates a builtin that releases a session-level
+// advisory lock.
+func makeAdvisoryUnlockBuiltin(shared bool, info string) builtinDefinition {
+	lockType := "ExclusiveLock"
+	if shared {
+		lockType = "ShareLock"
+	}
+	overloads := make([]tree.Overload, len(advisoryLockParamTypes))
+	for i, params := range advisoryLockParamTypes {
+		overloads[i] = tree.Overload{
+			Types:      params,
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if evalCtx.AdvisoryLocks == nil {
+					return nil, errAdvisoryLocksNotSupported
+				}
+				key, err := makeAdvisoryLockKey(ctx, evalCtx, args)
+				if err != nil {
+					return nil, err
+				}
+				released, err := evalCtx.AdvisoryLocks.Unlock(ctx, key, shared)
+				if err != nil {
+					return nil, err
+				}
+				if !released && evalCtx.ClientNoticeSender != nil {
+					evalCtx.ClientNoticeSender.BufferClientNotice(ctx, pgnotice.NewWithSeverityf(
+						"WARNING", "you don't own a lock of type %s", lockType,
+					))
+				}
+				return tree.MakeDBool(tree.DBool(released)), nil
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true}, overloads...)
+}
+
 // Format the array {type,othertype} as type, othertype.
 // If there are no args, output the empty string.
 const getFunctionArgStringQuery = `
@@ -1181,68 +1302,114 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
-	"pg_try_advisory_lock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "int", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	"pg_advisory_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, false /* xact */, false, /* try */
+		"Obtains an exclusive session-level advisory lock, waiting if necessary.",
 	),
 
-	"pg_advisory_unlock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	"pg_advisory_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, false /* xact */, false, /* try */
+		"Obtains a shared session-level advisory lock, waiting if necessary.",
 	),
 
-	"pg_advisory_unlock_shared": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	"pg_advisory_xact_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, true /* xact */, false, /* try */
+		"Obtains an exclusive transaction-level advisory lock, waiting if necessary.",
+	),
+
+	"pg_advisory_xact_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, true /* xact */, false, /* try */
+		"Obtains a shared transaction-level advisory lock, waiting if necessary.",
+	),
+
+	"pg_try_advisory_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, false /* xact */, true, /* try */
+		"Obtains an exclusive session-level advisory lock if available. "+
+			"Returns false without waiting if the lock cannot be acquired immediately.",
+	),
+
+	"pg_try_advisory_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, false /* xact */, true, /* try */
+		"Obtains a shared session-level advisory lock if available. "+
+			"Returns false without waiting if the lock cannot be acquired immediately.",
+	),
+
+	"pg_try_advisory_xact_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, true /* xact */, true, /* try */
+		"Obtains an exclusive transaction-level advisory lock if available. "+
+			"Returns false without waiting if the lock cannot be acquired immediately.",
+	),
+
+	"pg_try_advisory_xact_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, true /* xact */, true, /* try */
+		"Obtains a shared transaction-level advisory lock if available. "+
+			"Returns false without waiting if the lock cannot be acquired immediately.",
+	),
+
+	"pg_advisory_unlock": makeAdvisoryUnlockBuiltin(
+		false, /* shared */
+		"Releases a previously-acquired exclusive session-level advisory lock. "+
+			"Returns true if the lock is successfully released. If the lock was not held, "+
+			"false is returned and a warning is reported.",
+	),
+
+	"pg_advisory_unlock_shared": makeAdvisoryUnlockBuiltin(
+		true, /* shared */
+		"Releases a previously-acquired shared session-level advisory lock. "+
+			"Returns true if the lock is successfully released. If the lock was not held, "+
+			"false is returned and a warning is reported.",
+	),
+
+	"pg_advisory_unlock_all": makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				if evalCtx.AdvisoryLocks == nil {
+					return nil, errAdvisoryLocksNotSupported
+				}
+				if err := evalCtx.AdvisoryLocks.UnlockAll(ctx); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
 			},
-			Info:       notUsableInfo,
+			Info:       "Releases all session-level advisory locks held by the current session.",
 			Volatility: volatility.Volatile,
 		},
 	),
 
-	"pg_advisory_unlock_all": makeBuiltin(defProps(),
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
 		tree.Overload{
-			Types:      tree.ParamTypes{},
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.Void),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+			// NULL channels are rejected below, and a NULL payload is treated as an
+			// empty one, as in Postgres.
+			CalledOnNullInput: true,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if evalCtx.Notifications == nil {
+					return nil, pgerror.New(pgcode.FeatureNotSupported,
+						"notifications are not supported in this context")
+				}
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.InvalidParameterValue,
+						"channel name cannot be empty")
+				}
+				var payload string
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				channel := string(tree.MustBeDString(args[0]))
+				if err := evalCtx.Notifications.Notify(ctx, channel, payload); err != nil {
+					return nil, err
+				}
 				return tree.DVoidDatum, nil
 			},
-			Info:       notUsableInfo,
+			Info: "Sends a notification event with the given payload to the sessions listening on " +
+				"the given channel, when the current transaction commits.",
 			Volatility: volatility.Volatile,
 		},
 	),
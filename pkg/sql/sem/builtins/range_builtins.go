@@ -0,0 +1,217 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return makeRangeBoolOverload(t, func(r *tree.DRange) bool {
+				return r.Empty
+			}, "Returns true if the range is empty.")
+		},
+	),
+	"lower_inc": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return makeRangeBoolOverload(t, func(r *tree.DRange) bool {
+				return !r.Empty && !r.Lower.IsInfinite() && r.Lower.Inclusive
+			}, "Returns true if the lower bound of the range is inclusive.")
+		},
+	),
+	"upper_inc": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return makeRangeBoolOverload(t, func(r *tree.DRange) bool {
+				return !r.Empty && !r.Upper.IsInfinite() && r.Upper.Inclusive
+			}, "Returns true if the upper bound of the range is inclusive.")
+		},
+	),
+	"lower_inf": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return makeRangeBoolOverload(t, func(r *tree.DRange) bool {
+				return !r.Empty && r.Lower.IsInfinite()
+			}, "Returns true if the range has no lower bound.")
+		},
+	),
+	"upper_inf": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return makeRangeBoolOverload(t, func(r *tree.DRange) bool {
+				return !r.Empty && r.Upper.IsInfinite()
+			}, "Returns true if the range has no upper bound.")
+		},
+	),
+	"range_adjacent": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return tree.Overload{
+				Types:      tree.ParamTypes{{Name: "left", Typ: t}, {Name: "right", Typ: t}},
+				ReturnType: tree.FixedReturnType(types.Bool),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return tree.MakeDBool(tree.DBool(
+						tree.MustBeDRange(args[0]).Adjacent(tree.MustBeDRange(args[1])),
+					)), nil
+				},
+				Info: "Returns true if the ranges are adjacent. This function is used " +
+					"to implement the `-|-` operator.",
+				Volatility: volatility.Immutable,
+			}
+		},
+	),
+	"range_merge": collectOverloads(defProps(), types.RangeTypes,
+		func(t *types.T) tree.Overload {
+			return tree.Overload{
+				Types:      tree.ParamTypes{{Name: "left", Typ: t}, {Name: "right", Typ: t}},
+				ReturnType: tree.FixedReturnType(t),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					r, err := tree.MustBeDRange(args[0]).Merge(tree.MustBeDRange(args[1]))
+					if err != nil {
+						return nil, err
+					}
+					return r, nil
+				},
+				Info: "Returns the smallest range which includes both of the given " +
+					"ranges.",
+				Volatility: volatility.Immutable,
+			}
+		},
+	),
+}
+
+// makeRangeBoolOverload returns an overload which computes a boolean property
+// of a range of type t.
+func makeRangeBoolOverload(t *types.T, f func(*tree.DRange) bool, info string) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: "range", Typ: t}},
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(f(tree.MustBeDRange(args[0])))), nil
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeRangeBoundOverloads returns the overloads of the lower and upper
+// builtins for all range types. These are part of the string builtins of the
+// same name since a name can only be registered once.
+func makeRangeBoundOverloads(lower bool) []tree.Overload {
+	info := "Returns the upper bound of the range, or NULL if the range is " +
+		"empty or has no upper bound."
+	if lower {
+		info = "Returns the lower bound of the range, or NULL if the range is " +
+			"empty or has no lower bound."
+	}
+	overloads := make([]tree.Overload, 0, len(types.RangeTypes))
+	for _, t := range types.RangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: t}},
+			ReturnType: tree.FixedReturnType(t.RangeContents()),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := tree.MustBeDRange(args[0])
+				b := r.Upper
+				if lower {
+					b = r.Lower
+				}
+				if r.Empty || b.IsInfinite() {
+					return tree.DNull, nil
+				}
+				return b.Val, nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeRangeConstructorOverloads returns the constructor overloads for the
+// range type t, which are part of the cast builtin named after the type. A
+// NULL bound makes the range unbounded on that side.
+func makeRangeConstructorOverloads(t *types.T) []tree.Overload {
+	elemType := t.RangeContents()
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		if len(bounds) != 2 || (bounds[0] != '[' && bounds[0] != '(') ||
+			(bounds[1] != ']' && bounds[1] != ')') {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		var lower, upper tree.RangeBound
+		if args[0] != tree.DNull {
+			lower = tree.RangeBound{Val: args[0], Inclusive: bounds[0] == '['}
+		}
+		if args[1] != tree.DNull {
+			upper = tree.RangeBound{Val: args[1], Inclusive: bounds[1] == ']'}
+		}
+		r, err := tree.NewDRange(t, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	return []tree.Overload{
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elemType},
+				{Name: "upper", Typ: elemType},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			Class: tree.NormalClass,
+			Info: fmt.Sprintf("Constructs a %s with an inclusive lower bound and an "+
+				"exclusive upper bound.", t.SQLString()),
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elemType},
+				{Name: "upper", Typ: elemType},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return tree.DNull, nil
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			Class: tree.NormalClass,
+			Info: fmt.Sprintf("Constructs a %s with the given bounds. The bounds "+
+				"argument is one of `[]`, `[)`, `(]` or `()`.", t.SQLString()),
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	}
+}
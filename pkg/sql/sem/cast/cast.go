@@ -213,6 +213,27 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. A
+	// domain can be implicitly cast to its base type, and a cast to a domain is
+	// allowed in the same contexts as a cast to its base type. Casts to domains
+	// are stable because the constraints of a domain can be altered.
+	if src.IsDomain() || tgt.IsDomain() {
+		if src.Oid() == tgt.Oid() {
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		if src.IsDomain() {
+			return LookupCast(src.DomainBaseType(), tgt)
+		}
+		c, ok := LookupCast(src, tgt.DomainBaseType())
+		if ok && c.Volatility < volatility.Stable {
+			c.Volatility = volatility.Stable
+		}
+		return c, ok
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
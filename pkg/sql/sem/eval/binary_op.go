@@ -306,6 +306,18 @@ func (e *evaluator) EvalContainedByJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).ContainsRange(tree.MustBeDRange(a)))), nil
+}
+
+func (e *evaluator) EvalContainedByElemRangeOp(
+	ctx context.Context, _ *tree.ContainedByElemRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(b).ContainsElem(tree.UnwrapDOidWrapper(a)))), nil
+}
+
 func (e *evaluator) EvalContainsArrayOp(
 	ctx context.Context, _ *tree.ContainsArrayOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -324,6 +336,18 @@ func (e *evaluator) EvalContainsJsonbOp(
 	return tree.MakeDBool(tree.DBool(c)), nil
 }
 
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).ContainsRange(tree.MustBeDRange(b)))), nil
+}
+
+func (e *evaluator) EvalContainsRangeElemOp(
+	ctx context.Context, _ *tree.ContainsRangeElemOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(a).ContainsElem(tree.UnwrapDOidWrapper(b)))), nil
+}
+
 func (e *evaluator) EvalDivDecimalIntOp(
 	ctx context.Context, _ *tree.DivDecimalIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -691,6 +715,40 @@ func (e *evaluator) EvalJSONFetchValStringOp(
 	return &tree.DJSON{JSON: j}, nil
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The jsonpath operators suppress errors, as if the silent argument of the
+	// corresponding builtins were true.
+	exists, ok, err := tree.MustBeDJsonpath(right).Exists(
+		tree.MustBeDJSON(left).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(exists)), nil
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The jsonpath operators suppress errors, as if the silent argument of the
+	// corresponding builtins were true.
+	res, ok, err := tree.MustBeDJsonpath(right).Match(
+		tree.MustBeDJSON(left).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	switch res.Type() {
+	case json.TrueJSONType:
+		return tree.DBoolTrue, nil
+	case json.FalseJSONType:
+		return tree.DBoolFalse, nil
+	}
+	return tree.DNull, nil
+}
+
 func (e *evaluator) EvalJSONSomeExistsOp(
 	ctx context.Context, _ *tree.JSONSomeExistsOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -1212,6 +1270,12 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Overlaps(tree.MustBeDRange(right)))), nil
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1712,3 +1776,33 @@ func (e *evaluator) EvalMultPGVectorOp(
 	}
 	return tree.NewDPGVector(ret), nil
 }
+
+func (e *evaluator) EvalPlusRangeOp(
+	ctx context.Context, _ *tree.PlusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, err := tree.MustBeDRange(left).Union(tree.MustBeDRange(right))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (e *evaluator) EvalMinusRangeOp(
+	ctx context.Context, _ *tree.MinusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, err := tree.MustBeDRange(left).Difference(tree.MustBeDRange(right))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (e *evaluator) EvalMultRangeOp(
+	ctx context.Context, _ *tree.MultRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	r, err := tree.MustBeDRange(left).Intersect(tree.MustBeDRange(right))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
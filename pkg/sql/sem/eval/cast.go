@@ -102,6 +102,9 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	if t.IsDomain() {
+		return performDomainCast(ctx, evalCtx, d, t, truncateWidth)
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -454,7 +457,7 @@ func performCastWithoutPrecisionTruncation(
 				ts,
 				tree.FmtBareStrings,
 			)
-		case *tree.DTuple:
+		case *tree.DTuple, *tree.DRange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -500,6 +503,8 @@ func performCastWithoutPrecisionTruncation(
 			s = tree.AsStringWithFlags(t, tree.FmtPgwireText)
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Path.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -637,6 +642,25 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.RangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_3) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use range types",
+				clusterversion.V24_3.Version())
+		}
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DRange:
+			if d.ResolvedType().Oid() == t.Oid() {
+				return d, nil
+			}
+		}
+
 	case types.RefCursorFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -918,6 +942,20 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_3) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use jsonpath",
+				clusterversion.V24_3.Version())
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		case *tree.DCollatedString:
+			return tree.ParseDJsonpath(v.Contents)
+		case *tree.DJsonpath:
+			return d, nil
+		}
 	case types.TSQueryFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -284,6 +284,18 @@ type Context struct {
 	// during local execution. It may be unset.
 	RoutineSender DeferredRoutineSender
 
+	// DeferredConstraints tracks the deferred constraint checks of the current
+	// transaction. It is nil for implicit transactions and internal executors.
+	DeferredConstraints DeferredConstraints
+
+	// AdvisoryLocks manages the advisory locks of the session. It is nil for
+	// internal executors running under an outer transaction.
+	AdvisoryLocks AdvisoryLocks
+
+	// Notifications manages the notification channels of the session. It is
+	// nil for internal executors running under an outer transaction.
+	Notifications Notifications
+
 	// RNGFactory, if set, provides the random number generator for the "random"
 	// built-in function.
 	//
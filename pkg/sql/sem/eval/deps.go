@@ -434,6 +434,22 @@ type Planner interface {
 	// PLpgSQL FETCH statement.
 	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (res tree.Datums, err error)
 
+	// PLpgSQLExecuteDynamic executes the given SQL string, with the given
+	// arguments bound to its placeholders. If into is true, the first row of the
+	// result is returned, or nil if there are no rows. If strict is also true,
+	// an error is returned unless the query returns exactly one row. Used to
+	// implement the PLpgSQL EXECUTE statement.
+	PLpgSQLExecuteDynamic(
+		ctx context.Context, query string, args tree.Datums, into, strict bool,
+	) (res tree.Datums, err error)
+
+	// PLpgSQLOpenDynamicCursor opens a cursor with the given name for the given
+	// SQL string, with the given arguments bound to its placeholders. Used to
+	// implement the PLpgSQL OPEN ... FOR EXECUTE statement.
+	PLpgSQLOpenDynamicCursor(
+		ctx context.Context, cursorName tree.Name, query string, args tree.Datums,
+	) error
+
 	// AutoCommit indicates whether the Planner has flagged the current statement
 	// as eligible for transaction auto-commit.
 	AutoCommit() bool
@@ -578,6 +594,78 @@ type DeferredRoutineSender interface {
 	SendDeferredRoutine(nestedRoutine *tree.RoutineExpr, args tree.Datums)
 }
 
+// DeferredConstraints tracks the deferrable constraints of the current
+// transaction. The checks of deferred constraints are still run by the
+// statements that modify the constrained tables, but the violations they find
+// don't cause the statements to fail; instead, the keys of the violating rows
+// are validated again when the transaction commits or when the constraints are
+// switched back to immediate mode with SET CONSTRAINTS.
+type DeferredConstraints interface {
+	// IsDeferred returns true if the checks of the given constraint should be
+	// deferred until the end of the transaction.
+	IsDeferred(
+		tableID catid.DescID, name string, deferrability tree.ConstraintDeferrability,
+	) bool
+
+	// Defer records that a row with the given key violated the given
+	// constraint, so the key must be validated again before the transaction
+	// commits. The key values correspond to the columns of the constraint.
+	Defer(ctx context.Context, tableID catid.DescID, name string, keyVals tree.Datums) error
+}
+
+// AdvisoryLockKey identifies an advisory lock. As in Postgres, a lock acquired
+// with a single bigint key and a lock acquired with a pair of int4 keys never
+// conflict: the former has ObjSubID 1 and the high and low halves of the key in
+// ClassID and ObjID, while the latter has ObjSubID 2.
+type AdvisoryLockKey struct {
+	DatabaseID uint32
+	ClassID    uint32
+	ObjID      uint32
+	ObjSubID   uint16
+}
+
+// AdvisoryLocks manages the cluster-wide advisory locks held by a session.
+// Locks can be acquired multiple times; a session-level lock is held until it
+// has been released as many times as it was acquired, while a
+// transaction-level lock is held until the end of the current transaction.
+type AdvisoryLocks interface {
+	// Lock acquires the given advisory lock in shared or exclusive mode, for
+	// the session or for the current transaction. If wait is false and the lock
+	// is held in a conflicting mode by another session, Lock returns false
+	// instead of waiting for it to be released.
+	Lock(ctx context.Context, key AdvisoryLockKey, shared, xact, wait bool) (bool, error)
+
+	// Unlock releases a session-level advisory lock once. It returns false if
+	// the session does not hold the lock in the given mode.
+	Unlock(ctx context.Context, key AdvisoryLockKey, shared bool) (bool, error)
+
+	// UnlockAll releases all the session-level advisory locks of the session.
+	UnlockAll(ctx context.Context) error
+}
+
+// Notifications manages the notification channels a session listens on and
+// the notifications it sends, which implement LISTEN, UNLISTEN and NOTIFY. As
+// in Postgres, all of them take effect when the current transaction commits.
+type Notifications interface {
+	// Listen starts listening on the given channel.
+	Listen(ctx context.Context, channel string)
+
+	// Unlisten stops listening on the given channel.
+	Unlisten(ctx context.Context, channel string)
+
+	// UnlistenAll stops listening on all channels.
+	UnlistenAll(ctx context.Context)
+
+	// Notify sends a notification with the given payload on the given channel.
+	// Identical notifications sent by the same transaction are only delivered
+	// once.
+	Notify(ctx context.Context, channel, payload string) error
+
+	// ListeningChannels returns the channels the session is listening on, in
+	// sorted order.
+	ListeningChannels() []string
+}
+
 // PrivilegedAccessor gives access to certain queries that would otherwise
 // require someone with RootUser access to query a given data source.
 // It is defined independently to prevent a circular dependency on sql, tree and sqlbase.
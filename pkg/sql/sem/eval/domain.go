@@ -0,0 +1,98 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// performDomainCast casts the given datum to the base type of the domain typ,
+// and then checks that the result satisfies the constraints of the domain.
+// Values of a domain have the same representation as values of its base type,
+// so the result is a datum of the base type.
+func performDomainCast(
+	ctx context.Context, evalCtx *Context, d tree.Datum, typ *types.T, truncateWidth bool,
+) (tree.Datum, error) {
+	res, err := performCast(ctx, evalCtx, d, typ.DomainBaseType(), truncateWidth)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckDomainConstraints(ctx, evalCtx, res, typ); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CheckDomainConstraints returns an error if the given datum, which must have
+// the representation of the base type of the domain typ, violates the NOT NULL
+// or CHECK constraints of the domain. As in Postgres, a CHECK constraint is
+// satisfied if its expression evaluates to true or NULL.
+func CheckDomainConstraints(
+	ctx context.Context, evalCtx *Context, d tree.Datum, typ *types.T,
+) error {
+	md := typ.TypeMeta.DomainData
+	if md == nil {
+		return errors.AssertionFailedf("domain %s is not hydrated", typ.SQLStringForError())
+	}
+	if d == tree.DNull && md.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", typ.Name())
+	}
+	if len(md.Checks) == 0 {
+		return nil
+	}
+	evalCtx.PushIVarContainer(domainValue{d: d, typ: typ.DomainBaseType()})
+	defer evalCtx.PopIVarContainer()
+	for _, c := range md.Checks {
+		if c.Err != nil {
+			return errors.Wrapf(c.Err, "domain %s", typ.Name())
+		}
+		typedExpr, ok := c.TypedExpr.(tree.TypedExpr)
+		if !ok {
+			return errors.AssertionFailedf(
+				"check constraint %q of domain %s is not type-checked", c.Name, typ.Name())
+		}
+		res, err := Expr(ctx, evalCtx, typedExpr)
+		if err != nil {
+			return err
+		}
+		if res == tree.DBoolFalse {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q", typ.Name(), c.Name)
+		}
+	}
+	return nil
+}
+
+// domainValue is the IndexedVarContainer of the VALUE of a domain in its type-
+// checked CHECK expressions.
+type domainValue struct {
+	d   tree.Datum
+	typ *types.T
+}
+
+var _ IndexedVarContainer = domainValue{}
+
+// IndexedVarEval implements the IndexedVarContainer interface.
+func (v domainValue) IndexedVarEval(int) (tree.Datum, error) {
+	return v.d, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (v domainValue) IndexedVarResolvedType(int) *types.T {
+	return v.typ
+}
@@ -182,8 +182,9 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
-	if d == tree.DNull {
+	// NULL cast to anything is NULL, unless the target type is a domain that
+	// does not allow NULL values.
+	if d == tree.DNull && !expr.ResolvedType().IsDomain() {
 		return d, nil
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
@@ -369,6 +370,12 @@ func (e *evaluator) EvalDefaultVal(ctx context.Context, expr *tree.DefaultVal) (
 	return nil, errors.AssertionFailedf("unhandled type %T", expr)
 }
 
+func (e *evaluator) EvalGroupingExpr(
+	ctx context.Context, expr *tree.GroupingExpr,
+) (tree.Datum, error) {
+	return nil, errors.AssertionFailedf("unhandled type %T", expr)
+}
+
 func (e *evaluator) EvalIsNotNullExpr(
 	ctx context.Context, expr *tree.IsNotNullExpr,
 ) (tree.Datum, error) {
@@ -49,6 +49,11 @@ func (tc *unsupportedTypeChecker) CheckType(ctx context.Context, typ *types.T) e
 			"%s not supported until version 24.2", errorTypeString,
 		)
 	}
+	if typ.Family() == types.RangeFamily && !tc.version.IsActive(ctx, clusterversion.V24_3) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s not supported until version 24.3", typ.Name(),
+		)
+	}
 
 	return nil
 }
@@ -223,8 +223,12 @@ func (s *CursorDeclaration) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_assign
 type Assignment struct {
 	StatementImpl
-	Var   Variable
-	Value Expr
+	Var Variable
+	// Indirection is the name of the field of a composite-typed variable that
+	// is assigned, as in "rec.field := value". It is empty if the whole
+	// variable is assigned.
+	Indirection tree.Name
+	Value       Expr
 }
 
 func (s *Assignment) CopyNode() *Assignment {
@@ -238,6 +242,10 @@ func (s *Assignment) PlpgSQLStatementTag() string {
 
 func (s *Assignment) Format(ctx *tree.FmtCtx) {
 	ctx.FormatNode(&s.Var)
+	if s.Indirection != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&s.Indirection)
+	}
 	ctx.WriteString(" := ")
 	ctx.FormatNode(s.Value)
 	ctx.WriteString(";\n")
@@ -610,11 +618,45 @@ type ForInt struct {
 	Lower   Expr
 	Upper   Expr
 	Step    Expr
-	Reverse int
+	Reverse bool
 	Body    []Statement
 }
 
+func (s *ForInt) CopyNode() *ForInt {
+	copyNode := *s
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForInt) PlpgSQLStatementTag() string {
@@ -622,14 +664,29 @@ func (s *ForInt) PlpgSQLStatementTag() string {
 }
 
 func (s *ForInt) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForInt).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
+// ForQuery holds the fields shared by the FOR loops that iterate over the
+// rows of a query.
 type ForQuery struct {
 	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
+	Label  string
+	Target []Variable
+	Body   []Statement
 }
 
 func (s *ForQuery) Format(ctx *tree.FmtCtx) {
@@ -643,12 +700,54 @@ func (s *ForQuery) WalkStmt(visitor StatementVisitor) Statement {
 	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
 }
 
+// formatLoopStart formats the label and the target variables of a query loop,
+// up to and including the IN keyword.
+func (s *ForQuery) formatLoopStart(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.WriteString(" IN ")
+}
+
+// formatLoopEnd formats the body of a query loop and the END LOOP clause.
+func (s *ForQuery) formatLoopEnd(ctx *tree.FmtCtx) {
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
+}
+
 type ForSelect struct {
 	ForQuery
-	Query Expr
+	Query tree.Statement
+}
+
+func (s *ForSelect) CopyNode() *ForSelect {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
 }
 
 func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+	s.formatLoopStart(ctx)
+	ctx.FormatNode(s.Query)
+	s.formatLoopEnd(ctx)
 }
 
 func (s *ForSelect) PlpgSQLStatementTag() string {
@@ -656,7 +755,20 @@ func (s *ForSelect) PlpgSQLStatementTag() string {
 }
 
 func (s *ForSelect) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForSelect).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 type ForCursor struct {
@@ -697,13 +809,44 @@ func (s *ForDynamic) WalkStmt(visitor StatementVisitor) Statement {
 type ForEachArray struct {
 	StatementImpl
 	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Var   Variable
+	// Slice is the number of dimensions of each slice of the array that is
+	// assigned to the loop variable. It is zero when iterating over individual
+	// elements.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	ctx.FormatNode(&s.Var)
+	if s.Slice != 0 {
+		ctx.WriteString(fmt.Sprintf(" SLICE %d", s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -711,7 +854,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -812,13 +968,24 @@ func (s *Return) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
+}
+
+func (s *ReturnNext) CopyNode() *ReturnNext {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -826,17 +993,25 @@ func (s *ReturnNext) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnNext) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
-	DynamicQuery Expr
-	Params       []Expr
+	Query tree.Statement
+}
+
+func (s *ReturnQuery) CopyNode() *ReturnQuery {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -844,7 +1019,8 @@ func (s *ReturnQuery) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_raise
@@ -984,35 +1160,46 @@ func (s *Execute) WalkStmt(visitor StatementVisitor) Statement {
 }
 
 // stmt_dynexecute
-// TODO(chengxiong): query should be a better expression type.
 type DynamicExecute struct {
 	StatementImpl
-	Query  string
-	Into   bool
-	Strict bool
-	Target Variable
+	Query  Expr
+	Strict bool // INTO STRICT flag
+	Target []Variable
 	Params []Expr
 }
 
 func (s *DynamicExecute) CopyNode() *DynamicExecute {
 	copyNode := *s
+	copyNode.Target = append([]Variable(nil), s.Target...)
 	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
 func (s *DynamicExecute) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty print the original command
-	ctx.WriteString("EXECUTE a dynamic command")
-	if s.Into {
-		ctx.WriteString(" WITH INTO")
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(s.Query)
+	if s.Target != nil {
+		ctx.WriteString(" INTO ")
 		if s.Strict {
-			ctx.WriteString(" STRICT")
+			ctx.WriteString("STRICT ")
+		}
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Target[i])
 		}
 	}
 	if s.Params != nil {
-		ctx.WriteString(" WITH USING")
+		ctx.WriteString(" USING ")
+		for i, param := range s.Params {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(param)
+		}
 	}
-	ctx.WriteString("\n")
+	ctx.WriteString(";\n")
 }
 
 func (s *DynamicExecute) PlpgSQLStatementTag() string {
@@ -1027,10 +1214,25 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// Query is the SELECT statement that results from replacing the PERFORM
+	// keyword with SELECT.
+	Query tree.Statement
+}
+
+func (s *Perform) CopyNode() *Perform {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// Format the query, then replace the leading SELECT keyword with PERFORM.
+	start := ctx.Len()
+	ctx.FormatNode(s.Query)
+	query := strings.TrimPrefix(ctx.String()[start:], "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(query)
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -1038,7 +1240,8 @@ func (s *Perform) PlpgSQLStatementTag() string {
 }
 
 func (s *Perform) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_call
@@ -1117,10 +1320,15 @@ type Open struct {
 	CurVar Variable
 	Scroll tree.CursorScrollOption
 	Query  tree.Statement
+	// DynamicQuery and Params are set for OPEN ... FOR EXECUTE, in which case
+	// the query is a string that is only known at execution time.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *Open) CopyNode() *Open {
 	copyNode := *s
+	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
@@ -1136,6 +1344,18 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 	if s.Query != nil {
 		ctx.WriteString(" FOR ")
 		ctx.FormatNode(s.Query)
+	} else if s.DynamicQuery != nil {
+		ctx.WriteString(" FOR EXECUTE ")
+		ctx.FormatNode(s.DynamicQuery)
+		if s.Params != nil {
+			ctx.WriteString(" USING ")
+			for i, param := range s.Params {
+				if i > 0 {
+					ctx.WriteString(", ")
+				}
+				ctx.FormatNode(param)
+			}
+		}
 	}
 	ctx.WriteString(";\n")
 }
@@ -163,6 +163,38 @@ func (v *SQLStmtVisitor) Visit(
 			cpy.Query = s
 			newStmt = cpy
 		}
+		e, v.Err = simpleVisit(t.DynamicQuery, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.DynamicQuery != e {
+			if newStmt == stmt {
+				newStmt = t.CopyNode()
+			}
+			newStmt.(*plpgsqltree.Open).DynamicQuery = e
+		}
+		for i, p := range t.Params {
+			e, v.Err = simpleVisit(p, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Params[i] != e {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.Open).Params[i] = e
+			}
+		}
+	case *plpgsqltree.Perform:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s
+			newStmt = cpy
+		}
 	case *plpgsqltree.Declaration:
 		e, v.Err = simpleVisit(t.Expr, v.Fn)
 		if v.Err != nil {
@@ -292,15 +324,23 @@ func (v *SQLStmtVisitor) Visit(
 		}
 
 	case *plpgsqltree.DynamicExecute:
+		e, v.Err = simpleVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != e {
+			cpy := t.CopyNode()
+			cpy.Query = e
+			newStmt = cpy
+		}
 		for i, p := range t.Params {
 			e, v.Err = simpleVisit(p, v.Fn)
 			if v.Err != nil {
 				return stmt, false
 			}
 			if t.Params[i] != e {
-				if newStmt != stmt {
-					cpy := t.CopyNode()
-					newStmt = cpy
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
 				}
 				newStmt.(*plpgsqltree.DynamicExecute).Params[i] = e
 			}
@@ -316,9 +356,67 @@ func (v *SQLStmtVisitor) Visit(
 			newStmt = cpy
 		}
 
-	case *plpgsqltree.ForInt, *plpgsqltree.ForSelect, *plpgsqltree.ForCursor,
-		*plpgsqltree.ForDynamic, *plpgsqltree.ForEachArray, *plpgsqltree.ReturnNext,
-		*plpgsqltree.ReturnQuery, *plpgsqltree.Perform:
+	case *plpgsqltree.ForInt:
+		var lower, upper, step tree.Expr
+		lower, v.Err = simpleVisit(t.Lower, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		upper, v.Err = simpleVisit(t.Upper, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		step, v.Err = simpleVisit(t.Step, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Lower != lower || t.Upper != upper || t.Step != step {
+			cpy := t.CopyNode()
+			cpy.Lower, cpy.Upper, cpy.Step = lower, upper, step
+			newStmt = cpy
+		}
+	case *plpgsqltree.ForSelect:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s
+			newStmt = cpy
+		}
+	case *plpgsqltree.ForEachArray:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnNext:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnQuery:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s
+			newStmt = cpy
+		}
+
+	case *plpgsqltree.ForCursor, *plpgsqltree.ForDynamic:
 		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
 	}
 	if v.Err != nil {
@@ -81,6 +81,9 @@ func (*AlterTableAddIdentity) alterTableCmd()        {}
 func (*AlterTableSetIdentity) alterTableCmd()        {}
 func (*AlterTableIdentity) alterTableCmd()           {}
 func (*AlterTableDropIdentity) alterTableCmd()       {}
+func (*AlterTableSetRLSMode) alterTableCmd()         {}
+func (*AlterTableInherit) alterTableCmd()            {}
+func (*AlterTableNoInherit) alterTableCmd()          {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -105,6 +108,9 @@ var _ AlterTableCmd = &AlterTableAddIdentity{}
 var _ AlterTableCmd = &AlterTableSetIdentity{}
 var _ AlterTableCmd = &AlterTableIdentity{}
 var _ AlterTableCmd = &AlterTableDropIdentity{}
+var _ AlterTableCmd = &AlterTableSetRLSMode{}
+var _ AlterTableCmd = &AlterTableInherit{}
+var _ AlterTableCmd = &AlterTableNoInherit{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -178,12 +184,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:      *d.References.Table,
+					FromCols:   NameList{d.Name},
+					ToCols:     targetCol,
+					Name:       d.References.ConstraintName,
+					Actions:    d.References.Actions,
+					Match:      d.References.Match,
+					Deferrable: d.References.Deferrable,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
@@ -874,6 +881,78 @@ func (node *AlterTableDropIdentity) Format(ctx *FmtCtx) {
 	}
 }
 
+// TableRLSMode is the row-level security mode set by an ALTER TABLE command.
+type TableRLSMode int
+
+// TableRLSMode values.
+const (
+	TableRLSEnable TableRLSMode = iota
+	TableRLSDisable
+	TableRLSForce
+	TableRLSNoForce
+)
+
+var tableRLSModeName = [...]string{
+	TableRLSEnable:  "ENABLE",
+	TableRLSDisable: "DISABLE",
+	TableRLSForce:   "FORCE",
+	TableRLSNoForce: "NO FORCE",
+}
+
+func (m TableRLSMode) String() string {
+	return tableRLSModeName[m]
+}
+
+// AlterTableSetRLSMode represents an ALTER TABLE {ENABLE | DISABLE | FORCE |
+// NO FORCE} ROW LEVEL SECURITY command.
+type AlterTableSetRLSMode struct {
+	Mode TableRLSMode
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableSetRLSMode) TelemetryName() string {
+	return "set_rls_mode"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableSetRLSMode) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ")
+	ctx.WriteString(node.Mode.String())
+	ctx.WriteString(" ROW LEVEL SECURITY")
+}
+
+// AlterTableInherit represents an ALTER TABLE INHERIT command.
+type AlterTableInherit struct {
+	Parent TableName
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableInherit) TelemetryName() string {
+	return "inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableInherit) Format(ctx *FmtCtx) {
+	ctx.WriteString(" INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
+// AlterTableNoInherit represents an ALTER TABLE NO INHERIT command.
+type AlterTableNoInherit struct {
+	Parent TableName
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableNoInherit) TelemetryName() string {
+	return "no_inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableNoInherit) Format(ctx *FmtCtx) {
+	ctx.WriteString(" NO INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
 // GetTableType returns a string representing the type of table the command
 // is operating on.
 // It is assumed if the table is not a sequence or a view, then it is a
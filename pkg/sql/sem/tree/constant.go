@@ -542,10 +542,17 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.PGVector,
 		types.PGVectorArray,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.DateRange,
 		types.RefCursor,
 		types.RefCursorArray,
 		types.TSQuery,
@@ -143,3 +143,53 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checks of a constraint can be
+// deferred until the end of the transaction. See SET CONSTRAINTS.
+type ConstraintDeferrability semenumpb.ConstraintDeferrability
+
+// The values for ConstraintDeferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// ConstraintDeferrabilityType allows the conversion from a
+// semenumpb.ConstraintDeferrability to a tree.ConstraintDeferrability.
+var ConstraintDeferrabilityType = [...]ConstraintDeferrability{
+	semenumpb.ConstraintDeferrability_NOT_DEFERRABLE:      ConstraintNotDeferrable,
+	semenumpb.ConstraintDeferrability_INITIALLY_IMMEDIATE: ConstraintInitiallyImmediate,
+	semenumpb.ConstraintDeferrability_INITIALLY_DEFERRED:  ConstraintInitiallyDeferred,
+}
+
+// ConstraintDeferrabilityValue allows the conversion from a
+// tree.ConstraintDeferrability to a semenumpb.ConstraintDeferrability.
+var ConstraintDeferrabilityValue = [...]semenumpb.ConstraintDeferrability{
+	ConstraintNotDeferrable:      semenumpb.ConstraintDeferrability_NOT_DEFERRABLE,
+	ConstraintInitiallyImmediate: semenumpb.ConstraintDeferrability_INITIALLY_IMMEDIATE,
+	ConstraintInitiallyDeferred:  semenumpb.ConstraintDeferrability_INITIALLY_DEFERRED,
+}
+
+// String implements the fmt.Stringer interface.
+func (x ConstraintDeferrability) String() string {
+	switch x {
+	case ConstraintNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE INITIALLY IMMEDIATE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
+
+// Format implements the NodeFormatter interface. Nothing is written for
+// constraints that are not deferrable, which is the default.
+func (x *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if *x != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(x.String())
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -412,6 +413,105 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	TypeName *UnresolvedObjectName
+	Type     ResolvableTypeReference
+	// Default is the default expression of the domain, if any.
+	Default Expr
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// Checks are the CHECK constraints of the domain.
+	Checks []DomainCheck
+}
+
+var _ Statement = &CreateDomain{}
+
+// DomainCheck is a CHECK constraint of a domain. The expression refers to the
+// value being checked as VALUE.
+type DomainCheck struct {
+	// Name is the name of the constraint, or empty if it was not specified.
+	Name Name
+	Expr Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainCheck) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("CHECK (")
+	ctx.FormatNode(node.Expr)
+	ctx.WriteByte(')')
+}
+
+// ReplaceDomainValue returns a copy of the given domain CHECK expression in
+// which the references to VALUE are replaced with the given expression.
+func ReplaceDomainValue(expr Expr, value Expr) (Expr, error) {
+	return SimpleVisit(expr, func(e Expr) (recurse bool, newExpr Expr, err error) {
+		if n, ok := e.(*UnresolvedName); ok && !n.Star && n.NumParts == 1 && n.Parts[0] == "value" {
+			return false, value, nil
+		}
+		return true, e, nil
+	})
+}
+
+// NewCreateDomain constructs a CreateDomain statement from the constraints
+// specified after the base type.
+func NewCreateDomain(
+	name *UnresolvedObjectName,
+	typRef ResolvableTypeReference,
+	qualifications []NamedColumnQualification,
+) (*CreateDomain, error) {
+	d := &CreateDomain{TypeName: name, Type: typRef}
+	nullSpecified := false
+	for _, c := range qualifications {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if d.Default != nil {
+				return nil, pgerror.New(pgcode.Syntax, "multiple default expressions")
+			}
+			d.Default = t.Expr
+		case NotNullConstraint:
+			if nullSpecified && !d.NotNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			nullSpecified, d.NotNull = true, true
+		case NullConstraint:
+			if d.NotNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			nullSpecified = true
+		case *ColumnCheckConstraint:
+			d.Checks = append(d.Checks, DomainCheck{Name: c.Name, Expr: t.Expr})
+		default:
+			return nil, errors.AssertionFailedf("unexpected domain constraint %T", t)
+		}
+	}
+	return d, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	if node.Default != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+	if node.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	for i := range node.Checks {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Checks[i])
+	}
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -426,6 +526,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -518,6 +619,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrable     ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -701,6 +803,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrable = t.Deferrable
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -871,6 +974,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrable)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -1009,10 +1113,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table      TableName
+	Col        Name // empty-string means use PK
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1102,6 +1207,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -1110,6 +1216,7 @@ type UniqueConstraintTableDef struct {
 	PrimaryKey   bool
 	WithoutIndex bool
 	IfNotExists  bool
+	Deferrable   ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1154,6 +1261,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrable)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1179,6 +1287,7 @@ type ForeignKeyConstraintTableDef struct {
 	ToCols      NameList
 	Actions     ReferenceActions
 	Match       CompositeKeyMatchMethod
+	Deferrable  ConstraintDeferrability
 	IfNotExists bool
 }
 
@@ -1210,6 +1319,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrable)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1256,6 +1366,82 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement. Two rows violate the constraint if the comparisons of all of
+// its columns with the given operators return true.
+type ExclusionConstraintTableDef struct {
+	Name Name
+	// AccessMethod is the access method specified with USING, if any. It is
+	// only kept for display purposes, since exclusion constraints do not
+	// create an index, but are checked using an existing index of the table.
+	AccessMethod Name
+	Elems        ExclusionConstraintElems
+	Predicate    Expr
+	IfNotExists  bool
+}
+
+// ExclusionConstraintElem is a column of an exclusion constraint, along with
+// the operator used to compare its values.
+type ExclusionConstraintElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// ExclusionConstraintElems is a list of ExclusionConstraintElem.
+type ExclusionConstraintElems []ExclusionConstraintElem
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.AccessMethod != "" {
+		ctx.WriteString("USING ")
+		ctx.FormatNode(&node.AccessMethod)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionConstraintElems) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1484,8 +1670,24 @@ const (
 	CreateTableOnCommitUnset CreateTableOnCommitSetting = iota
 	// CreateTableOnCommitPreserveRows indicates that ON COMMIT PRESERVE ROWS was set.
 	CreateTableOnCommitPreserveRows
+	// CreateTableOnCommitDeleteRows indicates that ON COMMIT DELETE ROWS was set.
+	CreateTableOnCommitDeleteRows
+	// CreateTableOnCommitDrop indicates that ON COMMIT DROP was set.
+	CreateTableOnCommitDrop
 )
 
+// Format implements the NodeFormatter interface.
+func (node CreateTableOnCommitSetting) Format(ctx *FmtCtx) {
+	switch node {
+	case CreateTableOnCommitPreserveRows:
+		ctx.WriteString("ON COMMIT PRESERVE ROWS")
+	case CreateTableOnCommitDeleteRows:
+		ctx.WriteString("ON COMMIT DELETE ROWS")
+	case CreateTableOnCommitDrop:
+		ctx.WriteString("ON COMMIT DROP")
+	}
+}
+
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
 	IfNotExists      bool
@@ -1494,6 +1696,8 @@ type CreateTable struct {
 	Persistence      Persistence
 	StorageParams    StorageParams
 	OnCommit         CreateTableOnCommitSetting
+	// Inherits contains the parent tables listed in the INHERITS clause.
+	Inherits TableNames
 	// In CREATE...AS queries, Defs represents a list of ColumnTableDefs, one for
 	// each column, and a ConstraintTableDef for each constraint on a subset of
 	// these columns.
@@ -1556,10 +1760,16 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		}
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(node.AsSource)
+		node.formatOnCommit(ctx)
 	} else {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
 		ctx.WriteByte(')')
+		if len(node.Inherits) > 0 {
+			ctx.WriteString(" INHERITS (")
+			ctx.FormatNode(&node.Inherits)
+			ctx.WriteByte(')')
+		}
 		if node.PartitionByTable != nil {
 			ctx.FormatNode(node.PartitionByTable)
 		}
@@ -1568,6 +1778,7 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 			ctx.FormatNode(&node.StorageParams)
 			ctx.WriteByte(')')
 		}
+		node.formatOnCommit(ctx)
 		if node.Locality != nil {
 			ctx.WriteString(" ")
 			ctx.FormatNode(node.Locality)
@@ -1575,6 +1786,16 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 	}
 }
 
+// formatOnCommit formats the ON COMMIT clause of a temporary table. ON COMMIT
+// PRESERVE ROWS is the default, so it is omitted.
+func (node *CreateTable) formatOnCommit(ctx *FmtCtx) {
+	switch node.OnCommit {
+	case CreateTableOnCommitDeleteRows, CreateTableOnCommitDrop:
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.OnCommit)
+	}
+}
+
 // HoistConstraints finds column check and foreign key constraints defined
 // inline with their columns and makes them table-level constraints, stored in
 // n.Defs. For example, the foreign key constraint in
@@ -1620,12 +1841,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:      *col.References.Table,
+					FromCols:   NameList{col.Name},
+					ToCols:     targetCol,
+					Name:       col.References.ConstraintName,
+					Actions:    col.References.Actions,
+					Match:      col.References.Match,
+					Deferrable: col.References.Deferrable,
 				})
 				col.References.Table = nil
 			}
@@ -1857,6 +2079,10 @@ const (
 	LikeTableOptDefaults
 	LikeTableOptGenerated
 	LikeTableOptIndexes
+	LikeTableOptComments
+	LikeTableOptIdentity
+	LikeTableOptStatistics
+	LikeTableOptStorage
 
 	// Make sure this field stays last!
 	likeTableOptInvalid
@@ -1880,6 +2106,14 @@ func (o LikeTableOpt) String() string {
 		return "GENERATED"
 	case LikeTableOptIndexes:
 		return "INDEXES"
+	case LikeTableOptComments:
+		return "COMMENTS"
+	case LikeTableOptIdentity:
+		return "IDENTITY"
+	case LikeTableOptStatistics:
+		return "STATISTICS"
+	case LikeTableOptStorage:
+		return "STORAGE"
 	case LikeTableOptAll:
 		return "ALL"
 	default:
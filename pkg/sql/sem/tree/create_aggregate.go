@@ -0,0 +1,102 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateAggregate represents a CREATE AGGREGATE statement. A user-defined
+// aggregate folds its input rows into a state with a transition function, and
+// optionally computes the result from the final state with a final function.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+var _ Statement = &CreateAggregate{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteByte(')')
+}
+
+// AggregateOptionKind identifies an option of a CREATE AGGREGATE statement.
+type AggregateOptionKind int
+
+const (
+	// AggregateOptionTransitionFunc is the SFUNC option.
+	AggregateOptionTransitionFunc AggregateOptionKind = iota
+	// AggregateOptionStateType is the STYPE option.
+	AggregateOptionStateType
+	// AggregateOptionFinalFunc is the FINALFUNC option.
+	AggregateOptionFinalFunc
+	// AggregateOptionInitialCondition is the INITCOND option.
+	AggregateOptionInitialCondition
+)
+
+// AggregateOption is an option of a CREATE AGGREGATE statement. Only the
+// field which corresponds to the kind of the option is set.
+type AggregateOption struct {
+	Kind AggregateOptionKind
+	// Func is set for SFUNC and FINALFUNC.
+	Func RoutineName
+	// Type is set for STYPE.
+	Type ResolvableTypeReference
+	// InitialCondition is set for INITCOND. It is the string representation
+	// of the initial state.
+	InitialCondition string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	switch node.Kind {
+	case AggregateOptionTransitionFunc:
+		ctx.WriteString("SFUNC = ")
+		ctx.FormatNode(&node.Func)
+	case AggregateOptionStateType:
+		ctx.WriteString("STYPE = ")
+		ctx.FormatTypeReference(node.Type)
+	case AggregateOptionFinalFunc:
+		ctx.WriteString("FINALFUNC = ")
+		ctx.FormatNode(&node.Func)
+	case AggregateOptionInitialCondition:
+		ctx.WriteString("INITCOND = ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.InitialCondition, ctx.flags.EncodeFlags())
+		}
+	}
+}
+
+// AggregateOptions is a list of options of a CREATE AGGREGATE statement.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node[i])
+	}
+}
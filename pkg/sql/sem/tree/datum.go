@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -3750,6 +3751,491 @@ func (d *DPGVector) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.T.Size()
 }
 
+// DRange is the Datum representation of the range types. A range is either
+// empty, or has a lower and an upper bound, each of which may be infinite.
+//
+// Ranges of discrete element types (int4range, int8range and daterange) are
+// always kept in the canonical form `[lower,upper)`, so that equal ranges
+// have equal representations.
+type DRange struct {
+	typ *types.T
+	// Empty is true if the range contains no values. The bounds are unused in
+	// that case.
+	Empty bool
+	Lower RangeBound
+	Upper RangeBound
+}
+
+// RangeBound is a bound of a DRange.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val itself is contained in the range. It is always
+	// false for infinite bounds.
+	Inclusive bool
+}
+
+// IsInfinite returns true if the bound is infinite.
+func (b RangeBound) IsInfinite() bool {
+	return b.Val == nil
+}
+
+// NewDEmptyRange returns a new empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange returns a new range of the given range type with the given bounds.
+// The range is canonicalized if its element type is discrete. An error is
+// returned if the lower bound is greater than the upper bound.
+func NewDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if lower.IsInfinite() {
+		lower.Inclusive = false
+	}
+	if upper.IsInfinite() {
+		upper.Inclusive = false
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		c := compareRangeValues(lower.Val, upper.Val)
+		if c > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		}
+		if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if rangeIsDiscrete(typ) {
+		var err error
+		if !lower.IsInfinite() && !lower.Inclusive {
+			if lower.Val, err = nextRangeValue(typ, lower.Val); err != nil {
+				return nil, err
+			}
+			lower.Inclusive = true
+		}
+		if !upper.IsInfinite() && upper.Inclusive {
+			if upper.Val, err = nextRangeValue(typ, upper.Val); err != nil {
+				return nil, err
+			}
+			upper.Inclusive = false
+		}
+		if !lower.IsInfinite() && !upper.IsInfinite() &&
+			compareRangeValues(lower.Val, upper.Val) >= 0 {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// rangeIsDiscrete returns true if the element type of the given range type is
+// discrete, in which case the range is stored in canonical form.
+func rangeIsDiscrete(typ *types.T) bool {
+	switch typ.Oid() {
+	case oid.T_int4range, oid.T_int8range, oid.T_daterange:
+		return true
+	}
+	return false
+}
+
+// nextRangeValue returns the value that immediately follows the given value of
+// a discrete range type.
+func nextRangeValue(typ *types.T, v Datum) (Datum, error) {
+	switch t := v.(type) {
+	case *DInt:
+		if typ.Oid() == oid.T_int4range && *t >= math.MaxInt32 {
+			return nil, ErrInt4OutOfRange
+		}
+		if *t == math.MaxInt64 {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		n, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(n), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected discrete range element %T", v)
+}
+
+// compareRangeValues compares two finite range bound values, which must be of
+// the same type.
+func compareRangeValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		u := b.(*DInt)
+		if *t < *u {
+			return -1
+		} else if *t > *u {
+			return 1
+		}
+		return 0
+	case *DDecimal:
+		return CompareDecimals(&t.Decimal, &b.(*DDecimal).Decimal)
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return t.Time.Compare(b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return t.Time.Compare(b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range element %T", a))
+}
+
+// compareRangeBounds compares two range bounds. aIsLower and bIsLower indicate
+// whether a and b are lower or upper bounds, which determines how infinite and
+// exclusive bounds sort.
+func compareRangeBounds(a RangeBound, aIsLower bool, b RangeBound, bIsLower bool) int {
+	if a.IsInfinite() && b.IsInfinite() {
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return -1
+		}
+		return 1
+	}
+	if a.IsInfinite() {
+		if aIsLower {
+			return -1
+		}
+		return 1
+	}
+	if b.IsInfinite() {
+		if bIsLower {
+			return 1
+		}
+		return -1
+	}
+	if c := compareRangeValues(a.Val, b.Val); c != 0 {
+		return c
+	}
+	// The values are equal, so the inclusivity of the bounds decides.
+	switch {
+	case !a.Inclusive && !b.Inclusive:
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !a.Inclusive:
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !b.Inclusive:
+		if bIsLower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface. Empty ranges sort before all other
+// ranges; other ranges are ordered by their lower bounds, and then by their
+// upper bounds.
+func (d *DRange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DRange)
+	if !ok || v.typ.Oid() != d.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	switch {
+	case d.Empty && v.Empty:
+		return 0, nil
+	case d.Empty:
+		return -1, nil
+	case v.Empty:
+		return 1, nil
+	}
+	if c := compareRangeBounds(d.Lower, true /* aIsLower */, v.Lower, true /* bIsLower */); c != 0 {
+		return c, nil
+	}
+	return compareRangeBounds(d.Upper, false /* aIsLower */, v.Upper, false /* bIsLower */), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface. Ranges are formatted like in
+// Postgres, e.g. `[1,10)` or `empty`. Bound values are quoted if necessary.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	if d.Empty {
+		ctx.WriteString("empty")
+	} else {
+		if d.Lower.Inclusive {
+			ctx.WriteByte('[')
+		} else {
+			ctx.WriteByte('(')
+		}
+		d.formatBound(ctx, d.Lower)
+		ctx.WriteByte(',')
+		d.formatBound(ctx, d.Upper)
+		if d.Upper.Inclusive {
+			ctx.WriteByte(']')
+		} else {
+			ctx.WriteByte(')')
+		}
+	}
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+func (d *DRange) formatBound(ctx *FmtCtx, b RangeBound) {
+	if b.IsInfinite() {
+		return
+	}
+	s := AsStringWithFlags(
+		b.Val, FmtBareStrings, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location),
+	)
+	quote := s == "" || rangeQuoteSet.in(s)
+	if quote {
+		ctx.WriteByte('"')
+	}
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			// Like in Postgres, " and \ are doubled.
+			ctx.WriteRune(r)
+		}
+		ctx.WriteRune(r)
+	}
+	if quote {
+		ctx.WriteByte('"')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower.Val != nil {
+		sz += d.Lower.Val.Size()
+	}
+	if d.Upper.Val != nil {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range []RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsElem returns true if the given value of the element type is
+// contained in the range.
+func (d *DRange) ContainsElem(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if !d.Lower.IsInfinite() {
+		c := compareRangeValues(d.Lower.Val, v)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.IsInfinite() {
+		c := compareRangeValues(d.Upper.Val, v)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsRange returns true if every value of the other range is contained in
+// the range. The empty range is contained in every range.
+func (d *DRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Lower, true) <= 0 &&
+		compareRangeBounds(d.Upper, false, other.Upper, false) >= 0
+}
+
+// Overlaps returns true if the ranges have any value in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Upper, false) <= 0 &&
+		compareRangeBounds(other.Lower, true, d.Upper, false) <= 0
+}
+
+// Adjacent returns true if the ranges do not overlap, but there are no values
+// between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.Upper, other.Lower) || rangeBoundsAdjacent(other.Upper, d.Lower)
+}
+
+// rangeBoundsAdjacent returns true if the given upper bound is immediately
+// followed by the given lower bound.
+func rangeBoundsAdjacent(upper, lower RangeBound) bool {
+	if upper.IsInfinite() || lower.IsInfinite() {
+		return false
+	}
+	// Ranges of discrete types are canonical, so it suffices to check for
+	// equal values with exactly one inclusive bound.
+	return compareRangeValues(upper.Val, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+}
+
+// Merge returns the smallest range that contains both ranges.
+func (d *DRange) Merge(other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) < 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) > 0 {
+		upper = other.Upper
+	}
+	return NewDRange(d.typ, lower, upper)
+}
+
+// Union returns the union of the ranges. An error is returned if the result
+// would not be contiguous.
+func (d *DRange) Union(other *DRange) (*DRange, error) {
+	if !d.Empty && !other.Empty && !d.Overlaps(other) && !d.Adjacent(other) {
+		return nil, pgerror.New(pgcode.DataException,
+			"result of range union would not be contiguous")
+	}
+	return d.Merge(other)
+}
+
+// Intersect returns the intersection of the ranges.
+func (d *DRange) Intersect(other *DRange) (*DRange, error) {
+	if !d.Overlaps(other) {
+		return NewDEmptyRange(d.typ), nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) > 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) < 0 {
+		upper = other.Upper
+	}
+	return NewDRange(d.typ, lower, upper)
+}
+
+// Difference returns the values of the range that are not contained in the
+// other range. An error is returned if the result would not be contiguous.
+func (d *DRange) Difference(other *DRange) (*DRange, error) {
+	if d.Empty || other.Empty {
+		return d, nil
+	}
+	cmpL1L2 := compareRangeBounds(d.Lower, true, other.Lower, true)
+	cmpL1U2 := compareRangeBounds(d.Lower, true, other.Upper, false)
+	cmpU1L2 := compareRangeBounds(d.Upper, false, other.Lower, true)
+	cmpU1U2 := compareRangeBounds(d.Upper, false, other.Upper, false)
+	switch {
+	case cmpL1L2 < 0 && cmpU1U2 > 0:
+		return nil, pgerror.New(pgcode.DataException,
+			"result of range difference would not be contiguous")
+	case cmpL1U2 > 0 || cmpU1L2 < 0:
+		// The ranges do not overlap.
+		return d, nil
+	case cmpL1L2 >= 0 && cmpU1U2 <= 0:
+		// The other range contains the range.
+		return NewDEmptyRange(d.typ), nil
+	case cmpL1L2 <= 0 && cmpU1L2 >= 0 && cmpU1U2 <= 0:
+		upper := RangeBound{Val: other.Lower.Val, Inclusive: !other.Lower.Inclusive}
+		return NewDRange(d.typ, d.Lower, upper)
+	case cmpL1L2 >= 0 && cmpU1U2 >= 0 && cmpL1U2 <= 0:
+		lower := RangeBound{Val: other.Upper.Val, Inclusive: !other.Upper.Inclusive}
+		return NewDRange(d.typ, lower, d.Upper)
+	}
+	return nil, errors.AssertionFailedf("unexpected case in range difference")
+}
+
 // DBox2D is the Datum representation of the Box2D type.
 type DBox2D struct {
 	geo.CartesianBoundingBox
@@ -4024,7 +4510,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector:
+		*DTSVector, *DTSQuery, *DPGLSN, *DPGVector, *DRange, *DJsonpath:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4136,6 +4622,128 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	jsonpath.Path
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Path.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	l, r := d.String(), v.String()
+	if l < r {
+		return -1, nil
+	} else if l > r {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Path.String()))
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(p jsonpath.Path) *DJsonpath {
+	return &DJsonpath{Path: p}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(p), nil
+}
+
 // DTSQuery is the tsquery Datum.
 type DTSQuery struct {
 	tsearch.TSQuery
@@ -6076,6 +6684,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.GeometryFamily:       {unsafe.Sizeof(DGeometry{}), variableSize},
 	types.PGLSNFamily:          {unsafe.Sizeof(DPGLSN{}), fixedSize},
 	types.PGVectorFamily:       {unsafe.Sizeof(DPGVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
 	types.RefCursorFamily:      {unsafe.Sizeof(DString("")), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
@@ -6085,6 +6694,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
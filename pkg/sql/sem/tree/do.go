@@ -0,0 +1,73 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// DoBlock represents a DO statement, which executes an anonymous code block.
+// The code block is always written in PL/pgSQL, and is parsed and planned as
+// an ephemeral procedure with no parameters when the statement is executed.
+type DoBlock struct {
+	// Code is the body of the anonymous code block.
+	Code string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DoBlock) Format(ctx *FmtCtx) {
+	ctx.WriteString("DO ")
+	if ctx.flags.HasFlags(FmtAnonymize) || ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("$$_$$")
+		return
+	}
+	// Use a dollar-quote tag that does not appear in the code block. This
+	// ensures that the formatted statement can be parsed even when the DO
+	// statement is nested within the body of a routine or another code block.
+	tag := "$$"
+	if ctx.flags.HasFlags(FmtTagDollarQuotes) {
+		tag = "$do$"
+	}
+	for i := 1; strings.Contains(node.Code, tag); i++ {
+		tag = fmt.Sprintf("$do%d$", i)
+	}
+	ctx.WriteString(tag)
+	ctx.WriteString(node.Code)
+	ctx.WriteString(tag)
+}
+
+var _ Statement = &DoBlock{}
+
+// ValidateDoBlockLanguage returns an error if the given language cannot be
+// used to write an anonymous code block.
+func ValidateDoBlockLanguage(lang string) error {
+	routineLang, err := AsRoutineLanguage(lang)
+	if err != nil {
+		return err
+	}
+	switch routineLang {
+	case RoutineLangPLpgSQL:
+		return nil
+	case RoutineLangSQL, RoutineLangC:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"language \"%s\" does not support inline code execution", lang,
+		)
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.UndefinedObject, "language \"%s\" does not exist", lang),
+		"Use CREATE EXTENSION to load the language into the database.",
+	)
+}
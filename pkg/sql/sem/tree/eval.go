@@ -477,10 +477,39 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// initRangeOperators initializes the union (+), difference (-) and
+// intersection (*) operators of the range types.
+func initRangeOperators() {
+	for _, t := range types.RangeTypes {
+		addBinOp(treebin.Plus, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &PlusRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		addBinOp(treebin.Minus, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &MinusRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		addBinOp(treebin.Mult, &BinOp{
+			LeftType:   t,
+			RightType:  t,
+			ReturnType: t,
+			EvalOp:     &MultRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+	}
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -1494,16 +1523,56 @@ func cmpOpFixups(
 		))
 	}
 
-	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
 		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
+	// Range comparisons.
+	for _, t := range types.RangeTypes {
+		appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.In, makeEvalTupleIn(t, volatility.Immutable))
+		appendCmpOp(treecmp.Overlaps, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &OverlapsRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.Contains, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &ContainsRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.Contains, &CmpOp{
+			LeftType:   t,
+			RightType:  t.RangeContents(),
+			EvalOp:     &ContainsRangeElemOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.ContainedBy, &CmpOp{
+			LeftType:   t,
+			RightType:  t,
+			EvalOp:     &ContainedByRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+		appendCmpOp(treecmp.ContainedBy, &CmpOp{
+			LeftType:   t.RangeContents(),
+			RightType:  t,
+			EvalOp:     &ContainedByElemRangeOp{},
+			Volatility: volatility.Immutable,
+		})
+	}
+
+	// Array equality comparisons.
+	for _, t := range append(append(types.Scalar, types.AnyEnum), types.RangeTypes...) {
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1983,6 +2052,15 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
 	treecmp.Contains: {overloads: []*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -2044,6 +2122,12 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -75,6 +75,9 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -191,6 +194,8 @@ type (
 	PlusPGLSNDecimalOp struct{}
 	// PlusPGVectorOp is a BinaryEvalOp.
 	PlusPGVectorOp struct{}
+	// PlusRangeOp is a BinaryEvalOp.
+	PlusRangeOp struct{}
 )
 
 type (
@@ -248,6 +253,8 @@ type (
 	MinusPGLSNOp struct{}
 	// MinusPGVectorOp is a BinaryEvalOp.
 	MinusPGVectorOp struct{}
+	// MinusRangeOp is a BinaryEvalOp.
+	MinusRangeOp struct{}
 )
 type (
 	// MultDecimalIntOp is a BinaryEvalOp.
@@ -274,6 +281,8 @@ type (
 	MultIntervalIntOp struct{}
 	// MultPGVectorOp is a BinaryEvalOp.
 	MultPGVectorOp struct{}
+	// MultRangeOp is a BinaryEvalOp.
+	MultRangeOp struct{}
 )
 
 type (
@@ -385,6 +394,12 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
@@ -397,8 +412,20 @@ type ContainsArrayOp struct{}
 // ContainsJsonbOp is a BinaryEvalOp.
 type ContainsJsonbOp struct{}
 
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainsRangeElemOp is a BinaryEvalOp.
+type ContainsRangeElemOp struct{}
+
 // ContainedByArrayOp is a BinaryEvalOp.
 type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// ContainedByElemRangeOp is a BinaryEvalOp.
+type ContainedByElemRangeOp struct{}
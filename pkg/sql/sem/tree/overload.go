@@ -295,6 +295,32 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// Variadic is set if the last input parameter of a user-defined routine has
+	// the VARIADIC class. The type of that parameter in Types is an array, and
+	// a call can supply any number of trailing arguments of the element type
+	// instead, which are packed into an array during type checking.
+	Variadic bool
+	// UserDefinedAggregate is set if the overload is a user-defined aggregate
+	// function. It is only set when UDFContainsOnlySignature is false.
+	UserDefinedAggregate *UserDefinedAggregateOverload
+}
+
+// UserDefinedAggregateOverload describes how a user-defined aggregate function
+// is computed from its state transition and final functions.
+type UserDefinedAggregateOverload struct {
+	// TransitionFunc is the OID of the state transition function, which is
+	// called with the current state followed by the aggregate arguments and
+	// returns the new state.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the final function, which is called with the
+	// final state and returns the result of the aggregate. It is zero if the
+	// aggregate returns its final state.
+	FinalFunc oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// InitialCondition is the text representation of the initial state. It is
+	// nil if the initial state is NULL.
+	InitialCondition *string
 }
 
 // params implements the overloadImpl interface.
@@ -762,6 +788,10 @@ type overloadTypeChecker struct {
 	constIdxs       intsets.Fast // index into exprs/typedExprs
 	placeholderIdxs intsets.Fast // index into exprs/typedExprs
 	overloadsIdxArr [16]uint8
+	// variadicCall is true if the last argument was marked with VARIADIC in
+	// the function call, in which case only variadic routines are candidates
+	// and that argument is matched against the array parameter directly.
+	variadicCall bool
 }
 
 var overloadTypeCheckerPool = sync.Pool{
@@ -814,6 +844,7 @@ func (s *overloadTypeChecker) release() {
 	s.resolvableIdxs = intsets.Fast{}
 	s.constIdxs = intsets.Fast{}
 	s.placeholderIdxs = intsets.Fast{}
+	s.variadicCall = false
 	overloadTypeCheckerPool.Put(s)
 }
 
@@ -859,6 +890,46 @@ func getParamsAndOrdinal(
 	return params, ordinal
 }
 
+// expandVariadicParams replaces the parameter list of each variadic
+// user-defined routine with one that accepts the trailing arguments of the
+// call individually, using the element type of the VARIADIC array parameter.
+// At least one argument must be supplied for the VARIADIC parameter, so the
+// expanded list always has at least as many parameters as the original one.
+//
+// The parameter lists are left unchanged if the call marked its last argument
+// with VARIADIC since the argument is then passed as the array itself.
+func (s *overloadTypeChecker) expandVariadicParams() {
+	if s.variadicCall {
+		return
+	}
+	for i := range s.overloads {
+		ol, ok := s.overloads[i].(*Overload)
+		if !ok || !ol.Variadic {
+			continue
+		}
+		params, ok := ol.Types.(ParamTypes)
+		if !ok || len(params) == 0 {
+			continue
+		}
+		numInputExprs := len(s.exprs)
+		if ol.Type == ProcedureRoutine {
+			numInputExprs -= len(ol.OutParamOrdinals)
+		}
+		numFixed := len(params) - 1
+		numVariadic := numInputExprs - numFixed
+		if numVariadic < 1 {
+			numVariadic = 1
+		}
+		last := params[numFixed]
+		expanded := make(ParamTypes, numFixed, numFixed+numVariadic)
+		copy(expanded, params[:numFixed])
+		for j := 0; j < numVariadic; j++ {
+			expanded = append(expanded, ParamType{Name: last.Name, Typ: last.Typ.ArrayContents()})
+		}
+		s.params[i] = expanded
+	}
+}
+
 // typeCheckOverloadedExprs determines the correct overload to use for the given set of
 // expression parameters, along with an optional desired return type. It returns the expression
 // parameters after being type checked, along with a slice of candidate overloadImpls. The
@@ -927,6 +998,7 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		s.overloadIdxs = make([]uint8, 0, numOverloads)
 	}
 	s.overloadIdxs = s.overloadIdxs[:numOverloads]
+	s.expandVariadicParams()
 	// foundOutParams and foundDefaultExprs indicate whether at least one
 	// overload has non-nil outParamOrdinals mapping and defaultExprs,
 	// respectively. If none are found, we can avoid redundant calls to the
@@ -961,11 +1033,18 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		}
 		// Some "suffix" parameters have DEFAULT expressions, so values for them
 		// can be omitted from the input expressions.
-		// TODO(88947): this logic might need to change to support VARIADIC.
+		// Variadic routines cannot have DEFAULT expressions, so params is never
+		// an expanded VARIADIC parameter list here.
 		paramsLen := params.Length()
 		return paramsLen-len(defaultExprs) <= numInputExprs && numInputExprs <= paramsLen
 	}
 	s.overloadIdxs = filterParams(s.overloadIdxs, s.overloads, s.params, matchLen)
+	if s.variadicCall {
+		s.overloadIdxs = filterOverloads(s.overloadIdxs, s.overloads, func(o overloadImpl) bool {
+			ol, ok := o.(*Overload)
+			return ok && ol.Variadic
+		})
+	}
 
 	makeFilter := func(i int, fn func(params TypeList, ordinal int) bool) func(overloadImpl, TypeList) bool {
 		if !foundOutParams {
@@ -1049,13 +1128,15 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 
 	// Remove any overloads with polymorphic parameters for which the supplied
 	// argument types are invalid.
-	s.overloadIdxs = filterOverloads(s.overloadIdxs, s.overloads, func(o overloadImpl) bool {
+	s.overloadIdxs = filterParams(s.overloadIdxs, s.overloads, s.params, func(o overloadImpl, p TypeList) bool {
 		ol, ok := o.(*Overload)
 		if !ok || ol.Type == BuiltinRoutine {
 			// Don't filter builtin routines.
 			return true
 		}
-		params := ol.Types.(ParamTypes)
+		// Use the parameters from the type checker rather than from the
+		// overload since the VARIADIC parameter might have been expanded.
+		params := p.(ParamTypes)
 		var outParams ParamTypes
 		if ol.Type == ProcedureRoutine && foundOutParams {
 			outParams = ol.OutParamTypes.(ParamTypes)
@@ -0,0 +1,170 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var missingLeftBracketRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, `missing left parenthesis or bracket`)
+var missingCommaRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, `missing comma after lower bound`)
+var tooManyCommasRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, `too many commas`)
+var extraTextRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, `junk after right parenthesis or bracket`)
+var unexpectedEndRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, `unexpected end of input`)
+
+type rangeParseState struct {
+	s                string
+	ctx              ParseContext
+	dependsOnContext bool
+	t                *types.T
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	p.s = strings.TrimLeftFunc(p.s, unicode.IsSpace)
+}
+
+// parseBound parses a range bound, which ends at the first unquoted and
+// unescaped ',', ')' or ']'. An unquoted empty bound is infinite.
+func (p *rangeParseState) parseBound() (RangeBound, error) {
+	var result strings.Builder
+	i := 0
+	inQuote := false
+	quoted := false
+	for ; i < len(p.s); i++ {
+		ch := p.s[i]
+		if !inQuote && (ch == ',' || ch == ')' || ch == ']') {
+			break
+		}
+		switch ch {
+		case '\\':
+			// The character following a '\' is taken literally.
+			i++
+			if i >= len(p.s) {
+				return RangeBound{}, unexpectedEndRangeError
+			}
+			result.WriteByte(p.s[i])
+		case '"':
+			if inQuote && i+1 < len(p.s) && p.s[i+1] == '"' {
+				// Two double quotes in a quoted bound are one double quote.
+				result.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+				quoted = true
+			}
+		case '(', '[':
+			if !inQuote {
+				return RangeBound{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+					"unexpected %q in range bound", ch)
+			}
+			result.WriteByte(ch)
+		default:
+			result.WriteByte(ch)
+		}
+	}
+	if inQuote || i >= len(p.s) {
+		return RangeBound{}, unexpectedEndRangeError
+	}
+	p.s = p.s[i:]
+	s := result.String()
+	if !quoted && strings.TrimSpace(s) == "" {
+		return RangeBound{}, nil
+	}
+	d, dependsOnContext, err := ParseAndRequireString(p.t.RangeContents(), s, p.ctx)
+	if err != nil {
+		return RangeBound{}, err
+	}
+	if dependsOnContext {
+		p.dependsOnContext = true
+	}
+	if dec, ok := d.(*DDecimal); ok && dec.Form == apd.NaN {
+		return RangeBound{}, pgerror.New(pgcode.DataException, "range bound cannot be NaN")
+	}
+	return RangeBound{Val: d}, nil
+}
+
+// ParseDRangeFromString parses the string-form of a range, handling cases such
+// as `'[1,10)'::int4range` and `'empty'::daterange`. The input type t is the
+// type of the range to parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	ret, dependsOnContext, err := doParseDRangeFromString(ctx, s, t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return ret, dependsOnContext, nil
+}
+
+// doParseDRangeFromString does most of the work of ParseDRangeFromString,
+// except the error it returns isn't prettified as a parsing error.
+func doParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	if t.Family() != types.RangeFamily {
+		return nil, false, errors.AssertionFailedf("not a range type %s", t.SQLStringForError())
+	}
+	p := rangeParseState{s: s, ctx: ctx, t: t}
+	p.eatWhitespace()
+	if len(p.s) >= len("empty") && strings.EqualFold(p.s[:len("empty")], "empty") {
+		p.s = p.s[len("empty"):]
+		p.eatWhitespace()
+		if len(p.s) != 0 {
+			return nil, false, extraTextRangeError
+		}
+		return NewDEmptyRange(t), false, nil
+	}
+
+	if len(p.s) == 0 || (p.s[0] != '[' && p.s[0] != '(') {
+		return nil, false, missingLeftBracketRangeError
+	}
+	lowerInclusive := p.s[0] == '['
+	p.s = p.s[1:]
+	lower, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	if p.s[0] != ',' {
+		return nil, false, missingCommaRangeError
+	}
+	p.s = p.s[1:]
+	upper, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	switch p.s[0] {
+	case ',':
+		return nil, false, tooManyCommasRangeError
+	case ']':
+		upper.Inclusive = true
+	}
+	p.s = p.s[1:]
+	p.eatWhitespace()
+	if len(p.s) != 0 {
+		return nil, false, extraTextRangeError
+	}
+	lower.Inclusive = lowerInclusive
+	r, err := NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, false, err
+	}
+	return r, p.dependsOnContext, nil
+}
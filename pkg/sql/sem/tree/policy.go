@@ -0,0 +1,173 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// PolicyType indicates whether a row-level security policy is permissive or
+// restrictive.
+type PolicyType int
+
+// PolicyType values.
+const (
+	// PolicyTypeDefault indicates that no type was specified. It is treated as
+	// PolicyTypePermissive.
+	PolicyTypeDefault PolicyType = iota
+	PolicyTypePermissive
+	PolicyTypeRestrictive
+)
+
+var policyTypeName = [...]string{
+	PolicyTypeDefault:     "",
+	PolicyTypePermissive:  "PERMISSIVE",
+	PolicyTypeRestrictive: "RESTRICTIVE",
+}
+
+func (p PolicyType) String() string {
+	return policyTypeName[p]
+}
+
+// PolicyCommand indicates the commands to which a row-level security policy
+// applies.
+type PolicyCommand int
+
+// PolicyCommand values.
+const (
+	// PolicyCommandDefault indicates that no command was specified. It is
+	// treated as PolicyCommandAll.
+	PolicyCommandDefault PolicyCommand = iota
+	PolicyCommandAll
+	PolicyCommandSelect
+	PolicyCommandInsert
+	PolicyCommandUpdate
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandDefault: "",
+	PolicyCommandAll:     "ALL",
+	PolicyCommandSelect:  "SELECT",
+	PolicyCommandInsert:  "INSERT",
+	PolicyCommandUpdate:  "UPDATE",
+	PolicyCommandDelete:  "DELETE",
+}
+
+func (p PolicyCommand) String() string {
+	return policyCommandName[p]
+}
+
+// PolicyExpressions contains the USING and WITH CHECK expressions of a
+// row-level security policy. Either expression may be nil if it was not
+// specified.
+type PolicyExpressions struct {
+	Using     Expr
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *PolicyExpressions) Format(ctx *FmtCtx) {
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteString(")")
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteString(")")
+	}
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	PolicyName Name
+	TableName  *UnresolvedObjectName
+	Type       PolicyType
+	Cmd        PolicyCommand
+	Roles      RoleSpecList
+	Exprs      PolicyExpressions
+}
+
+var _ Statement = &CreatePolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.Type != PolicyTypeDefault {
+		ctx.WriteString(" AS ")
+		ctx.WriteString(node.Type.String())
+	}
+	if node.Cmd != PolicyCommandDefault {
+		ctx.WriteString(" FOR ")
+		ctx.WriteString(node.Cmd.String())
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	ctx.FormatNode(&node.Exprs)
+}
+
+// AlterPolicy represents an ALTER POLICY statement. Either NewPolicyName is
+// set, for ALTER POLICY ... RENAME TO, or some of Roles and Exprs are set.
+type AlterPolicy struct {
+	PolicyName    Name
+	TableName     *UnresolvedObjectName
+	NewPolicyName Name
+	Roles         RoleSpecList
+	Exprs         PolicyExpressions
+}
+
+var _ Statement = &AlterPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.NewPolicyName != "" {
+		ctx.WriteString(" RENAME TO ")
+		ctx.FormatNode(&node.NewPolicyName)
+		return
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	ctx.FormatNode(&node.Exprs)
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	PolicyName   Name
+	TableName    *UnresolvedObjectName
+	DropBehavior DropBehavior
+	IfExists     bool
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -141,6 +141,16 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// OwnsResultBuffer is true if the routine is a set-returning PL/pgSQL
+	// routine. The rows added to the result buffer by the routine and its
+	// sub-routines become the result of the routine.
+	OwnsResultBuffer bool
+
+	// AddsToResultBuffer is true if the result of the *first* body statement
+	// should be added to the result buffer of the closest ancestor routine for
+	// which OwnsResultBuffer is true.
+	AddsToResultBuffer bool
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -158,21 +168,25 @@ func NewTypedRoutineExpr(
 	blockStart bool,
 	blockState *BlockState,
 	cursorDeclaration *RoutineOpenCursor,
+	ownsResultBuffer bool,
+	addsToResultBuffer bool,
 ) *RoutineExpr {
 	return &RoutineExpr{
-		Args:              args,
-		ForEachPlan:       gen,
-		Typ:               typ,
-		EnableStepping:    enableStepping,
-		Name:              name,
-		CalledOnNullInput: calledOnNullInput,
-		MultiColOutput:    multiColOutput,
-		Generator:         generator,
-		TailCall:          tailCall,
-		Procedure:         procedure,
-		BlockStart:        blockStart,
-		BlockState:        blockState,
-		CursorDeclaration: cursorDeclaration,
+		Args:               args,
+		ForEachPlan:        gen,
+		Typ:                typ,
+		EnableStepping:     enableStepping,
+		Name:               name,
+		CalledOnNullInput:  calledOnNullInput,
+		MultiColOutput:     multiColOutput,
+		Generator:          generator,
+		TailCall:           tailCall,
+		Procedure:          procedure,
+		BlockStart:         blockStart,
+		BlockState:         blockState,
+		CursorDeclaration:  cursorDeclaration,
+		OwnsResultBuffer:   ownsResultBuffer,
+		AddsToResultBuffer: addsToResultBuffer,
 	}
 }
 
@@ -201,6 +215,23 @@ func (node *RoutineExpr) Walk(v Visitor) Expr {
 	return node
 }
 
+// UserDefinedAggregate contains the routines that implement a user-defined
+// aggregate function. It is only created by execbuilder.
+type UserDefinedAggregate struct {
+	// Transition is the routine that computes the next state from the current
+	// state and the arguments of an input row. It has no Args; the state and
+	// the arguments are supplied when it is evaluated.
+	Transition *RoutineExpr
+
+	// Final is the routine that computes the result of the aggregate from the
+	// final state. It is nil if the aggregate has no final function, in which
+	// case the final state is the result.
+	Final *RoutineExpr
+
+	// InitialState is the state before any input rows are aggregated.
+	InitialState Datum
+}
+
 // RoutineExceptionHandler encapsulates the information needed to match and
 // handle errors for the exception block of a routine defined with PLpgSQL.
 type RoutineExceptionHandler struct {
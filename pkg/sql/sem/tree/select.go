@@ -593,7 +593,10 @@ type AliasedTableExpr struct {
 	IndexFlags *IndexFlags
 	Ordinality bool
 	Lateral    bool
-	As         AliasClause
+	// Only is set if the table was prefixed with ONLY, in which case the rows
+	// of the tables that inherit from it are not included.
+	Only bool
+	As   AliasClause
 }
 
 // Format implements the NodeFormatter interface.
@@ -601,6 +604,9 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 	if node.Lateral {
 		ctx.WriteString("LATERAL ")
 	}
+	if node.Only {
+		ctx.WriteString("ONLY ")
+	}
 	ctx.FormatNode(node.Expr)
 	if node.IndexFlags != nil {
 		ctx.FormatNode(node.IndexFlags)
@@ -780,6 +786,54 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType represents the kind of a GroupingSet.
+type GroupingSetType int
+
+// GroupingSetType values.
+const (
+	// GroupingSets is an explicit list of grouping sets, as in
+	// GROUPING SETS ((a, b), (a), ()).
+	GroupingSets GroupingSetType = iota
+	// Rollup represents ROLLUP (a, b), which is equivalent to
+	// GROUPING SETS ((a, b), (a), ()).
+	Rollup
+	// Cube represents CUBE (a, b), which is equivalent to
+	// GROUPING SETS ((a, b), (a), (b), ()).
+	Cube
+)
+
+var groupingSetTypeName = [...]string{
+	GroupingSets: "GROUPING SETS",
+	Rollup:       "ROLLUP",
+	Cube:         "CUBE",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. A parenthesized list of expressions within the item is represented
+// by a Tuple and forms a single grouping set element. For GROUPING SETS, the
+// elements can themselves be nested GroupingSets.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+var _ Expr = &GroupingSet{}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+// String implements the fmt.Stringer interface.
+func (node *GroupingSet) String() string { return AsString(node) }
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -1217,6 +1217,7 @@ func (expr *FuncExpr) TypeCheck(
 	s := getOverloadTypeChecker(
 		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
 	)
+	s.variadicCall = expr.Variadic
 	defer s.release()
 
 	if err = expr.typeCheckWithFuncAncestor(semaCtx, func() error {
@@ -1245,6 +1246,7 @@ func (expr *FuncExpr) TypeCheck(
 					}
 				}()
 				s2 := getOverloadTypeChecker((*qualifiedOverloads)(&functionOverloads), expr.Exprs...)
+				s2.variadicCall = expr.Variadic
 				defer s2.release()
 				err2 := s2.typeCheckOverloadedExprs(ctx, semaCtx, desired, false /* inBinOp */)
 				if err2 == nil && len(s2.overloadIdxs) > 0 {
@@ -1457,17 +1459,29 @@ func (expr *FuncExpr) TypeCheck(
 		}
 	}
 
-	for i, subExpr := range s.typedExprs {
+	typedExprs := s.typedExprs
+	if overloadImpl.Variadic && !expr.Variadic {
+		// Pack the trailing arguments into an array for the VARIADIC parameter.
+		// The call is then equivalent to one that marks the array with
+		// VARIADIC, which is how it is formatted from now on.
+		typedExprs, err = packVariadicArgs(overloadImpl, typedExprs)
+		if err != nil {
+			return nil, err
+		}
+		expr.Exprs = make(Exprs, len(typedExprs))
+		expr.Variadic = true
+	}
+	for i, subExpr := range typedExprs {
 		expr.Exprs[i] = subExpr
 	}
 
 	expr.Func.FunctionReference = def
 	expr.fn = overloadImpl
 	expr.fnProps = &overloadImpl.FunctionProperties
-	expr.typ = overloadImpl.returnType()(s.typedExprs)
+	expr.typ = overloadImpl.returnType()(typedExprs)
 	if expr.typ == UnknownReturnType {
 		typeNames := make([]string, 0, len(expr.Exprs))
-		for _, expr := range s.typedExprs {
+		for _, expr := range typedExprs {
 			typeNames = append(typeNames, expr.ResolvedType().String())
 		}
 		return nil, pgerror.Newf(
@@ -1486,6 +1500,96 @@ func (expr *FuncExpr) TypeCheck(
 	return expr, nil
 }
 
+// packVariadicArgs replaces the arguments supplied for the VARIADIC parameter
+// of the given routine with a single array. The VARIADIC parameter is always
+// the last input parameter, so the packed arguments are at the end of args.
+func packVariadicArgs(ol *Overload, args []TypedExpr) ([]TypedExpr, error) {
+	params, ok := ol.Types.(ParamTypes)
+	if !ok || len(params) == 0 {
+		return nil, errors.AssertionFailedf("unexpected parameters for variadic routine: %s", ol.Types)
+	}
+	numFixed := len(params) - 1
+	if ol.Type == ProcedureRoutine {
+		// Arguments for OUT parameters of procedures are supplied in the CALL
+		// statement. OUT parameters cannot follow the VARIADIC parameter of a
+		// procedure, so they all precede the packed arguments.
+		if ol.UDFContainsOnlySignature {
+			numFixed += len(ol.OutParamOrdinals)
+		} else {
+			for i := range ol.RoutineParams {
+				if ol.RoutineParams[i].Class == RoutineParamOut {
+					numFixed++
+				}
+			}
+		}
+	}
+	if len(args) <= numFixed {
+		return nil, errors.AssertionFailedf(
+			"expected more than %d arguments for variadic routine, got %d", numFixed, len(args),
+		)
+	}
+	arrTyp := params[len(params)-1].Typ
+	elemTyp := arrTyp.ArrayContents()
+	if arrTyp.IsPolymorphicType() {
+		// The arguments have already been checked for consistency, so the
+		// element type is given by any argument that is not NULL.
+		elemTyp = nil
+		for _, arg := range args[numFixed:] {
+			if typ := arg.ResolvedType(); typ.Family() != types.UnknownFamily {
+				elemTyp = typ
+				break
+			}
+		}
+		if elemTyp == nil {
+			return nil, pgerror.New(pgcode.DatatypeMismatch,
+				"could not determine polymorphic type because input has type unknown",
+			)
+		}
+		arrTyp = types.MakeArray(elemTyp)
+	}
+	elems := make(TypedExprs, 0, len(args)-numFixed)
+	for _, arg := range args[numFixed:] {
+		if typ := arg.ResolvedType(); typ.Family() != types.UnknownFamily && !typ.Identical(elemTyp) {
+			arg = NewTypedCastExpr(arg, elemTyp)
+		}
+		elems = append(elems, arg)
+	}
+	packed := make([]TypedExpr, numFixed+1)
+	copy(packed, args[:numFixed])
+	packed[numFixed] = NewTypedArray(elems, arrTyp)
+	return packed, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingExpr) TypeCheck(
+	ctx context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	if semaCtx != nil && semaCtx.Properties.IsSet(RejectAggregates) {
+		return nil, pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", semaCtx.Properties.required.context)
+	}
+	// The result is a bit mask with one bit per argument.
+	if len(expr.Exprs) > 31 {
+		return nil, pgerror.New(pgcode.TooManyArguments, "GROUPING must have fewer than 32 arguments")
+	}
+	for i, e := range expr.Exprs {
+		typedExpr, err := e.TypeCheck(ctx, semaCtx, types.Any)
+		if err != nil {
+			return nil, err
+		}
+		expr.Exprs[i] = typedExpr
+	}
+	expr.typ = types.Int
+	return expr, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s can only appear in a GROUP BY clause", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *IfErrExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -2134,6 +2238,12 @@ func (d *DPGVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Ty
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -2152,6 +2262,12 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -180,6 +180,9 @@ func ShowCreateTable(
 	if err := showConstraintClause(ctx, desc, p.EvalContext(), &p.semaCtx, p.SessionData(), f); err != nil {
 		return "", err
 	}
+	if err := showInheritsClause(desc, dbPrefix, lCtx, f); err != nil {
+		return "", err
+	}
 
 	if err := ShowCreatePartitioning(
 		a, p.ExecCfg().Codec, desc, desc.GetPrimaryIndex(), desc.GetPrimaryIndex().GetPartitioning(),
@@ -194,6 +197,13 @@ func ShowCreateTable(
 		f.Buffer.WriteString(`)`)
 	}
 
+	switch desc.GetOnCommit() {
+	case descpb.TableDescriptor_DELETE_ROWS:
+		f.Buffer.WriteString(` ON COMMIT DELETE ROWS`)
+	case descpb.TableDescriptor_DROP:
+		f.Buffer.WriteString(` ON COMMIT DROP`)
+	}
+
 	if err := showCreateLocality(desc, f); err != nil {
 		return "", err
 	}
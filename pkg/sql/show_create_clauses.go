@@ -593,6 +593,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrability != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+		buf.WriteByte(' ')
+		buf.WriteString(tree.ConstraintDeferrabilityType[fk.Deferrability].String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -628,6 +632,38 @@ func ShowCreateSequence(
 	return f.CloseAndGetString(), nil
 }
 
+// showInheritsClause creates the INHERITS clause for a CREATE statement,
+// writing it to tree.FmtCtx f.
+func showInheritsClause(
+	desc catalog.TableDescriptor, dbPrefix string, lCtx simpleSchemaResolver, f *tree.FmtCtx,
+) error {
+	if len(desc.GetInherits()) == 0 {
+		return nil
+	}
+	f.WriteString(" INHERITS (")
+	for i, id := range desc.GetInherits() {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		var parentName tree.TableName
+		if lCtx != nil {
+			parent, err := lCtx.getTableByID(id)
+			if err != nil {
+				return err
+			}
+			parentName, err = getTableNameFromTableDescriptor(lCtx, parent, dbPrefix)
+			if err != nil {
+				return err
+			}
+		} else {
+			parentName = tree.MakeUnqualifiedTableName(tree.Name(fmt.Sprintf("[%d as ref]", id)))
+		}
+		f.FormatNode(&parentName)
+	}
+	f.WriteString(")")
+	return nil
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
@@ -855,13 +891,27 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		if c.IsExclusion() {
+			elems, err := formatExclusionConstraintElems(desc, c)
+			if err != nil {
+				return err
+			}
+			f.WriteString("EXCLUDE (")
+			f.WriteString(elems)
+			f.WriteString(")")
+		} else {
+			f.WriteString("UNIQUE WITHOUT INDEX (")
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
+		}
+		if d := c.UniqueWithoutIndexDesc().Deferrability; d != semenumpb.ConstraintDeferrability_NOT_DEFERRABLE {
+			f.WriteByte(' ')
+			f.WriteString(tree.ConstraintDeferrabilityType[d].String())
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -879,3 +929,21 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// formatExclusionConstraintElems returns the columns of the given exclusion
+// constraint along with the operators used to compare them, as they appear in
+// an EXCLUDE clause.
+func formatExclusionConstraintElems(
+	desc catalog.TableDescriptor, c catalog.UniqueWithoutIndexConstraint,
+) (string, error) {
+	colNames, err := catalog.ColumnNamesForIDs(desc, c.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return "", err
+	}
+	ops := c.ExclusionOperators()
+	elems := make([]string, len(colNames))
+	for i := range colNames {
+		elems[i] = fmt.Sprintf("%s WITH %s", colNames[i], ops[i])
+	}
+	return strings.Join(elems, ", "), nil
+}
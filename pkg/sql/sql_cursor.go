@@ -18,10 +18,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -331,6 +333,124 @@ func (p *planner) PLpgSQLFetchCursor(
 	return res, err
 }
 
+// PLpgSQLExecuteDynamic implements the eval.Planner interface. The query is
+// parsed and planned each time it is executed, so it always reflects the
+// current state of the schema.
+func (p *planner) PLpgSQLExecuteDynamic(
+	ctx context.Context, query string, args tree.Datums, into, strict bool,
+) (res tree.Datums, err error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, err
+	}
+	switch stmt.AST.(type) {
+	case *tree.BeginTransaction, *tree.CommitTransaction, *tree.RollbackTransaction,
+		*tree.Savepoint, *tree.ReleaseSavepoint, *tree.RollbackToSavepoint:
+		return nil, unimplemented.NewWithIssue(
+			88198, "EXECUTE of transaction commands is not implemented",
+		)
+	}
+	qargs := make([]interface{}, len(args))
+	for i := range args {
+		qargs[i] = args[i]
+	}
+	const opName = "plpgsql-execute"
+	if !into {
+		_, err = p.ExecEx(ctx, opName, sessiondata.NoSessionDataOverride, query, qargs...)
+		return nil, err
+	}
+	if stmt.AST.StatementReturnType() != tree.Rows {
+		return nil, pgerror.New(pgcode.Syntax, "INTO used with a command that cannot return data")
+	}
+	rows, err := p.QueryIteratorEx(ctx, opName, sessiondata.NoSessionDataOverride, query, qargs...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.CombineErrors(err, rows.Close())
+	}()
+	ok, err := rows.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if strict {
+			return nil, pgerror.New(pgcode.NoDataFound, "query returned no rows")
+		}
+		return nil, nil
+	}
+	res = rows.Cur()
+	if strict {
+		// Check that there are no more rows.
+		if ok, err = rows.Next(ctx); err != nil {
+			return nil, err
+		}
+		if ok {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.TooManyRows, "query returned more than one row"),
+				"Make sure the query returns a single row, or use LIMIT 1.",
+			)
+		}
+	}
+	return res, nil
+}
+
+// PLpgSQLOpenDynamicCursor implements the eval.Planner interface. Like the
+// cursors opened by PLpgSQL OPEN statements with a static query, the query is
+// executed eagerly, and its result is buffered in a row container.
+func (p *planner) PLpgSQLOpenDynamicCursor(
+	ctx context.Context, cursorName tree.Name, query string, args tree.Datums,
+) (err error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+	if _, ok := stmt.AST.(*tree.Select); !ok {
+		return pgerror.Newf(
+			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.AST.StatementTag(),
+		)
+	}
+	if err = p.checkIfCursorExists(cursorName); err != nil {
+		return err
+	}
+	qargs := make([]interface{}, len(args))
+	for i := range args {
+		qargs[i] = args[i]
+	}
+	rows, err := p.InternalSQLTxn().QueryIteratorEx(
+		ctx, "plpgsql-open-cursor", p.txn, sessiondata.NoSessionDataOverride, query, qargs...,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.CombineErrors(err, rows.Close())
+	}()
+	cursorHelper, err := p.newPLpgSQLCursorHelper(cursorName, query, rows.Types())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if !cursorHelper.addedCursor {
+			// The cursor wasn't successfully added to the list, so clean it up here.
+			err = errors.CombineErrors(err, cursorHelper.Close())
+		}
+	}()
+	for {
+		ok, err := rows.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if err = cursorHelper.container.AddRow(ctx, rows.Cur()); err != nil {
+			return err
+		}
+	}
+	return cursorHelper.createCursor(p)
+}
+
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
@@ -49,6 +49,9 @@ var ForeignKeyCascadesUseCounter = telemetry.GetCounterOnce("sql.plan.fk.cascade
 // LATERAL keyword.
 var LateralJoinUseCounter = telemetry.GetCounterOnce("sql.plan.lateral-join")
 
+// MergeUseCounter is to be incremented whenever a MERGE statement is planned.
+var MergeUseCounter = telemetry.GetCounterOnce("sql.plan.merge")
+
 // HashJoinHintUseCounter is to be incremented whenever a query specifies a
 // hash join via a query hint.
 var HashJoinHintUseCounter = telemetry.GetCounterOnce("sql.plan.hints.hash-join")
@@ -0,0 +1,486 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// checkInheritanceParent checks that the given table can be used as the parent
+// of a table in the given database, either with CREATE TABLE ... INHERITS or
+// with ALTER TABLE ... INHERIT.
+func (p *planner) checkInheritanceParent(
+	ctx context.Context, parent *tabledesc.Mutable, dbID descpb.ID,
+) error {
+	if parent.IsVirtualTable() || parent.IsTemporary() || !parent.IsTable() {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"cannot inherit from relation %q", parent.GetName())
+	}
+	if parent.GetParentID() != dbID {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cross-database inheritance references not supported: %q", parent.GetName())
+	}
+	hasOwnership, err := p.HasOwnership(ctx, parent)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", parent.GetName())
+	}
+	return nil
+}
+
+// isInheritedColumn returns whether the given column of a table is inherited
+// by that table from one of its parents. Only the columns created by the user
+// are inherited.
+func isInheritedColumn(
+	ctx context.Context, p *planner, tableDesc catalog.TableDescriptor, colName tree.Name,
+) (bool, error) {
+	for _, parentID := range tableDesc.GetInherits() {
+		parent, err := p.Descriptors().MutableByID(p.txn).Table(ctx, parentID)
+		if err != nil {
+			return false, err
+		}
+		col := catalog.FindColumnByTreeName(parent, colName)
+		if col == nil || col.Dropped() {
+			continue
+		}
+		implicit, err := isImplicitlyCreatedBySystem(parent, col.ColumnDesc())
+		if err != nil {
+			return false, err
+		}
+		if !implicit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkColumnChangeAllowedWithInheritance checks that the given operation on a
+// column of a table does not break the invariant that every table has all the
+// columns of its parents with the same types. Only the addition of columns is
+// propagated through the inheritance hierarchy (see
+// addColumnToInheritingTables), so other changes to such columns are
+// disallowed.
+func checkColumnChangeAllowedWithInheritance(
+	ctx context.Context, p *planner, tableDesc catalog.TableDescriptor, colName tree.Name, op string,
+) error {
+	if len(tableDesc.GetInheritedBy()) > 0 {
+		return unimplemented.NewWithIssuef(22456,
+			"cannot %s column %q of a table that is inherited by other tables", op, colName)
+	}
+	inherited, err := isInheritedColumn(ctx, p, tableDesc, colName)
+	if err != nil {
+		return err
+	}
+	if inherited {
+		return pgerror.Newf(pgcode.InvalidTableDefinition,
+			"cannot %s inherited column %q", op, colName)
+	}
+	return nil
+}
+
+// alterTableInherit implements ALTER TABLE ... INHERIT. As in Postgres, the
+// table must already have all the columns and check constraints of the new
+// parent.
+func (p *planner) alterTableInherit(
+	ctx context.Context, tableDesc *tabledesc.Mutable, t *tree.AlterTableInherit,
+) error {
+	if tableDesc.IsTemporary() {
+		return unimplemented.NewWithIssue(22456, "INHERIT is not supported for temporary tables")
+	}
+	_, parent, err := p.ResolveMutableTableDescriptor(
+		ctx, &t.Parent, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	if err := p.checkInheritanceParent(ctx, parent, tableDesc.GetParentID()); err != nil {
+		return err
+	}
+	for _, id := range tableDesc.Inherits {
+		if id == parent.GetID() {
+			return pgerror.Newf(pgcode.DuplicateTable,
+				"relation %q would be inherited from more than once", parent.GetName())
+		}
+	}
+	// Disallow cycles by checking that the new parent is not the table itself
+	// or one of its descendants.
+	toVisit := []*tabledesc.Mutable{tableDesc}
+	for len(toVisit) > 0 {
+		desc := toVisit[0]
+		toVisit = toVisit[1:]
+		if desc.GetID() == parent.GetID() {
+			return errors.WithDetailf(
+				pgerror.New(pgcode.DuplicateTable, "circular inheritance not allowed"),
+				"%q is already a child of %q", parent.GetName(), tableDesc.GetName(),
+			)
+		}
+		for _, id := range desc.InheritedBy {
+			child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+			if err != nil {
+				return err
+			}
+			toVisit = append(toVisit, child)
+		}
+	}
+
+	for i := range parent.Columns {
+		parentCol := &parent.Columns[i]
+		implicit, err := isImplicitlyCreatedBySystem(parent, parentCol)
+		if err != nil {
+			return err
+		}
+		if implicit {
+			continue
+		}
+		col := catalog.FindColumnByName(tableDesc, parentCol.Name)
+		if col == nil || !col.Public() {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table is missing column %q", parentCol.Name)
+		}
+		if !col.GetType().Identical(parentCol.Type) {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table %q has different type for column %q", tableDesc.GetName(), parentCol.Name)
+		}
+		if !parentCol.Nullable && col.IsNullable() {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q in child table must be marked NOT NULL", parentCol.Name)
+		}
+	}
+	for _, parentCheck := range parent.Checks {
+		if parentCheck.FromHashShardedColumn {
+			continue
+		}
+		c := catalog.FindConstraintByName(tableDesc, parentCheck.Name)
+		var ck catalog.CheckConstraint
+		if c != nil {
+			ck = c.AsCheck()
+		}
+		if ck == nil {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table is missing constraint %q", parentCheck.Name)
+		}
+		same, err := sameCheckExpr(parentCheck.Expr, ck.GetExpr())
+		if err != nil {
+			return err
+		}
+		if !same {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table %q has different definition for check constraint %q",
+				tableDesc.GetName(), parentCheck.Name)
+		}
+	}
+
+	tableDesc.Inherits = append(tableDesc.Inherits, parent.GetID())
+	parent.InheritedBy = append(parent.InheritedBy, tableDesc.GetID())
+	return p.writeSchemaChange(
+		ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("adding inheriting table %s(%d) to table %s(%d)",
+			tableDesc.GetName(), tableDesc.GetID(), parent.GetName(), parent.GetID()),
+	)
+}
+
+// sameCheckExpr returns whether the given serialized check constraint
+// expressions are the same.
+func sameCheckExpr(a, b string) (bool, error) {
+	aExpr, err := parser.ParseExpr(a)
+	if err != nil {
+		return false, err
+	}
+	bExpr, err := parser.ParseExpr(b)
+	if err != nil {
+		return false, err
+	}
+	return tree.Serialize(aExpr) == tree.Serialize(bExpr), nil
+}
+
+// forEachInheritingTable calls fn for every table that inherits from the given
+// table, directly or not. A table that inherits from the given table through
+// several paths is visited once.
+func (p *planner) forEachInheritingTable(
+	ctx context.Context, tableDesc catalog.TableDescriptor, fn func(*tabledesc.Mutable) error,
+) error {
+	var visited catalog.DescriptorIDSet
+	toVisit := append([]descpb.ID(nil), tableDesc.GetInheritedBy()...)
+	for len(toVisit) > 0 {
+		id := toVisit[0]
+		toVisit = toVisit[1:]
+		if visited.Contains(id) {
+			continue
+		}
+		visited.Add(id)
+		child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(child); err != nil {
+			return err
+		}
+		toVisit = append(toVisit, child.InheritedBy...)
+	}
+	return nil
+}
+
+// checkCanAlterInheritingTable checks that a change to a table can be
+// propagated to the given table inheriting from it.
+func (p *planner) checkCanAlterInheritingTable(ctx context.Context, child *tabledesc.Mutable) error {
+	if err := p.CheckPrivilege(ctx, child, privilege.CREATE); err != nil {
+		return pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+			"must be owner of table %s or have CREATE privilege on table %s",
+			tree.Name(child.GetName()), tree.Name(child.GetName()))
+	}
+	return checkTableSchemaUnlocked(child)
+}
+
+// addColumnToInheritingTables adds a column added to a table with ALTER TABLE
+// ... ADD COLUMN to the tables inheriting from it, as in Postgres. A table that
+// already has a column with the same name and type keeps it, and the column
+// becomes inherited. As in Postgres, UNIQUE constraints are not inherited.
+func (p *planner) addColumnToInheritingTables(
+	params runParams, n *alterTableNode, t *tree.AlterTableAddColumn,
+) error {
+	if len(n.tableDesc.InheritedBy) == 0 {
+		return nil
+	}
+	d := t.ColumnDef
+	if d.IsSerial || d.GeneratedIdentity.IsGeneratedAsIdentity {
+		return unimplemented.NewWithIssuef(22456,
+			"cannot add a serial or identity column to a table that is inherited by other tables")
+	}
+	parentCol, err := catalog.MustFindColumnByTreeName(n.tableDesc, d.Name)
+	if err != nil {
+		return err
+	}
+	return p.forEachInheritingTable(params.ctx, n.tableDesc, func(child *tabledesc.Mutable) error {
+		if err := p.checkCanAlterInheritingTable(params.ctx, child); err != nil {
+			return err
+		}
+		if col := catalog.FindColumnByTreeName(child, d.Name); col != nil && col.Public() {
+			if !col.GetType().Identical(parentCol.GetType()) {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"child table %q has different type for column %q", child.GetName(), d.Name)
+			}
+			p.BufferClientNotice(params.ctx, pgnotice.Newf(
+				"merging definition of column %q for child %q", d.Name, child.GetName()))
+			return nil
+		}
+		childDef := *d
+		childDef.Unique.IsUnique = false
+		childDef.Unique.ConstraintName = ""
+		tn, err := p.getQualifiedTableName(params.ctx, child)
+		if err != nil {
+			return err
+		}
+		childNode := &alterTableNode{n: n.n, prefix: n.prefix, tableDesc: child}
+		p.runWithOptions(resolveFlags{contextDatabaseID: child.ParentID}, func() {
+			err = p.addColumnImpl(params, childNode, tn, child, &tree.AlterTableAddColumn{
+				IfNotExists: t.IfNotExists,
+				ColumnDef:   &childDef,
+			})
+		})
+		if err != nil {
+			return err
+		}
+		return p.writeSchemaChange(
+			params.ctx, child, child.ClusterVersion().NextMutationID,
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+		)
+	})
+}
+
+// addCheckToInheritingTables adds a check constraint added to a table with
+// ALTER TABLE ... ADD CONSTRAINT to the tables inheriting from it, with the
+// same name, as in Postgres. A table that already has a check constraint with
+// the same name and expression keeps it.
+func (p *planner) addCheckToInheritingTables(
+	params runParams,
+	n *alterTableNode,
+	d *tree.CheckConstraintTableDef,
+	ck *descpb.TableDescriptor_CheckConstraint,
+) error {
+	if len(n.tableDesc.InheritedBy) == 0 {
+		return nil
+	}
+	return p.forEachInheritingTable(params.ctx, n.tableDesc, func(child *tabledesc.Mutable) error {
+		if err := p.checkCanAlterInheritingTable(params.ctx, child); err != nil {
+			return err
+		}
+		if c := catalog.FindConstraintByName(child, ck.Name); c != nil {
+			if childCheck := c.AsCheck(); childCheck != nil {
+				same, err := sameCheckExpr(ck.Expr, childCheck.GetExpr())
+				if err != nil {
+					return err
+				}
+				if same {
+					p.BufferClientNotice(params.ctx, pgnotice.Newf(
+						"merging constraint %q with inherited definition", ck.Name))
+					return nil
+				}
+			}
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for relation %q already exists", ck.Name, child.GetName())
+		}
+		tn, err := p.getQualifiedTableName(params.ctx, child)
+		if err != nil {
+			return err
+		}
+		childDef := *d
+		childDef.Name = tree.Name(ck.Name)
+		p.runWithOptions(resolveFlags{contextDatabaseID: child.ParentID}, func() {
+			ckBuilder := schemaexpr.MakeCheckConstraintBuilder(params.ctx, *tn, child, &p.semaCtx)
+			for _, c := range child.AllConstraints() {
+				ckBuilder.MarkNameInUse(c.GetName())
+			}
+			var childCk *descpb.TableDescriptor_CheckConstraint
+			childCk, err = ckBuilder.Build(&childDef, params.ExecCfg().Settings.Version.ActiveVersion(params.ctx))
+			if err != nil {
+				return
+			}
+			childCk.Validity = ck.Validity
+			child.AddCheckMutation(childCk, descpb.DescriptorMutation_ADD)
+		})
+		if err != nil {
+			return err
+		}
+		return p.writeSchemaChange(
+			params.ctx, child, child.ClusterVersion().NextMutationID,
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+		)
+	})
+}
+
+// alterTableNoInherit implements ALTER TABLE ... NO INHERIT. The table keeps
+// all of its columns and constraints.
+func (p *planner) alterTableNoInherit(
+	ctx context.Context, tableDesc *tabledesc.Mutable, t *tree.AlterTableNoInherit,
+) error {
+	_, parent, err := p.ResolveMutableTableDescriptor(
+		ctx, &t.Parent, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, id := range tableDesc.Inherits {
+		if id == parent.GetID() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return pgerror.Newf(pgcode.UndefinedTable,
+			"relation %q is not a parent of relation %q", parent.GetName(), tableDesc.GetName())
+	}
+	tableDesc.Inherits = removeInheritanceReference(tableDesc.Inherits, parent.GetID())
+	parent.InheritedBy = removeInheritanceReference(parent.InheritedBy, tableDesc.GetID())
+	return p.writeSchemaChange(
+		ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("removing inheriting table %s(%d) from table %s(%d)",
+			tableDesc.GetName(), tableDesc.GetID(), parent.GetName(), parent.GetID()),
+	)
+}
+
+// checkDropInheritedTable checks that the tables inheriting from a table being
+// dropped are dropped as well. With CASCADE, these tables are added to td.
+func (p *planner) checkDropInheritedTable(
+	ctx context.Context,
+	droppedDesc *tabledesc.Mutable,
+	td map[descpb.ID]toDelete,
+	behavior tree.DropBehavior,
+) error {
+	for _, id := range droppedDesc.InheritedBy {
+		if _, ok := td[id]; ok {
+			continue
+		}
+		child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if behavior != tree.DropCascade {
+			return sqlerrors.NewDependentBlocksOpError(
+				"drop", "table", droppedDesc.GetName(), "table", child.GetName(),
+			)
+		}
+		if err := p.CheckPrivilege(ctx, child, privilege.DROP); err != nil {
+			return err
+		}
+		tn, err := p.getQualifiedTableName(ctx, child)
+		if err != nil {
+			return err
+		}
+		td[id] = toDelete{tn, child}
+		if err := p.checkDropInheritedTable(ctx, child, td, behavior); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeInheritanceReferences removes the references between a table being
+// dropped and its parents and children. Any child that is not dropped along
+// with the table no longer inherits from it.
+func (p *planner) removeInheritanceReferences(
+	ctx context.Context, tableDesc *tabledesc.Mutable,
+) error {
+	refs := append(append([]descpb.ID(nil), tableDesc.Inherits...), tableDesc.InheritedBy...)
+	for _, id := range refs {
+		other, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving inheritance reference to table ID %d", id)
+		}
+		if other.Dropped() {
+			// The other table is being dropped too. No need to modify it further.
+			continue
+		}
+		other.Inherits = removeInheritanceReference(other.Inherits, tableDesc.GetID())
+		other.InheritedBy = removeInheritanceReference(other.InheritedBy, tableDesc.GetID())
+		if err := p.writeSchemaChange(
+			ctx, other, descpb.InvalidMutationID,
+			fmt.Sprintf("removing inheritance references for table %s(%d) from table %s(%d)",
+				tableDesc.GetName(), tableDesc.GetID(), other.GetName(), other.GetID()),
+		); err != nil {
+			return err
+		}
+	}
+	tableDesc.Inherits = nil
+	tableDesc.InheritedBy = nil
+	return nil
+}
+
+func removeInheritanceReference(ids []descpb.ID, id descpb.ID) []descpb.ID {
+	updated := ids[:0]
+	for _, other := range ids {
+		if other != id {
+			updated = append(updated, other)
+		}
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+	return updated
+}
@@ -378,6 +378,136 @@ func cleanupTempSchemaObjects(
 	return nil
 }
 
+// tempTableCommitActions tracks the temporary tables of a SQL transaction
+// that have ON COMMIT DELETE ROWS or ON COMMIT DROP actions. The actions are
+// applied right before the transaction commits, and are discarded if it rolls
+// back.
+type tempTableCommitActions struct {
+	// deleteRows contains the ON COMMIT DELETE ROWS tables written by the
+	// transaction. Tables that are not written keep no rows, so they don't need
+	// to be cleared.
+	deleteRows catalog.DescriptorIDSet
+	// drop contains the ON COMMIT DROP tables created by the transaction.
+	drop catalog.DescriptorIDSet
+}
+
+// register records the ON COMMIT action of the given table, if any. It returns
+// true if the table has an action.
+func (a *tempTableCommitActions) register(desc catalog.TableDescriptor) bool {
+	if !desc.IsTemporary() {
+		return false
+	}
+	switch desc.GetOnCommit() {
+	case descpb.TableDescriptor_DELETE_ROWS:
+		a.deleteRows.Add(desc.GetID())
+		return true
+	case descpb.TableDescriptor_DROP:
+		a.drop.Add(desc.GetID())
+		return true
+	}
+	return false
+}
+
+// empty returns true if there are no actions to apply.
+func (a *tempTableCommitActions) empty() bool {
+	return a.deleteRows.Empty() && a.drop.Empty()
+}
+
+// reset clears the state of the tracker at the end of a transaction.
+func (a *tempTableCommitActions) reset() {
+	*a = tempTableCommitActions{}
+}
+
+// registerTempTableCommitAction records the ON COMMIT action of the given
+// table, so that it is applied when the current transaction commits. It
+// returns true if the table has an action.
+func (p *planner) registerTempTableCommitAction(desc catalog.TableDescriptor) bool {
+	if p.extendedEvalCtx.tempTableCommitActions == nil {
+		return false
+	}
+	return p.extendedEvalCtx.tempTableCommitActions.register(desc)
+}
+
+// applyTempTableCommitActions deletes the rows of the ON COMMIT DELETE ROWS
+// tables and drops the ON COMMIT DROP tables recorded in the given tracker. It
+// runs in the committing transaction, before its schema change jobs are
+// created, so that the drops are committed along with the rest of the
+// transaction.
+func (p *planner) applyTempTableCommitActions(
+	ctx context.Context, actions *tempTableCommitActions,
+) error {
+	if actions.empty() {
+		return nil
+	}
+	// The statements below may record new actions for the same tables, so
+	// take the current ones first.
+	deleteRows, drop := actions.deleteRows, actions.drop
+	actions.reset()
+
+	// lookupTable returns the name of the given table, or nil if the table
+	// doesn't exist anymore because it was dropped by the transaction.
+	lookupTable := func(id descpb.ID) (*tree.TableName, error) {
+		desc, err := p.Descriptors().ByIDWithoutLeased(p.Txn()).Get().Table(ctx, id)
+		if err != nil {
+			if errors.Is(err, catalog.ErrDescriptorNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if desc.Dropped() {
+			return nil, nil
+		}
+		return p.getQualifiedTableName(ctx, desc)
+	}
+
+	// Tables are cleared in the reverse order of their creation, so that rows
+	// referencing other temporary tables are deleted before the rows they
+	// reference.
+	deleteRowsIDs := deleteRows.Ordered()
+	for i := len(deleteRowsIDs) - 1; i >= 0; i-- {
+		tn, err := lookupTable(deleteRowsIDs[i])
+		if err != nil {
+			return err
+		}
+		if tn == nil {
+			continue
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, "temp-table-on-commit-delete-rows", p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			fmt.Sprintf("DELETE FROM %s", tn.FQString()),
+		); err != nil {
+			return err
+		}
+	}
+
+	var query strings.Builder
+	for _, id := range drop.Ordered() {
+		tn, err := lookupTable(id)
+		if err != nil {
+			return err
+		}
+		if tn == nil {
+			continue
+		}
+		if query.Len() == 0 {
+			query.WriteString("DROP TABLE ")
+		} else {
+			query.WriteString(", ")
+		}
+		query.WriteString(tn.FQString())
+	}
+	if query.Len() == 0 {
+		return nil
+	}
+	query.WriteString(" CASCADE")
+	_, err := p.InternalSQLTxn().ExecEx(
+		ctx, "temp-table-on-commit-drop", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride, query.String(),
+	)
+	return err
+}
+
 // isMeta1LeaseholderFunc helps us avoid an import into pkg/storage.
 type isMeta1LeaseholderFunc func(context.Context, hlc.ClockTimestamp) (bool, error)
 
@@ -283,6 +283,18 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 		return err
 	}
 
+	// Validate the constraints being added to a domain against its existing
+	// values. The constraints are already enforced on new values, since all
+	// leases on the previous versions of the domain have been dropped.
+	if typeDesc.AsDomainTypeDescriptor() != nil && typeDesc.HasPendingSchemaChanges() {
+		if err := t.validateDomainConstraints(ctx); err != nil {
+			return err
+		}
+		if err := refreshTypeDescriptorLeases(ctx, leaseMgr, t.execCfg.DB, typeDesc); err != nil {
+			return err
+		}
+	}
+
 	// For all the read only members the current job is responsible for, either
 	// promote them to writeable or remove them from the descriptor entirely,
 	// as dictated by the direction.
@@ -1420,6 +1432,10 @@ func (t *typeChangeResumer) OnFailOrCancel(
 			return err
 		}
 
+		if err := tc.cleanupDomainConstraints(ctx); err != nil {
+			return err
+		}
+
 		if fn := tc.execCfg.TypeSchemaChangerTestingKnobs.RunAfterOnFailOrCancel; fn != nil {
 			return fn()
 		}
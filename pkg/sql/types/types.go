@@ -215,6 +215,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -241,6 +244,36 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation. It
+// describes the constraints that the values of the domain must satisfy.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or the
+	// empty string if the domain has no default.
+	DefaultExpr string
+	// Checks are the CHECK constraints of the domain that are enforced on new
+	// values. The expressions refer to the value being checked as VALUE.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a domain.
+type DomainCheck struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized expression of the constraint.
+	Expr string
+	// TypedExpr is the type-checked expression of the constraint, in which
+	// VALUE is replaced with the indexed variable with index 0. It is a
+	// tree.TypedExpr, which this package cannot refer to, and is set when the
+	// type is hydrated. It must not be modified, since the metadata can be
+	// shared between goroutines.
+	TypedExpr interface{}
+	// Err is set instead of TypedExpr if the expression could not be
+	// type-checked.
+	Err error
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -496,6 +529,70 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of Int4 values.
+	Int4Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int4range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int8Range is the type of a range of Int values.
+	Int8Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int8range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// NumRange is the type of a range of Decimal values.
+	NumRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_numrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSRange is the type of a range of Timestamp values.
+	TSRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tsrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSTZRange is the type of a range of TimestampTZ values.
+	TSTZRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tstzrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// DateRange is the type of a range of Date values.
+	DateRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_daterange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// RangeTypes contains all the built-in range types.
+	RangeTypes = []*T{
+		Int4Range,
+		Int8Range,
+		NumRange,
+		TSRange,
+		TSTZRange,
+		DateRange,
+	}
+
 	// Void is the type representing void.
 	Void = &T{
 		InternalType: InternalType{
@@ -517,6 +614,16 @@ var (
 		},
 	}
 
+	// Jsonpath is the jsonpath type, which represents a SQL/JSON path
+	// expression.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// TSQuery is the tsquery type, which represents a full text search query.
 	TSQuery = &T{
 		InternalType: InternalType{
@@ -1192,6 +1299,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type with the given stable
+// type ID and base type. The domain has the same family, width and other
+// attributes as its base type. Note that it does not hydrate cached fields on
+// the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	t := &T{InternalType: base.InternalType}
+	t.InternalType.Oid = typeOID
+	t.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID:      arrayTypeOID,
+		DomainBaseTypeOID: base.Oid(),
+	}
+	return t
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1362,6 +1483,10 @@ func (t *T) TypeModifier() int32 {
 	if t.Family() == ArrayFamily {
 		return t.ArrayContents().TypeModifier()
 	}
+	// Domains have no type modifiers of their own.
+	if t.IsDomain() {
+		return int32(-1)
+	}
 	// The type modifier for "char" is always -1.
 	if t.Oid() == oid.T_char {
 		return int32(-1)
@@ -1402,6 +1527,10 @@ func (t *T) TypeModifier() int32 {
 // precision. If the given type already has no type modifiers, it is returned
 // unchanged and the function does not allocate a new type.
 func (t *T) WithoutTypeModifiers() *T {
+	if t.IsDomain() {
+		// Domains have no type modifiers of their own.
+		return t
+	}
 	switch t.Family() {
 	case ArrayFamily:
 		// Remove type modifiers of the array content type.
@@ -1506,6 +1635,23 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// IsDomain returns true if t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainBaseTypeOID != 0
+}
+
+// DomainBaseType returns the base type of a domain. It panics if t is not a
+// domain type.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		panic(errors.AssertionFailedf("type %s is not a domain", t.SQLStringForError()))
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseTypeOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1535,9 +1681,11 @@ var familyNames = map[Family]redact.SafeString{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
 	PGVectorFamily:       "vector",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
@@ -1573,6 +1721,13 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return "unknown_domain"
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1648,11 +1803,51 @@ func (t *T) Name() string {
 		}
 		return t.TypeMeta.Name.Basename()
 
+	case RangeFamily:
+		switch t.Oid() {
+		case oid.T_int4range:
+			return "int4range"
+		case oid.T_int8range:
+			return "int8range"
+		case oid.T_numrange:
+			return "numrange"
+		case oid.T_tsrange:
+			return "tsrange"
+		case oid.T_tstzrange:
+			return "tstzrange"
+		case oid.T_daterange:
+			return "daterange"
+		}
+		panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
+
 	default:
 		return string(fam.Name())
 	}
 }
 
+// RangeContents returns the type of the bounds of a range type. It returns nil
+// if the type is not a range type.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	switch t.Oid() {
+	case oid.T_int4range:
+		return Int4
+	case oid.T_int8range:
+		return Int
+	case oid.T_numrange:
+		return Decimal
+	case oid.T_tsrange:
+		return Timestamp
+	case oid.T_tstzrange:
+		return TimestampTZ
+	case oid.T_daterange:
+		return Date
+	}
+	panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
+}
+
 // PGName returns the Postgres name for the type. This is sometimes different
 // than the native CRDB name for it (i.e. the Name function). It is used when
 // compatibility with PG is important. Examples of differences:
@@ -1712,6 +1907,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1799,6 +1997,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JsonpathFamily:
+		return "jsonpath"
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1822,6 +2022,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "pg_lsn"
 	case PGVectorFamily:
 		return "vector"
+	case RangeFamily:
+		return t.Name()
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1916,6 +2118,11 @@ func (t *T) InformationSchemaName() string {
 	if t.Family() == ArrayFamily {
 		return "ARRAY"
 	}
+	// Columns of a domain type show the data type of the base type, as in
+	// Postgres.
+	if t.IsDomain() {
+		return t.DomainBaseType().InformationSchemaName()
+	}
 	// TypeMeta attributes are populated only when it is user defined type.
 	if t.TypeMeta.Name != nil {
 		return "USER-DEFINED"
@@ -1927,6 +2134,14 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		// Do not include the catalog name, as for the enum and composite types
+		// below.
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -2056,7 +2271,7 @@ func (t *T) SQLString() string {
 // type name to be a fully-qualified 3-part name.
 func (t *T) SQLStringFullyQualified() string {
 	if t.TypeMeta.Name != nil &&
-		(t.Family() == EnumFamily || (t.Family() == TupleFamily && t.UserDefined())) {
+		(t.Family() == EnumFamily || (t.Family() == TupleFamily && t.UserDefined()) || t.IsDomain()) {
 		// Include the catalog in the type name. This is necessary to properly
 		// resolve the type, as some code paths require the database name to
 		// correctly distinguish cross-database references.
@@ -2083,6 +2298,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		case ArrayFamily:
 			prefix = "ARRAY"
 		}
+		if t.IsDomain() {
+			prefix = "DOMAIN"
+		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
 	}
 	switch t.Family() {
@@ -2095,7 +2313,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily, RangeFamily,
+		JsonpathFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2170,6 +2389,12 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily:
+		// Range types with different element types are not compatible.
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2359,6 +2584,20 @@ func (t *InternalType) Identical(other *InternalType) bool {
 	return t.Oid == other.Oid
 }
 
+// convertDomainBaseType applies the given upgrade or downgrade step to the
+// fields of a domain type, which describe its base type. The OID of the domain
+// is preserved.
+func (t *T) convertDomainBaseType(convert func(*T) error) error {
+	base := t.DomainBaseType()
+	if err := convert(base); err != nil {
+		return err
+	}
+	domainOID, md := t.InternalType.Oid, t.InternalType.UDTMetadata
+	t.InternalType = base.InternalType
+	t.InternalType.Oid, t.InternalType.UDTMetadata = domainOID, md
+	return nil
+}
+
 // Unmarshal deserializes a type from the given byte representation using gogo
 // protobuf serialization rules. It is backwards-compatible with formats used
 // by older versions of CRDB.
@@ -2383,6 +2622,9 @@ func (t *T) Unmarshal(data []byte) error {
 // setting required values. This is necessary to preserve backwards-
 // compatibility with older formats (e.g. restoring database from old backup).
 func (t *T) upgradeType() error {
+	if t.IsDomain() {
+		return t.convertDomainBaseType((*T).upgradeType)
+	}
 	switch t.Family() {
 	case IntFamily:
 		// Check VisibleType field that was populated in previous versions.
@@ -2620,6 +2862,9 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	if t.IsDomain() {
+		return t.convertDomainBaseType((*T).downgradeType)
+	}
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -2872,6 +3117,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case PGVectorFamily:
 		return false, 121432
+	case JsonpathFamily:
+		return false, 22513
 	default:
 		return true, 0
 	}
@@ -3084,7 +3331,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"box":           21286,
 	"cidr":          18846,
 	"circle":        21286,
-	"jsonpath":      22513,
 	"line":          21286,
 	"lseg":          21286,
 	"macaddr":       45813,
@@ -48,6 +48,10 @@ type updateRun struct {
 
 	checkOrds checkSet
 
+	// checkPolicy is true if the last check column holds the result of the
+	// row-level security policies of the table.
+	checkPolicy bool
+
 	// done informs a new call to BatchedNext() that the previous call to
 	// BatchedNext() has completed the work already.
 	done bool
@@ -191,7 +195,7 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		checkVals := sourceVals[len(u.run.tu.ru.FetchCols)+len(u.run.tu.ru.UpdateCols)+u.run.numPassthrough:]
 		if err := checkMutationInput(
 			params.ctx, params.EvalContext(), &params.p.semaCtx, params.p.SessionData(),
-			u.run.tu.tableDesc(), u.run.checkOrds, checkVals,
+			u.run.tu.tableDesc(), u.run.checkOrds, checkVals, u.run.checkPolicy,
 		); err != nil {
 			return err
 		}
@@ -44,6 +44,10 @@ type upsertRun struct {
 	tw        optTableUpserter
 	checkOrds checkSet
 
+	// checkPolicy is true if the last check column holds the result of the
+	// row-level security policies of the table.
+	checkPolicy bool
+
 	// insertCols are the columns being inserted/upserted into.
 	insertCols []catalog.Column
 
@@ -173,7 +177,7 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		checkVals := rowVals[ord:]
 		if err := checkMutationInput(
 			params.ctx, params.p.EvalContext(), &params.p.semaCtx, params.p.SessionData(),
-			n.run.tw.tableDesc(), n.run.checkOrds, checkVals,
+			n.run.tw.tableDesc(), n.run.checkOrds, checkVals, n.run.checkPolicy,
 		); err != nil {
 			return err
 		}
@@ -76,6 +76,9 @@ type windowFuncHolder struct {
 	partitionIdxs  []int
 	columnOrdering colinfo.ColumnOrdering
 	frame          *tree.WindowFrame
+
+	// userDefined is set if the window function is a user-defined aggregate.
+	userDefined *tree.UserDefinedAggregate
 }
 
 // samePartition returns whether w and other have the same PARTITION BY clause.
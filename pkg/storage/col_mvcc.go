@@ -16,6 +16,7 @@ import (
 	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
@@ -81,6 +82,12 @@ import (
 // mvccScanFetchAdapter which - via the singleResults struct - exposes access to
 // the current KV that the pebbleMVCCScanner is pointing at.
 //
+// If the IndexFetchSpec includes a filter (made up of simple predicates on the
+// fetched columns), the cFetcherWrapper evaluates it on each coldata.Batch
+// produced by the cFetcher and only returns the rows that satisfy it. The
+// filter is best effort - the SQL layer evaluates it again - but it allows us
+// to avoid shipping most rows of selective scans over the network.
+//
 // Note that there is an additional "implicit synchronization" between
 // components that is not shown on this diagram. In particular,
 // storage.singleResults.maybeTrimPartialLastRow must be in sync with the
@@ -169,6 +176,26 @@ var GetCFetcherWrapper func(
 	mustSerialize bool,
 ) (CFetcherWrapper, error)
 
+// ColBatchAggregator computes aggregates over the coldata.Batch'es produced by
+// a CFetcherWrapper. It powers the AggregateScan command.
+type ColBatchAggregator interface {
+	// Add accumulates the rows of the given batch into the aggregates. The
+	// batch is not retained.
+	Add(ctx context.Context, batch coldata.Batch) error
+
+	// Result returns the aggregates of all rows added so far, encoded as
+	// described in kvpb.AggregateScanResponse.
+	Result() ([]byte, error)
+}
+
+// GetColBatchAggregator returns a ColBatchAggregator for the given aggregates
+// over the fetched columns of the fetchpb.IndexFetchSpec. Like
+// GetCFetcherWrapper, it's injected from pkg/sql/colfetcher.
+var GetColBatchAggregator func(
+	indexFetchSpec *fetchpb.IndexFetchSpec,
+	aggregates []kvpb.AggregateScanRequest_Aggregate,
+) (ColBatchAggregator, error)
+
 // onNextKVFn represents the transition that the mvccScanFetchAdapter needs to
 // perform on the following NextKV() call.
 type onNextKVFn int
@@ -413,13 +440,45 @@ func MVCCScanToCols(
 		return MVCCScanResult{}, err
 	}
 	defer iter.Close()
-	return mvccScanToCols(ctx, iter, indexFetchSpec, key, endKey, timestamp, opts, st)
+	return mvccScanToCols(ctx, iter, indexFetchSpec, nil /* aggregator */, key, endKey, timestamp, opts, st)
 }
 
+// MVCCScanToColsAndAggregate is like MVCCScanToCols, but instead of returning
+// the columnar batches, it passes each of them to the given aggregator as soon
+// as it has been produced. The returned result includes the resume span and the
+// number of keys and bytes scanned, but no data.
+func MVCCScanToColsAndAggregate(
+	ctx context.Context,
+	reader Reader,
+	indexFetchSpec *fetchpb.IndexFetchSpec,
+	aggregator ColBatchAggregator,
+	key, endKey roachpb.Key,
+	timestamp hlc.Timestamp,
+	opts MVCCScanOptions,
+	st *cluster.Settings,
+) (MVCCScanResult, error) {
+	iter, err := newMVCCIterator(
+		ctx, reader, timestamp, !opts.Tombstones, opts.DontInterleaveIntents, IterOptions{
+			KeyTypes:     IterKeyTypePointsAndRanges,
+			LowerBound:   key,
+			UpperBound:   endKey,
+			ReadCategory: opts.ReadCategory,
+		},
+	)
+	if err != nil {
+		return MVCCScanResult{}, err
+	}
+	defer iter.Close()
+	return mvccScanToCols(ctx, iter, indexFetchSpec, aggregator, key, endKey, timestamp, opts, st)
+}
+
+// mvccScanToCols implements MVCCScanToCols and MVCCScanToColsAndAggregate. If
+// aggregator is non-nil, the batches are aggregated instead of being returned.
 func mvccScanToCols(
 	ctx context.Context,
 	iter MVCCIterator,
 	indexFetchSpec *fetchpb.IndexFetchSpec,
+	aggregator ColBatchAggregator,
 	key, endKey roachpb.Key,
 	timestamp hlc.Timestamp,
 	opts MVCCScanOptions,
@@ -455,8 +514,9 @@ func mvccScanToCols(
 	defer acc.Close(ctx)
 	_, isLocal := grpcutil.IsLocalRequestContext(ctx)
 	// Note that the CFetcherWrapper might still serialize the batches even for
-	// local requests.
-	mustSerialize := !isLocal
+	// local requests. The batches are never serialized when they are aggregated
+	// since they don't leave this node.
+	mustSerialize := !isLocal && aggregator == nil
 	wrapper, err := GetCFetcherWrapper(
 		ctx,
 		st,
@@ -477,6 +537,7 @@ func mvccScanToCols(
 		res = MVCCScanResult{}
 	}
 	for {
+		usedBefore := acc.Used()
 		serializedBatch, colBatch, err := wrapper.NextBatch(ctx)
 		if err != nil {
 			return res, err
@@ -484,6 +545,20 @@ func mvccScanToCols(
 		if serializedBatch == nil && colBatch == nil {
 			break
 		}
+		if aggregator != nil {
+			if colBatch == nil {
+				return MVCCScanResult{}, errors.AssertionFailedf(
+					"serialized batch returned by the CFetcherWrapper for an aggregate scan",
+				)
+			}
+			if err = aggregator.Add(ctx, colBatch); err != nil {
+				return MVCCScanResult{}, err
+			}
+			// The batch is not retained, so we can release the memory that
+			// was reserved for it.
+			acc.Shrink(ctx, acc.Used()-usedBefore)
+			continue
+		}
 		if len(serializedBatch) > 0 {
 			res.KVData = append(res.KVData, serializedBatch)
 		} else {
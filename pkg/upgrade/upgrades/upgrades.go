@@ -123,6 +123,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore affected tables"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"add the replication_slots and publications system tables",
+		clusterversion.V24_3_ReplicationSlotsAndPublications.Version(),
+		upgrade.NoPrecondition,
+		createReplicationSlotsAndPublicationsTables,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore these tables"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"add the advisory_locks system table",
+		clusterversion.V24_3_AdvisoryLocks.Version(),
+		upgrade.NoPrecondition,
+		createAdvisoryLocksTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
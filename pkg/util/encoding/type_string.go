@@ -54,6 +54,8 @@ func _() {
 	_ = x[JsonEmptyArray-42]
 	_ = x[JsonEmptyArrayDesc-43]
 	_ = x[PGVector-44]
+	_ = x[Range-45]
+	_ = x[Jsonpath-46]
 }
 
 func (i Type) String() string {
@@ -148,6 +150,10 @@ func (i Type) String() string {
 		return "JsonEmptyArrayDesc"
 	case PGVector:
 		return "PGVector"
+	case Range:
+		return "Range"
+	case Jsonpath:
+		return "Jsonpath"
 	default:
 		return "Type(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,966 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	// decimalCtx is the context used for division. It matches the default
+	// context used for decimals in SQL.
+	decimalCtx = &apd.Context{
+		Precision:   20,
+		Rounding:    apd.RoundHalfUp,
+		MaxExponent: 2000,
+		MinExponent: -2000,
+		Traps:       apd.DefaultTraps,
+	}
+	// exactCtx is the context used for addition, subtraction and
+	// multiplication.
+	exactCtx = decimalCtx.WithPrecision(0)
+	// highPrecisionCtx is the context used for modulo.
+	highPrecisionCtx = decimalCtx.WithPrecision(2000)
+)
+
+// Query evaluates the path against the target document and returns the
+// resulting sequence of items. The values of the variables of the path are
+// taken from vars, which must be an object or nil. If silent is true, errors
+// during the evaluation of the path are suppressed, and an empty sequence is
+// returned instead.
+func (p Path) Query(target, vars json.JSON, silent bool) ([]json.JSON, error) {
+	if err := p.checkVars(vars); err != nil {
+		return nil, err
+	}
+	ev := evaluator{root: target, vars: vars, strict: p.Strict, last: -1}
+	res, err := ev.eval(p.Expr)
+	if err != nil {
+		if silent {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// Exists returns whether the path returns any items for the target document.
+// If silent is true and the evaluation of the path fails, ok is false.
+func (p Path) Exists(target, vars json.JSON, silent bool) (exists bool, ok bool, _ error) {
+	if err := p.checkVars(vars); err != nil {
+		return false, false, err
+	}
+	ev := evaluator{root: target, vars: vars, strict: p.Strict, last: -1}
+	res, err := ev.eval(p.Expr)
+	if err != nil {
+		if silent {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return len(res) > 0, true, nil
+}
+
+// Match evaluates a path which is a predicate against the target document and
+// returns its result. The result is NULL if the predicate is unknown. If
+// silent is true and the evaluation of the path fails, ok is false.
+func (p Path) Match(target, vars json.JSON, silent bool) (_ json.JSON, ok bool, _ error) {
+	res, err := p.Query(target, vars, silent)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(res) == 1 {
+		switch res[0].Type() {
+		case json.TrueJSONType, json.FalseJSONType, json.NullJSONType:
+			return res[0], true, nil
+		}
+	}
+	if silent {
+		return nil, false, nil
+	}
+	return nil, false, pgerror.New(pgcode.SingletonSQLJSONItemRequired,
+		"single boolean result is expected")
+}
+
+// checkVars checks that vars is an object which contains all the variables
+// referenced by the path.
+func (p Path) checkVars(vars json.JSON) error {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return pgerror.New(pgcode.InvalidParameterValue,
+			`"vars" argument is not an object`)
+	}
+	var err error
+	walkExpr(p.Expr, func(e Expr) {
+		v, ok := e.(Variable)
+		if !ok || err != nil {
+			return
+		}
+		var val json.JSON
+		if vars != nil {
+			if val, err = vars.FetchValKey(v.Name); err != nil {
+				return
+			}
+		}
+		if val == nil {
+			err = pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", v.Name)
+		}
+	})
+	return err
+}
+
+// walkExpr calls f on e and on all the expressions nested within it.
+func walkExpr(e Expr, f func(Expr)) {
+	f(e)
+	switch t := e.(type) {
+	case Chain:
+		walkExpr(t.Expr, f)
+		for _, a := range t.Accessors {
+			switch a := a.(type) {
+			case ArrayAccessor:
+				for _, s := range a.Subscripts {
+					walkExpr(s.From, f)
+					if s.To != nil {
+						walkExpr(s.To, f)
+					}
+				}
+			case FilterAccessor:
+				walkExpr(a.Predicate, f)
+			}
+		}
+	case BinaryExpr:
+		walkExpr(t.Left, f)
+		walkExpr(t.Right, f)
+	case UnaryExpr:
+		walkExpr(t.Operand, f)
+	case ExistsExpr:
+		walkExpr(t.Expr, f)
+	case IsUnknownExpr:
+		walkExpr(t.Expr, f)
+	case LikeRegexExpr:
+		walkExpr(t.Expr, f)
+	}
+}
+
+// triBool is the result of a predicate, which can be unknown.
+type triBool int
+
+const (
+	triFalse triBool = iota
+	triTrue
+	triUnknown
+)
+
+func (b triBool) toJSON() json.JSON {
+	switch b {
+	case triTrue:
+		return json.TrueJSONValue
+	case triFalse:
+		return json.FalseJSONValue
+	}
+	return json.NullJSONValue
+}
+
+type evaluator struct {
+	root, vars json.JSON
+	strict     bool
+	// current is the value of @.
+	current json.JSON
+	// last is the value of last, or -1 outside of array subscripts.
+	last int
+}
+
+// eval evaluates an expression and returns the resulting sequence of items.
+func (ev *evaluator) eval(e Expr) ([]json.JSON, error) {
+	switch t := e.(type) {
+	case Root:
+		return []json.JSON{ev.root}, nil
+	case Current:
+		return []json.JSON{ev.current}, nil
+	case Last:
+		if ev.last < 0 {
+			return nil, pgerror.New(pgcode.Syntax, "evaluating jsonpath LAST outside of array subscript")
+		}
+		return []json.JSON{json.FromInt(ev.last)}, nil
+	case Variable:
+		v, err := ev.vars.FetchValKey(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []json.JSON{v}, nil
+	case Literal:
+		switch v := t.Value.(type) {
+		case nil:
+			return []json.JSON{json.NullJSONValue}, nil
+		case bool:
+			return []json.JSON{json.FromBool(v)}, nil
+		case string:
+			return []json.JSON{json.FromString(v)}, nil
+		case *apd.Decimal:
+			return []json.JSON{json.FromDecimal(*v)}, nil
+		}
+		return nil, errors.AssertionFailedf("unexpected jsonpath literal %T", t.Value)
+	case Chain:
+		items, err := ev.eval(t.Expr)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range t.Accessors {
+			var next []json.JSON
+			for _, item := range items {
+				if next, err = ev.evalAccessor(a, item, next); err != nil {
+					return nil, err
+				}
+			}
+			items = next
+		}
+		return items, nil
+	case BinaryExpr:
+		if t.Op.isPredicate() {
+			return ev.evalPredicateToJSON(e)
+		}
+		return ev.evalArithmetic(t)
+	case UnaryExpr:
+		if t.Op == OpNot {
+			return ev.evalPredicateToJSON(e)
+		}
+		items, err := ev.evalUnwrapped(t.Operand)
+		if err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			d, ok := item.AsDecimal()
+			if !ok {
+				return nil, pgerror.Newf(pgcode.SQLJSONNumberNotFound,
+					"operand of unary jsonpath operator %s is not a numeric value", unaryOpName(t.Op))
+			}
+			if t.Op == OpMinus {
+				var neg apd.Decimal
+				neg.Neg(d)
+				items[i] = json.FromDecimal(neg)
+			}
+		}
+		return items, nil
+	case ExistsExpr, IsUnknownExpr, LikeRegexExpr:
+		return ev.evalPredicateToJSON(e)
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath expression %T", e)
+}
+
+func unaryOpName(op UnaryOp) string {
+	if op == OpMinus {
+		return "-"
+	}
+	return "+"
+}
+
+// evalUnwrapped evaluates an expression, unwrapping the arrays in the result
+// in lax mode.
+func (ev *evaluator) evalUnwrapped(e Expr) ([]json.JSON, error) {
+	items, err := ev.eval(e)
+	if err != nil || ev.strict {
+		return items, err
+	}
+	var res []json.JSON
+	for _, item := range items {
+		if item.Type() == json.ArrayJSONType {
+			elems, _ := item.AsArray()
+			res = append(res, elems...)
+		} else {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+func (ev *evaluator) evalPredicateToJSON(e Expr) ([]json.JSON, error) {
+	res, err := ev.evalPredicate(e)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// evalAccessor applies an accessor to an item and appends the resulting items
+// to res.
+func (ev *evaluator) evalAccessor(a Accessor, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	// In lax mode, the member accessors, filters and some of the item methods
+	// are applied to the elements of arrays.
+	if !ev.strict && item.Type() == json.ArrayJSONType {
+		unwrap := false
+		switch t := a.(type) {
+		case MemberAccessor, WildcardMemberAccessor, FilterAccessor:
+			unwrap = true
+		case MethodAccessor:
+			unwrap = t.Method != MethodType && t.Method != MethodSize
+		}
+		if unwrap {
+			elems, _ := item.AsArray()
+			var err error
+			for _, elem := range elems {
+				if res, err = ev.evalAccessor(a, elem, res); err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		}
+	}
+
+	switch t := a.(type) {
+	case MemberAccessor:
+		if item.Type() != json.ObjectJSONType {
+			if ev.strict {
+				return nil, pgerror.New(pgcode.SQLJSONMemberNotFound,
+					"jsonpath member accessor can only be applied to an object")
+			}
+			return res, nil
+		}
+		v, err := item.FetchValKey(t.Key)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			if ev.strict {
+				return nil, pgerror.Newf(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key %q", t.Key)
+			}
+			return res, nil
+		}
+		return append(res, v), nil
+
+	case WildcardMemberAccessor:
+		if item.Type() != json.ObjectJSONType {
+			if ev.strict {
+				return nil, pgerror.New(pgcode.SQLJSONObjectNotFound,
+					"jsonpath wildcard member accessor can only be applied to an object")
+			}
+			return res, nil
+		}
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			res = append(res, it.Value())
+		}
+		return res, nil
+
+	case WildcardArrayAccessor:
+		if item.Type() != json.ArrayJSONType {
+			if ev.strict {
+				return nil, pgerror.New(pgcode.SQLJSONArrayNotFound,
+					"jsonpath wildcard array accessor can only be applied to an array")
+			}
+			return append(res, item), nil
+		}
+		elems, _ := item.AsArray()
+		return append(res, elems...), nil
+
+	case ArrayAccessor:
+		return ev.evalArrayAccessor(t, item, res)
+
+	case AnyAccessor:
+		return ev.evalAnyAccessor(item, 0, t.First, t.Last, res)
+
+	case FilterAccessor:
+		prev := ev.current
+		ev.current = item
+		b, err := ev.evalPredicate(t.Predicate)
+		ev.current = prev
+		if err != nil {
+			return nil, err
+		}
+		if b == triTrue {
+			res = append(res, item)
+		}
+		return res, nil
+
+	case MethodAccessor:
+		return ev.evalMethod(t.Method, item, res)
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath accessor %T", a)
+}
+
+func (ev *evaluator) evalArrayAccessor(
+	a ArrayAccessor, item json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	var elems []json.JSON
+	if item.Type() == json.ArrayJSONType {
+		elems, _ = item.AsArray()
+	} else if ev.strict {
+		return nil, pgerror.New(pgcode.SQLJSONArrayNotFound,
+			"jsonpath array accessor can only be applied to an array")
+	} else {
+		// In lax mode, a non-array item is treated as an array with a single
+		// element.
+		elems = []json.JSON{item}
+	}
+	prevLast := ev.last
+	ev.last = len(elems) - 1
+	defer func() { ev.last = prevLast }()
+	for _, s := range a.Subscripts {
+		from, err := ev.evalSubscript(s.From)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if s.To != nil {
+			if to, err = ev.evalSubscript(s.To); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if ev.strict {
+				return nil, pgerror.New(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds")
+			}
+			if from < 0 {
+				from = 0
+			}
+			if to >= len(elems) {
+				to = len(elems) - 1
+			}
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalSubscript evaluates an array subscript, which must be a single number.
+// The number is truncated to an integer.
+func (ev *evaluator) evalSubscript(e Expr) (int, error) {
+	items, err := ev.evalUnwrapped(e)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			if v, err := truncate(d).Int64(); err == nil && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int(v), nil
+			}
+		}
+	}
+	return 0, pgerror.New(pgcode.InvalidSQLJSONSubscript,
+		"jsonpath array subscript is not a single numeric value")
+}
+
+// truncate returns d rounded towards zero.
+func truncate(d *apd.Decimal) *apd.Decimal {
+	var res apd.Decimal
+	ctx := *highPrecisionCtx
+	ctx.Rounding = apd.RoundDown
+	_, _ = ctx.RoundToIntegralValue(&res, d)
+	return &res
+}
+
+// evalAnyAccessor appends the items nested within the given item at levels
+// first through last to res. The item itself is at the given level.
+func (ev *evaluator) evalAnyAccessor(
+	item json.JSON, level, first, last uint32, res []json.JSON,
+) ([]json.JSON, error) {
+	if level > last {
+		return res, nil
+	}
+	if level >= first {
+		res = append(res, item)
+	}
+	var children []json.JSON
+	switch item.Type() {
+	case json.ArrayJSONType:
+		children, _ = item.AsArray()
+	case json.ObjectJSONType:
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			children = append(children, it.Value())
+		}
+	}
+	var err error
+	for _, c := range children {
+		if res, err = ev.evalAnyAccessor(c, level+1, first, last, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ev *evaluator) evalMethod(m Method, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	switch m {
+	case MethodType:
+		return append(res, json.FromString(typeName(item))), nil
+
+	case MethodSize:
+		if item.Type() == json.ArrayJSONType {
+			return append(res, json.FromInt(item.Len())), nil
+		}
+		if ev.strict {
+			return nil, pgerror.New(pgcode.SQLJSONArrayNotFound,
+				"jsonpath item method .size() can only be applied to an array")
+		}
+		return append(res, json.FromInt(1)), nil
+
+	case MethodDouble:
+		var f float64
+		switch item.Type() {
+		case json.NumberJSONType:
+			d, _ := item.AsDecimal()
+			var err error
+			if f, err = d.Float64(); err != nil {
+				return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m)
+			}
+		case json.StringJSONType:
+			s, err := item.AsText()
+			if err != nil {
+				return nil, err
+			}
+			if f, err = strconv.ParseFloat(strings.TrimSpace(*s), 64); err != nil {
+				return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m)
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a string or numeric value", m)
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"NaN or Infinity is not allowed for jsonpath item method .%s()", m)
+		}
+		j, err := json.FromFloat64(f)
+		if err != nil {
+			return nil, err
+		}
+		return append(res, j), nil
+
+	case MethodCeiling, MethodFloor, MethodAbs:
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m)
+		}
+		var r apd.Decimal
+		var err error
+		switch m {
+		case MethodCeiling:
+			_, err = exactCtx.Ceil(&r, d)
+		case MethodFloor:
+			_, err = exactCtx.Floor(&r, d)
+		default:
+			r.Abs(d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(res, json.FromDecimal(r)), nil
+
+	case MethodKeyValue:
+		if item.Type() != json.ObjectJSONType {
+			return nil, pgerror.Newf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .%s() can only be applied to an object", m)
+		}
+		it, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			// Postgres uses the offset of the object within the document as
+			// its id. We do not have such an offset, so the id is always 0.
+			b := json.NewObjectBuilder(3)
+			b.Add("id", json.FromInt(0))
+			b.Add("key", json.FromString(it.Key()))
+			b.Add("value", it.Value())
+			res = append(res, b.Build())
+		}
+		return res, nil
+
+	case MethodDatetime:
+		return nil, unimplemented.NewWithIssue(22513, "jsonpath item method .datetime() is not supported")
+	}
+	return nil, errors.AssertionFailedf("unexpected jsonpath item method %d", m)
+}
+
+// typeName returns the name of the type of the item, as returned by the
+// .type() item method.
+func typeName(item json.JSON) string {
+	switch item.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func (ev *evaluator) evalArithmetic(e BinaryExpr) ([]json.JSON, error) {
+	left, err := ev.evalNumericOperand(e.Left, "left", e.Op)
+	if err != nil {
+		return nil, err
+	}
+	right, err := ev.evalNumericOperand(e.Right, "right", e.Op)
+	if err != nil {
+		return nil, err
+	}
+	var r apd.Decimal
+	switch e.Op {
+	case OpAdd:
+		_, err = exactCtx.Add(&r, left, right)
+	case OpSub:
+		_, err = exactCtx.Sub(&r, left, right)
+	case OpMul:
+		_, err = exactCtx.Mul(&r, left, right)
+	case OpDiv, OpMod:
+		if right.IsZero() {
+			return nil, pgerror.New(pgcode.DivisionByZero, "division by zero")
+		}
+		if e.Op == OpDiv {
+			_, err = decimalCtx.Quo(&r, left, right)
+		} else {
+			_, err = highPrecisionCtx.Rem(&r, left, right)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unexpected jsonpath arithmetic operator %s", e.Op)
+	}
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.NumericValueOutOfRange, "jsonpath operator %s", e.Op)
+	}
+	return []json.JSON{json.FromDecimal(r)}, nil
+}
+
+// evalNumericOperand evaluates an operand of an arithmetic operator, which
+// must be a single number.
+func (ev *evaluator) evalNumericOperand(e Expr, side string, op BinaryOp) (*apd.Decimal, error) {
+	items, err := ev.evalUnwrapped(e)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			return d, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.SingletonSQLJSONItemRequired,
+		"%s operand of jsonpath operator %s is not a single numeric value", side, op)
+}
+
+// evalPredicate evaluates a predicate. Errors which occur while evaluating
+// the operands of the predicate make its result unknown.
+func (ev *evaluator) evalPredicate(e Expr) (triBool, error) {
+	switch t := e.(type) {
+	case BinaryExpr:
+		switch t.Op {
+		case OpAnd, OpOr:
+			left, err := ev.evalPredicate(t.Left)
+			if err != nil {
+				return triUnknown, err
+			}
+			if (t.Op == OpAnd && left == triFalse) || (t.Op == OpOr && left == triTrue) {
+				return left, nil
+			}
+			right, err := ev.evalPredicate(t.Right)
+			if err != nil {
+				return triUnknown, err
+			}
+			if t.Op == OpAnd {
+				if left == triTrue {
+					return right, nil
+				}
+				if right == triFalse {
+					return triFalse, nil
+				}
+				return triUnknown, nil
+			}
+			if left == triFalse {
+				return right, nil
+			}
+			if right == triTrue {
+				return triTrue, nil
+			}
+			return triUnknown, nil
+		case OpStartsWith:
+			return ev.evalStartsWith(t)
+		}
+		if !t.Op.isPredicate() {
+			return triUnknown, pgerror.New(pgcode.Syntax, "jsonpath expression is not a predicate")
+		}
+		return ev.evalComparison(t)
+
+	case UnaryExpr:
+		if t.Op != OpNot {
+			return triUnknown, pgerror.New(pgcode.Syntax, "jsonpath expression is not a predicate")
+		}
+		b, err := ev.evalPredicate(t.Operand)
+		if err != nil {
+			return triUnknown, err
+		}
+		switch b {
+		case triTrue:
+			return triFalse, nil
+		case triFalse:
+			return triTrue, nil
+		}
+		return triUnknown, nil
+
+	case ExistsExpr:
+		items, err := ev.eval(t.Expr)
+		if err != nil {
+			return triUnknown, nil //nolint:returnerrcheck
+		}
+		if len(items) > 0 {
+			return triTrue, nil
+		}
+		return triFalse, nil
+
+	case IsUnknownExpr:
+		b, err := ev.evalPredicate(t.Expr)
+		if err != nil {
+			return triUnknown, err
+		}
+		if b == triUnknown {
+			return triTrue, nil
+		}
+		return triFalse, nil
+
+	case LikeRegexExpr:
+		re, err := compileRegex(t.Pattern, t.Flags)
+		if err != nil {
+			return triUnknown, err
+		}
+		return ev.evalAnyItem(t.Expr, func(item json.JSON) triBool {
+			s, ok := asString(item)
+			if !ok {
+				return triUnknown
+			}
+			if re.MatchString(s) {
+				return triTrue
+			}
+			return triFalse
+		})
+	}
+	// Any other expression is evaluated, and is treated as a predicate if it
+	// returns a single boolean, as Postgres does for the top-level expression
+	// of a filter.
+	items, err := ev.eval(e)
+	if err != nil {
+		return triUnknown, nil //nolint:returnerrcheck
+	}
+	if len(items) == 1 {
+		switch items[0].Type() {
+		case json.TrueJSONType:
+			return triTrue, nil
+		case json.FalseJSONType:
+			return triFalse, nil
+		}
+	}
+	return triUnknown, nil
+}
+
+// evalAnyItem evaluates e, and returns true if f returns true for any of the
+// resulting items. In strict mode, the result is unknown if f returns unknown
+// for any item.
+func (ev *evaluator) evalAnyItem(e Expr, f func(json.JSON) triBool) (triBool, error) {
+	items, err := ev.evalUnwrapped(e)
+	if err != nil {
+		return triUnknown, nil //nolint:returnerrcheck
+	}
+	found, unknown := false, false
+	for _, item := range items {
+		switch f(item) {
+		case triTrue:
+			if !ev.strict {
+				return triTrue, nil
+			}
+			found = true
+		case triUnknown:
+			if ev.strict {
+				return triUnknown, nil
+			}
+			unknown = true
+		}
+	}
+	switch {
+	case found:
+		return triTrue, nil
+	case unknown:
+		return triUnknown, nil
+	}
+	return triFalse, nil
+}
+
+func (ev *evaluator) evalStartsWith(e BinaryExpr) (triBool, error) {
+	prefixes, err := ev.evalUnwrapped(e.Right)
+	if err != nil {
+		return triUnknown, nil //nolint:returnerrcheck
+	}
+	if len(prefixes) != 1 {
+		return triUnknown, nil
+	}
+	prefix, ok := asString(prefixes[0])
+	if !ok {
+		return triUnknown, nil
+	}
+	return ev.evalAnyItem(e.Left, func(item json.JSON) triBool {
+		s, ok := asString(item)
+		if !ok {
+			return triUnknown
+		}
+		if strings.HasPrefix(s, prefix) {
+			return triTrue
+		}
+		return triFalse
+	})
+}
+
+// evalComparison evaluates a comparison, which is true if the comparison is
+// true for any pair of items from the operands.
+func (ev *evaluator) evalComparison(e BinaryExpr) (triBool, error) {
+	left, err := ev.evalUnwrapped(e.Left)
+	if err != nil {
+		return triUnknown, nil //nolint:returnerrcheck
+	}
+	right, err := ev.evalUnwrapped(e.Right)
+	if err != nil {
+		return triUnknown, nil //nolint:returnerrcheck
+	}
+	found, unknown := false, false
+	for _, l := range left {
+		for _, r := range right {
+			switch compareItems(e.Op, l, r) {
+			case triTrue:
+				if !ev.strict {
+					return triTrue, nil
+				}
+				found = true
+			case triUnknown:
+				if ev.strict {
+					return triUnknown, nil
+				}
+				unknown = true
+			}
+		}
+	}
+	switch {
+	case found:
+		return triTrue, nil
+	case unknown:
+		return triUnknown, nil
+	}
+	return triFalse, nil
+}
+
+// compareItems compares two items with the given comparison operator. The
+// result is unknown if the items are not comparable.
+func compareItems(op BinaryOp, l, r json.JSON) triBool {
+	lt, rt := l.Type(), r.Type()
+	if lt == json.TrueJSONType {
+		lt = json.FalseJSONType
+	}
+	if rt == json.TrueJSONType {
+		rt = json.FalseJSONType
+	}
+	if lt != rt {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			// Null is only equal to itself, and is not ordered with respect to
+			// other values.
+			if op == OpNe {
+				return triTrue
+			}
+			return triFalse
+		}
+		return triUnknown
+	}
+	var c int
+	switch lt {
+	case json.NullJSONType:
+		c = 0
+	case json.FalseJSONType, json.NumberJSONType, json.StringJSONType:
+		var err error
+		if c, err = l.Compare(r); err != nil {
+			return triUnknown
+		}
+	default:
+		// Arrays and objects are not comparable.
+		return triUnknown
+	}
+	var res bool
+	switch op {
+	case OpEq:
+		res = c == 0
+	case OpNe:
+		res = c != 0
+	case OpLt:
+		res = c < 0
+	case OpLe:
+		res = c <= 0
+	case OpGt:
+		res = c > 0
+	case OpGe:
+		res = c >= 0
+	}
+	if res {
+		return triTrue
+	}
+	return triFalse
+}
+
+func asString(item json.JSON) (string, bool) {
+	if item.Type() != json.StringJSONType {
+		return "", false
+	}
+	s, err := item.AsText()
+	if err != nil || s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+// compileRegex compiles the pattern of a like_regex predicate with the given
+// flags.
+func compileRegex(pattern, flags string) (*regexp.Regexp, error) {
+	var goFlags strings.Builder
+	for _, f := range flags {
+		switch f {
+		case 'i', 's', 'm':
+			goFlags.WriteRune(f)
+		case 'q':
+			pattern = regexp.QuoteMeta(pattern)
+		case 'x':
+			return nil, unimplemented.NewWithIssue(22513, "XQuery \"x\" flag (expanded regular expressions) is not implemented")
+		}
+	}
+	if goFlags.Len() > 0 {
+		pattern = "(?" + goFlags.String() + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return re, nil
+}
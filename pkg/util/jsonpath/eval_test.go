@@ -0,0 +1,175 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	vars, err := json.ParseJSON(`{"x": 2, "s": "ab"}`)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		doc      string
+		path     string
+		expected string
+		// errCode is the code of the expected error, if any.
+		errCode pgcode.Code
+	}{
+		{`{"a": [1, 2, 3, 4, 5]}`, `$.a[*] ? (@ > 2)`, `3; 4; 5`, pgcode.Code{}},
+		{`{"a": [1, 2, 3, 4, 5]}`, `$.a[1 to last]`, `2; 3; 4; 5`, pgcode.Code{}},
+		{`{"a": [1, 2, 3, 4, 5]}`, `$.a[last - 1, 0]`, `4; 1`, pgcode.Code{}},
+		{`{"a": [1, 2, 3, 4, 5]}`, `lax $.a[10]`, ``, pgcode.Code{}},
+		{`{"a": [1, 2, 3, 4, 5]}`, `strict $.a[10]`, ``, pgcode.InvalidSQLJSONSubscript},
+		{`{"a": [1, 2, 3, 4, 5]}`, `$.a.size()`, `5`, pgcode.Code{}},
+		{`{"a": [1, 2, 3, 4, 5]}`, `$.a + 1`, ``, pgcode.SingletonSQLJSONItemRequired},
+		{`{"a": [1]}`, `$.a + 1`, `2`, pgcode.Code{}},
+		{`{"a": 1}`, `$.a[0]`, `1`, pgcode.Code{}},
+		{`{"a": 1}`, `strict $.a[0]`, ``, pgcode.SQLJSONArrayNotFound},
+		{`{"a": {"b": 1}}`, `$.a.c`, ``, pgcode.Code{}},
+		{`{"a": {"b": 1}}`, `strict $.a.c`, ``, pgcode.SQLJSONMemberNotFound},
+		{`{"a": {"b": 1}}`, `$.a.keyvalue()`, `{"id": 0, "key": "b", "value": 1}`, pgcode.Code{}},
+		{`{"a": {"b": 1}}`, `$.*`, `{"b": 1}`, pgcode.Code{}},
+		{`{"a": {"b": 1}}`, `$.**`, `{"a": {"b": 1}}; {"b": 1}; 1`, pgcode.Code{}},
+		{`{"a": {"b": 1}}`, `$.**{1 to last}`, `{"b": 1}; 1`, pgcode.Code{}},
+		{`[{"a": 1}, {"a": 2}]`, `$.a`, `1; 2`, pgcode.Code{}},
+		{`[{"a": 1}, {"a": 2}]`, `strict $.a`, ``, pgcode.SQLJSONMemberNotFound},
+		{`[1, "a", null, true]`, `$[*].type()`, `"number"; "string"; "null"; "boolean"`, pgcode.Code{}},
+		{`[1.5, -2.5]`, `$.floor()`, `1; -3`, pgcode.Code{}},
+		{`[1.5, -2.5]`, `$.ceiling()`, `2; -2`, pgcode.Code{}},
+		{`[1.5, -2.5]`, `$.abs()`, `1.5; 2.5`, pgcode.Code{}},
+		{`["1.5", 2]`, `$.double()`, `1.5; 2`, pgcode.Code{}},
+		{`"abc"`, `$.double()`, ``, pgcode.NonNumericSQLJSONItem},
+		{`1`, `$ / 0`, ``, pgcode.DivisionByZero},
+		{`10`, `$ / 4`, `2.5000000000000000000`, pgcode.Code{}},
+		{`10`, `$ % 3`, `1`, pgcode.Code{}},
+		{`10`, `$ + $x`, `12`, pgcode.Code{}},
+		{`[1, 2, 3]`, `-$[*]`, `-1; -2; -3`, pgcode.Code{}},
+		{`["a"]`, `-$[*]`, ``, pgcode.SQLJSONNumberNotFound},
+		{`[[1, 2], [3]]`, `$[*][0]`, `1; 3`, pgcode.Code{}},
+		{`["abc", "xbc"]`, `$[*] ? (@ starts with "a")`, `"abc"`, pgcode.Code{}},
+		{`["abc", "xbc"]`, `$[*] ? (@ starts with $s)`, `"abc"`, pgcode.Code{}},
+		{`["abc", "ABc"]`, `$[*] ? (@ like_regex "^ab" flag "i")`, `"abc"; "ABc"`, pgcode.Code{}},
+		{`[1, null, "a"]`, `$[*] ? (@ != null)`, `1; "a"`, pgcode.Code{}},
+		{`[1, null, "a"]`, `$[*] ? (@ > 0)`, `1`, pgcode.Code{}},
+		{`[1, 2]`, `$[*] ? ((@ > "a") is unknown)`, `1; 2`, pgcode.Code{}},
+		{`{"a": [{"b": 1}, {"b": 5}]}`, `$.a ? (@.b > 2).b`, `5`, pgcode.Code{}},
+		{`{"a": [{"b": 1}, {"b": 5}]}`, `$ ? (exists (@.a ? (@.b > 4))).a[0].b`, `1`, pgcode.Code{}},
+		{`{"a": 1}`, `$.a == 1`, `true`, pgcode.Code{}},
+		{`{"a": 1}`, `$.a > "x"`, `null`, pgcode.Code{}},
+		{`{"a": [1, "x"]}`, `$.a[*] > 0`, `true`, pgcode.Code{}},
+		{`{"a": [1, "x"]}`, `strict $.a[*] > 0`, `null`, pgcode.Code{}},
+	} {
+		t.Run(tc.doc+" "+tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.doc)
+			require.NoError(t, err)
+			res, err := p.Query(target, vars, false /* silent */)
+			if tc.errCode != (pgcode.Code{}) {
+				require.Error(t, err)
+				assert.Equal(t, tc.errCode, pgerror.GetPGCode(err))
+
+				// In silent mode, the error is suppressed.
+				res, err = p.Query(target, vars, true /* silent */)
+				require.NoError(t, err)
+				assert.Empty(t, res)
+				return
+			}
+			require.NoError(t, err)
+			strs := make([]string, len(res))
+			for i := range res {
+				strs[i] = res[i].String()
+			}
+			assert.Equal(t, tc.expected, strings.Join(strs, "; "))
+		})
+	}
+}
+
+func TestUndefinedVariable(t *testing.T) {
+	p, err := Parse(`$ + $y`)
+	require.NoError(t, err)
+	// Undefined variables are reported even in silent mode.
+	for _, silent := range []bool{false, true} {
+		_, err = p.Query(json.FromInt(1), nil /* vars */, silent)
+		require.Error(t, err)
+		assert.Equal(t, pgcode.UndefinedObject, pgerror.GetPGCode(err))
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		doc      string
+		path     string
+		expected string
+		err      string
+	}{
+		{`{"a": 1}`, `$.a == 1`, `true`, ``},
+		{`{"a": 1}`, `$.a != 1`, `false`, ``},
+		{`{"a": 1}`, `$.a > "x"`, `null`, ``},
+		{`{"a": true}`, `$.a`, `true`, ``},
+		{`{"a": 1}`, `$.a`, ``, `single boolean result is expected`},
+	} {
+		t.Run(tc.doc+" "+tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.doc)
+			require.NoError(t, err)
+			res, ok, err := p.Match(target, nil /* vars */, false /* silent */)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, res.String())
+		})
+	}
+}
+
+func TestExists(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2]}`)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		path     string
+		exists   bool
+		ok       bool
+		silent   bool
+		hasError bool
+	}{
+		{`$.a`, true, true, false, false},
+		{`$.b`, false, true, false, false},
+		{`$.a ? (@ > 5)`, false, true, false, false},
+		{`strict $.b`, false, false, false, true},
+		{`strict $.b`, false, false, true, false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			exists, ok, err := p.Exists(target, nil /* vars */, tc.silent)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exists, exists)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
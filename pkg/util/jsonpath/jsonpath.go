@@ -0,0 +1,459 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON documents. See
+// https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH
+// for a description of the language.
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Path is a parsed SQL/JSON path expression.
+type Path struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors are reported. Otherwise, it is evaluated in lax mode,
+	// in which arrays are automatically unwrapped and structural errors are
+	// suppressed.
+	Strict bool
+	// Expr is the expression of the path.
+	Expr Expr
+}
+
+// String returns the canonical representation of the path, which matches the
+// output of Postgres.
+func (p Path) String() string {
+	var b strings.Builder
+	if p.Strict {
+		b.WriteString("strict ")
+	}
+	formatExpr(&b, p.Expr, true /* parens */)
+	return b.String()
+}
+
+// Expr is a node of a path expression.
+type Expr interface {
+	jsonpathExpr()
+}
+
+// Root is the $ variable, which refers to the JSON document being queried.
+type Root struct{}
+
+// Current is the @ variable, which refers to the item being tested by a
+// filter expression.
+type Current struct{}
+
+// Last is the last keyword, which refers to the last index of the array
+// being subscripted.
+type Last struct{}
+
+// Variable is a named variable whose value is supplied when the path is
+// evaluated.
+type Variable struct {
+	Name string
+}
+
+// Literal is a constant JSON scalar. Its value is one of nil (for null),
+// bool, string or *apd.Decimal.
+type Literal struct {
+	Value interface{}
+}
+
+// Chain is an expression followed by a non-empty sequence of accessors, each
+// of which is applied to the items produced by the previous one.
+type Chain struct {
+	Expr      Expr
+	Accessors []Accessor
+}
+
+// BinaryOp is an operator of a BinaryExpr.
+type BinaryOp int
+
+// The binary operators, in order of increasing precedence within each group.
+const (
+	OpOr BinaryOp = iota
+	OpAnd
+	OpEq
+	OpNe
+	OpLt
+	OpLe
+	OpGt
+	OpGe
+	OpAdd
+	OpSub
+	OpMul
+	OpDiv
+	OpMod
+	OpStartsWith
+)
+
+var binaryOpNames = [...]string{
+	OpOr:         "||",
+	OpAnd:        "&&",
+	OpEq:         "==",
+	OpNe:         "!=",
+	OpLt:         "<",
+	OpLe:         "<=",
+	OpGt:         ">",
+	OpGe:         ">=",
+	OpAdd:        "+",
+	OpSub:        "-",
+	OpMul:        "*",
+	OpDiv:        "/",
+	OpMod:        "%",
+	OpStartsWith: "starts with",
+}
+
+// String returns the representation of the operator.
+func (op BinaryOp) String() string {
+	return binaryOpNames[op]
+}
+
+// isPredicate returns true if the operator produces a boolean.
+func (op BinaryOp) isPredicate() bool {
+	return op < OpAdd || op == OpStartsWith
+}
+
+// BinaryExpr is a binary arithmetic, comparison or logical expression.
+type BinaryExpr struct {
+	Op          BinaryOp
+	Left, Right Expr
+}
+
+// UnaryOp is an operator of a UnaryExpr.
+type UnaryOp int
+
+// The unary operators.
+const (
+	OpPlus UnaryOp = iota
+	OpMinus
+	OpNot
+)
+
+// UnaryExpr is a unary arithmetic or logical expression.
+type UnaryExpr struct {
+	Op      UnaryOp
+	Operand Expr
+}
+
+// ExistsExpr is the exists predicate, which tests whether a path returns any
+// items.
+type ExistsExpr struct {
+	Expr Expr
+}
+
+// IsUnknownExpr is the is unknown predicate, which tests whether a predicate
+// returns unknown.
+type IsUnknownExpr struct {
+	Expr Expr
+}
+
+// LikeRegexExpr is the like_regex predicate, which matches strings against a
+// regular expression.
+type LikeRegexExpr struct {
+	Expr    Expr
+	Pattern string
+	Flags   string
+}
+
+func (Root) jsonpathExpr()          {}
+func (Current) jsonpathExpr()       {}
+func (Last) jsonpathExpr()          {}
+func (Variable) jsonpathExpr()      {}
+func (Literal) jsonpathExpr()       {}
+func (Chain) jsonpathExpr()         {}
+func (BinaryExpr) jsonpathExpr()    {}
+func (UnaryExpr) jsonpathExpr()     {}
+func (ExistsExpr) jsonpathExpr()    {}
+func (IsUnknownExpr) jsonpathExpr() {}
+func (LikeRegexExpr) jsonpathExpr() {}
+
+// Accessor is a step of a Chain.
+type Accessor interface {
+	jsonpathAccessor()
+}
+
+// MemberAccessor is the .key accessor, which returns the value of a key of
+// an object.
+type MemberAccessor struct {
+	Key string
+}
+
+// WildcardMemberAccessor is the .* accessor, which returns all the values of
+// an object.
+type WildcardMemberAccessor struct{}
+
+// Subscript is an index or a range of indexes in an ArrayAccessor.
+type Subscript struct {
+	From Expr
+	// To is nil if the subscript is a single index.
+	To Expr
+}
+
+// ArrayAccessor is the [subscript, ...] accessor, which returns elements of
+// an array.
+type ArrayAccessor struct {
+	Subscripts []Subscript
+}
+
+// WildcardArrayAccessor is the [*] accessor, which returns all the elements
+// of an array.
+type WildcardArrayAccessor struct{}
+
+// AnyLast is the value of AnyAccessor.Last if the accessor has no upper
+// bound on the nesting level.
+const AnyLast = math.MaxUint32
+
+// AnyAccessor is the .** accessor, which returns the item and all the values
+// nested within it, at nesting levels First through Last.
+type AnyAccessor struct {
+	First, Last uint32
+}
+
+// FilterAccessor is the ? (predicate) accessor, which returns the items for
+// which the predicate is true.
+type FilterAccessor struct {
+	Predicate Expr
+}
+
+// Method is an item method.
+type Method int
+
+// The item methods.
+const (
+	MethodType Method = iota
+	MethodSize
+	MethodDouble
+	MethodCeiling
+	MethodFloor
+	MethodAbs
+	MethodKeyValue
+	MethodDatetime
+)
+
+var methodNames = [...]string{
+	MethodType:     "type",
+	MethodSize:     "size",
+	MethodDouble:   "double",
+	MethodCeiling:  "ceiling",
+	MethodFloor:    "floor",
+	MethodAbs:      "abs",
+	MethodKeyValue: "keyvalue",
+	MethodDatetime: "datetime",
+}
+
+// String returns the name of the method.
+func (m Method) String() string {
+	return methodNames[m]
+}
+
+// MethodAccessor is the .method() accessor, which applies an item method.
+type MethodAccessor struct {
+	Method Method
+}
+
+func (MemberAccessor) jsonpathAccessor()         {}
+func (WildcardMemberAccessor) jsonpathAccessor() {}
+func (ArrayAccessor) jsonpathAccessor()          {}
+func (WildcardArrayAccessor) jsonpathAccessor()  {}
+func (AnyAccessor) jsonpathAccessor()            {}
+func (FilterAccessor) jsonpathAccessor()         {}
+func (MethodAccessor) jsonpathAccessor()         {}
+
+// The precedence of expressions, used to decide where parentheses are needed
+// when formatting.
+const (
+	precOr = iota
+	precAnd
+	precComparison
+	precAdditive
+	precMultiplicative
+	precUnary
+	precPrimary
+)
+
+func precedence(e Expr) int {
+	switch t := e.(type) {
+	case BinaryExpr:
+		switch t.Op {
+		case OpOr:
+			return precOr
+		case OpAnd:
+			return precAnd
+		case OpAdd, OpSub:
+			return precAdditive
+		case OpMul, OpDiv, OpMod:
+			return precMultiplicative
+		default:
+			return precComparison
+		}
+	case LikeRegexExpr:
+		return precComparison
+	case UnaryExpr:
+		if t.Op != OpNot {
+			return precUnary
+		}
+	}
+	return precPrimary
+}
+
+// formatExpr writes the representation of e to b. If parens is true,
+// operator expressions are enclosed in parentheses.
+func formatExpr(b *strings.Builder, e Expr, parens bool) {
+	switch t := e.(type) {
+	case Root:
+		b.WriteByte('$')
+	case Current:
+		b.WriteByte('@')
+	case Last:
+		b.WriteString("last")
+	case Variable:
+		b.WriteByte('$')
+		writeString(b, t.Name)
+	case Literal:
+		switch v := t.Value.(type) {
+		case nil:
+			b.WriteString("null")
+		case bool:
+			b.WriteString(strconv.FormatBool(v))
+		case string:
+			writeString(b, v)
+		case *apd.Decimal:
+			b.WriteString(v.Text('f'))
+		}
+	case Chain:
+		formatExpr(b, t.Expr, true /* parens */)
+		for _, a := range t.Accessors {
+			formatAccessor(b, a)
+		}
+	case BinaryExpr:
+		if parens {
+			b.WriteByte('(')
+		}
+		formatExpr(b, t.Left, precedence(t.Left) <= precedence(t))
+		b.WriteByte(' ')
+		b.WriteString(t.Op.String())
+		b.WriteByte(' ')
+		formatExpr(b, t.Right, precedence(t.Right) <= precedence(t))
+		if parens {
+			b.WriteByte(')')
+		}
+	case UnaryExpr:
+		if t.Op == OpNot {
+			b.WriteString("!(")
+			formatExpr(b, t.Operand, false /* parens */)
+			b.WriteByte(')')
+			return
+		}
+		if parens {
+			b.WriteByte('(')
+		}
+		if t.Op == OpPlus {
+			b.WriteByte('+')
+		} else {
+			b.WriteByte('-')
+		}
+		formatExpr(b, t.Operand, precedence(t.Operand) <= precedence(t))
+		if parens {
+			b.WriteByte(')')
+		}
+	case ExistsExpr:
+		b.WriteString("exists (")
+		formatExpr(b, t.Expr, false /* parens */)
+		b.WriteByte(')')
+	case IsUnknownExpr:
+		b.WriteByte('(')
+		formatExpr(b, t.Expr, false /* parens */)
+		b.WriteString(") is unknown")
+	case LikeRegexExpr:
+		if parens {
+			b.WriteByte('(')
+		}
+		formatExpr(b, t.Expr, false /* parens */)
+		b.WriteString(" like_regex ")
+		writeString(b, t.Pattern)
+		if t.Flags != "" {
+			b.WriteString(" flag ")
+			writeString(b, t.Flags)
+		}
+		if parens {
+			b.WriteByte(')')
+		}
+	}
+}
+
+func formatAccessor(b *strings.Builder, a Accessor) {
+	switch t := a.(type) {
+	case MemberAccessor:
+		b.WriteByte('.')
+		writeString(b, t.Key)
+	case WildcardMemberAccessor:
+		b.WriteString(".*")
+	case ArrayAccessor:
+		b.WriteByte('[')
+		for i, s := range t.Subscripts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			formatExpr(b, s.From, false /* parens */)
+			if s.To != nil {
+				b.WriteString(" to ")
+				formatExpr(b, s.To, false /* parens */)
+			}
+		}
+		b.WriteByte(']')
+	case WildcardArrayAccessor:
+		b.WriteString("[*]")
+	case AnyAccessor:
+		b.WriteString(".**")
+		switch {
+		case t.First == 0 && t.Last == AnyLast:
+		case t.First == t.Last:
+			b.WriteByte('{')
+			writeAnyLevel(b, t.First)
+			b.WriteByte('}')
+		default:
+			b.WriteByte('{')
+			writeAnyLevel(b, t.First)
+			b.WriteString(" to ")
+			writeAnyLevel(b, t.Last)
+			b.WriteByte('}')
+		}
+	case FilterAccessor:
+		b.WriteString("?(")
+		formatExpr(b, t.Predicate, false /* parens */)
+		b.WriteByte(')')
+	case MethodAccessor:
+		b.WriteByte('.')
+		b.WriteString(t.Method.String())
+		b.WriteString("()")
+	}
+}
+
+func writeAnyLevel(b *strings.Builder, level uint32) {
+	if level == AnyLast {
+		b.WriteString("last")
+	} else {
+		b.WriteString(strconv.FormatUint(uint64(level), 10))
+	}
+}
+
+// writeString writes s to b as a double-quoted string literal, using the same
+// escaping as JSON strings.
+func writeString(b *strings.Builder, s string) {
+	b.WriteString(json.FromString(s).String())
+}
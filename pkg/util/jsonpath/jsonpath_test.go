@@ -0,0 +1,97 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`  $  `, `$`},
+		{`lax $`, `$`},
+		{`strict $.a.b`, `strict $."a"."b"`},
+		{`$."a b".c`, `$."a b"."c"`},
+		{`$.a[*]`, `$."a"[*]`},
+		{`$.a[1 to last, 0]`, `$."a"[1 to last,0]`},
+		{`$.a[last - 1]`, `$."a"[last - 1]`},
+		{`$.a[$.b]`, `$."a"[$."b"]`},
+		{`$.*`, `$.*`},
+		{`$.**`, `$.**`},
+		{`$.**{1}`, `$.**{1}`},
+		{`$.**{2 to last}`, `$.**{2 to last}`},
+		{`$.a[*]?(@ > 1 && @ < 5)`, `$."a"[*]?(@ > 1 && @ < 5)`},
+		{`$ ? (@.a == 1 || @.b != "x")`, `$?(@."a" == 1 || @."b" != "x")`},
+		{`$ ? ((@.a == 1 || @.b == 2) && @.c == 3)`, `$?((@."a" == 1 || @."b" == 2) && @."c" == 3)`},
+		{`$ ? (@ <> 1)`, `$?(@ != 1)`},
+		{`-$.a + 2 * 3`, `(-$."a" + 2 * 3)`},
+		{`(1 + 2) * 3`, `((1 + 2) * 3)`},
+		{`1 - (2 - 3)`, `(1 - (2 - 3))`},
+		{`$x + 1`, `($"x" + 1)`},
+		{`$"foo bar"`, `$"foo bar"`},
+		{`$.a.type().size()`, `$."a".type().size()`},
+		{`$.a.double().abs().floor().ceiling()`, `$."a".double().abs().floor().ceiling()`},
+		{`$.keyvalue()`, `$.keyvalue()`},
+		{`$ ? (@ like_regex "^ab" flag "i")`, `$?(@ like_regex "^ab" flag "i")`},
+		{`$ ? (@ starts with "x")`, `$?(@ starts with "x")`},
+		{`$ ? (@ starts with $x)`, `$?(@ starts with $"x")`},
+		{`$ ? (exists (@.b))`, `$?(exists (@."b"))`},
+		{`$ ? (!(@.b == 1))`, `$?(!(@."b" == 1))`},
+		{`$ ? ((@.b == 1) is unknown)`, `$?((@."b" == 1) is unknown)`},
+		{`$ ? (@ == 1.50)`, `$?(@ == 1.50)`},
+		{`$[1.5e1]`, `$[15]`},
+		{`$ == null`, `($ == null)`},
+		{`true`, `true`},
+		{`"a\tbA"`, `"a\tbA"`},
+		{`$.a == 1`, `($."a" == 1)`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p.String())
+
+			// The canonical representation must parse to the same path.
+			p2, err := Parse(p.String())
+			require.NoError(t, err)
+			assert.Equal(t, p.String(), p2.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$ == 1 == 2`, `syntax error at or near "==" of jsonpath input`},
+		{`@.a`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$.a.foo()`, `syntax error at or near "(" of jsonpath input`},
+		{`$ ? (@ like_regex "a" flag "z")`, `invalid input syntax for type jsonpath`},
+		{`$ ? (@ like_regex "(" )`, `invalid regular expression`},
+		{`"abc`, `unterminated quoted string`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
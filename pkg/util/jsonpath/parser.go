@@ -0,0 +1,791 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is an unquoted identifier or keyword.
+	tokIdent
+	tokString
+	tokNumber
+	tokVariable
+	// tokOp is any punctuation.
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	// val is the text of identifiers and operators, the unescaped value of
+	// strings and variables, and the text of numbers.
+	val string
+	// pos is the offset of the token in the input.
+	pos int
+}
+
+// lexer splits a path expression into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// operators lists the multi-character operators before the single-character
+// ones, so that the longest operator is matched.
+var operators = []string{
+	"**", "==", "!=", "<>", "<=", ">=", "&&", "||",
+	"$", "@", ".", "*", "[", "]", "(", ")", "{", "}", ",", "?",
+	"<", ">", "!", "+", "-", "/", "%",
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case c == '"':
+		s, err := l.scanString()
+		return token{kind: tokString, val: s, pos: start}, err
+	case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"':
+		l.pos++
+		s, err := l.scanString()
+		return token{kind: tokVariable, val: s, pos: start}, err
+	case c == '$' && l.pos+1 < len(l.input) && isIdentStart(l.input[l.pos+1]):
+		l.pos++
+		return token{kind: tokVariable, val: l.scanIdent(), pos: start}, nil
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.input) && isDigit(l.input[l.pos+1])):
+		return token{kind: tokNumber, val: l.scanNumber(), pos: start}, nil
+	case isIdentStart(c):
+		return token{kind: tokIdent, val: l.scanIdent(), pos: start}, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokOp, val: op, pos: start}, nil
+		}
+	}
+	return token{}, syntaxError(l.input[l.pos:l.pos+1], false /* atEnd */)
+}
+
+func (l *lexer) scanIdent() string {
+	start := l.pos
+	for l.pos < len(l.input) && (isIdentStart(l.input[l.pos]) || isDigit(l.input[l.pos])) {
+		l.pos++
+	}
+	return l.input[start:l.pos]
+}
+
+func (l *lexer) scanNumber() string {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	// Only consume a decimal point if it is followed by a digit, so that
+	// accessors can follow integers.
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' && isDigit(l.input[l.pos+1]) {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		end := l.pos + 1
+		if end < len(l.input) && (l.input[end] == '+' || l.input[end] == '-') {
+			end++
+		}
+		if end < len(l.input) && isDigit(l.input[end]) {
+			l.pos = end
+			for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+				l.pos++
+			}
+		}
+	}
+	return l.input[start:l.pos]
+}
+
+// scanString scans a double-quoted string starting at the current position
+// and returns its unescaped value. The escape sequences are those of JSON,
+// along with \v, \xNN and \u{N...}.
+func (l *lexer) scanString() (string, error) {
+	l.pos++
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.input) {
+			return "", pgerror.New(pgcode.Syntax, "unterminated quoted string in jsonpath input")
+		}
+		c := l.input[l.pos]
+		l.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if l.pos >= len(l.input) {
+				return "", pgerror.New(pgcode.Syntax, "unexpected end after backslash in jsonpath input")
+			}
+			e := l.input[l.pos]
+			l.pos++
+			switch e {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'v':
+				b.WriteByte('\v')
+			case 'x':
+				r, err := l.scanHex(2, 2)
+				if err != nil {
+					return "", err
+				}
+				b.WriteRune(r)
+			case 'u':
+				var r rune
+				var err error
+				if l.pos < len(l.input) && l.input[l.pos] == '{' {
+					l.pos++
+					if r, err = l.scanHex(1, 6); err != nil {
+						return "", err
+					}
+					if l.pos >= len(l.input) || l.input[l.pos] != '}' {
+						return "", pgerror.New(pgcode.Syntax, "invalid Unicode escape sequence in jsonpath input")
+					}
+					l.pos++
+				} else if r, err = l.scanHex(4, 4); err != nil {
+					return "", err
+				}
+				if utf16IsHighSurrogate(r) && strings.HasPrefix(l.input[l.pos:], `\u`) {
+					l.pos += 2
+					lo, err := l.scanHex(4, 4)
+					if err != nil {
+						return "", err
+					}
+					r = utf16Decode(r, lo)
+				}
+				if r == 0 || !utf8.ValidRune(r) {
+					return "", pgerror.New(pgcode.UntranslatableCharacter,
+						"unsupported Unicode escape sequence in jsonpath input")
+				}
+				b.WriteRune(r)
+			default:
+				// Any other escaped character, including " \ and /, stands for
+				// itself.
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// scanHex scans between min and max hexadecimal digits.
+func (l *lexer) scanHex(min, max int) (rune, error) {
+	start := l.pos
+	for l.pos < len(l.input) && l.pos-start < max && isHexDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos-start < min {
+		return 0, pgerror.New(pgcode.Syntax, "invalid hexadecimal character sequence in jsonpath input")
+	}
+	v, err := strconv.ParseUint(l.input[start:l.pos], 16, 32)
+	if err != nil {
+		return 0, errors.NewAssertionErrorWithWrappedErrf(err, "invalid hexadecimal digits")
+	}
+	return rune(v), nil
+}
+
+func utf16IsHighSurrogate(r rune) bool {
+	return r >= 0xd800 && r < 0xdc00
+}
+
+func utf16Decode(hi, lo rune) rune {
+	if lo < 0xdc00 || lo >= 0xe000 {
+		return utf8.RuneError
+	}
+	return (hi-0xd800)<<10 | (lo - 0xdc00) + 0x10000
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= utf8.RuneSelf || unicode.IsLetter(rune(c))
+}
+
+func syntaxError(near string, atEnd bool) error {
+	if atEnd {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", near)
+}
+
+// parser is a recursive descent parser for path expressions.
+type parser struct {
+	lexer lexer
+	tok   token
+	// peeked is the token following tok, if hasPeeked is true.
+	peeked    token
+	hasPeeked bool
+	// filterDepth is the number of filter expressions which enclose the
+	// current position, in which @ is allowed.
+	filterDepth int
+	// subscriptDepth is the number of array subscripts which enclose the
+	// current position, in which last is allowed.
+	subscriptDepth int
+}
+
+// Parse parses a SQL/JSON path expression.
+func Parse(s string) (Path, error) {
+	p := parser{lexer: lexer{input: s}}
+	if err := p.advance(); err != nil {
+		return Path{}, err
+	}
+	var path Path
+	if p.isKeyword("strict") || p.isKeyword("lax") {
+		path.Strict = p.tok.val == "strict"
+		if err := p.advance(); err != nil {
+			return Path{}, err
+		}
+	}
+	if p.tok.kind == tokEOF {
+		return Path{}, syntaxError("", true /* atEnd */)
+	}
+	expr, err := p.parseExpr(precOr)
+	if err != nil {
+		return Path{}, err
+	}
+	if p.tok.kind != tokEOF {
+		return Path{}, p.unexpected()
+	}
+	path.Expr = expr
+	return path, nil
+}
+
+func (p *parser) advance() error {
+	if p.hasPeeked {
+		p.tok, p.hasPeeked = p.peeked, false
+		return nil
+	}
+	var err error
+	p.tok, err = p.lexer.next()
+	return err
+}
+
+func (p *parser) peek() (token, error) {
+	if !p.hasPeeked {
+		var err error
+		if p.peeked, err = p.lexer.next(); err != nil {
+			return token{}, err
+		}
+		p.hasPeeked = true
+	}
+	return p.peeked, nil
+}
+
+func (p *parser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.val == op
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	return p.tok.kind == tokIdent && p.tok.val == kw
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return syntaxError("", true /* atEnd */)
+	}
+	end := p.lexer.pos
+	if p.hasPeeked {
+		end = p.peeked.pos
+	}
+	return syntaxError(strings.TrimSpace(p.lexer.input[p.tok.pos:end]), false /* atEnd */)
+}
+
+// expectOp consumes the given operator, returning an error if the current
+// token is not that operator.
+func (p *parser) expectOp(op string) error {
+	if !p.isOp(op) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// expectKeyword consumes the given keyword, returning an error if the current
+// token is not that keyword.
+func (p *parser) expectKeyword(kw string) error {
+	if !p.isKeyword(kw) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// binaryOp returns the binary operator at the current position, along with
+// its precedence.
+func (p *parser) binaryOp() (op BinaryOp, prec int, ok bool) {
+	switch p.tok.kind {
+	case tokOp:
+		switch p.tok.val {
+		case "||":
+			return OpOr, precOr, true
+		case "&&":
+			return OpAnd, precAnd, true
+		case "==":
+			return OpEq, precComparison, true
+		case "!=", "<>":
+			return OpNe, precComparison, true
+		case "<":
+			return OpLt, precComparison, true
+		case "<=":
+			return OpLe, precComparison, true
+		case ">":
+			return OpGt, precComparison, true
+		case ">=":
+			return OpGe, precComparison, true
+		case "+":
+			return OpAdd, precAdditive, true
+		case "-":
+			return OpSub, precAdditive, true
+		case "*":
+			return OpMul, precMultiplicative, true
+		case "/":
+			return OpDiv, precMultiplicative, true
+		case "%":
+			return OpMod, precMultiplicative, true
+		}
+	case tokIdent:
+		switch p.tok.val {
+		case "starts":
+			return OpStartsWith, precComparison, true
+		case "like_regex":
+			// like_regex is not a BinaryOp, but it has the same precedence as
+			// the comparison operators.
+			return 0, precComparison, true
+		}
+	}
+	return 0, 0, false
+}
+
+// parseExpr parses an expression containing operators with at least the
+// given precedence.
+func (p *parser) parseExpr(minPrec int) (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, prec, ok := p.binaryOp()
+		if !ok || prec < minPrec {
+			return left, nil
+		}
+		if p.isKeyword("like_regex") {
+			if left, err = p.parseLikeRegex(left); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var right Expr
+		if op == OpStartsWith {
+			if err := p.expectKeyword("with"); err != nil {
+				return nil, err
+			}
+			switch p.tok.kind {
+			case tokString:
+				right = Literal{Value: p.tok.val}
+			case tokVariable:
+				right = Variable{Name: p.tok.val}
+			default:
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		} else {
+			// All binary operators are left-associative, and the comparison
+			// operators are not associative at all.
+			nextPrec := prec + 1
+			if right, err = p.parseExpr(nextPrec); err != nil {
+				return nil, err
+			}
+			if prec == precComparison {
+				if _, nextOpPrec, ok := p.binaryOp(); ok && nextOpPrec == precComparison {
+					return nil, p.unexpected()
+				}
+			}
+		}
+		left = BinaryExpr{Op: op, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseLikeRegex(left Expr) (Expr, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokString {
+		return nil, p.unexpected()
+	}
+	e := LikeRegexExpr{Expr: left, Pattern: p.tok.val}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isKeyword("flag") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokString {
+			return nil, p.unexpected()
+		}
+		for _, f := range p.tok.val {
+			if !strings.ContainsRune("isxmq", f) {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate",
+					f)
+			}
+		}
+		e.Flags = p.tok.val
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := compileRegex(e.Pattern, e.Flags); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	switch {
+	case p.isOp("+"), p.isOp("-"):
+		op := OpPlus
+		if p.tok.val == "-" {
+			op = OpMinus
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Op: op, Operand: operand}, nil
+	case p.isOp("!"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		// The operand of ! must be a parenthesized predicate or an exists
+		// predicate.
+		if !p.isOp("(") && !p.isKeyword("exists") {
+			return nil, p.unexpected()
+		}
+		operand, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Op: OpNot, Operand: operand}, nil
+	}
+	return p.parseAccessorExpr()
+}
+
+func (p *parser) parseAccessorExpr() (Expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var accessors []Accessor
+	for {
+		a, ok, err := p.parseAccessor()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		accessors = append(accessors, a)
+	}
+	if len(accessors) == 0 {
+		return e, nil
+	}
+	return Chain{Expr: e, Accessors: accessors}, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	var e Expr
+	switch p.tok.kind {
+	case tokString:
+		e = Literal{Value: p.tok.val}
+	case tokNumber:
+		d, _, err := apd.NewFromString(p.tok.val)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric literal in jsonpath input")
+		}
+		e = Literal{Value: d}
+	case tokVariable:
+		e = Variable{Name: p.tok.val}
+	case tokIdent:
+		switch p.tok.val {
+		case "null":
+			e = Literal{Value: nil}
+		case "true":
+			e = Literal{Value: true}
+		case "false":
+			e = Literal{Value: false}
+		case "last":
+			if p.subscriptDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			e = Last{}
+		case "exists":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseExpr(precOr)
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp(")") {
+				return nil, p.unexpected()
+			}
+			e = ExistsExpr{Expr: inner}
+		default:
+			return nil, p.unexpected()
+		}
+	case tokOp:
+		switch p.tok.val {
+		case "$":
+			e = Root{}
+		case "@":
+			if p.filterDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			e = Current{}
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseExpr(precOr)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			if p.isKeyword("is") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if err := p.expectKeyword("unknown"); err != nil {
+					return nil, err
+				}
+				return IsUnknownExpr{Expr: inner}, nil
+			}
+			return inner, nil
+		default:
+			return nil, p.unexpected()
+		}
+	default:
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// parseAccessor parses the accessor at the current position, if there is
+// one.
+func (p *parser) parseAccessor() (_ Accessor, ok bool, _ error) {
+	switch {
+	case p.isOp("."):
+		if err := p.advance(); err != nil {
+			return nil, false, err
+		}
+		a, err := p.parseDotAccessor()
+		return a, err == nil, err
+	case p.isOp("["):
+		if err := p.advance(); err != nil {
+			return nil, false, err
+		}
+		a, err := p.parseArrayAccessor()
+		return a, err == nil, err
+	case p.isOp("?"):
+		if err := p.advance(); err != nil {
+			return nil, false, err
+		}
+		if err := p.expectOp("("); err != nil {
+			return nil, false, err
+		}
+		p.filterDepth++
+		pred, err := p.parseExpr(precOr)
+		p.filterDepth--
+		if err != nil {
+			return nil, false, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, false, err
+		}
+		return FilterAccessor{Predicate: pred}, true, nil
+	}
+	return nil, false, nil
+}
+
+// parseDotAccessor parses the accessor following a dot.
+func (p *parser) parseDotAccessor() (Accessor, error) {
+	switch p.tok.kind {
+	case tokOp:
+		switch p.tok.val {
+		case "*":
+			return WildcardMemberAccessor{}, p.advance()
+		case "**":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return p.parseAnyAccessor()
+		}
+	case tokString:
+		key := p.tok.val
+		return MemberAccessor{Key: key}, p.advance()
+	case tokIdent:
+		name := p.tok.val
+		next, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if next.kind == tokOp && next.val == "(" {
+			for m, n := range methodNames {
+				if n != name {
+					continue
+				}
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if err := p.expectOp(")"); err != nil {
+					return nil, err
+				}
+				return MethodAccessor{Method: Method(m)}, nil
+			}
+		}
+		return MemberAccessor{Key: name}, p.advance()
+	}
+	return nil, p.unexpected()
+}
+
+// parseAnyAccessor parses the optional levels of a .** accessor.
+func (p *parser) parseAnyAccessor() (Accessor, error) {
+	a := AnyAccessor{First: 0, Last: AnyLast}
+	if !p.isOp("{") {
+		return a, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if a.First, err = p.parseAnyLevel(); err != nil {
+		return nil, err
+	}
+	a.Last = a.First
+	if p.isKeyword("to") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if a.Last, err = p.parseAnyLevel(); err != nil {
+			return nil, err
+		}
+	}
+	return a, p.expectOp("}")
+}
+
+func (p *parser) parseAnyLevel() (uint32, error) {
+	if p.isKeyword("last") {
+		return AnyLast, p.advance()
+	}
+	if p.tok.kind != tokNumber {
+		return 0, p.unexpected()
+	}
+	v, err := strconv.ParseUint(p.tok.val, 10, 32)
+	if err != nil || v >= AnyLast {
+		return 0, p.unexpected()
+	}
+	return uint32(v), p.advance()
+}
+
+// parseArrayAccessor parses the accessor following an opening bracket.
+func (p *parser) parseArrayAccessor() (Accessor, error) {
+	if p.isOp("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return WildcardArrayAccessor{}, p.expectOp("]")
+	}
+	p.subscriptDepth++
+	defer func() { p.subscriptDepth-- }()
+	var a ArrayAccessor
+	for {
+		from, err := p.parseExpr(precOr)
+		if err != nil {
+			return nil, err
+		}
+		s := Subscript{From: from}
+		if p.isKeyword("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if s.To, err = p.parseExpr(precOr); err != nil {
+				return nil, err
+			}
+		}
+		a.Subscripts = append(a.Subscripts, s)
+		if !p.isOp(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return a, p.expectOp("]")
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math/rand"
+
+	"github.com/cockroachdb/apd/v3"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// Random generates a random path, consisting of a chain of accessors applied
+// to the root item.
+func Random(rng *rand.Rand) Path {
+	accessors := make([]Accessor, 1+rng.Intn(4))
+	for i := range accessors {
+		switch rng.Intn(6) {
+		case 0:
+			accessors[i] = WildcardMemberAccessor{}
+		case 1:
+			accessors[i] = WildcardArrayAccessor{}
+		case 2:
+			accessors[i] = ArrayAccessor{Subscripts: []Subscript{
+				{From: Literal{Value: apd.New(int64(rng.Intn(10)), 0)}},
+			}}
+		case 3:
+			accessors[i] = FilterAccessor{Predicate: BinaryExpr{
+				Op:    BinaryOp(int(OpEq) + rng.Intn(int(OpGe-OpEq)+1)),
+				Left:  Current{},
+				Right: Literal{Value: apd.New(int64(rng.Intn(100)), 0)},
+			}}
+		default:
+			key := make([]byte, 1+rng.Intn(5))
+			for j := range key {
+				key[j] = alphabet[rng.Intn(len(alphabet))]
+			}
+			accessors[i] = MemberAccessor{Key: string(key)}
+		}
+	}
+	return Path{
+		Strict: rng.Intn(4) == 0,
+		Expr:   Chain{Expr: Root{}, Accessors: accessors},
+	}
+}